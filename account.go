@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // AccountService contains methods and other services that help with interacting
@@ -52,9 +55,13 @@ func (r *AccountService) Link(ctx context.Context, body AccountLinkParams, opts
 // Retrieves comprehensive analytics for a specific financial account, including
 // historical balance trends, projected cash flow, and AI-driven insights into
 // spending patterns.
-func (r *AccountService) GetDetails(ctx context.Context, accountID interface{}, opts ...option.RequestOption) (res *AccountGetDetailsResponse, err error) {
+func (r *AccountService) GetDetails(ctx context.Context, accountID string, opts ...option.RequestOption) (res *AccountGetDetailsResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("accounts/%v/details", accountID)
+	if accountID == "" {
+		err = errors.New("missing required accountId parameter")
+		return
+	}
+	path := fmt.Sprintf("accounts/%s/details", accountID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
@@ -62,18 +69,39 @@ func (r *AccountService) GetDetails(ctx context.Context, accountID interface{},
 // Fetches a comprehensive, real-time list of all external financial accounts
 // linked to the user's profile, including consolidated balances and institutional
 // details.
-func (r *AccountService) GetMe(ctx context.Context, query AccountGetMeParams, opts ...option.RequestOption) (res *AccountGetMeResponse, err error) {
+func (r *AccountService) GetMe(ctx context.Context, query AccountGetMeParams, opts ...option.RequestOption) (res *pagination.Page[LinkedAccount], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "accounts/me"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Fetches a comprehensive, real-time list of all external financial accounts
+// linked to the user's profile, including consolidated balances and institutional
+// details.
+func (r *AccountService) GetMeAutoPaging(ctx context.Context, query AccountGetMeParams, opts ...option.RequestOption) *pagination.PageAutoPager[LinkedAccount] {
+	return pagination.NewPageAutoPager(r.GetMe(ctx, query, opts...))
 }
 
 // Fetches digital statements for a specific account, allowing filtering by date
 // range and format.
-func (r *AccountService) GetStatements(ctx context.Context, accountID interface{}, query AccountGetStatementsParams, opts ...option.RequestOption) (res *AccountGetStatementsResponse, err error) {
+func (r *AccountService) GetStatements(ctx context.Context, accountID string, query AccountGetStatementsParams, opts ...option.RequestOption) (res *AccountGetStatementsResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("accounts/%v/statements", accountID)
+	if accountID == "" {
+		err = errors.New("missing required accountId parameter")
+		return
+	}
+	path := fmt.Sprintf("accounts/%s/statements", accountID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
 	return
 }
@@ -81,27 +109,27 @@ func (r *AccountService) GetStatements(ctx context.Context, accountID interface{
 // Summary information for a linked financial account.
 type LinkedAccount struct {
 	// Unique identifier for the linked account within .
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// ISO 4217 currency code of the account.
-	Currency interface{} `json:"currency,required"`
+	Currency string `json:"currency,required"`
 	// Current balance of the account.
-	CurrentBalance interface{} `json:"currentBalance,required"`
+	CurrentBalance Decimal `json:"currentBalance,required"`
 	// Name of the financial institution where the account is held.
-	InstitutionName interface{} `json:"institutionName,required"`
+	InstitutionName string `json:"institutionName,required"`
 	// Timestamp when the account balance was last synced.
-	LastUpdated interface{} `json:"lastUpdated,required"`
+	LastUpdated time.Time `json:"lastUpdated,required" format:"date-time"`
 	// Display name of the account.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// General type of the account.
 	Type LinkedAccountType `json:"type,required"`
 	// Available balance (after pending transactions) of the account.
-	AvailableBalance interface{} `json:"availableBalance"`
+	AvailableBalance Decimal `json:"availableBalance"`
 	// Optional: Identifier from the external data provider (e.g., Plaid).
-	ExternalID interface{} `json:"externalId"`
+	ExternalID string `json:"externalId"`
 	// Masked account number (e.g., last 4 digits).
-	Mask interface{} `json:"mask"`
+	Mask string `json:"mask"`
 	// Specific subtype of the account (e.g., checking, savings, IRA, 401k).
-	Subtype interface{}       `json:"subtype"`
+	Subtype string            `json:"subtype"`
 	JSON    linkedAccountJSON `json:"-"`
 }
 
@@ -152,13 +180,13 @@ func (r LinkedAccountType) IsKnown() bool {
 type AccountLinkResponse struct {
 	// The URI to redirect the user to complete authentication with the external
 	// institution.
-	AuthUri interface{} `json:"authUri,required"`
+	AuthUri string `json:"authUri,required"`
 	// Unique session ID for the account linking process.
-	LinkSessionID interface{} `json:"linkSessionId,required"`
+	LinkSessionID string `json:"linkSessionId,required"`
 	// Current status of the linking process.
 	Status AccountLinkResponseStatus `json:"status,required"`
 	// A descriptive message regarding the next steps.
-	Message interface{}             `json:"message"`
+	Message string                  `json:"message"`
 	JSON    accountLinkResponseJSON `json:"-"`
 }
 
@@ -201,16 +229,16 @@ func (r AccountLinkResponseStatus) IsKnown() bool {
 // Summary information for a linked financial account.
 type AccountGetDetailsResponse struct {
 	// Name of the primary holder for this account.
-	AccountHolder interface{} `json:"accountHolder"`
+	AccountHolder string `json:"accountHolder"`
 	// Historical daily balance data.
 	BalanceHistory []AccountGetDetailsResponseBalanceHistory `json:"balanceHistory"`
 	// Annual interest rate (if applicable).
-	InterestRate interface{} `json:"interestRate"`
+	InterestRate float64 `json:"interestRate"`
 	// Date the account was opened.
-	OpenedDate        interface{}                                `json:"openedDate"`
+	OpenedDate        time.Time                                  `json:"openedDate" format:"date"`
 	ProjectedCashFlow AccountGetDetailsResponseProjectedCashFlow `json:"projectedCashFlow"`
 	// Total number of transactions in this account.
-	TransactionsCount interface{}                   `json:"transactionsCount"`
+	TransactionsCount int64                         `json:"transactionsCount"`
 	JSON              accountGetDetailsResponseJSON `json:"-"`
 	LinkedAccount
 }
@@ -237,8 +265,8 @@ func (r accountGetDetailsResponseJSON) RawJSON() string {
 }
 
 type AccountGetDetailsResponseBalanceHistory struct {
-	Balance interface{}                                 `json:"balance"`
-	Date    interface{}                                 `json:"date"`
+	Balance Decimal                                     `json:"balance"`
+	Date    time.Time                                   `json:"date" format:"date"`
 	JSON    accountGetDetailsResponseBalanceHistoryJSON `json:"-"`
 }
 
@@ -261,11 +289,11 @@ func (r accountGetDetailsResponseBalanceHistoryJSON) RawJSON() string {
 
 type AccountGetDetailsResponseProjectedCashFlow struct {
 	// AI confidence score for the cash flow projection (0-100).
-	ConfidenceScore interface{} `json:"confidenceScore"`
+	ConfidenceScore float64 `json:"confidenceScore"`
 	// Projected cash flow for the next 30 days.
-	Days30 interface{} `json:"days30"`
+	Days30 Decimal `json:"days30"`
 	// Projected cash flow for the next 90 days.
-	Days90 interface{}                                    `json:"days90"`
+	Days90 Decimal                                        `json:"days90"`
 	JSON   accountGetDetailsResponseProjectedCashFlowJSON `json:"-"`
 }
 
@@ -287,37 +315,15 @@ func (r accountGetDetailsResponseProjectedCashFlowJSON) RawJSON() string {
 	return r.raw
 }
 
-type AccountGetMeResponse struct {
-	Data []LinkedAccount          `json:"data"`
-	JSON accountGetMeResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// accountGetMeResponseJSON contains the JSON metadata for the struct
-// [AccountGetMeResponse]
-type accountGetMeResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *AccountGetMeResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r accountGetMeResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type AccountGetStatementsResponse struct {
 	// The account ID the statement belongs to.
-	AccountID interface{} `json:"accountId,required"`
+	AccountID string `json:"accountId,required"`
 	// Map of available download URLs for different formats.
 	DownloadURLs AccountGetStatementsResponseDownloadURLs `json:"downloadUrls,required"`
 	// The period covered by the statement.
-	Period interface{} `json:"period,required"`
+	Period string `json:"period,required"`
 	// Unique identifier for the statement.
-	StatementID interface{}                      `json:"statementId,required"`
+	StatementID string                           `json:"statementId,required"`
 	JSON        accountGetStatementsResponseJSON `json:"-"`
 }
 
@@ -343,9 +349,9 @@ func (r accountGetStatementsResponseJSON) RawJSON() string {
 // Map of available download URLs for different formats.
 type AccountGetStatementsResponseDownloadURLs struct {
 	// Signed URL to download the statement in CSV format.
-	Csv interface{} `json:"csv"`
+	Csv string `json:"csv"`
 	// Signed URL to download the statement in PDF format.
-	Pdf  interface{}                                  `json:"pdf"`
+	Pdf  string                                       `json:"pdf"`
 	JSON accountGetStatementsResponseDownloadURLsJSON `json:"-"`
 }
 
@@ -368,15 +374,15 @@ func (r accountGetStatementsResponseDownloadURLsJSON) RawJSON() string {
 
 type AccountLinkParams struct {
 	// Two-letter ISO country code of the institution.
-	CountryCode param.Field[interface{}] `json:"countryCode,required"`
+	CountryCode param.Field[string] `json:"countryCode,required"`
 	// Name of the financial institution to link.
-	InstitutionName param.Field[interface{}] `json:"institutionName,required"`
+	InstitutionName param.Field[string] `json:"institutionName,required"`
 	// Optional: Specific identifier for a third-party linking provider (e.g., 'plaid',
 	// 'finicity').
-	ProviderIdentifier param.Field[interface{}] `json:"providerIdentifier"`
+	ProviderIdentifier param.Field[string] `json:"providerIdentifier"`
 	// Optional: URI to redirect the user after completing the external authentication
 	// flow.
-	RedirectUri param.Field[interface{}] `json:"redirectUri"`
+	RedirectUri param.Field[string] `json:"redirectUri"`
 }
 
 func (r AccountLinkParams) MarshalJSON() (data []byte, err error) {
@@ -385,9 +391,9 @@ func (r AccountLinkParams) MarshalJSON() (data []byte, err error) {
 
 type AccountGetMeParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [AccountGetMeParams]'s query parameters as `url.Values`.
@@ -400,9 +406,9 @@ func (r AccountGetMeParams) URLQuery() (v url.Values) {
 
 type AccountGetStatementsParams struct {
 	// Month for the statement (1-12).
-	Month param.Field[interface{}] `query:"month,required"`
+	Month param.Field[int64] `query:"month,required"`
 	// Year for the statement.
-	Year param.Field[interface{}] `query:"year,required"`
+	Year param.Field[int64] `query:"year,required"`
 	// Desired format for the statement. Use 'application/json' Accept header for
 	// download links.
 	Format param.Field[AccountGetStatementsParamsFormat] `query:"format"`
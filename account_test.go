@@ -26,10 +26,10 @@ func TestAccountLinkWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Accounts.Link(context.TODO(), jocall3.AccountLinkParams{
-		CountryCode:        jocall3.F[any]("US"),
-		InstitutionName:    jocall3.F[any]("Bank of America"),
-		ProviderIdentifier: jocall3.F[any](map[string]interface{}{}),
-		RedirectUri:        jocall3.F[any](map[string]interface{}{}),
+		CountryCode:        jocall3.F("US"),
+		InstitutionName:    jocall3.F("Bank of America"),
+		ProviderIdentifier: jocall3.F("string"),
+		RedirectUri:        jocall3.F("string"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -75,8 +75,8 @@ func TestAccountGetMeWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Accounts.GetMe(context.TODO(), jocall3.AccountGetMeParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -103,8 +103,8 @@ func TestAccountGetStatementsWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"acc_chase_checking_4567",
 		jocall3.AccountGetStatementsParams{
-			Month:  jocall3.F[any](7),
-			Year:   jocall3.F[any](2024),
+			Month:  jocall3.F(int64(7)),
+			Year:   jocall3.F(int64(2024)),
 			Format: jocall3.F(jocall3.AccountGetStatementsParamsFormatPdf),
 		},
 	)
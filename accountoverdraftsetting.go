@@ -4,6 +4,7 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
@@ -34,36 +35,44 @@ func NewAccountOverdraftSettingService(opts ...option.RequestOption) (r *Account
 }
 
 // Retrieves the current overdraft protection settings for a specific account.
-func (r *AccountOverdraftSettingService) GetOverdraftSettings(ctx context.Context, accountID interface{}, opts ...option.RequestOption) (res *OverdraftSettings, err error) {
+func (r *AccountOverdraftSettingService) GetOverdraftSettings(ctx context.Context, accountID string, opts ...option.RequestOption) (res *OverdraftSettings, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("accounts/%v/overdraft-settings", accountID)
+	if accountID == "" {
+		err = errors.New("missing required accountId parameter")
+		return
+	}
+	path := fmt.Sprintf("accounts/%s/overdraft-settings", accountID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
 
 // Updates the overdraft protection settings for a specific account, enabling or
 // disabling protection and configuring preferences.
-func (r *AccountOverdraftSettingService) UpdateOverdraftSettings(ctx context.Context, accountID interface{}, body AccountOverdraftSettingUpdateOverdraftSettingsParams, opts ...option.RequestOption) (res *OverdraftSettings, err error) {
+func (r *AccountOverdraftSettingService) UpdateOverdraftSettings(ctx context.Context, accountID string, body AccountOverdraftSettingUpdateOverdraftSettingsParams, opts ...option.RequestOption) (res *OverdraftSettings, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("accounts/%v/overdraft-settings", accountID)
+	if accountID == "" {
+		err = errors.New("missing required accountId parameter")
+		return
+	}
+	path := fmt.Sprintf("accounts/%s/overdraft-settings", accountID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
 
 type OverdraftSettings struct {
 	// The account ID these overdraft settings apply to.
-	AccountID interface{} `json:"accountId,required"`
+	AccountID string `json:"accountId,required"`
 	// If true, overdraft protection is enabled.
-	Enabled interface{} `json:"enabled,required"`
+	Enabled bool `json:"enabled,required"`
 	// User's preference for how overdraft fees are handled or if transactions should
 	// be declined.
 	FeePreference OverdraftSettingsFeePreference `json:"feePreference,required"`
 	// The ID of the linked savings account, if `linkToSavings` is true.
-	LinkedSavingsAccountID interface{} `json:"linkedSavingsAccountId"`
+	LinkedSavingsAccountID string `json:"linkedSavingsAccountId"`
 	// If true, attempts to draw funds from a linked savings account.
-	LinkToSavings interface{} `json:"linkToSavings"`
+	LinkToSavings bool `json:"linkToSavings"`
 	// The maximum amount that can be covered by overdraft protection.
-	ProtectionLimit interface{}           `json:"protectionLimit"`
+	ProtectionLimit Decimal               `json:"protectionLimit"`
 	JSON            overdraftSettingsJSON `json:"-"`
 }
 
@@ -108,16 +117,16 @@ func (r OverdraftSettingsFeePreference) IsKnown() bool {
 
 type AccountOverdraftSettingUpdateOverdraftSettingsParams struct {
 	// Enable or disable overdraft protection.
-	Enabled param.Field[interface{}] `json:"enabled"`
+	Enabled param.Field[bool] `json:"enabled"`
 	// New preference for how overdraft fees are handled.
 	FeePreference param.Field[AccountOverdraftSettingUpdateOverdraftSettingsParamsFeePreference] `json:"feePreference"`
 	// New ID of the linked savings account, if `linkToSavings` is true. Set to null to
 	// unlink.
-	LinkedSavingsAccountID param.Field[interface{}] `json:"linkedSavingsAccountId"`
+	LinkedSavingsAccountID param.Field[string] `json:"linkedSavingsAccountId"`
 	// Enable or disable linking to a savings account for overdraft coverage.
-	LinkToSavings param.Field[interface{}] `json:"linkToSavings"`
+	LinkToSavings param.Field[bool] `json:"linkToSavings"`
 	// New maximum amount for overdraft protection. Set to null to remove limit.
-	ProtectionLimit param.Field[interface{}] `json:"protectionLimit"`
+	ProtectionLimit param.Field[Decimal] `json:"protectionLimit"`
 }
 
 func (r AccountOverdraftSettingUpdateOverdraftSettingsParams) MarshalJSON() (data []byte, err error) {
@@ -51,11 +51,11 @@ func TestAccountOverdraftSettingUpdateOverdraftSettingsWithOptionalParams(t *tes
 		context.TODO(),
 		"acc_chase_checking_4567",
 		jocall3.AccountOverdraftSettingUpdateOverdraftSettingsParams{
-			Enabled:                jocall3.F[any](false),
+			Enabled:                jocall3.F(false),
 			FeePreference:          jocall3.F(jocall3.AccountOverdraftSettingUpdateOverdraftSettingsParamsFeePreferenceDeclineIfOverLimit),
-			LinkedSavingsAccountID: jocall3.F[any]("acc_new_savings_5678"),
-			LinkToSavings:          jocall3.F[any](false),
-			ProtectionLimit:        jocall3.F[any](750),
+			LinkedSavingsAccountID: jocall3.F("acc_new_savings_5678"),
+			LinkToSavings:          jocall3.F(false),
+			ProtectionLimit:        jocall3.F(jocall3.MustDecimal("750")),
 		},
 	)
 	if err != nil {
@@ -0,0 +1,379 @@
+package jocall3
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jocall3/go/internal/requestconfig"
+	"github.com/jocall3/go/option"
+)
+
+// DownloadStatement writes the statement of an account for the given month and
+// year to w, in the given format.
+//
+// It looks up the download URL with [AccountService.GetStatements] and then
+// streams the file from it. Both requests go through the client's usual
+// options, so they carry the same authentication and are retried in the same
+// way; a download that fails after part of the file has been written to w is
+// not retried. The written file is not buffered in memory.
+func (r *AccountService) DownloadStatement(ctx context.Context, accountID string, month int64, year int64, format AccountGetStatementsParamsFormat, w io.Writer, opts ...option.RequestOption) (err error) {
+	statement, err := r.GetStatements(ctx, accountID, AccountGetStatementsParams{
+		Month:  F(month),
+		Year:   F(year),
+		Format: F(format),
+	}, opts...)
+	if err != nil {
+		return err
+	}
+
+	var downloadURL string
+	switch format {
+	case AccountGetStatementsParamsFormatPdf:
+		downloadURL = statement.DownloadURLs.Pdf
+	case AccountGetStatementsParamsFormatCsv:
+		downloadURL = statement.DownloadURLs.Csv
+	default:
+		return fmt.Errorf("jocall3: unknown statement format %q", format)
+	}
+	if downloadURL == "" {
+		return fmt.Errorf("jocall3: no %s download is available for statement %s", format, statement.StatementID)
+	}
+
+	accept := "application/pdf"
+	if format == AccountGetStatementsParamsFormatCsv {
+		accept = "text/csv"
+	}
+	var res *http.Response
+	opts = append(slices.Concat(r.Options, opts), option.WithHeader("Accept", accept))
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, downloadURL, nil, &res, opts...)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, err = io.Copy(w, res.Body)
+	return err
+}
+
+// StatementRow is a line of a CSV account statement.
+type StatementRow struct {
+	// The date the transaction was posted.
+	Date        time.Time
+	Description string
+	// The signed amount of the transaction: negative for money leaving the
+	// account.
+	Amount Decimal
+	// The balance of the account after the transaction, or nil if the statement
+	// has no balance column or the row leaves it empty.
+	RunningBalance *Decimal
+	// The number of the line the row was read from, counting the header as 1.
+	Line int
+}
+
+// StatementParseError is returned by a [StatementReader] for a CSV statement it
+// cannot read.
+type StatementParseError struct {
+	// The line the error was found on.
+	Line int
+	// The column the error was found in, if any.
+	Column string
+	Err    error
+}
+
+func (e *StatementParseError) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf("jocall3: statement line %d, column %q: %s", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("jocall3: statement line %d: %s", e.Line, e.Err)
+}
+
+func (e *StatementParseError) Unwrap() error { return e.Err }
+
+// The header names recognized for each column of a CSV statement, compared
+// without regard to case, spaces or underscores.
+var (
+	statementDateColumns        = []string{"date", "posteddate", "postingdate", "transactiondate", "bookingdate"}
+	statementDescriptionColumns = []string{"description", "details", "memo", "payee", "narrative"}
+	statementAmountColumns      = []string{"amount", "transactionamount"}
+	statementDebitColumns       = []string{"debit", "withdrawal", "withdrawals", "moneyout"}
+	statementCreditColumns      = []string{"credit", "deposit", "deposits", "moneyin"}
+	statementBalanceColumns     = []string{"balance", "runningbalance", "balanceafter"}
+)
+
+// The layouts dates in a CSV statement are parsed with, in order.
+var statementDateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"01/02/2006",
+	"1/2/2006",
+	"02 Jan 2006",
+	"Jan 2, 2006",
+}
+
+// StatementReader reads the rows of a CSV statement, as downloaded by
+// [AccountService.DownloadStatement], one at a time.
+//
+// The first record must be a header. Columns are found by name, so their order
+// does not matter and unknown columns are ignored: a date, a description, and
+// either a signed amount or separate debit and credit columns are required,
+// and a running balance is optional. Amounts may carry a currency symbol,
+// thousands separators, or parentheses for negative values.
+type StatementReader struct {
+	csv *csv.Reader
+	// The index of each column, or -1 if it is missing.
+	date, description, amount, debit, credit, balance int
+	header                                            []string
+	err                                               error
+}
+
+// NewStatementReader returns a reader of the CSV statement in r.
+func NewStatementReader(r io.Reader) *StatementReader {
+	c := csv.NewReader(r)
+	c.FieldsPerRecord = -1
+	c.TrimLeadingSpace = true
+	c.ReuseRecord = true
+	return &StatementReader{csv: c}
+}
+
+// Next returns the next row of the statement, or [io.EOF] once every row has
+// been read. Blank lines are skipped. Errors other than io.EOF are
+// [*StatementParseError] values, except for errors reading the underlying
+// reader. After an error about the values of a row, Next may be called again to
+// carry on with the following rows.
+func (s *StatementReader) Next() (StatementRow, error) {
+	if s.err != nil {
+		return StatementRow{}, s.err
+	}
+	if s.header == nil {
+		if s.err = s.readHeader(); s.err != nil {
+			return StatementRow{}, s.err
+		}
+	}
+	for {
+		record, err := s.csv.Read()
+		if err != nil {
+			var csvErr *csv.ParseError
+			if errors.As(err, &csvErr) {
+				err = &StatementParseError{Line: csvErr.Line, Err: csvErr.Err}
+			}
+			s.err = err
+			return StatementRow{}, err
+		}
+		if statementBlank(record) {
+			continue
+		}
+		line, _ := s.csv.FieldPos(0)
+		row, err := s.parse(line, record)
+		if err != nil {
+			return StatementRow{}, err
+		}
+		return row, nil
+	}
+}
+
+// ReadAll returns every remaining row of the statement.
+func (s *StatementReader) ReadAll() ([]StatementRow, error) {
+	var rows []StatementRow
+	for {
+		row, err := s.Next()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return rows, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+// ParseStatementCSV returns every row of the CSV statement in r, as read by a
+// [StatementReader].
+func ParseStatementCSV(r io.Reader) ([]StatementRow, error) {
+	return NewStatementReader(r).ReadAll()
+}
+
+func (s *StatementReader) readHeader() error {
+	record, err := s.csv.Read()
+	if err == io.EOF {
+		return &StatementParseError{Line: 1, Err: errors.New("missing header")}
+	}
+	if err != nil {
+		return err
+	}
+	s.header = make([]string, len(record))
+	copy(s.header, record)
+	if len(s.header) > 0 {
+		// Spreadsheet software often starts CSV files with a byte order mark.
+		s.header[0] = strings.TrimPrefix(s.header[0], "\ufeff")
+	}
+
+	s.date = s.column(statementDateColumns)
+	s.description = s.column(statementDescriptionColumns)
+	s.amount = s.column(statementAmountColumns)
+	s.debit = s.column(statementDebitColumns)
+	s.credit = s.column(statementCreditColumns)
+	s.balance = s.column(statementBalanceColumns)
+
+	var missing []string
+	if s.date < 0 {
+		missing = append(missing, "date")
+	}
+	if s.description < 0 {
+		missing = append(missing, "description")
+	}
+	if s.amount < 0 && (s.debit < 0 || s.credit < 0) {
+		missing = append(missing, "amount")
+	}
+	if len(missing) > 0 {
+		return &StatementParseError{Line: 1, Err: fmt.Errorf("missing %s column", strings.Join(missing, ", "))}
+	}
+	return nil
+}
+
+func (s *StatementReader) column(names []string) int {
+	for i, h := range s.header {
+		h = strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(h)))
+		for _, name := range names {
+			if h == name {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (s *StatementReader) parse(line int, record []string) (row StatementRow, err error) {
+	row.Line = line
+	field := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	fail := func(i int, err error) (StatementRow, error) {
+		return StatementRow{}, &StatementParseError{Line: line, Column: s.header[i], Err: err}
+	}
+
+	row.Date, err = parseStatementDate(field(s.date))
+	if err != nil {
+		return fail(s.date, err)
+	}
+	row.Description = field(s.description)
+
+	if s.amount >= 0 && field(s.amount) != "" {
+		if row.Amount, err = parseStatementAmount(field(s.amount)); err != nil {
+			return fail(s.amount, err)
+		}
+	} else if s.debit >= 0 && s.credit >= 0 {
+		var debit, credit Decimal
+		if v := field(s.debit); v != "" {
+			if debit, err = parseStatementAmount(v); err != nil {
+				return fail(s.debit, err)
+			}
+		}
+		if v := field(s.credit); v != "" {
+			if credit, err = parseStatementAmount(v); err != nil {
+				return fail(s.credit, err)
+			}
+		}
+		// Debits are money out whether or not the file writes them as negative.
+		if debit.Sign() > 0 {
+			debit = debit.Neg()
+		}
+		row.Amount = credit.Add(debit)
+	} else {
+		return fail(s.amount, errors.New("missing amount"))
+	}
+
+	if v := field(s.balance); v != "" {
+		balance, err := parseStatementAmount(v)
+		if err != nil {
+			return fail(s.balance, err)
+		}
+		row.RunningBalance = &balance
+	}
+	return row, nil
+}
+
+func parseStatementDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("missing date")
+	}
+	for _, layout := range statementDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a date", value)
+}
+
+// statementAmountPattern matches an unsigned amount, with "," as the only
+// accepted thousands separator and "." as the decimal point.
+var statementAmountPattern = regexp.MustCompile(`^(?:\d{1,3}(?:,\d{3})+|\d+)(?:\.\d+)?$|^\.\d+$`)
+
+// statementCurrencySymbols are the currency symbols allowed around an amount.
+const statementCurrencySymbols = "$€£¥"
+
+// parseStatementAmount parses an amount such as "-1,234.56", "$12.00",
+// "(15.00)", "15.00-", "12.34 CR" or "12.34 DR". Anything it cannot read
+// unambiguously, like "1.234,56", is an error rather than a guess.
+func parseStatementAmount(value string) (Decimal, error) {
+	s := strings.TrimSpace(value)
+	signs := 0
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		signs++
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	s = strings.Trim(s, " "+statementCurrencySymbols)
+	switch strings.ToUpper(s[max(len(s)-2, 0):]) {
+	case "CR":
+		signs++
+		s = s[:len(s)-2]
+	case "DR":
+		signs++
+		negative = true
+		s = s[:len(s)-2]
+	}
+	s = strings.TrimRight(s, " ")
+	if strings.HasSuffix(s, "-") {
+		signs++
+		negative = true
+		s = s[:len(s)-1]
+	}
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		signs++
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+	s = strings.Trim(s, " "+statementCurrencySymbols)
+	if signs > 1 || !statementAmountPattern.MatchString(s) {
+		return Decimal{}, fmt.Errorf("cannot parse %q as an amount", value)
+	}
+	d, err := NewDecimal(strings.ReplaceAll(s, ",", ""))
+	if err != nil {
+		return Decimal{}, fmt.Errorf("cannot parse %q as an amount", value)
+	}
+	if negative {
+		d = d.Neg()
+	}
+	return d, nil
+}
+
+func statementBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+package jocall3_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+func TestDownloadStatement(t *testing.T) {
+	const csvStatement = "Date,Description,Amount,Balance\n2024-06-01,Coffee,-4.50,995.50\n"
+	downloads := 0
+	client := jocall3.NewClient(
+		option.WithBaseURL("https://api.example.com/"),
+		option.WithHeader("Authorization", "Bearer token"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					if req.Header.Get("Authorization") != "Bearer token" {
+						t.Errorf("Expected the request to %s to be authenticated", req.URL)
+					}
+					switch req.URL.Path {
+					case "/accounts/acc_1/statements":
+						if q := req.URL.Query(); q.Get("month") != "6" || q.Get("year") != "2024" || q.Get("format") == "" {
+							t.Errorf("Unexpected statement query %s", req.URL.RawQuery)
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Header:     http.Header{"Content-Type": []string{"application/json"}},
+							Body:       io.NopCloser(strings.NewReader(`{"accountId":"acc_1","statementId":"stmt_1","period":"2024-06","downloadUrls":{"csv":"https://files.example.com/stmt_1.csv?sig=abc"}}`)),
+						}, nil
+					case "/stmt_1.csv":
+						downloads++
+						if req.URL.Host != "files.example.com" || req.URL.Query().Get("sig") != "abc" {
+							t.Errorf("Expected the signed URL to be requested, got %s", req.URL)
+						}
+						if accept := req.Header.Get("Accept"); accept != "text/csv" {
+							t.Errorf("Expected Accept text/csv, got %q", accept)
+						}
+						if downloads == 1 {
+							return &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After-Ms": []string{"1"}}, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+						}
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Header:     http.Header{"Content-Type": []string{"text/csv"}},
+							Body:       io.NopCloser(strings.NewReader(csvStatement)),
+						}, nil
+					}
+					t.Fatalf("Unexpected request to %s", req.URL)
+					return nil, nil
+				},
+			},
+		}),
+	)
+
+	var buf bytes.Buffer
+	err := client.Accounts.DownloadStatement(context.Background(), "acc_1", 6, 2024, jocall3.AccountGetStatementsParamsFormatCsv, &buf)
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if buf.String() != csvStatement {
+		t.Errorf("Expected the statement to be written, got %q", buf.String())
+	}
+	if downloads != 2 {
+		t.Errorf("Expected the failed download to be retried, got %d downloads", downloads)
+	}
+
+	err = client.Accounts.DownloadStatement(context.Background(), "acc_1", 6, 2024, jocall3.AccountGetStatementsParamsFormatPdf, &buf)
+	if err == nil || !strings.Contains(err.Error(), "no pdf download") {
+		t.Errorf("Expected a missing pdf download to fail, got %v", err)
+	}
+}
+
+func TestParseStatementCSV(t *testing.T) {
+	rows, err := jocall3.ParseStatementCSV(strings.NewReader("\ufeffPosted Date,Memo,Debit,Credit,Running Balance,Category\n" +
+		"06/01/2024,\"Coffee, large\",$4.50,,\"1,995.50\",Food\n" +
+		"\n" +
+		"06/02/2024,Salary,,\"2,000.00\",\"3,995.50\",Income\n" +
+		"06/03/2024,Refund reversal,(10.00),,,\n"))
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+
+	expected := []struct {
+		date, description, amount, balance string
+		line                               int
+	}{
+		{"2024-06-01", "Coffee, large", "-4.50", "1995.50", 2},
+		{"2024-06-02", "Salary", "2000.00", "3995.50", 4},
+		{"2024-06-03", "Refund reversal", "-10.00", "", 5},
+	}
+	for i, row := range rows {
+		want := expected[i]
+		if row.Date.Format(time.DateOnly) != want.date || row.Description != want.description || row.Amount.String() != want.amount || row.Line != want.line {
+			t.Errorf("Row %d: unexpected %+v", i, row)
+		}
+		if (row.RunningBalance == nil) != (want.balance == "") || (row.RunningBalance != nil && row.RunningBalance.String() != want.balance) {
+			t.Errorf("Row %d: expected balance %q, got %v", i, want.balance, row.RunningBalance)
+		}
+	}
+}
+
+func TestParseStatementCSVAmounts(t *testing.T) {
+	for value, want := range map[string]string{
+		"12.34":      "12.34",
+		"-1,234.56":  "-1234.56",
+		"+5":         "5",
+		"$12.00":     "12.00",
+		"-$12.00":    "-12.00",
+		"€ 3.50":     "3.50",
+		"(15.00)":    "-15.00",
+		"15.00-":     "-15.00",
+		"12.34 CR":   "12.34",
+		"12.34 DR":   "-12.34",
+		"1,234.56dr": "-1234.56",
+		"$1,000 ":    "1000",
+	} {
+		rows, err := jocall3.ParseStatementCSV(strings.NewReader("date,description,amount\n2024-06-01,Coffee,\"" + value + "\"\n"))
+		if err != nil || len(rows) != 1 || rows[0].Amount.String() != want {
+			t.Errorf("%s: expected %s, got %+v: %v", value, want, rows, err)
+		}
+	}
+
+	// Amounts that could only be read by guessing are rejected.
+	for _, value := range []string{"1.234,56", "12,34", "12.34 XX", "1 234.56", "--5", "-12.34 DR", "(5.00)-", "12.3.4", "CR", "."} {
+		_, err := jocall3.ParseStatementCSV(strings.NewReader("date,description,amount\n2024-06-01,Coffee,\"" + value + "\"\n"))
+		var parseErr *jocall3.StatementParseError
+		if !errors.As(err, &parseErr) || parseErr.Column != "amount" {
+			t.Errorf("%s: expected an amount error, got %v", value, err)
+		}
+	}
+}
+
+func TestParseStatementCSVErrors(t *testing.T) {
+	_, err := jocall3.ParseStatementCSV(strings.NewReader("Date,Amount\n2024-06-01,1.00\n"))
+	var parseErr *jocall3.StatementParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 1 || !strings.Contains(err.Error(), "missing description column") {
+		t.Errorf("Expected a missing column error, got %v", err)
+	}
+
+	r := jocall3.NewStatementReader(strings.NewReader("date,description,amount\n2024-06-01,Coffee,abc\n2024-06-02,Tea,-3.00\n"))
+	_, err = r.Next()
+	if !errors.As(err, &parseErr) || parseErr.Line != 2 || parseErr.Column != "amount" {
+		t.Errorf("Expected an amount error on line 2, got %v", err)
+	}
+	row, err := r.Next()
+	if err != nil || row.Description != "Tea" {
+		t.Errorf("Expected reading to carry on after a bad row, got %+v, %v", row, err)
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF, got %v", err)
+	}
+}
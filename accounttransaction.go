@@ -4,16 +4,17 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
 
-	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // AccountTransactionService contains methods and other services that help with
@@ -37,40 +38,38 @@ func NewAccountTransactionService(opts ...option.RequestOption) (r *AccountTrans
 
 // Retrieves a list of pending transactions that have not yet cleared for a
 // specific financial account.
-func (r *AccountTransactionService) GetPending(ctx context.Context, accountID interface{}, query AccountTransactionGetPendingParams, opts ...option.RequestOption) (res *AccountTransactionGetPendingResponse, err error) {
+func (r *AccountTransactionService) GetPending(ctx context.Context, accountID string, query AccountTransactionGetPendingParams, opts ...option.RequestOption) (res *pagination.Page[Transaction], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("accounts/%v/transactions/pending", accountID)
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
-}
-
-type AccountTransactionGetPendingResponse struct {
-	Data []Transaction                            `json:"data"`
-	JSON accountTransactionGetPendingResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// accountTransactionGetPendingResponseJSON contains the JSON metadata for the
-// struct [AccountTransactionGetPendingResponse]
-type accountTransactionGetPendingResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
+	if accountID == "" {
+		err = errors.New("missing required accountId parameter")
+		return
+	}
+	path := fmt.Sprintf("accounts/%s/transactions/pending", accountID)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
 }
 
-func (r *AccountTransactionGetPendingResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r accountTransactionGetPendingResponseJSON) RawJSON() string {
-	return r.raw
+// Retrieves a list of pending transactions that have not yet cleared for a
+// specific financial account.
+func (r *AccountTransactionService) GetPendingAutoPaging(ctx context.Context, accountID string, query AccountTransactionGetPendingParams, opts ...option.RequestOption) *pagination.PageAutoPager[Transaction] {
+	return pagination.NewPageAutoPager(r.GetPending(ctx, accountID, query, opts...))
 }
 
 type AccountTransactionGetPendingParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [AccountTransactionGetPendingParams]'s query parameters as
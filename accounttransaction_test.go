@@ -29,8 +29,8 @@ func TestAccountTransactionGetPendingWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"acc_chase_checking_4567",
 		jocall3.AccountTransactionGetPendingParams{
-			Limit:  jocall3.F[any](map[string]interface{}{}),
-			Offset: jocall3.F[any](map[string]interface{}{}),
+			Limit:  jocall3.F(int64(0)),
+			Offset: jocall3.F(int64(0)),
 		},
 	)
 	if err != nil {
@@ -4,6 +4,7 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -14,6 +15,7 @@ import (
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // AIAdService contains methods and other services that help with interacting with
@@ -39,39 +41,59 @@ func NewAIAdService(opts ...option.RequestOption) (r *AIAdService) {
 
 // Retrieves a list of all video advertisements previously generated by the user in
 // the AI Ad Studio.
-func (r *AIAdService) ListGenerated(ctx context.Context, query AIAdListGeneratedParams, opts ...option.RequestOption) (res *AIAdListGeneratedResponse, err error) {
+func (r *AIAdService) ListGenerated(ctx context.Context, query AIAdListGeneratedParams, opts ...option.RequestOption) (res *pagination.Page[VideoOperationStatus], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "ai/ads"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of all video advertisements previously generated by the user in
+// the AI Ad Studio.
+func (r *AIAdService) ListGeneratedAutoPaging(ctx context.Context, query AIAdListGeneratedParams, opts ...option.RequestOption) *pagination.PageAutoPager[VideoOperationStatus] {
+	return pagination.NewPageAutoPager(r.ListGenerated(ctx, query, opts...))
 }
 
 // Polls the real-time status of an asynchronous video generation operation. Once
 // complete ('done'), the response includes a temporary, signed URL to access and
 // download the generated video asset.
-func (r *AIAdService) GetStatus(ctx context.Context, operationID interface{}, opts ...option.RequestOption) (res *VideoOperationStatus, err error) {
+func (r *AIAdService) GetStatus(ctx context.Context, operationID string, opts ...option.RequestOption) (res *VideoOperationStatus, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("ai/ads/operations/%v", operationID)
+	if operationID == "" {
+		err = errors.New("missing required operationId parameter")
+		return
+	}
+	path := fmt.Sprintf("ai/ads/operations/%s", operationID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
 
 type VideoOperationStatus struct {
 	// A descriptive status message.
-	Message interface{} `json:"message,required"`
+	Message string `json:"message,required"`
 	// The unique identifier for the video generation operation.
-	OperationID interface{} `json:"operationId,required"`
+	OperationID string `json:"operationId,required"`
 	// Estimated completion percentage (0-100).
-	ProgressPercentage interface{} `json:"progressPercentage,required"`
+	ProgressPercentage float64 `json:"progressPercentage,required"`
 	// Current status of the video generation job.
 	Status VideoOperationStatusStatus `json:"status,required"`
 	// Error message if the operation failed.
-	ErrorMessage interface{} `json:"errorMessage"`
+	ErrorMessage string `json:"errorMessage"`
 	// Temporary, signed URL to a preview image/thumbnail of the video.
-	PreviewImageUri interface{} `json:"previewImageUri"`
+	PreviewImageUri string `json:"previewImageUri"`
 	// Temporary, signed URL to the generated video asset (available when status is
 	// 'done').
-	VideoUri interface{}              `json:"videoUri"`
+	VideoUri string                   `json:"videoUri"`
 	JSON     videoOperationStatusJSON `json:"-"`
 }
 
@@ -116,33 +138,11 @@ func (r VideoOperationStatusStatus) IsKnown() bool {
 	return false
 }
 
-type AIAdListGeneratedResponse struct {
-	Data []VideoOperationStatus        `json:"data"`
-	JSON aiAdListGeneratedResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// aiAdListGeneratedResponseJSON contains the JSON metadata for the struct
-// [AIAdListGeneratedResponse]
-type aiAdListGeneratedResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *AIAdListGeneratedResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r aiAdListGeneratedResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type AIAdListGeneratedParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 	// Filter ads by their generation status.
 	Status param.Field[AIAdListGeneratedParamsStatus] `query:"status"`
 }
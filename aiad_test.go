@@ -26,8 +26,8 @@ func TestAIAdListGeneratedWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.AI.Ads.ListGenerated(context.TODO(), jocall3.AIAdListGeneratedParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 		Status: jocall3.F(jocall3.AIAdListGeneratedParamsStatusDone),
 	})
 	if err != nil {
@@ -55,17 +55,17 @@ func (r *AIAdGenerateService) Standard(ctx context.Context, body AIAdGenerateSta
 
 type GenerateVideoParam struct {
 	// Desired length of the video in seconds.
-	LengthSeconds param.Field[interface{}] `json:"lengthSeconds,required"`
+	LengthSeconds param.Field[int64] `json:"lengthSeconds,required"`
 	// The textual prompt to guide the AI video generation.
-	Prompt param.Field[interface{}] `json:"prompt,required"`
+	Prompt param.Field[string] `json:"prompt,required"`
 	// Artistic style of the video.
 	Style param.Field[GenerateVideoStyle] `json:"style,required"`
 	// Aspect ratio of the video (e.g., 16:9 for widescreen, 9:16 for vertical shorts).
 	AspectRatio param.Field[GenerateVideoAspectRatio] `json:"aspectRatio"`
 	// Optional: Hex color codes to influence the video's aesthetic.
-	BrandColors param.Field[[]interface{}] `json:"brandColors"`
+	BrandColors param.Field[[]string] `json:"brandColors"`
 	// Optional: Additional keywords to guide the AI's content generation.
-	Keywords param.Field[[]interface{}] `json:"keywords"`
+	Keywords param.Field[[]string] `json:"keywords"`
 }
 
 func (r GenerateVideoParam) MarshalJSON() (data []byte, err error) {
@@ -111,9 +111,9 @@ func (r GenerateVideoAspectRatio) IsKnown() bool {
 type AIAdGenerateAdvancedResponse struct {
 	// Estimated time until advanced video generation is complete. May be longer than
 	// standard generation.
-	EstimatedCompletionTimeSeconds interface{} `json:"estimatedCompletionTimeSeconds"`
+	EstimatedCompletionTimeSeconds int64 `json:"estimatedCompletionTimeSeconds"`
 	// The unique identifier for the advanced video generation operation.
-	OperationID interface{}                      `json:"operationId"`
+	OperationID string                           `json:"operationId"`
 	JSON        aiAdGenerateAdvancedResponseJSON `json:"-"`
 }
 
@@ -136,9 +136,9 @@ func (r aiAdGenerateAdvancedResponseJSON) RawJSON() string {
 
 type AIAdGenerateStandardResponse struct {
 	// Estimated time until video generation is complete.
-	EstimatedCompletionTimeSeconds interface{} `json:"estimatedCompletionTimeSeconds"`
+	EstimatedCompletionTimeSeconds int64 `json:"estimatedCompletionTimeSeconds"`
 	// The unique identifier for the video generation operation.
-	OperationID interface{}                      `json:"operationId"`
+	OperationID string                           `json:"operationId"`
 	JSON        aiAdGenerateStandardResponseJSON `json:"-"`
 }
 
@@ -161,9 +161,9 @@ func (r aiAdGenerateStandardResponseJSON) RawJSON() string {
 
 type AIAdGenerateAdvancedParams struct {
 	// Desired length of the video in seconds.
-	LengthSeconds param.Field[interface{}] `json:"lengthSeconds,required"`
+	LengthSeconds param.Field[int64] `json:"lengthSeconds,required"`
 	// The textual prompt to guide the AI video generation.
-	Prompt param.Field[interface{}] `json:"prompt,required"`
+	Prompt param.Field[string] `json:"prompt,required"`
 	// Artistic style of the video.
 	Style param.Field[AIAdGenerateAdvancedParamsStyle] `json:"style,required"`
 	// Aspect ratio of the video (e.g., 16:9 for widescreen, 9:16 for vertical shorts).
@@ -173,17 +173,17 @@ type AIAdGenerateAdvancedParams struct {
 	// Genre of background music.
 	BackgroundMusicGenre param.Field[AIAdGenerateAdvancedParamsBackgroundMusicGenre] `json:"backgroundMusicGenre"`
 	// URLs to brand assets (e.g., logos, specific imagery) to be incorporated.
-	BrandAssets param.Field[[]interface{}] `json:"brandAssets"`
+	BrandAssets param.Field[[]string] `json:"brandAssets"`
 	// Optional: Hex color codes to influence the video's aesthetic.
-	BrandColors param.Field[[]interface{}] `json:"brandColors"`
+	BrandColors param.Field[[]string] `json:"brandColors"`
 	// Call-to-action text and URL to be displayed.
 	CallToAction param.Field[AIAdGenerateAdvancedParamsCallToAction] `json:"callToAction"`
 	// Optional: Additional keywords to guide the AI's content generation.
-	Keywords param.Field[[]interface{}] `json:"keywords"`
+	Keywords param.Field[[]string] `json:"keywords"`
 	// Style/tone for the AI voiceover.
 	VoiceoverStyle param.Field[AIAdGenerateAdvancedParamsVoiceoverStyle] `json:"voiceoverStyle"`
 	// Optional: Text for an AI-generated voiceover.
-	VoiceoverText param.Field[interface{}] `json:"voiceoverText"`
+	VoiceoverText param.Field[string] `json:"voiceoverText"`
 }
 
 func (r AIAdGenerateAdvancedParams) MarshalJSON() (data []byte, err error) {
@@ -265,9 +265,9 @@ func (r AIAdGenerateAdvancedParamsBackgroundMusicGenre) IsKnown() bool {
 
 // Call-to-action text and URL to be displayed.
 type AIAdGenerateAdvancedParamsCallToAction struct {
-	DisplayTimeSeconds param.Field[interface{}] `json:"displayTimeSeconds"`
-	Text               param.Field[interface{}] `json:"text"`
-	URL                param.Field[interface{}] `json:"url"`
+	DisplayTimeSeconds param.Field[int64]  `json:"displayTimeSeconds"`
+	Text               param.Field[string] `json:"text"`
+	URL                param.Field[string] `json:"url"`
 }
 
 func (r AIAdGenerateAdvancedParamsCallToAction) MarshalJSON() (data []byte, err error) {
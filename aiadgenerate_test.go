@@ -26,22 +26,22 @@ func TestAIAdGenerateAdvancedWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.AI.Ads.Generate.Advanced(context.TODO(), jocall3.AIAdGenerateAdvancedParams{
-		LengthSeconds:        jocall3.F[any](15),
-		Prompt:               jocall3.F[any]("A captivating ad featuring a young entrepreneur using 's AI tools to grow their startup. Focus on innovation and ease of use."),
+		LengthSeconds:        jocall3.F(int64(15)),
+		Prompt:               jocall3.F("A captivating ad featuring a young entrepreneur using 's AI tools to grow their startup. Focus on innovation and ease of use."),
 		Style:                jocall3.F(jocall3.AIAdGenerateAdvancedParamsStyleCinematic),
 		AspectRatio:          jocall3.F(jocall3.AIAdGenerateAdvancedParamsAspectRatio16_9),
 		AudienceTarget:       jocall3.F(jocall3.AIAdGenerateAdvancedParamsAudienceTargetCorporate),
 		BackgroundMusicGenre: jocall3.F(jocall3.AIAdGenerateAdvancedParamsBackgroundMusicGenreCorporate),
-		BrandAssets:          jocall3.F([]interface{}{"https://demobank.com/assets/corporate_logo.png"}),
-		BrandColors:          jocall3.F([]interface{}{"#0000FF", "#FFD700"}),
+		BrandAssets:          jocall3.F([]string{"https://demobank.com/assets/corporate_logo.png"}),
+		BrandColors:          jocall3.F([]string{"#0000FF", "#FFD700"}),
 		CallToAction: jocall3.F(jocall3.AIAdGenerateAdvancedParamsCallToAction{
-			DisplayTimeSeconds: jocall3.F[any](5),
-			Text:               jocall3.F[any]("Learn more at DemoBank.com/business"),
-			URL:                jocall3.F[any]("https://demobank.com/business"),
+			DisplayTimeSeconds: jocall3.F(int64(5)),
+			Text:               jocall3.F("Learn more at DemoBank.com/business"),
+			URL:                jocall3.F("https://demobank.com/business"),
 		}),
-		Keywords:       jocall3.F([]interface{}{"innovation", "fintech", "startup"}),
+		Keywords:       jocall3.F([]string{"innovation", "fintech", "startup"}),
 		VoiceoverStyle: jocall3.F(jocall3.AIAdGenerateAdvancedParamsVoiceoverStyleMaleProfessional),
-		VoiceoverText:  jocall3.F[any](": Your business, powered by intelligent finance."),
+		VoiceoverText:  jocall3.F(": Your business, powered by intelligent finance."),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -66,12 +66,12 @@ func TestAIAdGenerateStandardWithOptionalParams(t *testing.T) {
 	)
 	_, err := client.AI.Ads.Generate.Standard(context.TODO(), jocall3.AIAdGenerateStandardParams{
 		GenerateVideo: jocall3.GenerateVideoParam{
-			LengthSeconds: jocall3.F[any](15),
-			Prompt:        jocall3.F[any]("A captivating ad featuring a young entrepreneur using 's AI tools to grow their startup. Focus on innovation and ease of use."),
+			LengthSeconds: jocall3.F(int64(15)),
+			Prompt:        jocall3.F("A captivating ad featuring a young entrepreneur using 's AI tools to grow their startup. Focus on innovation and ease of use."),
 			Style:         jocall3.F(jocall3.GenerateVideoStyleCinematic),
 			AspectRatio:   jocall3.F(jocall3.GenerateVideoAspectRatio16_9),
-			BrandColors:   jocall3.F([]interface{}{"#0000FF", "#FFD700"}),
-			Keywords:      jocall3.F([]interface{}{"innovation", "fintech", "startup"}),
+			BrandColors:   jocall3.F([]string{"#0000FF", "#FFD700"}),
+			Keywords:      jocall3.F([]string{"innovation", "fintech", "startup"}),
 		},
 	})
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // AIAdvisorService contains methods and other services that help with interacting
@@ -39,42 +40,37 @@ func NewAIAdvisorService(opts ...option.RequestOption) (r *AIAdvisorService) {
 // Retrieves a dynamic manifest of all integrated AI tools that Quantum can invoke
 // and execute, providing details on their capabilities, parameters, and access
 // requirements.
-func (r *AIAdvisorService) ListTools(ctx context.Context, query AIAdvisorListToolsParams, opts ...option.RequestOption) (res *AIAdvisorListToolsResponse, err error) {
+func (r *AIAdvisorService) ListTools(ctx context.Context, query AIAdvisorListToolsParams, opts ...option.RequestOption) (res *pagination.Page[AIAdvisorListToolsResponseData], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "ai/advisor/tools"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
-}
-
-type AIAdvisorListToolsResponse struct {
-	Data []AIAdvisorListToolsResponseData `json:"data"`
-	JSON aiAdvisorListToolsResponseJSON   `json:"-"`
-	PaginatedList
-}
-
-// aiAdvisorListToolsResponseJSON contains the JSON metadata for the struct
-// [AIAdvisorListToolsResponse]
-type aiAdvisorListToolsResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *AIAdvisorListToolsResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
 }
 
-func (r aiAdvisorListToolsResponseJSON) RawJSON() string {
-	return r.raw
+// Retrieves a dynamic manifest of all integrated AI tools that Quantum can invoke
+// and execute, providing details on their capabilities, parameters, and access
+// requirements.
+func (r *AIAdvisorService) ListToolsAutoPaging(ctx context.Context, query AIAdvisorListToolsParams, opts ...option.RequestOption) *pagination.PageAutoPager[AIAdvisorListToolsResponseData] {
+	return pagination.NewPageAutoPager(r.ListTools(ctx, query, opts...))
 }
 
 type AIAdvisorListToolsResponseData struct {
 	// The OAuth2 scope required to execute this tool.
-	AccessScope interface{} `json:"accessScope,required"`
+	AccessScope string `json:"accessScope,required"`
 	// A description of what the tool does.
-	Description interface{} `json:"description,required"`
+	Description string `json:"description,required"`
 	// The unique name of the AI tool (function name).
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// OpenAPI schema object defining the input parameters for the tool function.
 	Parameters AIAdvisorListToolsResponseDataParameters `json:"parameters,required"`
 	JSON       aiAdvisorListToolsResponseDataJSON       `json:"-"`
@@ -101,8 +97,8 @@ func (r aiAdvisorListToolsResponseDataJSON) RawJSON() string {
 
 // OpenAPI schema object defining the input parameters for the tool function.
 type AIAdvisorListToolsResponseDataParameters struct {
-	Properties interface{}                                  `json:"properties"`
-	Required   []interface{}                                `json:"required"`
+	Properties map[string]interface{}                       `json:"properties"`
+	Required   []string                                     `json:"required"`
 	Type       AIAdvisorListToolsResponseDataParametersType `json:"type"`
 	JSON       aiAdvisorListToolsResponseDataParametersJSON `json:"-"`
 }
@@ -141,9 +137,9 @@ func (r AIAdvisorListToolsResponseDataParametersType) IsKnown() bool {
 
 type AIAdvisorListToolsParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [AIAdvisorListToolsParams]'s query parameters as
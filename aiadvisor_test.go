@@ -26,8 +26,8 @@ func TestAIAdvisorListToolsWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.AI.Advisor.ListTools(context.TODO(), jocall3.AIAdvisorListToolsParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -7,12 +7,15 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
+	"github.com/jocall3/go/packages/ssestream"
 )
 
 // AIAdvisorChatService contains methods and other services that help with
@@ -36,11 +39,27 @@ func NewAIAdvisorChatService(opts ...option.RequestOption) (r *AIAdvisorChatServ
 
 // Fetches the full conversation history with the Quantum AI Advisor for a given
 // session or user.
-func (r *AIAdvisorChatService) GetHistory(ctx context.Context, query AIAdvisorChatGetHistoryParams, opts ...option.RequestOption) (res *AIAdvisorChatGetHistoryResponse, err error) {
+func (r *AIAdvisorChatService) GetHistory(ctx context.Context, query AIAdvisorChatGetHistoryParams, opts ...option.RequestOption) (res *pagination.Page[AIAdvisorChatGetHistoryResponseData], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "ai/advisor/chat/history"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Fetches the full conversation history with the Quantum AI Advisor for a given
+// session or user.
+func (r *AIAdvisorChatService) GetHistoryAutoPaging(ctx context.Context, query AIAdvisorChatGetHistoryParams, opts ...option.RequestOption) *pagination.PageAutoPager[AIAdvisorChatGetHistoryResponseData] {
+	return pagination.NewPageAutoPager(r.GetHistory(ctx, query, opts...))
 }
 
 // Initiates or continues a sophisticated conversation with Quantum, the AI
@@ -54,35 +73,29 @@ func (r *AIAdvisorChatService) SendMessage(ctx context.Context, body AIAdvisorCh
 	return
 }
 
-type AIAdvisorChatGetHistoryResponse struct {
-	Data []AIAdvisorChatGetHistoryResponseData `json:"data"`
-	JSON aiAdvisorChatGetHistoryResponseJSON   `json:"-"`
-	PaginatedList
-}
-
-// aiAdvisorChatGetHistoryResponseJSON contains the JSON metadata for the struct
-// [AIAdvisorChatGetHistoryResponse]
-type aiAdvisorChatGetHistoryResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *AIAdvisorChatGetHistoryResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r aiAdvisorChatGetHistoryResponseJSON) RawJSON() string {
-	return r.raw
+// Streaming variant of [AIAdvisorChatService.SendMessage]. Quantum's reply is
+// delivered as a sequence of server-sent events carrying text deltas and
+// function-call chunks, which can be combined into the complete message with an
+// [AIAdvisorChatMessageAccumulator].
+func (r *AIAdvisorChatService) SendMessageStreaming(ctx context.Context, body AIAdvisorChatSendMessageParams, opts ...option.RequestOption) (stream *ssestream.Stream[AIAdvisorChatStreamEvent]) {
+	var (
+		raw *http.Response
+		err error
+	)
+	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithJSONSet("stream", true), option.WithHeader("Accept", "text/event-stream")}, opts...)
+	path := "ai/advisor/chat"
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &raw, opts...)
+	return ssestream.NewStream[AIAdvisorChatStreamEvent](raw, err)
 }
 
 type AIAdvisorChatGetHistoryResponseData struct {
 	// The textual content of the message.
-	Content interface{} `json:"content,required"`
+	Content string `json:"content,required"`
 	// Role of the speaker (user, assistant, or tool interaction).
 	Role AIAdvisorChatGetHistoryResponseDataRole `json:"role,required"`
 	// Timestamp of the message.
-	Timestamp interface{} `json:"timestamp,required"`
+	Timestamp time.Time `json:"timestamp,required" format:"date-time"`
 	// If role is 'tool_call', details of the tool function called by the AI.
 	FunctionCall AIAdvisorChatGetHistoryResponseDataFunctionCall `json:"functionCall"`
 	// If role is 'tool_response', the output from the tool function.
@@ -130,8 +143,8 @@ func (r AIAdvisorChatGetHistoryResponseDataRole) IsKnown() bool {
 
 // If role is 'tool_call', details of the tool function called by the AI.
 type AIAdvisorChatGetHistoryResponseDataFunctionCall struct {
-	Args interface{}                                         `json:"args"`
-	Name interface{}                                         `json:"name"`
+	Args map[string]interface{}                              `json:"args"`
+	Name string                                              `json:"name"`
 	JSON aiAdvisorChatGetHistoryResponseDataFunctionCallJSON `json:"-"`
 }
 
@@ -154,8 +167,8 @@ func (r aiAdvisorChatGetHistoryResponseDataFunctionCallJSON) RawJSON() string {
 
 // If role is 'tool_response', the output from the tool function.
 type AIAdvisorChatGetHistoryResponseDataFunctionResponse struct {
-	Name     interface{}                                             `json:"name"`
-	Response interface{}                                             `json:"response"`
+	Name     string                                                  `json:"name"`
+	Response map[string]interface{}                                  `json:"response"`
 	JSON     aiAdvisorChatGetHistoryResponseDataFunctionResponseJSON `json:"-"`
 }
 
@@ -178,16 +191,16 @@ func (r aiAdvisorChatGetHistoryResponseDataFunctionResponseJSON) RawJSON() strin
 
 type AIAdvisorChatSendMessageResponse struct {
 	// The active conversation session ID.
-	SessionID interface{} `json:"sessionId,required"`
+	SessionID string `json:"sessionId,required"`
 	// A list of tool functions the AI wants the system to execute.
 	FunctionCalls []AIAdvisorChatSendMessageResponseFunctionCall `json:"functionCalls,nullable"`
 	// A list of proactive AI insights or recommendations generated by Quantum.
 	ProactiveInsights []AIInsight `json:"proactiveInsights,nullable"`
 	// Indicates if the AI's response implies that the user needs to take a specific
 	// action (e.g., provide more input, confirm a tool call).
-	RequiresUserAction interface{} `json:"requiresUserAction"`
+	RequiresUserAction bool `json:"requiresUserAction"`
 	// The AI Advisor's textual response.
-	Text interface{}                          `json:"text"`
+	Text string                               `json:"text"`
 	JSON aiAdvisorChatSendMessageResponseJSON `json:"-"`
 }
 
@@ -213,11 +226,11 @@ func (r aiAdvisorChatSendMessageResponseJSON) RawJSON() string {
 
 type AIAdvisorChatSendMessageResponseFunctionCall struct {
 	// Unique ID for this tool call, used to link with `functionResponse`.
-	ID interface{} `json:"id"`
+	ID string `json:"id"`
 	// Key-value pairs representing the arguments to pass to the tool function.
-	Args interface{} `json:"args"`
+	Args map[string]interface{} `json:"args"`
 	// The name of the tool function to call.
-	Name interface{}                                      `json:"name"`
+	Name string                                           `json:"name"`
 	JSON aiAdvisorChatSendMessageResponseFunctionCallJSON `json:"-"`
 }
 
@@ -239,14 +252,85 @@ func (r aiAdvisorChatSendMessageResponseFunctionCallJSON) RawJSON() string {
 	return r.raw
 }
 
+// A single event from [AIAdvisorChatService.SendMessageStreaming].
+type AIAdvisorChatStreamEvent struct {
+	// The kind of event.
+	Type AIAdvisorChatStreamEventType `json:"type,required"`
+	// Partial JSON for the arguments of the function call at `index`, present on
+	// `function_call_delta` events. Concatenating the chunks for an index yields
+	// the complete arguments object.
+	ArgsDelta string `json:"argsDelta"`
+	// The next piece of the AI Advisor's textual response, present on
+	// `text_delta` events.
+	Delta string `json:"delta"`
+	// Unique ID of the function call, sent with its first chunk.
+	ID string `json:"id"`
+	// Position of the function call within the message, present on
+	// `function_call_delta` events.
+	Index int64 `json:"index"`
+	// The name of the tool function to call, sent with its first chunk.
+	Name string `json:"name"`
+	// A list of proactive AI insights or recommendations generated by Quantum, sent
+	// with `message_stop`.
+	ProactiveInsights []AIInsight `json:"proactiveInsights,nullable"`
+	// Indicates if the AI's response implies that the user needs to take a specific
+	// action, sent with `message_stop`.
+	RequiresUserAction bool `json:"requiresUserAction"`
+	// The active conversation session ID, sent with `message_start`.
+	SessionID string                       `json:"sessionId"`
+	JSON      aiAdvisorChatStreamEventJSON `json:"-"`
+}
+
+// aiAdvisorChatStreamEventJSON contains the JSON metadata for the struct
+// [AIAdvisorChatStreamEvent]
+type aiAdvisorChatStreamEventJSON struct {
+	Type               apijson.Field
+	ArgsDelta          apijson.Field
+	Delta              apijson.Field
+	ID                 apijson.Field
+	Index              apijson.Field
+	Name               apijson.Field
+	ProactiveInsights  apijson.Field
+	RequiresUserAction apijson.Field
+	SessionID          apijson.Field
+	raw                string
+	ExtraFields        map[string]apijson.Field
+}
+
+func (r *AIAdvisorChatStreamEvent) UnmarshalJSON(data []byte) (err error) {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r aiAdvisorChatStreamEventJSON) RawJSON() string {
+	return r.raw
+}
+
+// The kind of event.
+type AIAdvisorChatStreamEventType string
+
+const (
+	AIAdvisorChatStreamEventTypeMessageStart      AIAdvisorChatStreamEventType = "message_start"
+	AIAdvisorChatStreamEventTypeTextDelta         AIAdvisorChatStreamEventType = "text_delta"
+	AIAdvisorChatStreamEventTypeFunctionCallDelta AIAdvisorChatStreamEventType = "function_call_delta"
+	AIAdvisorChatStreamEventTypeMessageStop       AIAdvisorChatStreamEventType = "message_stop"
+)
+
+func (r AIAdvisorChatStreamEventType) IsKnown() bool {
+	switch r {
+	case AIAdvisorChatStreamEventTypeMessageStart, AIAdvisorChatStreamEventTypeTextDelta, AIAdvisorChatStreamEventTypeFunctionCallDelta, AIAdvisorChatStreamEventTypeMessageStop:
+		return true
+	}
+	return false
+}
+
 type AIAdvisorChatGetHistoryParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 	// Optional: Filter history by a specific session ID. If omitted, recent
 	// conversations will be returned.
-	SessionID param.Field[interface{}] `query:"sessionId"`
+	SessionID param.Field[string] `query:"sessionId"`
 }
 
 // URLQuery serializes [AIAdvisorChatGetHistoryParams]'s query parameters as
@@ -263,10 +347,10 @@ type AIAdvisorChatSendMessageParams struct {
 	// executed.
 	FunctionResponse param.Field[AIAdvisorChatSendMessageParamsFunctionResponse] `json:"functionResponse"`
 	// The user's textual input to the AI Advisor.
-	Message param.Field[interface{}] `json:"message"`
+	Message param.Field[string] `json:"message"`
 	// Optional: Session ID to continue a conversation. If omitted, a new session is
 	// started.
-	SessionID param.Field[interface{}] `json:"sessionId"`
+	SessionID param.Field[string] `json:"sessionId"`
 }
 
 func (r AIAdvisorChatSendMessageParams) MarshalJSON() (data []byte, err error) {
@@ -277,9 +361,9 @@ func (r AIAdvisorChatSendMessageParams) MarshalJSON() (data []byte, err error) {
 // executed.
 type AIAdvisorChatSendMessageParamsFunctionResponse struct {
 	// The name of the tool function for which this is a response.
-	Name param.Field[interface{}] `json:"name"`
+	Name param.Field[string] `json:"name"`
 	// The JSON output from the execution of the tool function.
-	Response param.Field[interface{}] `json:"response"`
+	Response param.Field[map[string]interface{}] `json:"response"`
 }
 
 func (r AIAdvisorChatSendMessageParamsFunctionResponse) MarshalJSON() (data []byte, err error) {
@@ -26,9 +26,9 @@ func TestAIAdvisorChatGetHistoryWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.AI.Advisor.Chat.GetHistory(context.TODO(), jocall3.AIAdvisorChatGetHistoryParams{
-		Limit:     jocall3.F[any](map[string]interface{}{}),
-		Offset:    jocall3.F[any](map[string]interface{}{}),
-		SessionID: jocall3.F[any]("session-quantum-xyz-789-alpha"),
+		Limit:     jocall3.F(int64(0)),
+		Offset:    jocall3.F(int64(0)),
+		SessionID: jocall3.F("session-quantum-xyz-789-alpha"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -53,16 +53,16 @@ func TestAIAdvisorChatSendMessageWithOptionalParams(t *testing.T) {
 	)
 	_, err := client.AI.Advisor.Chat.SendMessage(context.TODO(), jocall3.AIAdvisorChatSendMessageParams{
 		FunctionResponse: jocall3.F(jocall3.AIAdvisorChatSendMessageParamsFunctionResponse{
-			Name: jocall3.F[any]("send_money"),
-			Response: jocall3.F[any](map[string]interface{}{
+			Name: jocall3.F("send_money"),
+			Response: jocall3.F(map[string]interface{}{
 				"status":        "success",
 				"transactionId": "pmt_654321",
 				"amountSent":    55.5,
 				"recipient":     "Alex",
 			}),
 		}),
-		Message:   jocall3.F[any]("Can you analyze my recent spending patterns and suggest areas for saving, focusing on my dining expenses?"),
-		SessionID: jocall3.F[any]("session-quantum-xyz-789-alpha"),
+		Message:   jocall3.F("Can you analyze my recent spending patterns and suggest areas for saving, focusing on my dining expenses?"),
+		SessionID: jocall3.F("session-quantum-xyz-789-alpha"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -0,0 +1,410 @@
+package jocall3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jocall3/go/option"
+)
+
+// DefaultChatRunnerMaxIterations is the number of messages a [ChatRunner] sends
+// in a single [ChatRunner.Run] unless [ChatRunner.MaxIterations] says otherwise.
+const DefaultChatRunnerMaxIterations = 10
+
+// Names under which [ChatRunner.RegisterBuiltinTools] registers its tools.
+const (
+	ChatToolListTransactions = "list_transactions"
+	ChatToolGetBudget        = "get_budget"
+)
+
+// ErrChatMaxIterations is returned by [ChatRunner.Run] when the advisor keeps
+// requesting tool calls after the runner has sent
+// [ChatRunner.MaxIterations] messages.
+var ErrChatMaxIterations = errors.New("jocall3: chat runner reached the maximum number of iterations")
+
+// ChatToolHandler executes a tool call requested by the AI Advisor. The result is
+// sent back to the advisor as the function response; it must marshal to JSON,
+// and anything other than a JSON object is wrapped as `{"result": ...}`.
+type ChatToolHandler func(ctx context.Context, args map[string]interface{}) (interface{}, error)
+
+// ChatToolError describes a tool call that could not be executed. Its message is
+// returned to the advisor as `{"error": ...}` so that it can correct the call.
+type ChatToolError struct {
+	Tool string
+	// The location of the offending argument, such as `args.limit`, or empty if
+	// the error is not about a specific argument.
+	Path    string
+	Message string
+}
+
+func (e *ChatToolError) Error() string {
+	if e.Path != "" {
+		return fmt.Sprintf("tool %s: %s: %s", e.Tool, e.Path, e.Message)
+	}
+	return fmt.Sprintf("tool %s: %s", e.Tool, e.Message)
+}
+
+// ChatRunner drives a conversation with the AI Advisor, executing the function
+// calls it requests with locally registered handlers and sending the results
+// back until the advisor replies without requesting any more calls.
+//
+// Arguments are validated against the parameter schemas returned by
+// [AIAdvisorService.ListTools] before a handler runs. Unknown tools, invalid
+// arguments and handler failures are reported to the advisor rather than
+// ending the run; only request errors and context cancellation stop it.
+//
+// A ChatRunner is not safe for concurrent use.
+type ChatRunner struct {
+	// The maximum number of messages sent by a single [ChatRunner.Run], including
+	// the initial one. Zero means [DefaultChatRunnerMaxIterations].
+	MaxIterations int
+	// OnFunctionCall, if set, is called before each requested function call is
+	// dispatched.
+	OnFunctionCall func(call AIAdvisorChatSendMessageResponseFunctionCall)
+
+	client   *Client
+	handlers map[string]ChatToolHandler
+	schemas  map[string]AIAdvisorListToolsResponseDataParameters
+}
+
+// NewChatRunner returns a runner that talks to the AI Advisor through client.
+func NewChatRunner(client *Client) *ChatRunner {
+	return &ChatRunner{
+		client:   client,
+		handlers: map[string]ChatToolHandler{},
+	}
+}
+
+// Register binds handler to the tool called name, replacing any previous
+// handler for that name.
+func (r *ChatRunner) Register(name string, handler ChatToolHandler) {
+	r.handlers[name] = handler
+}
+
+// RegisterBuiltinTools binds the tools that map directly onto SDK calls:
+// [ChatToolListTransactions] runs [TransactionService.List] and
+// [ChatToolGetBudget] runs [BudgetService.Get]. The options are applied to
+// those calls.
+func (r *ChatRunner) RegisterBuiltinTools(opts ...option.RequestOption) {
+	r.Register(ChatToolListTransactions, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		params, err := chatToolTransactionListParams(args)
+		if err != nil {
+			return nil, err
+		}
+		page, err := r.client.Transactions.List(ctx, params, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(page.JSON.RawJSON()), nil
+	})
+	r.Register(ChatToolGetBudget, func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		budgetID, _ := args["budgetId"].(string)
+		if budgetID == "" {
+			return nil, &ChatToolError{Tool: ChatToolGetBudget, Path: "args.budgetId", Message: "is required"}
+		}
+		budget, err := r.client.Budgets.Get(ctx, budgetID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(budget.JSON.RawJSON()), nil
+	})
+}
+
+// LoadTools fetches the tool manifest with [AIAdvisorService.ListToolsAutoPaging]
+// so that arguments can be validated. [ChatRunner.Run] calls it automatically
+// the first time if it has not been called yet.
+func (r *ChatRunner) LoadTools(ctx context.Context, opts ...option.RequestOption) error {
+	schemas := map[string]AIAdvisorListToolsResponseDataParameters{}
+	iter := r.client.AI.Advisor.ListToolsAutoPaging(ctx, AIAdvisorListToolsParams{}, opts...)
+	for iter.Next() {
+		tool := iter.Current()
+		schemas[tool.Name] = tool.Parameters
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	r.schemas = schemas
+	return nil
+}
+
+// Run sends params to the AI Advisor and resolves every function call in the
+// replies, returning the first reply that requests no further calls. If the
+// advisor is still requesting calls after [ChatRunner.MaxIterations] messages,
+// the latest reply is returned together with [ErrChatMaxIterations].
+func (r *ChatRunner) Run(ctx context.Context, params AIAdvisorChatSendMessageParams, opts ...option.RequestOption) (res *AIAdvisorChatSendMessageResponse, err error) {
+	if r.schemas == nil {
+		if err = r.LoadTools(ctx, opts...); err != nil {
+			return nil, err
+		}
+	}
+	maxIterations := r.MaxIterations
+	if maxIterations <= 0 {
+		maxIterations = DefaultChatRunnerMaxIterations
+	}
+
+	res, err = r.client.AI.Advisor.Chat.SendMessage(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	pending := res.FunctionCalls
+	for iterations := 1; len(pending) > 0; iterations++ {
+		if iterations >= maxIterations {
+			return res, ErrChatMaxIterations
+		}
+		call := pending[0]
+		pending = pending[1:]
+		if r.OnFunctionCall != nil {
+			r.OnFunctionCall(call)
+		}
+
+		output, err := r.call(ctx, call)
+		if err != nil {
+			return res, err
+		}
+		res, err = r.client.AI.Advisor.Chat.SendMessage(ctx, AIAdvisorChatSendMessageParams{
+			SessionID: F(res.SessionID),
+			FunctionResponse: F(AIAdvisorChatSendMessageParamsFunctionResponse{
+				Name:     F(call.Name),
+				Response: F(output),
+			}),
+		}, opts...)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, res.FunctionCalls...)
+	}
+	return res, nil
+}
+
+// call runs a single function call, converting failures the advisor can act on
+// into an error response. Only context errors are returned.
+func (r *ChatRunner) call(ctx context.Context, call AIAdvisorChatSendMessageResponseFunctionCall) (map[string]interface{}, error) {
+	args := call.Args
+	if args == nil {
+		args = map[string]interface{}{}
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	if _, ok := r.handlers[call.Name]; !ok {
+		err = &ChatToolError{Tool: call.Name, Message: "no handler is registered for this tool"}
+	} else if schema, ok := r.schemas[call.Name]; ok {
+		err = validateChatToolArgs(call.Name, schema, args)
+	}
+	if err == nil {
+		result, err = r.handlers[call.Name](ctx, args)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if err != nil {
+		return map[string]interface{}{"error": err.Error()}, nil
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return map[string]interface{}{"error": fmt.Sprintf("tool %s: encoding result: %v", call.Name, err)}, nil
+	}
+	var out map[string]interface{}
+	if json.Unmarshal(data, &out) != nil || out == nil {
+		out = map[string]interface{}{"result": json.RawMessage(data)}
+	}
+	return out, nil
+}
+
+func validateChatToolArgs(tool string, schema AIAdvisorListToolsResponseDataParameters, args map[string]interface{}) error {
+	properties := make(map[string]interface{}, len(schema.Properties))
+	for k, v := range schema.Properties {
+		properties[k] = v
+	}
+	required := make([]interface{}, len(schema.Required))
+	for i, name := range schema.Required {
+		required[i] = name
+	}
+	return validateChatToolValue(tool, "args", map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}, args)
+}
+
+// validateChatToolValue checks value against the subset of the OpenAPI schema
+// object that tool manifests use: type, enum, required, properties, items,
+// minimum/maximum and minLength/maxLength. Keywords it does not understand are
+// ignored.
+func validateChatToolValue(tool, path string, schema map[string]interface{}, value interface{}) error {
+	fail := func(format string, a ...interface{}) error {
+		return &ChatToolError{Tool: tool, Path: path, Message: fmt.Sprintf(format, a...)}
+	}
+
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || schema["type"] == nil || schema["type"] == "null" {
+			return nil
+		}
+		return fail("must not be null")
+	}
+
+	switch typ, _ := schema["type"].(string); typ {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return fail("must be a string")
+		}
+		if n, ok := schema["minLength"].(float64); ok && float64(len(s)) < n {
+			return fail("must be at least %v characters", n)
+		}
+		if n, ok := schema["maxLength"].(float64); ok && float64(len(s)) > n {
+			return fail("must be at most %v characters", n)
+		}
+		if format, _ := schema["format"].(string); format == "date" {
+			if _, err := time.Parse("2006-01-02", s); err != nil {
+				return fail("must be a date in YYYY-MM-DD format")
+			}
+		}
+	case "number", "integer":
+		n, ok := value.(float64)
+		if !ok {
+			return fail("must be a number")
+		}
+		if typ == "integer" && n != math.Trunc(n) {
+			return fail("must be an integer")
+		}
+		if min, ok := schema["minimum"].(float64); ok && n < min {
+			return fail("must be at least %v", min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && n > max {
+			return fail("must be at most %v", max)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fail("must be a boolean")
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fail("must be an array")
+		}
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range items {
+				if err := validateChatToolValue(tool, fmt.Sprintf("%s[%d]", path, i), itemSchema, item); err != nil {
+					return err
+				}
+			}
+		}
+	case "object":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fail("must be an object")
+		}
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := obj[name]; !present {
+					return &ChatToolError{Tool: tool, Path: path + "." + name, Message: "is required"}
+				}
+			}
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			propSchema, ok := properties[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if err := validateChatToolValue(tool, path+"."+name, propSchema, obj[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		for _, allowed := range enum {
+			if reflect.DeepEqual(allowed, value) {
+				return nil
+			}
+		}
+		options := make([]string, len(enum))
+		for i, allowed := range enum {
+			options[i] = fmt.Sprint(allowed)
+		}
+		return fail("must be one of %s", strings.Join(options, ", "))
+	}
+	return nil
+}
+
+func chatToolTransactionListParams(args map[string]interface{}) (params TransactionListParams, err error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return params, err
+	}
+	var decoded struct {
+		Category    *string                    `json:"category"`
+		EndDate     *string                    `json:"endDate"`
+		Limit       *int64                     `json:"limit"`
+		MaxAmount   *Decimal                   `json:"maxAmount"`
+		MinAmount   *Decimal                   `json:"minAmount"`
+		Offset      *int64                     `json:"offset"`
+		SearchQuery *string                    `json:"searchQuery"`
+		StartDate   *string                    `json:"startDate"`
+		Type        *TransactionListParamsType `json:"type"`
+	}
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return params, &ChatToolError{Tool: ChatToolListTransactions, Message: err.Error()}
+	}
+	date := func(name string, s *string) (t time.Time, err error) {
+		if t, err = time.Parse("2006-01-02", *s); err != nil {
+			err = &ChatToolError{Tool: ChatToolListTransactions, Path: "args." + name, Message: "must be a date in YYYY-MM-DD format"}
+		}
+		return
+	}
+
+	if decoded.Category != nil {
+		params.Category = F(*decoded.Category)
+	}
+	if decoded.EndDate != nil {
+		t, err := date("endDate", decoded.EndDate)
+		if err != nil {
+			return params, err
+		}
+		params.EndDate = F(t)
+	}
+	if decoded.Limit != nil {
+		params.Limit = F(*decoded.Limit)
+	}
+	if decoded.MaxAmount != nil {
+		params.MaxAmount = F(*decoded.MaxAmount)
+	}
+	if decoded.MinAmount != nil {
+		params.MinAmount = F(*decoded.MinAmount)
+	}
+	if decoded.Offset != nil {
+		params.Offset = F(*decoded.Offset)
+	}
+	if decoded.SearchQuery != nil {
+		params.SearchQuery = F(*decoded.SearchQuery)
+	}
+	if decoded.StartDate != nil {
+		t, err := date("startDate", decoded.StartDate)
+		if err != nil {
+			return params, err
+		}
+		params.StartDate = F(t)
+	}
+	if decoded.Type != nil {
+		params.Type = F(*decoded.Type)
+	}
+	return params, nil
+}
@@ -0,0 +1,132 @@
+package jocall3_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+const chatRunnerTools = `{"data":[
+	{"name":"get_budget","description":"Get a budget","accessScope":"read:budgets","parameters":{"type":"object","properties":{"budgetId":{"type":"string"}},"required":["budgetId"]}},
+	{"name":"convert","description":"Convert currency","accessScope":"read:fx","parameters":{"type":"object","properties":{"amount":{"type":"number","minimum":0},"currency":{"type":"string","enum":["USD","EUR"]}},"required":["amount","currency"]}}
+],"limit":10,"offset":0,"total":2}`
+
+// fakeAdvisor answers chat messages from a script: each reply is chosen by the
+// function response the runner sent, or by "" for the initial message.
+func fakeAdvisor(t *testing.T, replies map[string]string, sent *[]map[string]interface{}) *jocall3.Client {
+	return jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					var body string
+					switch {
+					case req.URL.Path == "/ai/advisor/tools":
+						body = chatRunnerTools
+					case req.URL.Path == "/budgets/budget_1":
+						body = `{"id":"budget_1","name":"Groceries","totalAmount":500,"spentAmount":120.5}`
+					case req.URL.Path == "/ai/advisor/chat":
+						var msg map[string]interface{}
+						json.NewDecoder(req.Body).Decode(&msg)
+						*sent = append(*sent, msg)
+						key := ""
+						if fr, ok := msg["functionResponse"].(map[string]interface{}); ok {
+							key = fr["name"].(string)
+						}
+						reply, ok := replies[key]
+						if !ok {
+							t.Fatalf("unexpected message: %v", msg)
+						}
+						body = reply
+					default:
+						t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			},
+		}),
+	)
+}
+
+func TestChatRunner(t *testing.T) {
+	var sent []map[string]interface{}
+	client := fakeAdvisor(t, map[string]string{
+		"":             `{"sessionId":"sess_1","functionCalls":[{"id":"call_1","name":"get_budget","args":{"budgetId":"budget_1"}},{"id":"call_2","name":"convert","args":{"amount":-5,"currency":"USD"}}]}`,
+		"get_budget":   `{"sessionId":"sess_1","functionCalls":[{"id":"call_3","name":"unknown_tool","args":{}}]}`,
+		"convert":      `{"sessionId":"sess_1"}`,
+		"unknown_tool": `{"sessionId":"sess_1","text":"You have $379.50 left."}`,
+	}, &sent)
+
+	runner := jocall3.NewChatRunner(client)
+	runner.RegisterBuiltinTools()
+	converted := false
+	runner.Register("convert", func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		converted = true
+		return 42, nil
+	})
+	var calls []string
+	runner.OnFunctionCall = func(call jocall3.AIAdvisorChatSendMessageResponseFunctionCall) {
+		calls = append(calls, call.ID)
+	}
+
+	res, err := runner.Run(context.Background(), jocall3.AIAdvisorChatSendMessageParams{
+		Message: jocall3.F("How much is left in my grocery budget?"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Text != "You have $379.50 left." {
+		t.Fatalf("unexpected final reply: %+v", res)
+	}
+	if strings.Join(calls, ",") != "call_1,call_2,call_3" || converted {
+		t.Fatalf("unexpected calls %v (converted=%v)", calls, converted)
+	}
+	if len(sent) != 4 {
+		t.Fatalf("expected 4 messages, got %d", len(sent))
+	}
+
+	budget := sent[1]["functionResponse"].(map[string]interface{})["response"].(map[string]interface{})
+	if sent[1]["sessionId"] != "sess_1" || budget["id"] != "budget_1" || budget["spentAmount"] != 120.5 {
+		t.Fatalf("unexpected budget response: %v", sent[1])
+	}
+	invalid := sent[2]["functionResponse"].(map[string]interface{})["response"].(map[string]interface{})
+	if invalid["error"] != "tool convert: args.amount: must be at least 0" {
+		t.Fatalf("unexpected validation response: %v", invalid)
+	}
+	unknown := sent[3]["functionResponse"].(map[string]interface{})["response"].(map[string]interface{})
+	if !strings.Contains(unknown["error"].(string), "no handler") {
+		t.Fatalf("unexpected unknown tool response: %v", unknown)
+	}
+}
+
+func TestChatRunnerMaxIterations(t *testing.T) {
+	var sent []map[string]interface{}
+	loop := `{"sessionId":"sess_1","functionCalls":[{"id":"call","name":"convert","args":{"amount":1,"currency":"EUR"}}]}`
+	client := fakeAdvisor(t, map[string]string{"": loop, "convert": loop}, &sent)
+
+	runner := jocall3.NewChatRunner(client)
+	runner.MaxIterations = 3
+	runner.Register("convert", func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		return map[string]interface{}{"amount": args["amount"].(float64) * 1.1}, nil
+	})
+	res, err := runner.Run(context.Background(), jocall3.AIAdvisorChatSendMessageParams{Message: jocall3.F("Convert")})
+	if !errors.Is(err, jocall3.ErrChatMaxIterations) {
+		t.Fatalf("expected ErrChatMaxIterations, got %v", err)
+	}
+	if res == nil || len(res.FunctionCalls) != 1 || len(sent) != 3 {
+		t.Fatalf("expected the last reply after 3 messages, got %+v after %d", res, len(sent))
+	}
+	if got := sent[1]["functionResponse"].(map[string]interface{})["response"].(map[string]interface{})["amount"]; got != 1.1 {
+		t.Fatalf("unexpected tool output: %v", got)
+	}
+}
@@ -0,0 +1,110 @@
+package jocall3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// AIAdvisorChatMessageAccumulator assembles the events of
+// [AIAdvisorChatService.SendMessageStreaming] into the complete message that
+// [AIAdvisorChatService.SendMessage] would have returned.
+//
+//	stream := client.AI.Advisor.Chat.SendMessageStreaming(ctx, params)
+//	acc := jocall3.AIAdvisorChatMessageAccumulator{}
+//	for stream.Next() {
+//		event := stream.Current()
+//		acc.AddEvent(event)
+//		fmt.Print(event.Delta)
+//	}
+//	if stream.Err() != nil {
+//		panic(stream.Err())
+//	}
+//	message := acc.Message()
+type AIAdvisorChatMessageAccumulator struct {
+	sessionID          string
+	text               strings.Builder
+	calls              []*aiAdvisorChatPendingFunctionCall
+	insights           []AIInsight
+	requiresUserAction bool
+	done               bool
+}
+
+type aiAdvisorChatPendingFunctionCall struct {
+	id   string
+	name string
+	args strings.Builder
+}
+
+// AddEvent folds event into the message. It reports an error if a function
+// call's arguments do not form a valid JSON object once the message stops, or
+// if an event arrives after the message has stopped.
+func (acc *AIAdvisorChatMessageAccumulator) AddEvent(event AIAdvisorChatStreamEvent) error {
+	if acc.done {
+		return fmt.Errorf("received %q event after message_stop", event.Type)
+	}
+	if event.SessionID != "" {
+		acc.sessionID = event.SessionID
+	}
+
+	switch event.Type {
+	case AIAdvisorChatStreamEventTypeTextDelta:
+		acc.text.WriteString(event.Delta)
+	case AIAdvisorChatStreamEventTypeFunctionCallDelta:
+		if event.Index < 0 {
+			return fmt.Errorf("function call chunk has negative index %d", event.Index)
+		}
+		for int64(len(acc.calls)) <= event.Index {
+			acc.calls = append(acc.calls, &aiAdvisorChatPendingFunctionCall{})
+		}
+		call := acc.calls[event.Index]
+		if event.ID != "" {
+			call.id = event.ID
+		}
+		if event.Name != "" {
+			call.name = event.Name
+		}
+		call.args.WriteString(event.ArgsDelta)
+	case AIAdvisorChatStreamEventTypeMessageStop:
+		acc.insights = event.ProactiveInsights
+		acc.requiresUserAction = event.RequiresUserAction
+		acc.done = true
+		for i, call := range acc.calls {
+			if call.args.Len() == 0 {
+				continue
+			}
+			var args map[string]interface{}
+			if err := json.Unmarshal([]byte(call.args.String()), &args); err != nil {
+				return fmt.Errorf("function call %d (%s) has invalid arguments: %w", i, call.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Done reports whether the message_stop event has been received.
+func (acc *AIAdvisorChatMessageAccumulator) Done() bool {
+	return acc.done
+}
+
+// Message returns the message assembled so far. Function call arguments that
+// are still incomplete are omitted.
+func (acc *AIAdvisorChatMessageAccumulator) Message() AIAdvisorChatSendMessageResponse {
+	msg := AIAdvisorChatSendMessageResponse{
+		SessionID:          acc.sessionID,
+		Text:               acc.text.String(),
+		ProactiveInsights:  acc.insights,
+		RequiresUserAction: acc.requiresUserAction,
+	}
+	for _, call := range acc.calls {
+		fc := AIAdvisorChatSendMessageResponseFunctionCall{ID: call.id, Name: call.name}
+		if call.args.Len() > 0 {
+			var args map[string]interface{}
+			if json.Unmarshal([]byte(call.args.String()), &args) == nil {
+				fc.Args = args
+			}
+		}
+		msg.FunctionCalls = append(msg.FunctionCalls, fc)
+	}
+	return msg
+}
@@ -0,0 +1,156 @@
+package jocall3_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+const chatStream = `event: message_start
+data: {"type":"message_start","sessionId":"sess_1"}
+
+: keep-alive
+
+event: text_delta
+data: {"type":"text_delta","delta":"You spent "}
+
+event: text_delta
+data: {"type":"text_delta","delta":"$42.10 on coffee."}
+
+event: function_call_delta
+data: {"type":"function_call_delta","index":0,"id":"call_1","name":"get_budget","argsDelta":"{\"budget"}
+
+event: function_call_delta
+data: {"type":"function_call_delta","index":0,"argsDelta":"Id\":\"budget_1\"}"}
+
+event: message_stop
+data: {"type":"message_stop","requiresUserAction":true}
+
+data: [DONE]
+
+`
+
+func TestAIAdvisorChatSendMessageStreaming(t *testing.T) {
+	var body map[string]interface{}
+	var accept string
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					accept = req.Header.Get("Accept")
+					json.NewDecoder(req.Body).Decode(&body)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"text/event-stream"}},
+						Body:       io.NopCloser(strings.NewReader(chatStream)),
+					}, nil
+				},
+			},
+		}),
+	)
+
+	stream := client.AI.Advisor.Chat.SendMessageStreaming(context.Background(), jocall3.AIAdvisorChatSendMessageParams{
+		Message: jocall3.F("How much did I spend on coffee?"),
+	})
+	acc := jocall3.AIAdvisorChatMessageAccumulator{}
+	var text strings.Builder
+	events := 0
+	for stream.Next() {
+		event := stream.Current()
+		if err := acc.AddEvent(event); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		text.WriteString(event.Delta)
+		events++
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accept != "text/event-stream" || body["stream"] != true || body["message"] != "How much did I spend on coffee?" {
+		t.Fatalf("unexpected request: accept=%q body=%v", accept, body)
+	}
+	if events != 6 || !acc.Done() {
+		t.Fatalf("expected 6 events and a finished message, got %d", events)
+	}
+
+	msg := acc.Message()
+	if msg.SessionID != "sess_1" || msg.Text != "You spent $42.10 on coffee." || msg.Text != text.String() || !msg.RequiresUserAction {
+		t.Fatalf("unexpected message: %+v", msg)
+	}
+	if len(msg.FunctionCalls) != 1 {
+		t.Fatalf("expected one function call, got %+v", msg.FunctionCalls)
+	}
+	if call := msg.FunctionCalls[0]; call.ID != "call_1" || call.Name != "get_budget" || call.Args["budgetId"] != "budget_1" {
+		t.Fatalf("unexpected function call: %+v", call)
+	}
+}
+
+func TestAIAdvisorChatMessageAccumulatorInvalidArgs(t *testing.T) {
+	acc := jocall3.AIAdvisorChatMessageAccumulator{}
+	acc.AddEvent(jocall3.AIAdvisorChatStreamEvent{Type: jocall3.AIAdvisorChatStreamEventTypeFunctionCallDelta, Index: 1, Name: "transfer", ArgsDelta: `{"amount":`})
+	if err := acc.AddEvent(jocall3.AIAdvisorChatStreamEvent{Type: jocall3.AIAdvisorChatStreamEventTypeMessageStop}); err == nil {
+		t.Fatalf("expected an error for truncated arguments")
+	}
+	if err := acc.AddEvent(jocall3.AIAdvisorChatStreamEvent{Type: jocall3.AIAdvisorChatStreamEventTypeTextDelta}); err == nil {
+		t.Fatalf("expected an error for an event after message_stop")
+	}
+	msg := acc.Message()
+	if len(msg.FunctionCalls) != 2 || msg.FunctionCalls[1].Name != "transfer" || msg.FunctionCalls[1].Args != nil {
+		t.Fatalf("unexpected function calls: %+v", msg.FunctionCalls)
+	}
+}
+
+func TestAIAdvisorChatSendMessageStreamingClose(t *testing.T) {
+	for _, cancel := range []bool{false, true} {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, "data: {\"type\":\"text_delta\",\"delta\":\"Hi\"}\n\n")
+			w.(http.Flusher).Flush()
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+
+		ctx, stop := context.WithCancel(context.Background())
+		client := jocall3.NewClient(option.WithBaseURL(srv.URL), option.WithMaxRetries(0))
+		stream := client.AI.Advisor.Chat.SendMessageStreaming(ctx, jocall3.AIAdvisorChatSendMessageParams{})
+		if !stream.Next() || stream.Current().Delta != "Hi" {
+			t.Fatalf("expected the first delta, got err %v", stream.Err())
+		}
+
+		done := make(chan bool)
+		go func() { done <- stream.Next() }()
+		if cancel {
+			stop()
+		} else {
+			time.Sleep(10 * time.Millisecond)
+			stream.Close()
+		}
+		select {
+		case more := <-done:
+			if more {
+				t.Fatalf("expected the stream to end")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("stream did not stop (cancel=%v)", cancel)
+		}
+		if cancel && !errors.Is(stream.Err(), context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", stream.Err())
+		}
+
+		stop()
+		close(release)
+		srv.Close()
+	}
+}
@@ -8,11 +8,11 @@ import (
 	"net/url"
 	"slices"
 
-	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // AIIncubatorService contains methods and other services that help with
@@ -38,40 +38,34 @@ func NewAIIncubatorService(opts ...option.RequestOption) (r *AIIncubatorService)
 
 // Retrieves a summary list of all business pitches submitted by the authenticated
 // user to Quantum Weaver.
-func (r *AIIncubatorService) ListPitches(ctx context.Context, query AIIncubatorListPitchesParams, opts ...option.RequestOption) (res *AIIncubatorListPitchesResponse, err error) {
+func (r *AIIncubatorService) ListPitches(ctx context.Context, query AIIncubatorListPitchesParams, opts ...option.RequestOption) (res *pagination.Page[QuantumWeaverState], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "ai/incubator/pitches"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
-}
-
-type AIIncubatorListPitchesResponse struct {
-	Data []QuantumWeaverState               `json:"data"`
-	JSON aiIncubatorListPitchesResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// aiIncubatorListPitchesResponseJSON contains the JSON metadata for the struct
-// [AIIncubatorListPitchesResponse]
-type aiIncubatorListPitchesResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *AIIncubatorListPitchesResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
 }
 
-func (r aiIncubatorListPitchesResponseJSON) RawJSON() string {
-	return r.raw
+// Retrieves a summary list of all business pitches submitted by the authenticated
+// user to Quantum Weaver.
+func (r *AIIncubatorService) ListPitchesAutoPaging(ctx context.Context, query AIIncubatorListPitchesParams, opts ...option.RequestOption) *pagination.PageAutoPager[QuantumWeaverState] {
+	return pagination.NewPageAutoPager(r.ListPitches(ctx, query, opts...))
 }
 
 type AIIncubatorListPitchesParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 	// Filter pitches by their current stage.
 	Status param.Field[AIIncubatorListPitchesParamsStatus] `query:"status"`
 }
@@ -4,9 +4,11 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -36,9 +38,13 @@ func NewAIIncubatorPitchService(opts ...option.RequestOption) (r *AIIncubatorPit
 // Retrieves the granular AI-driven analysis, strategic feedback, market validation
 // results, and any outstanding questions from Quantum Weaver for a specific
 // business pitch.
-func (r *AIIncubatorPitchService) GetDetails(ctx context.Context, pitchID interface{}, opts ...option.RequestOption) (res *AIIncubatorPitchGetDetailsResponse, err error) {
+func (r *AIIncubatorPitchService) GetDetails(ctx context.Context, pitchID string, opts ...option.RequestOption) (res *AIIncubatorPitchGetDetailsResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("ai/incubator/pitch/%v/details", pitchID)
+	if pitchID == "" {
+		err = errors.New("missing required pitchId parameter")
+		return
+	}
+	path := fmt.Sprintf("ai/incubator/pitch/%s/details", pitchID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
@@ -57,28 +63,32 @@ func (r *AIIncubatorPitchService) Submit(ctx context.Context, body AIIncubatorPi
 // Allows the entrepreneur to respond to specific questions or provide additional
 // details requested by Quantum Weaver, moving the pitch forward in the incubation
 // process.
-func (r *AIIncubatorPitchService) SubmitFeedback(ctx context.Context, pitchID interface{}, body AIIncubatorPitchSubmitFeedbackParams, opts ...option.RequestOption) (res *QuantumWeaverState, err error) {
+func (r *AIIncubatorPitchService) SubmitFeedback(ctx context.Context, pitchID string, body AIIncubatorPitchSubmitFeedbackParams, opts ...option.RequestOption) (res *QuantumWeaverState, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("ai/incubator/pitch/%v/feedback", pitchID)
+	if pitchID == "" {
+		err = errors.New("missing required pitchId parameter")
+		return
+	}
+	path := fmt.Sprintf("ai/incubator/pitch/%s/feedback", pitchID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
 
 type QuantumWeaverState struct {
 	// Timestamp of the last status update.
-	LastUpdated interface{} `json:"lastUpdated,required"`
+	LastUpdated time.Time `json:"lastUpdated,required" format:"date-time"`
 	// Guidance on the next actions for the user.
-	NextSteps interface{} `json:"nextSteps,required"`
+	NextSteps string `json:"nextSteps,required"`
 	// Unique identifier for the business pitch.
-	PitchID interface{} `json:"pitchId,required"`
+	PitchID string `json:"pitchId,required"`
 	// Current stage of the business pitch in the incubation process.
 	Stage QuantumWeaverStateStage `json:"stage,required"`
 	// A human-readable status message.
-	StatusMessage interface{} `json:"statusMessage,required"`
+	StatusMessage string `json:"statusMessage,required"`
 	// AI's estimated funding offer, if the pitch progresses.
-	EstimatedFundingOffer interface{} `json:"estimatedFundingOffer"`
+	EstimatedFundingOffer Decimal `json:"estimatedFundingOffer"`
 	// A summary of AI-generated feedback, if applicable.
-	FeedbackSummary interface{} `json:"feedbackSummary"`
+	FeedbackSummary string `json:"feedbackSummary"`
 	// List of questions from Quantum Weaver requiring the user's input.
 	Questions []QuantumWeaverStateQuestion `json:"questions,nullable"`
 	JSON      quantumWeaverStateJSON       `json:"-"`
@@ -131,10 +141,10 @@ func (r QuantumWeaverStateStage) IsKnown() bool {
 }
 
 type QuantumWeaverStateQuestion struct {
-	ID         interface{}                    `json:"id"`
-	Category   interface{}                    `json:"category"`
-	IsRequired interface{}                    `json:"isRequired"`
-	Question   interface{}                    `json:"question"`
+	ID         string                         `json:"id"`
+	Category   string                         `json:"category"`
+	IsRequired bool                           `json:"isRequired"`
+	Question   string                         `json:"question"`
 	JSON       quantumWeaverStateQuestionJSON `json:"-"`
 }
 
@@ -168,7 +178,7 @@ type AIIncubatorPitchGetDetailsResponse struct {
 	AIRiskAssessment AIIncubatorPitchGetDetailsResponseAIRiskAssessment `json:"aiRiskAssessment,nullable"`
 	// AI's score for how well the pitch matches potential investors in the network
 	// (0-1).
-	InvestorMatchScore interface{}                            `json:"investorMatchScore"`
+	InvestorMatchScore float64                                `json:"investorMatchScore"`
 	JSON               aiIncubatorPitchGetDetailsResponseJSON `json:"-"`
 	QuantumWeaverState
 }
@@ -196,8 +206,8 @@ func (r aiIncubatorPitchGetDetailsResponseJSON) RawJSON() string {
 // AI-generated coaching plan for the entrepreneur.
 type AIIncubatorPitchGetDetailsResponseAICoachingPlan struct {
 	Steps   []AIIncubatorPitchGetDetailsResponseAICoachingPlanStep `json:"steps"`
-	Summary interface{}                                            `json:"summary"`
-	Title   interface{}                                            `json:"title"`
+	Summary string                                                 `json:"summary"`
+	Title   string                                                 `json:"title"`
 	JSON    aiIncubatorPitchGetDetailsResponseAICoachingPlanJSON   `json:"-"`
 }
 
@@ -220,11 +230,11 @@ func (r aiIncubatorPitchGetDetailsResponseAICoachingPlanJSON) RawJSON() string {
 }
 
 type AIIncubatorPitchGetDetailsResponseAICoachingPlanStep struct {
-	Description interface{}                                                     `json:"description"`
+	Description string                                                          `json:"description"`
 	Resources   []AIIncubatorPitchGetDetailsResponseAICoachingPlanStepsResource `json:"resources"`
 	Status      AIIncubatorPitchGetDetailsResponseAICoachingPlanStepsStatus     `json:"status"`
-	Timeline    interface{}                                                     `json:"timeline"`
-	Title       interface{}                                                     `json:"title"`
+	Timeline    string                                                          `json:"timeline"`
+	Title       string                                                          `json:"title"`
 	JSON        aiIncubatorPitchGetDetailsResponseAICoachingPlanStepJSON        `json:"-"`
 }
 
@@ -249,8 +259,8 @@ func (r aiIncubatorPitchGetDetailsResponseAICoachingPlanStepJSON) RawJSON() stri
 }
 
 type AIIncubatorPitchGetDetailsResponseAICoachingPlanStepsResource struct {
-	Name interface{}                                                       `json:"name"`
-	URL  interface{}                                                       `json:"url"`
+	Name string                                                            `json:"name"`
+	URL  string                                                            `json:"url"`
 	JSON aiIncubatorPitchGetDetailsResponseAICoachingPlanStepsResourceJSON `json:"-"`
 }
 
@@ -290,10 +300,10 @@ func (r AIIncubatorPitchGetDetailsResponseAICoachingPlanStepsStatus) IsKnown() b
 
 // AI's detailed financial model analysis.
 type AIIncubatorPitchGetDetailsResponseAIFinancialModel struct {
-	BreakevenPoint        interface{}                                                             `json:"breakevenPoint"`
-	CapitalRequirements   interface{}                                                             `json:"capitalRequirements"`
-	CostStructureAnalysis interface{}                                                             `json:"costStructureAnalysis"`
-	RevenueBreakdown      interface{}                                                             `json:"revenueBreakdown"`
+	BreakevenPoint        string                                                                  `json:"breakevenPoint"`
+	CapitalRequirements   Decimal                                                                 `json:"capitalRequirements"`
+	CostStructureAnalysis map[string]interface{}                                                  `json:"costStructureAnalysis"`
+	RevenueBreakdown      map[string]interface{}                                                  `json:"revenueBreakdown"`
 	SensitivityAnalysis   []AIIncubatorPitchGetDetailsResponseAIFinancialModelSensitivityAnalysis `json:"sensitivityAnalysis"`
 	JSON                  aiIncubatorPitchGetDetailsResponseAIFinancialModelJSON                  `json:"-"`
 }
@@ -319,9 +329,9 @@ func (r aiIncubatorPitchGetDetailsResponseAIFinancialModelJSON) RawJSON() string
 }
 
 type AIIncubatorPitchGetDetailsResponseAIFinancialModelSensitivityAnalysis struct {
-	ProjectedIrr  interface{}                                                               `json:"projectedIRR"`
-	Scenario      interface{}                                                               `json:"scenario"`
-	TerminalValue interface{}                                                               `json:"terminalValue"`
+	ProjectedIrr  float64                                                                   `json:"projectedIRR"`
+	Scenario      string                                                                    `json:"scenario"`
+	TerminalValue Decimal                                                                   `json:"terminalValue"`
 	JSON          aiIncubatorPitchGetDetailsResponseAIFinancialModelSensitivityAnalysisJSON `json:"-"`
 }
 
@@ -346,10 +356,10 @@ func (r aiIncubatorPitchGetDetailsResponseAIFinancialModelSensitivityAnalysisJSO
 
 // AI's detailed market analysis.
 type AIIncubatorPitchGetDetailsResponseAIMarketAnalysis struct {
-	CompetitiveAdvantages []interface{}                                          `json:"competitiveAdvantages"`
-	GrowthOpportunities   interface{}                                            `json:"growthOpportunities"`
-	RiskFactors           interface{}                                            `json:"riskFactors"`
-	TargetMarketSize      interface{}                                            `json:"targetMarketSize"`
+	CompetitiveAdvantages []string                                               `json:"competitiveAdvantages"`
+	GrowthOpportunities   string                                                 `json:"growthOpportunities"`
+	RiskFactors           string                                                 `json:"riskFactors"`
+	TargetMarketSize      string                                                 `json:"targetMarketSize"`
 	JSON                  aiIncubatorPitchGetDetailsResponseAIMarketAnalysisJSON `json:"-"`
 }
 
@@ -374,9 +384,9 @@ func (r aiIncubatorPitchGetDetailsResponseAIMarketAnalysisJSON) RawJSON() string
 
 // AI's assessment of risks associated with the venture.
 type AIIncubatorPitchGetDetailsResponseAIRiskAssessment struct {
-	MarketRisk    interface{}                                            `json:"marketRisk"`
-	TeamRisk      interface{}                                            `json:"teamRisk"`
-	TechnicalRisk interface{}                                            `json:"technicalRisk"`
+	MarketRisk    string                                                 `json:"marketRisk"`
+	TeamRisk      string                                                 `json:"teamRisk"`
+	TechnicalRisk string                                                 `json:"technicalRisk"`
 	JSON          aiIncubatorPitchGetDetailsResponseAIRiskAssessmentJSON `json:"-"`
 }
 
@@ -401,14 +411,14 @@ func (r aiIncubatorPitchGetDetailsResponseAIRiskAssessmentJSON) RawJSON() string
 type AIIncubatorPitchSubmitParams struct {
 	// The user's detailed narrative business plan (e.g., executive summary, vision,
 	// strategy).
-	BusinessPlan param.Field[interface{}] `json:"businessPlan,required"`
+	BusinessPlan param.Field[string] `json:"businessPlan,required"`
 	// Key financial metrics and projections for the next 3-5 years.
 	FinancialProjections param.Field[AIIncubatorPitchSubmitParamsFinancialProjections] `json:"financialProjections,required"`
 	// Key profiles and expertise of the founding team members.
 	FoundingTeam param.Field[[]AIIncubatorPitchSubmitParamsFoundingTeam] `json:"foundingTeam,required"`
 	// Detailed analysis of the target market, problem statement, and proposed
 	// solution's unique value proposition.
-	MarketOpportunity param.Field[interface{}] `json:"marketOpportunity,required"`
+	MarketOpportunity param.Field[string] `json:"marketOpportunity,required"`
 }
 
 func (r AIIncubatorPitchSubmitParams) MarshalJSON() (data []byte, err error) {
@@ -418,14 +428,14 @@ func (r AIIncubatorPitchSubmitParams) MarshalJSON() (data []byte, err error) {
 // Key financial metrics and projections for the next 3-5 years.
 type AIIncubatorPitchSubmitParamsFinancialProjections struct {
 	// Estimated time to profitability.
-	ProfitabilityEstimate param.Field[interface{}] `json:"profitabilityEstimate"`
+	ProfitabilityEstimate param.Field[string] `json:"profitabilityEstimate"`
 	// Number of years for financial projections.
-	ProjectionYears param.Field[interface{}]   `json:"projectionYears"`
-	RevenueForecast param.Field[[]interface{}] `json:"revenueForecast"`
+	ProjectionYears param.Field[int64]     `json:"projectionYears"`
+	RevenueForecast param.Field[[]Decimal] `json:"revenueForecast"`
 	// Requested seed funding in USD.
-	SeedRoundAmount param.Field[interface{}] `json:"seedRoundAmount"`
+	SeedRoundAmount param.Field[Decimal] `json:"seedRoundAmount"`
 	// Pre-money valuation in USD.
-	ValuationPreMoney param.Field[interface{}] `json:"valuationPreMoney"`
+	ValuationPreMoney param.Field[Decimal] `json:"valuationPreMoney"`
 }
 
 func (r AIIncubatorPitchSubmitParamsFinancialProjections) MarshalJSON() (data []byte, err error) {
@@ -434,11 +444,11 @@ func (r AIIncubatorPitchSubmitParamsFinancialProjections) MarshalJSON() (data []
 
 type AIIncubatorPitchSubmitParamsFoundingTeam struct {
 	// Relevant experience.
-	Experience param.Field[interface{}] `json:"experience"`
+	Experience param.Field[string] `json:"experience"`
 	// Name of the team member.
-	Name param.Field[interface{}] `json:"name"`
+	Name param.Field[string] `json:"name"`
 	// Role of the team member.
-	Role param.Field[interface{}] `json:"role"`
+	Role param.Field[string] `json:"role"`
 }
 
 func (r AIIncubatorPitchSubmitParamsFoundingTeam) MarshalJSON() (data []byte, err error) {
@@ -448,7 +458,7 @@ func (r AIIncubatorPitchSubmitParamsFoundingTeam) MarshalJSON() (data []byte, er
 type AIIncubatorPitchSubmitFeedbackParams struct {
 	Answers param.Field[[]AIIncubatorPitchSubmitFeedbackParamsAnswer] `json:"answers"`
 	// General textual feedback or additional details for Quantum Weaver.
-	Feedback param.Field[interface{}] `json:"feedback"`
+	Feedback param.Field[string] `json:"feedback"`
 }
 
 func (r AIIncubatorPitchSubmitFeedbackParams) MarshalJSON() (data []byte, err error) {
@@ -457,9 +467,9 @@ func (r AIIncubatorPitchSubmitFeedbackParams) MarshalJSON() (data []byte, err er
 
 type AIIncubatorPitchSubmitFeedbackParamsAnswer struct {
 	// The answer to the specific question.
-	Answer param.Field[interface{}] `json:"answer,required"`
+	Answer param.Field[string] `json:"answer,required"`
 	// The ID of the question being answered.
-	QuestionID param.Field[interface{}] `json:"questionId,required"`
+	QuestionID param.Field[string] `json:"questionId,required"`
 }
 
 func (r AIIncubatorPitchSubmitFeedbackParamsAnswer) MarshalJSON() (data []byte, err error) {
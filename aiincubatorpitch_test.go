@@ -48,24 +48,24 @@ func TestAIIncubatorPitchSubmitWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.AI.Incubator.Pitch.Submit(context.TODO(), jocall3.AIIncubatorPitchSubmitParams{
-		BusinessPlan: jocall3.F[any]("Quantum-AI powered financial advisor platform leveraging neural networks for predictive analytics and hyper-personalized advice..."),
+		BusinessPlan: jocall3.F("Quantum-AI powered financial advisor platform leveraging neural networks for predictive analytics and hyper-personalized advice..."),
 		FinancialProjections: jocall3.F(jocall3.AIIncubatorPitchSubmitParamsFinancialProjections{
-			ProfitabilityEstimate: jocall3.F[any]("Achieve profitability within 18 months."),
-			ProjectionYears:       jocall3.F[any](3),
-			RevenueForecast:       jocall3.F([]interface{}{500000, 2000000, 6000000}),
-			SeedRoundAmount:       jocall3.F[any](2500000),
-			ValuationPreMoney:     jocall3.F[any](10000000),
+			ProfitabilityEstimate: jocall3.F("Achieve profitability within 18 months."),
+			ProjectionYears:       jocall3.F(int64(3)),
+			RevenueForecast:       jocall3.F([]jocall3.Decimal{jocall3.MustDecimal("500000"), jocall3.MustDecimal("2000000"), jocall3.MustDecimal("6000000")}),
+			SeedRoundAmount:       jocall3.F(jocall3.MustDecimal("2500000")),
+			ValuationPreMoney:     jocall3.F(jocall3.MustDecimal("10000000")),
 		}),
 		FoundingTeam: jocall3.F([]jocall3.AIIncubatorPitchSubmitParamsFoundingTeam{{
-			Experience: jocall3.F[any]("15+ years in AI/ML, PhD in Quantum Computing, ex-Google Brain"),
-			Name:       jocall3.F[any]("Dr. Eleanor Vance"),
-			Role:       jocall3.F[any]("CEO & Lead AI Scientist"),
+			Experience: jocall3.F("15+ years in AI/ML, PhD in Quantum Computing, ex-Google Brain"),
+			Name:       jocall3.F("Dr. Eleanor Vance"),
+			Role:       jocall3.F("CEO & Lead AI Scientist"),
 		}, {
-			Experience: jocall3.F[any]("20+ years in Fintech, ex-Goldman Sachs"),
-			Name:       jocall3.F[any]("Marcus Thorne"),
-			Role:       jocall3.F[any]("COO & Finance Expert"),
+			Experience: jocall3.F("20+ years in Fintech, ex-Goldman Sachs"),
+			Name:       jocall3.F("Marcus Thorne"),
+			Role:       jocall3.F("COO & Finance Expert"),
 		}}),
-		MarketOpportunity: jocall3.F[any]("The booming digital finance market coupled with demand for truly personalized, AI-driven financial guidance presents a multi-billion dollar opportunity. Our unique quantum-AI approach provides unparalleled accuracy and foresight."),
+		MarketOpportunity: jocall3.F("The booming digital finance market coupled with demand for truly personalized, AI-driven financial guidance presents a multi-billion dollar opportunity. Our unique quantum-AI approach provides unparalleled accuracy and foresight."),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -93,13 +93,13 @@ func TestAIIncubatorPitchSubmitFeedbackWithOptionalParams(t *testing.T) {
 		"pitch_qw_synergychain-xyz",
 		jocall3.AIIncubatorPitchSubmitFeedbackParams{
 			Answers: jocall3.F([]jocall3.AIIncubatorPitchSubmitFeedbackParamsAnswer{{
-				Answer:     jocall3.F[any]("Our mitigation strategy includes dedicated R&D and new hires with specific expertise."),
-				QuestionID: jocall3.F[any]("q_qa-team-001"),
+				Answer:     jocall3.F("Our mitigation strategy includes dedicated R&D and new hires with specific expertise."),
+				QuestionID: jocall3.F("q_qa-team-001"),
 			}, {
-				Answer:     jocall3.F[any]("Our CAC projections are based on pilot program results showing $500 per enterprise client with a conversion rate of 10% from trials."),
-				QuestionID: jocall3.F[any]("q_qa-market-002"),
+				Answer:     jocall3.F("Our CAC projections are based on pilot program results showing $500 per enterprise client with a conversion rate of 10% from trials."),
+				QuestionID: jocall3.F("q_qa-market-002"),
 			}}),
-			Feedback: jocall3.F[any]("Regarding the technical challenges, our team has allocated 3 months for R&D on quantum-resistant cryptography, mitigating the risk. We've also brought in Dr. Elena Petrova, a leading expert in secure multi-party computation."),
+			Feedback: jocall3.F("Regarding the technical challenges, our team has allocated 3 months for R&D on quantum-resistant cryptography, mitigating the risk. We've also brought in Dr. Elena Petrova, a leading expert in secure multi-party computation."),
 		},
 	)
 	if err != nil {
@@ -55,10 +55,10 @@ func (r *AIOracleSimulateService) RunStandard(ctx context.Context, body AIOracle
 
 type AdvancedSimulationResponse struct {
 	// A high-level summary of findings across all scenarios.
-	OverallSummary  interface{}                                `json:"overallSummary,required"`
+	OverallSummary  string                                     `json:"overallSummary,required"`
 	ScenarioResults []AdvancedSimulationResponseScenarioResult `json:"scenarioResults,required"`
 	// Unique identifier for the completed advanced simulation.
-	SimulationID interface{} `json:"simulationId,required"`
+	SimulationID string `json:"simulationId,required"`
 	// Overarching strategic recommendations derived from the comparison of scenarios.
 	StrategicRecommendations []AIInsight                    `json:"strategicRecommendations,nullable"`
 	JSON                     advancedSimulationResponseJSON `json:"-"`
@@ -87,13 +87,13 @@ func (r AdvancedSimulationResponse) implementsAIOracleSimulationGetResponse() {}
 
 type AdvancedSimulationResponseScenarioResult struct {
 	// Summary of results for this specific scenario.
-	NarrativeSummary interface{} `json:"narrativeSummary,required"`
+	NarrativeSummary string `json:"narrativeSummary,required"`
 	// Name of the individual scenario.
-	ScenarioName interface{} `json:"scenarioName,required"`
+	ScenarioName string `json:"scenarioName,required"`
 	// Specific AI insights for this scenario.
 	AIInsights []AIInsight `json:"aiInsights,nullable"`
 	// Projected net worth at the end of the simulation period for this scenario.
-	FinalNetWorthProjected interface{}                                               `json:"finalNetWorthProjected"`
+	FinalNetWorthProjected Decimal                                                   `json:"finalNetWorthProjected"`
 	LiquidityMetrics       AdvancedSimulationResponseScenarioResultsLiquidityMetrics `json:"liquidityMetrics,nullable"`
 	// Data for generating sensitivity analysis charts (e.g., how net worth changes as
 	// a variable is adjusted).
@@ -124,9 +124,9 @@ func (r advancedSimulationResponseScenarioResultJSON) RawJSON() string {
 
 type AdvancedSimulationResponseScenarioResultsLiquidityMetrics struct {
 	// Minimum cash balance reached during the scenario.
-	MinCashBalance interface{} `json:"minCashBalance"`
+	MinCashBalance Decimal `json:"minCashBalance"`
 	// Time in months to recover to pre-event financial state.
-	RecoveryTimeMonths interface{}                                                   `json:"recoveryTimeMonths"`
+	RecoveryTimeMonths int64                                                         `json:"recoveryTimeMonths"`
 	JSON               advancedSimulationResponseScenarioResultsLiquidityMetricsJSON `json:"-"`
 }
 
@@ -150,7 +150,7 @@ func (r advancedSimulationResponseScenarioResultsLiquidityMetricsJSON) RawJSON()
 
 type AdvancedSimulationResponseScenarioResultsSensitivityAnalysisGraph struct {
 	Data      []AdvancedSimulationResponseScenarioResultsSensitivityAnalysisGraphsData `json:"data"`
-	ParamName interface{}                                                              `json:"paramName"`
+	ParamName string                                                                   `json:"paramName"`
 	JSON      advancedSimulationResponseScenarioResultsSensitivityAnalysisGraphJSON    `json:"-"`
 }
 
@@ -173,8 +173,8 @@ func (r advancedSimulationResponseScenarioResultsSensitivityAnalysisGraphJSON) R
 }
 
 type AdvancedSimulationResponseScenarioResultsSensitivityAnalysisGraphsData struct {
-	OutcomeValue interface{}                                                                `json:"outcomeValue"`
-	ParamValue   interface{}                                                                `json:"paramValue"`
+	OutcomeValue Decimal                                                                    `json:"outcomeValue"`
+	ParamValue   Decimal                                                                    `json:"paramValue"`
 	JSON         advancedSimulationResponseScenarioResultsSensitivityAnalysisGraphsDataJSON `json:"-"`
 }
 
@@ -200,9 +200,9 @@ type SimulationResponse struct {
 	// Key quantitative and qualitative impacts identified by the AI.
 	KeyImpacts []SimulationResponseKeyImpact `json:"keyImpacts,required"`
 	// A natural language summary of the simulation's results and key findings.
-	NarrativeSummary interface{} `json:"narrativeSummary,required"`
+	NarrativeSummary string `json:"narrativeSummary,required"`
 	// Unique identifier for the completed simulation.
-	SimulationID interface{} `json:"simulationId,required"`
+	SimulationID string `json:"simulationId,required"`
 	// Actionable recommendations derived from the simulation.
 	Recommendations []AIInsight `json:"recommendations,nullable"`
 	// AI-driven risk assessment of the simulated scenario.
@@ -236,9 +236,9 @@ func (r simulationResponseJSON) RawJSON() string {
 func (r SimulationResponse) implementsAIOracleSimulationGetResponse() {}
 
 type SimulationResponseKeyImpact struct {
-	Metric   interface{}                          `json:"metric"`
+	Metric   string                               `json:"metric"`
 	Severity SimulationResponseKeyImpactsSeverity `json:"severity"`
-	Value    interface{}                          `json:"value"`
+	Value    Decimal                              `json:"value"`
 	JSON     simulationResponseKeyImpactJSON      `json:"-"`
 }
 
@@ -279,9 +279,9 @@ func (r SimulationResponseKeyImpactsSeverity) IsKnown() bool {
 // AI-driven risk assessment of the simulated scenario.
 type SimulationResponseRiskAnalysis struct {
 	// Maximum potential loss from peak to trough (e.g., 0.25 for 25%).
-	MaxDrawdown interface{} `json:"maxDrawdown"`
+	MaxDrawdown float64 `json:"maxDrawdown"`
 	// Measure of market volatility associated with the scenario.
-	VolatilityIndex interface{}                        `json:"volatilityIndex"`
+	VolatilityIndex float64                            `json:"volatilityIndex"`
 	JSON            simulationResponseRiskAnalysisJSON `json:"-"`
 }
 
@@ -303,8 +303,8 @@ func (r simulationResponseRiskAnalysisJSON) RawJSON() string {
 }
 
 type SimulationResponseVisualization struct {
-	DataUri interface{}                          `json:"dataUri"`
-	Title   interface{}                          `json:"title"`
+	DataUri string                               `json:"dataUri"`
+	Title   string                               `json:"title"`
 	Type    SimulationResponseVisualizationsType `json:"type"`
 	JSON    simulationResponseVisualizationJSON  `json:"-"`
 }
@@ -345,7 +345,7 @@ func (r SimulationResponseVisualizationsType) IsKnown() bool {
 
 type AIOracleSimulateRunAdvancedParams struct {
 	// A natural language prompt describing the complex, multi-variable scenario.
-	Prompt    param.Field[interface{}]                                 `json:"prompt,required"`
+	Prompt    param.Field[string]                                      `json:"prompt,required"`
 	Scenarios param.Field[[]AIOracleSimulateRunAdvancedParamsScenario] `json:"scenarios,required"`
 	// Optional: Global economic conditions to apply to all scenarios.
 	GlobalEconomicFactors param.Field[AIOracleSimulateRunAdvancedParamsGlobalEconomicFactors] `json:"globalEconomicFactors"`
@@ -359,11 +359,11 @@ func (r AIOracleSimulateRunAdvancedParams) MarshalJSON() (data []byte, err error
 
 type AIOracleSimulateRunAdvancedParamsScenario struct {
 	// The duration in years over which this scenario is simulated.
-	DurationYears param.Field[interface{}] `json:"durationYears,required"`
+	DurationYears param.Field[int64] `json:"durationYears,required"`
 	// A list of discrete or continuous events that define this scenario.
 	Events param.Field[[]AIOracleSimulateRunAdvancedParamsScenariosEvent] `json:"events,required"`
 	// A descriptive name for this specific scenario.
-	Name param.Field[interface{}] `json:"name,required"`
+	Name param.Field[string] `json:"name,required"`
 	// Parameters for multi-variable sensitivity analysis within this scenario.
 	SensitivityAnalysisParams param.Field[[]AIOracleSimulateRunAdvancedParamsScenariosSensitivityAnalysisParam] `json:"sensitivityAnalysisParams"`
 }
@@ -374,7 +374,7 @@ func (r AIOracleSimulateRunAdvancedParamsScenario) MarshalJSON() (data []byte, e
 
 type AIOracleSimulateRunAdvancedParamsScenariosEvent struct {
 	// Specific parameters for the event (e.g., durationMonths, impactPercentage).
-	Details param.Field[interface{}]                                          `json:"details"`
+	Details param.Field[map[string]interface{}]                               `json:"details"`
 	Type    param.Field[AIOracleSimulateRunAdvancedParamsScenariosEventsType] `json:"type"`
 }
 
@@ -402,14 +402,14 @@ func (r AIOracleSimulateRunAdvancedParamsScenariosEventsType) IsKnown() bool {
 
 type AIOracleSimulateRunAdvancedParamsScenariosSensitivityAnalysisParam struct {
 	// Maximum value for the parameter.
-	Max param.Field[interface{}] `json:"max"`
+	Max param.Field[float64] `json:"max"`
 	// Minimum value for the parameter.
-	Min param.Field[interface{}] `json:"min"`
+	Min param.Field[float64] `json:"min"`
 	// The name of the parameter to vary for sensitivity analysis (e.g.,
 	// 'interestRate', 'inflationRate', 'marketRecoveryRate').
-	ParamName param.Field[interface{}] `json:"paramName"`
+	ParamName param.Field[string] `json:"paramName"`
 	// Step increment for varying the parameter.
-	Step param.Field[interface{}] `json:"step"`
+	Step param.Field[float64] `json:"step"`
 }
 
 func (r AIOracleSimulateRunAdvancedParamsScenariosSensitivityAnalysisParam) MarshalJSON() (data []byte, err error) {
@@ -418,8 +418,8 @@ func (r AIOracleSimulateRunAdvancedParamsScenariosSensitivityAnalysisParam) Mars
 
 // Optional: Global economic conditions to apply to all scenarios.
 type AIOracleSimulateRunAdvancedParamsGlobalEconomicFactors struct {
-	InflationRate        param.Field[interface{}] `json:"inflationRate"`
-	InterestRateBaseline param.Field[interface{}] `json:"interestRateBaseline"`
+	InflationRate        param.Field[float64] `json:"inflationRate"`
+	InterestRateBaseline param.Field[float64] `json:"interestRateBaseline"`
 }
 
 func (r AIOracleSimulateRunAdvancedParamsGlobalEconomicFactors) MarshalJSON() (data []byte, err error) {
@@ -428,7 +428,7 @@ func (r AIOracleSimulateRunAdvancedParamsGlobalEconomicFactors) MarshalJSON() (d
 
 // Optional: Personal financial assumptions to override defaults.
 type AIOracleSimulateRunAdvancedParamsPersonalAssumptions struct {
-	AnnualSavingsRate param.Field[interface{}]                                                       `json:"annualSavingsRate"`
+	AnnualSavingsRate param.Field[float64]                                                           `json:"annualSavingsRate"`
 	RiskTolerance     param.Field[AIOracleSimulateRunAdvancedParamsPersonalAssumptionsRiskTolerance] `json:"riskTolerance"`
 }
 
@@ -454,10 +454,10 @@ func (r AIOracleSimulateRunAdvancedParamsPersonalAssumptionsRiskTolerance) IsKno
 
 type AIOracleSimulateRunStandardParams struct {
 	// A natural language prompt describing the 'what-if' scenario.
-	Prompt param.Field[interface{}] `json:"prompt,required"`
+	Prompt param.Field[string] `json:"prompt,required"`
 	// Optional structured parameters to guide the simulation (e.g., duration, amount,
 	// risk tolerance).
-	Parameters param.Field[interface{}] `json:"parameters"`
+	Parameters param.Field[map[string]interface{}] `json:"parameters"`
 }
 
 func (r AIOracleSimulateRunStandardParams) MarshalJSON() (data []byte, err error) {
@@ -4,17 +4,20 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"reflect"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 	"github.com/tidwall/gjson"
 )
 
@@ -39,40 +42,64 @@ func NewAIOracleSimulationService(opts ...option.RequestOption) (r *AIOracleSimu
 
 // Retrieves the full, detailed results of a specific financial simulation by its
 // ID.
-func (r *AIOracleSimulationService) Get(ctx context.Context, simulationID interface{}, opts ...option.RequestOption) (res *AIOracleSimulationGetResponse, err error) {
+func (r *AIOracleSimulationService) Get(ctx context.Context, simulationID string, opts ...option.RequestOption) (res *AIOracleSimulationGetResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("ai/oracle/simulations/%v", simulationID)
+	if simulationID == "" {
+		err = errors.New("missing required simulationId parameter")
+		return
+	}
+	path := fmt.Sprintf("ai/oracle/simulations/%s", simulationID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
 
 // Retrieves a list of all financial simulations previously run by the user,
 // including their status and summaries.
-func (r *AIOracleSimulationService) List(ctx context.Context, query AIOracleSimulationListParams, opts ...option.RequestOption) (res *AIOracleSimulationListResponse, err error) {
+func (r *AIOracleSimulationService) List(ctx context.Context, query AIOracleSimulationListParams, opts ...option.RequestOption) (res *pagination.Page[AIOracleSimulationListResponseData], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "ai/oracle/simulations"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of all financial simulations previously run by the user,
+// including their status and summaries.
+func (r *AIOracleSimulationService) ListAutoPaging(ctx context.Context, query AIOracleSimulationListParams, opts ...option.RequestOption) *pagination.PageAutoPager[AIOracleSimulationListResponseData] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Deletes a previously run financial simulation and its results.
-func (r *AIOracleSimulationService) Delete(ctx context.Context, simulationID interface{}, opts ...option.RequestOption) (err error) {
+func (r *AIOracleSimulationService) Delete(ctx context.Context, simulationID string, opts ...option.RequestOption) (err error) {
 	opts = slices.Concat(r.Options, opts)
 	opts = append([]option.RequestOption{option.WithHeader("Accept", "*/*")}, opts...)
-	path := fmt.Sprintf("ai/oracle/simulations/%v", simulationID)
+	if simulationID == "" {
+		err = errors.New("missing required simulationId parameter")
+		return
+	}
+	path := fmt.Sprintf("ai/oracle/simulations/%s", simulationID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, nil, opts...)
 	return
 }
 
 type AIOracleSimulationGetResponse struct {
-	// This field can have the runtime type of [interface{}].
-	SimulationID interface{} `json:"simulationId,required"`
+	// Unique identifier for the completed simulation.
+	SimulationID string `json:"simulationId,required"`
 	// This field can have the runtime type of [[]SimulationResponseKeyImpact].
 	KeyImpacts interface{} `json:"keyImpacts"`
-	// This field can have the runtime type of [interface{}].
-	NarrativeSummary interface{} `json:"narrativeSummary"`
-	// This field can have the runtime type of [interface{}].
-	OverallSummary interface{} `json:"overallSummary"`
+	// A natural language summary of the simulation's results and key findings.
+	NarrativeSummary string `json:"narrativeSummary"`
+	// A high-level summary of findings across all scenarios.
+	OverallSummary string `json:"overallSummary"`
 	// This field can have the runtime type of [[]AIInsight].
 	Recommendations interface{} `json:"recommendations"`
 	// This field can have the runtime type of [SimulationResponseRiskAnalysis].
@@ -146,41 +173,19 @@ func init() {
 	)
 }
 
-type AIOracleSimulationListResponse struct {
-	Data []AIOracleSimulationListResponseData `json:"data"`
-	JSON aiOracleSimulationListResponseJSON   `json:"-"`
-	PaginatedList
-}
-
-// aiOracleSimulationListResponseJSON contains the JSON metadata for the struct
-// [AIOracleSimulationListResponse]
-type aiOracleSimulationListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *AIOracleSimulationListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r aiOracleSimulationListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type AIOracleSimulationListResponseData struct {
 	// Timestamp when the simulation was initiated.
-	CreationDate interface{} `json:"creationDate,required"`
+	CreationDate time.Time `json:"creationDate,required" format:"date-time"`
 	// Timestamp when the simulation status or results were last updated.
-	LastUpdated interface{} `json:"lastUpdated,required"`
+	LastUpdated time.Time `json:"lastUpdated,required" format:"date-time"`
 	// Unique identifier for the simulation.
-	SimulationID interface{} `json:"simulationId,required"`
+	SimulationID string `json:"simulationId,required"`
 	// Current status of the simulation.
 	Status AIOracleSimulationListResponseDataStatus `json:"status,required"`
 	// A brief summary of what the simulation evaluated.
-	Summary interface{} `json:"summary,required"`
+	Summary string `json:"summary,required"`
 	// A user-friendly title for the simulation.
-	Title interface{}                            `json:"title,required"`
+	Title string                                 `json:"title,required"`
 	JSON  aiOracleSimulationListResponseDataJSON `json:"-"`
 }
 
@@ -224,9 +229,9 @@ func (r AIOracleSimulationListResponseDataStatus) IsKnown() bool {
 
 type AIOracleSimulationListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [AIOracleSimulationListParams]'s query parameters as
@@ -48,8 +48,8 @@ func TestAIOracleSimulationListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.AI.Oracle.Simulations.List(context.TODO(), jocall3.AIOracleSimulationListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
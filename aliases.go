@@ -7,3 +7,54 @@ import (
 )
 
 type Error = apierror.Error
+
+// FieldError is a problem with one field of a request, as reported by a
+// [ValidationError].
+type FieldError = apierror.FieldError
+
+// The typed forms of an [Error], for use with [errors.As]. Each has the same
+// fields as Error, and unwraps to it.
+type (
+	// AuthenticationError is an [Error] for a 401 Unauthorized response.
+	AuthenticationError = apierror.AuthenticationError
+	// ForbiddenError is an [Error] for a 403 Forbidden response.
+	ForbiddenError = apierror.ForbiddenError
+	// NotFoundError is an [Error] for a 404 Not Found response.
+	NotFoundError = apierror.NotFoundError
+	// ConflictError is an [Error] for a 409 Conflict response.
+	ConflictError = apierror.ConflictError
+	// ValidationError is an [Error] for a 400 Bad Request or 422 Unprocessable
+	// Entity response.
+	ValidationError = apierror.ValidationError
+	// RateLimitError is an [Error] for a 429 Too Many Requests response.
+	RateLimitError = apierror.RateLimitError
+)
+
+// The classes of an [Error], for use with [errors.Is].
+var (
+	ErrAuthentication = apierror.ErrAuthentication
+	ErrForbidden      = apierror.ErrForbidden
+	ErrNotFound       = apierror.ErrNotFound
+	ErrConflict       = apierror.ErrConflict
+	ErrValidation     = apierror.ErrValidation
+	ErrRateLimited    = apierror.ErrRateLimited
+)
+
+// The errors the API reports with a specific code, for use with [errors.Is].
+// They match an [Error] with the code whatever its status, which also matches
+// the class of its status.
+var (
+	// ErrInsufficientFunds matches the "insufficient_funds" and
+	// "insufficient_balance" codes.
+	ErrInsufficientFunds = apierror.ErrInsufficientFunds
+	// ErrKYCRequired matches the "kyc_required", "kyc_verification_required"
+	// and "identity_verification_required" codes.
+	ErrKYCRequired = apierror.ErrKYCRequired
+)
+
+// TransportError is returned when a request could not be sent or its response
+// could not be received, wrapping the error of the HTTP client.
+type TransportError = apierror.TransportError
+
+// DecodeError is returned when a successful response could not be decoded.
+type DecodeError = apierror.DecodeError
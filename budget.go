@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // BudgetService contains methods and other services that help with interacting
@@ -46,63 +49,91 @@ func (r *BudgetService) New(ctx context.Context, body BudgetNewParams, opts ...o
 
 // Retrieves detailed information for a specific budget, including current
 // spending, remaining amounts, and AI recommendations.
-func (r *BudgetService) Get(ctx context.Context, budgetID interface{}, opts ...option.RequestOption) (res *Budget, err error) {
+func (r *BudgetService) Get(ctx context.Context, budgetID string, opts ...option.RequestOption) (res *Budget, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("budgets/%v", budgetID)
+	if budgetID == "" {
+		err = errors.New("missing required budgetId parameter")
+		return
+	}
+	path := fmt.Sprintf("budgets/%s", budgetID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
 
 // Updates the parameters of an existing budget, such as total amount, dates, or
 // categories.
-func (r *BudgetService) Update(ctx context.Context, budgetID interface{}, body BudgetUpdateParams, opts ...option.RequestOption) (res *Budget, err error) {
+func (r *BudgetService) Update(ctx context.Context, budgetID string, body BudgetUpdateParams, opts ...option.RequestOption) (res *Budget, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("budgets/%v", budgetID)
+	if budgetID == "" {
+		err = errors.New("missing required budgetId parameter")
+		return
+	}
+	path := fmt.Sprintf("budgets/%s", budgetID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
 
 // Retrieves a list of all active and historical budgets for the authenticated
 // user.
-func (r *BudgetService) List(ctx context.Context, query BudgetListParams, opts ...option.RequestOption) (res *BudgetListResponse, err error) {
+func (r *BudgetService) List(ctx context.Context, query BudgetListParams, opts ...option.RequestOption) (res *pagination.Page[Budget], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "budgets"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of all active and historical budgets for the authenticated
+// user.
+func (r *BudgetService) ListAutoPaging(ctx context.Context, query BudgetListParams, opts ...option.RequestOption) *pagination.PageAutoPager[Budget] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Deletes a specific budget from the user's profile.
-func (r *BudgetService) Delete(ctx context.Context, budgetID interface{}, opts ...option.RequestOption) (err error) {
+func (r *BudgetService) Delete(ctx context.Context, budgetID string, opts ...option.RequestOption) (err error) {
 	opts = slices.Concat(r.Options, opts)
 	opts = append([]option.RequestOption{option.WithHeader("Accept", "*/*")}, opts...)
-	path := fmt.Sprintf("budgets/%v", budgetID)
+	if budgetID == "" {
+		err = errors.New("missing required budgetId parameter")
+		return
+	}
+	path := fmt.Sprintf("budgets/%s", budgetID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, nil, opts...)
 	return
 }
 
 type Budget struct {
 	// Unique identifier for the budget.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Percentage threshold at which an alert is triggered (e.g., 80% spent).
-	AlertThreshold interface{} `json:"alertThreshold,required"`
+	AlertThreshold float64 `json:"alertThreshold,required"`
 	// Breakdown of the budget by categories.
 	Categories []BudgetCategory `json:"categories,required"`
 	// End date of the budget period.
-	EndDate interface{} `json:"endDate,required"`
+	EndDate time.Time `json:"endDate,required" format:"date"`
 	// Name of the budget.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// The frequency or period of the budget.
 	Period BudgetPeriod `json:"period,required"`
 	// Remaining amount in the budget.
-	RemainingAmount interface{} `json:"remainingAmount,required"`
+	RemainingAmount Decimal `json:"remainingAmount,required"`
 	// Total amount spent against this budget so far.
-	SpentAmount interface{} `json:"spentAmount,required"`
+	SpentAmount Decimal `json:"spentAmount,required"`
 	// Start date of the budget period.
-	StartDate interface{} `json:"startDate,required"`
+	StartDate time.Time `json:"startDate,required" format:"date"`
 	// Current status of the budget.
 	Status BudgetStatus `json:"status,required"`
 	// Total amount allocated for the entire budget.
-	TotalAmount interface{} `json:"totalAmount,required"`
+	TotalAmount Decimal `json:"totalAmount,required"`
 	// AI-driven recommendations related to this budget.
 	AIRecommendations []AIInsight `json:"aiRecommendations,nullable"`
 	JSON              budgetJSON  `json:"-"`
@@ -136,13 +167,13 @@ func (r budgetJSON) RawJSON() string {
 
 type BudgetCategory struct {
 	// Amount allocated to this category.
-	Allocated interface{} `json:"allocated,required"`
+	Allocated Decimal `json:"allocated,required"`
 	// Name of the budget category.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// Remaining amount in this category.
-	Remaining interface{} `json:"remaining,required"`
+	Remaining Decimal `json:"remaining,required"`
 	// Amount spent in this category so far.
-	Spent interface{}        `json:"spent,required"`
+	Spent Decimal            `json:"spent,required"`
 	JSON  budgetCategoryJSON `json:"-"`
 }
 
@@ -201,44 +232,22 @@ func (r BudgetStatus) IsKnown() bool {
 	return false
 }
 
-type BudgetListResponse struct {
-	Data []Budget               `json:"data"`
-	JSON budgetListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// budgetListResponseJSON contains the JSON metadata for the struct
-// [BudgetListResponse]
-type budgetListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *BudgetListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r budgetListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type BudgetNewParams struct {
 	// End date of the budget period.
-	EndDate param.Field[interface{}] `json:"endDate,required"`
+	EndDate param.Field[time.Time] `json:"endDate,required" format:"date"`
 	// Name of the new budget.
-	Name param.Field[interface{}] `json:"name,required"`
+	Name param.Field[string] `json:"name,required"`
 	// The frequency or period of the budget.
 	Period param.Field[BudgetNewParamsPeriod] `json:"period,required"`
 	// Start date of the budget period.
-	StartDate param.Field[interface{}] `json:"startDate,required"`
+	StartDate param.Field[time.Time] `json:"startDate,required" format:"date"`
 	// Total amount allocated for the entire budget.
-	TotalAmount param.Field[interface{}] `json:"totalAmount,required"`
+	TotalAmount param.Field[Decimal] `json:"totalAmount,required"`
 	// If true, AI will automatically populate categories and amounts based on
 	// historical spending.
-	AIAutoPopulate param.Field[interface{}] `json:"aiAutoPopulate"`
+	AIAutoPopulate param.Field[bool] `json:"aiAutoPopulate"`
 	// Percentage threshold at which an alert is triggered.
-	AlertThreshold param.Field[interface{}] `json:"alertThreshold"`
+	AlertThreshold param.Field[float64] `json:"alertThreshold"`
 	// Initial breakdown of the budget by categories.
 	Categories param.Field[[]BudgetNewParamsCategory] `json:"categories"`
 }
@@ -268,8 +277,8 @@ func (r BudgetNewParamsPeriod) IsKnown() bool {
 }
 
 type BudgetNewParamsCategory struct {
-	Allocated param.Field[interface{}] `json:"allocated"`
-	Name      param.Field[interface{}] `json:"name"`
+	Allocated param.Field[Decimal] `json:"allocated"`
+	Name      param.Field[string]  `json:"name"`
 }
 
 func (r BudgetNewParamsCategory) MarshalJSON() (data []byte, err error) {
@@ -278,20 +287,20 @@ func (r BudgetNewParamsCategory) MarshalJSON() (data []byte, err error) {
 
 type BudgetUpdateParams struct {
 	// Updated percentage threshold for alerts.
-	AlertThreshold param.Field[interface{}] `json:"alertThreshold"`
+	AlertThreshold param.Field[float64] `json:"alertThreshold"`
 	// Updated breakdown of the budget by categories. Existing categories will be
 	// updated, new ones added.
 	Categories param.Field[[]BudgetUpdateParamsCategory] `json:"categories"`
 	// Updated end date of the budget period.
-	EndDate param.Field[interface{}] `json:"endDate"`
+	EndDate param.Field[time.Time] `json:"endDate" format:"date"`
 	// Updated name of the budget.
-	Name param.Field[interface{}] `json:"name"`
+	Name param.Field[string] `json:"name"`
 	// Updated start date of the budget period.
-	StartDate param.Field[interface{}] `json:"startDate"`
+	StartDate param.Field[time.Time] `json:"startDate" format:"date"`
 	// Updated status of the budget.
 	Status param.Field[BudgetUpdateParamsStatus] `json:"status"`
 	// Updated total amount for the entire budget.
-	TotalAmount param.Field[interface{}] `json:"totalAmount"`
+	TotalAmount param.Field[Decimal] `json:"totalAmount"`
 }
 
 func (r BudgetUpdateParams) MarshalJSON() (data []byte, err error) {
@@ -299,8 +308,8 @@ func (r BudgetUpdateParams) MarshalJSON() (data []byte, err error) {
 }
 
 type BudgetUpdateParamsCategory struct {
-	Allocated param.Field[interface{}] `json:"allocated"`
-	Name      param.Field[interface{}] `json:"name"`
+	Allocated param.Field[Decimal] `json:"allocated"`
+	Name      param.Field[string]  `json:"name"`
 }
 
 func (r BudgetUpdateParamsCategory) MarshalJSON() (data []byte, err error) {
@@ -326,9 +335,9 @@ func (r BudgetUpdateParamsStatus) IsKnown() bool {
 
 type BudgetListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [BudgetListParams]'s query parameters as `url.Values`.
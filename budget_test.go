@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -26,19 +27,19 @@ func TestBudgetNewWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Budgets.New(context.TODO(), jocall3.BudgetNewParams{
-		EndDate:        jocall3.F[any]("2024-09-30"),
-		Name:           jocall3.F[any]("September Living Expenses"),
+		EndDate:        jocall3.F(time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)),
+		Name:           jocall3.F("September Living Expenses"),
 		Period:         jocall3.F(jocall3.BudgetNewParamsPeriodMonthly),
-		StartDate:      jocall3.F[any]("2024-09-01"),
-		TotalAmount:    jocall3.F[any](2800),
-		AIAutoPopulate: jocall3.F[any](true),
-		AlertThreshold: jocall3.F[any](75),
+		StartDate:      jocall3.F(time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)),
+		TotalAmount:    jocall3.F(jocall3.MustDecimal("2800")),
+		AIAutoPopulate: jocall3.F(true),
+		AlertThreshold: jocall3.F(75.0),
 		Categories: jocall3.F([]jocall3.BudgetNewParamsCategory{{
-			Allocated: jocall3.F[any](1500),
-			Name:      jocall3.F[any]("Rent"),
+			Allocated: jocall3.F(jocall3.MustDecimal("1500")),
+			Name:      jocall3.F("Rent"),
 		}, {
-			Allocated: jocall3.F[any](400),
-			Name:      jocall3.F[any]("Groceries"),
+			Allocated: jocall3.F(jocall3.MustDecimal("400")),
+			Name:      jocall3.F("Groceries"),
 		}}),
 	})
 	if err != nil {
@@ -88,16 +89,16 @@ func TestBudgetUpdateWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"budget_monthly_aug",
 		jocall3.BudgetUpdateParams{
-			AlertThreshold: jocall3.F[any](85),
+			AlertThreshold: jocall3.F(85.0),
 			Categories: jocall3.F([]jocall3.BudgetUpdateParamsCategory{{
-				Allocated: jocall3.F[any](550),
-				Name:      jocall3.F[any]("Groceries"),
+				Allocated: jocall3.F(jocall3.MustDecimal("550")),
+				Name:      jocall3.F("Groceries"),
 			}}),
-			EndDate:     jocall3.F[any]("2024-08-31"),
-			Name:        jocall3.F[any]("August 2024 Revised Household Budget"),
-			StartDate:   jocall3.F[any]("2024-08-01"),
+			EndDate:     jocall3.F(time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC)),
+			Name:        jocall3.F("August 2024 Revised Household Budget"),
+			StartDate:   jocall3.F(time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)),
 			Status:      jocall3.F(jocall3.BudgetUpdateParamsStatusActive),
-			TotalAmount: jocall3.F[any](3200),
+			TotalAmount: jocall3.F(jocall3.MustDecimal("3200")),
 		},
 	)
 	if err != nil {
@@ -122,8 +123,8 @@ func TestBudgetListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Budgets.List(context.TODO(), jocall3.BudgetListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -33,11 +33,12 @@ type Client struct {
 	Goals          *GoalService
 	Notifications  *NotificationService
 	Marketplace    *MarketplaceService
+	Webhooks       *WebhookService
 }
 
 // DefaultClientOptions read from the environment (1231_API_KEY,
-// 1231_BIOMETRIC_TOKEN, JOCALL3_BASE_URL). This should be used to initialize new
-// clients.
+// 1231_BIOMETRIC_TOKEN, JOCALL3_WEBHOOK_SECRET, JOCALL3_BASE_URL). This should be
+// used to initialize new clients.
 func DefaultClientOptions() []option.RequestOption {
 	defaults := []option.RequestOption{option.WithEnvironmentProduction()}
 	if o, ok := os.LookupEnv("JOCALL3_BASE_URL"); ok {
@@ -49,13 +50,17 @@ func DefaultClientOptions() []option.RequestOption {
 	if o, ok := os.LookupEnv("1231_BIOMETRIC_TOKEN"); ok {
 		defaults = append(defaults, option.WithBiometricToken(o))
 	}
+	if o, ok := os.LookupEnv("JOCALL3_WEBHOOK_SECRET"); ok {
+		defaults = append(defaults, option.WithWebhookSecret(o))
+	}
 	return defaults
 }
 
 // NewClient generates a new client with the default option read from the
-// environment (1231_API_KEY, 1231_BIOMETRIC_TOKEN, JOCALL3_BASE_URL). The option
-// passed in as arguments are applied after these default arguments, and all option
-// will be passed down to the services and requests that this client makes.
+// environment (1231_API_KEY, 1231_BIOMETRIC_TOKEN, JOCALL3_WEBHOOK_SECRET,
+// JOCALL3_BASE_URL). The option passed in as arguments are applied after these
+// default arguments, and all option will be passed down to the services and
+// requests that this client makes.
 func NewClient(opts ...option.RequestOption) (r *Client) {
 	opts = append(DefaultClientOptions(), opts...)
 
@@ -77,6 +82,7 @@ func NewClient(opts ...option.RequestOption) (r *Client) {
 	r.Goals = NewGoalService(opts...)
 	r.Notifications = NewNotificationService(opts...)
 	r.Marketplace = NewMarketplaceService(opts...)
+	r.Webhooks = NewWebhookService(opts...)
 
 	return
 }
@@ -3,13 +3,32 @@
 package jocall3_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal"
 	"github.com/jocall3/go/option"
@@ -38,9 +57,9 @@ func TestUserAgentHeader(t *testing.T) {
 		}),
 	)
 	client.Users.Register(context.Background(), jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 	})
 	if userAgent != fmt.Sprintf("Jocall3/Go %s", internal.PackageVersion) {
 		t.Errorf("Expected User-Agent to be correct, but got: %#v", userAgent)
@@ -65,9 +84,9 @@ func TestRetryAfter(t *testing.T) {
 		}),
 	)
 	_, err := client.Users.Register(context.Background(), jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 	})
 	if err == nil {
 		t.Error("Expected there to be a cancel error")
@@ -103,9 +122,9 @@ func TestDeleteRetryCountHeader(t *testing.T) {
 		option.WithHeaderDel("X-Stainless-Retry-Count"),
 	)
 	_, err := client.Users.Register(context.Background(), jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 	})
 	if err == nil {
 		t.Error("Expected there to be a cancel error")
@@ -136,9 +155,9 @@ func TestOverwriteRetryCountHeader(t *testing.T) {
 		option.WithHeader("X-Stainless-Retry-Count", "42"),
 	)
 	_, err := client.Users.Register(context.Background(), jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 	})
 	if err == nil {
 		t.Error("Expected there to be a cancel error")
@@ -168,9 +187,9 @@ func TestRetryAfterMs(t *testing.T) {
 		}),
 	)
 	_, err := client.Users.Register(context.Background(), jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 	})
 	if err == nil {
 		t.Error("Expected there to be a cancel error")
@@ -194,9 +213,9 @@ func TestContextCancel(t *testing.T) {
 	cancelCtx, cancel := context.WithCancel(context.Background())
 	cancel()
 	_, err := client.Users.Register(cancelCtx, jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 	})
 	if err == nil {
 		t.Error("Expected there to be a cancel error")
@@ -217,9 +236,9 @@ func TestContextCancelDelay(t *testing.T) {
 	cancelCtx, cancel := context.WithTimeout(context.Background(), 2*time.Millisecond)
 	defer cancel()
 	_, err := client.Users.Register(cancelCtx, jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 	})
 	if err == nil {
 		t.Error("expected there to be a cancel error")
@@ -246,9 +265,9 @@ func TestContextDeadline(t *testing.T) {
 			}),
 		)
 		_, err := client.Users.Register(deadlineCtx, jocall3.UserRegisterParams{
-			Email:    jocall3.F[any]("alice.w@example.com"),
-			Name:     jocall3.F[any]("Alice Wonderland"),
-			Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+			Email:    jocall3.F("alice.w@example.com"),
+			Name:     jocall3.F("Alice Wonderland"),
+			Password: jocall3.F("SecureP@ssw0rd2024!"),
 		})
 		if err == nil {
 			t.Error("expected there to be a deadline error")
@@ -265,3 +284,1054 @@ func TestContextDeadline(t *testing.T) {
 		}
 	}
 }
+
+func TestListAutoPaging(t *testing.T) {
+	offsets := make([]string, 0)
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					offset := req.URL.Query().Get("offset")
+					offsets = append(offsets, offset)
+					body := `{"data":[{"id":"b1"},{"id":"b2"}],"limit":2,"offset":0,"total":3,"nextOffset":2}`
+					if offset == "2" {
+						body = `{"data":[{"id":"b3"}],"limit":2,"offset":2,"total":3,"nextOffset":null}`
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			},
+		}),
+	)
+	iter := client.Budgets.ListAutoPaging(context.Background(), jocall3.BudgetListParams{
+		Limit: jocall3.F(int64(2)),
+	})
+	ids := make([]interface{}, 0)
+	for iter.Next() {
+		ids = append(ids, iter.Current().ID)
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if expected := []interface{}{"b1", "b2", "b3"}; !reflect.DeepEqual(ids, expected) {
+		t.Errorf("Expected %v ids, got %v", expected, ids)
+	}
+	if expected := []string{"", "2"}; !reflect.DeepEqual(offsets, expected) {
+		t.Errorf("Expected %v offsets, got %v", expected, offsets)
+	}
+}
+
+func TestListAutoPagingContextCancel(t *testing.T) {
+	attempts := 0
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					if attempts > 1 {
+						<-req.Context().Done()
+						return nil, req.Context().Err()
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"data":[{"id":"b1"}],"limit":1,"offset":0,"total":5,"nextOffset":1}`)),
+					}, nil
+				},
+			},
+		}),
+	)
+	iter := client.Budgets.ListAutoPaging(cancelCtx, jocall3.BudgetListParams{})
+	for iter.Next() {
+		cancel()
+	}
+	if !errors.Is(iter.Err(), context.Canceled) {
+		t.Errorf("Expected a context cancelled error, got %v", iter.Err())
+	}
+	if iter.Index() != 1 {
+		t.Errorf("Expected 1 item, got %d", iter.Index())
+	}
+}
+
+func TestIdempotencyKeyReusedAcrossRetries(t *testing.T) {
+	keys := make([]string, 0)
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					keys = append(keys, req.Header.Get("Idempotency-Key"))
+					return &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Header: http.Header{
+							http.CanonicalHeaderKey("Retry-After-Ms"): []string{"1"},
+						},
+					}, nil
+				},
+			},
+		}),
+	)
+	params := jocall3.SustainabilityPurchaseCarbonOffsetsParams{
+		AmountKgCo2e:     jocall3.F(500.0),
+		PaymentAccountID: jocall3.F("acc_chase_checking_4567"),
+	}
+	client.Sustainability.PurchaseCarbonOffsets(context.Background(), params)
+	client.Sustainability.PurchaseCarbonOffsets(context.Background(), params)
+
+	if len(keys) != 6 {
+		t.Fatalf("Expected 6 attempts, got %d", len(keys))
+	}
+	if keys[0] == "" {
+		t.Fatalf("Expected an idempotency key to be generated")
+	}
+	if keys[0] != keys[1] || keys[0] != keys[2] || keys[3] != keys[4] || keys[3] != keys[5] {
+		t.Errorf("Expected the key to be reused across retries, got %v", keys)
+	}
+	if keys[0] == keys[3] {
+		t.Errorf("Expected separate calls to use distinct keys, got %v", keys)
+	}
+}
+
+func TestIdempotencyKeyOption(t *testing.T) {
+	var keys []string
+	var replayed bool
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					keys = append(keys, req.Header.Get("Idempotency-Key"))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header: http.Header{
+							"Content-Type":        []string{"application/json"},
+							"Idempotent-Replayed": []string{"true"},
+						},
+						Body: io.NopCloser(strings.NewReader(`{"transactionId":"txn_1"}`)),
+					}, nil
+				},
+			},
+		}),
+	)
+	_, err := client.Sustainability.PurchaseCarbonOffsets(
+		context.Background(),
+		jocall3.SustainabilityPurchaseCarbonOffsetsParams{
+			AmountKgCo2e:     jocall3.F(500.0),
+			PaymentAccountID: jocall3.F("acc_chase_checking_4567"),
+		},
+		option.WithIdempotencyKey("offsets-2024-09"),
+		option.WithIdempotentReplayedInto(&replayed),
+	)
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	client.Transactions.Get(context.Background(), "txn_1")
+
+	if expected := []string{"offsets-2024-09", ""}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v keys, got %v", expected, keys)
+	}
+	if !replayed {
+		t.Errorf("Expected the response to be reported as replayed")
+	}
+}
+
+func TestIdempotencyKeyOptionIgnoredForSafeMethods(t *testing.T) {
+	var keys []string
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					keys = append(keys, req.Header.Get("Idempotency-Key"))
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			},
+		}),
+	)
+	client.Transactions.Get(context.Background(), "txn_1", option.WithIdempotencyKey("offsets-2024-09"))
+
+	if expected := []string{""}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v keys, got %v", expected, keys)
+	}
+}
+
+func TestRetryPolicyStatuses(t *testing.T) {
+	attempts := 0
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					status := http.StatusServiceUnavailable
+					if attempts > 1 {
+						status = http.StatusBadGateway
+					}
+					return &http.Response{
+						StatusCode: status,
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithRetryPolicy(&option.BackoffRetryPolicy{
+			RetryableStatuses: []int{http.StatusServiceUnavailable},
+			Backoff:           func(int, time.Duration) time.Duration { return time.Millisecond },
+		}),
+	)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+	if err == nil {
+		t.Error("Expected there to be an error")
+	}
+	if attempts != 2 {
+		t.Errorf("Expected %d attempts, got %d", 2, attempts)
+	}
+}
+
+func TestRetryPolicyBeforeRetry(t *testing.T) {
+	var retries []int
+	var delays []time.Duration
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("connection reset by peer")
+				},
+			},
+		}),
+		option.WithMaxRetries(3),
+		option.WithRetryPolicy(&option.BackoffRetryPolicy{
+			Backoff: func(retry int, previous time.Duration) time.Duration {
+				return time.Duration(retry+1) * time.Millisecond
+			},
+			BeforeRetry: func(attempt option.RetryAttempt, delay time.Duration) {
+				if attempt.Err == nil || attempt.Request == nil {
+					t.Errorf("Expected the attempt to carry the request and its error")
+				}
+				retries = append(retries, attempt.Retry)
+				delays = append(delays, delay)
+			},
+		}),
+	)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+	if err == nil {
+		t.Error("Expected there to be an error")
+	}
+	if expected := []int{0, 1, 2}; !reflect.DeepEqual(retries, expected) {
+		t.Errorf("Expected retries %v, got %v", expected, retries)
+	}
+	if expected := []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}; !reflect.DeepEqual(delays, expected) {
+		t.Errorf("Expected delays %v, got %v", expected, delays)
+	}
+}
+
+func TestRetryPolicyRetryableError(t *testing.T) {
+	attempts := 0
+	errTimeout := errors.New("dial timeout")
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					return nil, errTimeout
+				},
+			},
+		}),
+		option.WithRetryPolicy(&option.BackoffRetryPolicy{
+			RetryableError: func(err error) bool { return !errors.Is(err, errTimeout) },
+		}),
+	)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+	if !errors.Is(err, errTimeout) {
+		t.Errorf("Expected the transport error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected %d attempts, got %d", 1, attempts)
+	}
+}
+
+func TestRetryPolicyBudget(t *testing.T) {
+	attempts := 0
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					return &http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithMaxRetries(10),
+		option.WithRetryPolicy(&option.BackoffRetryPolicy{
+			Budget:  250 * time.Millisecond,
+			Backoff: func(int, time.Duration) time.Duration { return 100 * time.Millisecond },
+		}),
+	)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+	if err == nil {
+		t.Error("Expected there to be an error")
+	}
+	if attempts != 3 {
+		t.Errorf("Expected %d attempts, got %d", 3, attempts)
+	}
+}
+
+func TestRetryPolicyBackoffBounds(t *testing.T) {
+	base, max := 10*time.Millisecond, 100*time.Millisecond
+	full := option.FullJitterBackoff(base, max)
+	decorrelated := option.DecorrelatedJitterBackoff(base, max)
+	exponential := option.ExponentialBackoff(base, max)
+	previous := time.Duration(0)
+	for retry := 0; retry < 20; retry++ {
+		if d := full(retry, 0); d < 0 || d > max {
+			t.Errorf("full jitter delay %v out of bounds", d)
+		}
+		d := decorrelated(retry, previous)
+		if d < base || d > max || (previous >= base && d > 3*previous) {
+			t.Errorf("decorrelated jitter delay %v out of bounds after %v", d, previous)
+		}
+		previous = d
+		if d := exponential(retry, 0); d > max || d < max*3/4 && retry >= 4 {
+			t.Errorf("exponential delay %v out of bounds for retry %d", d, retry)
+		}
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var paths []string
+	failing := true
+	var changes []string
+	breaker := &option.CircuitBreaker{
+		MinRequests: 3,
+		OpenTimeout: 50 * time.Millisecond,
+		OnStateChange: func(change option.CircuitStateChange) {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", change.Route, change.From, change.To))
+		},
+	}
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					paths = append(paths, req.URL.Path)
+					status := http.StatusOK
+					if failing && strings.HasPrefix(req.URL.Path, "/transactions/") {
+						status = http.StatusServiceUnavailable
+					}
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithMaxRetries(0),
+		option.WithCircuitBreaker(breaker),
+	)
+
+	for i := 0; i < 3; i++ {
+		client.Transactions.Get(context.Background(), fmt.Sprintf("txn_%d", i))
+	}
+	_, err := client.Transactions.Get(context.Background(), "txn_3")
+	if !errors.Is(err, option.ErrCircuitOpen) {
+		t.Fatalf("Expected the circuit to be open, got %v", err)
+	}
+	var openErr *option.CircuitOpenError
+	if !errors.As(err, &openErr) || openErr.Route != "GET /transactions/{transactionId}" || openErr.RetryAfter <= 0 {
+		t.Errorf("Unexpected error %#v", openErr)
+	}
+	if len(paths) != 3 {
+		t.Errorf("Expected the rejected request not to be sent, got %v", paths)
+	}
+	if _, err := client.Budgets.Get(context.Background(), "budget_1"); err != nil {
+		t.Errorf("Expected other routes to be unaffected, got %v", err)
+	}
+
+	failing = false
+	time.Sleep(60 * time.Millisecond)
+	if state := breaker.State(openErr.Host, openErr.Route); state != option.CircuitHalfOpen {
+		t.Errorf("Expected the circuit to be half-open, got %s", state)
+	}
+	if _, err := client.Transactions.Get(context.Background(), "txn_4"); err != nil {
+		t.Errorf("Expected the trial request to succeed, got %v", err)
+	}
+	if state := breaker.State(openErr.Host, openErr.Route); state != option.CircuitClosed {
+		t.Errorf("Expected the circuit to be closed, got %s", state)
+	}
+
+	expected := []string{
+		"GET /transactions/{transactionId}: closed -> open",
+		"GET /transactions/{transactionId}: open -> half-open",
+		"GET /transactions/{transactionId}: half-open -> closed",
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected state changes %v, got %v", expected, changes)
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailure(t *testing.T) {
+	attempts := 0
+	breaker := &option.CircuitBreaker{MinRequests: 1, OpenTimeout: 100 * time.Millisecond}
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					attempts++
+					return nil, errors.New("connection refused")
+				},
+			},
+		}),
+		option.WithCircuitBreaker(breaker),
+		option.WithRetryPolicy(&option.BackoffRetryPolicy{
+			Backoff: func(int, time.Duration) time.Duration { return time.Millisecond },
+		}),
+	)
+
+	// The first attempt opens the circuit, and the retries are rejected.
+	_, err := client.Goals.List(context.Background(), jocall3.GoalListParams{})
+	if !errors.Is(err, option.ErrCircuitOpen) || attempts != 1 {
+		t.Fatalf("Expected one attempt and an open circuit, got %d attempts and %v", attempts, err)
+	}
+
+	time.Sleep(120 * time.Millisecond)
+	client.Goals.List(context.Background(), jocall3.GoalListParams{}, option.WithMaxRetries(0))
+	if attempts != 2 {
+		t.Errorf("Expected a trial request, got %d attempts", attempts)
+	}
+	if _, err := client.Goals.List(context.Background(), jocall3.GoalListParams{}); !errors.Is(err, option.ErrCircuitOpen) {
+		t.Errorf("Expected the failed trial to open the circuit again, got %v", err)
+	}
+}
+
+func TestOpenTelemetry(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	var traceparents []string
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					traceparents = append(traceparents, req.Header.Get("traceparent"))
+					status := http.StatusOK
+					if len(traceparents) == 1 {
+						status = http.StatusServiceUnavailable
+					}
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"id":"txn_1"}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithRetryPolicy(&option.BackoffRetryPolicy{
+			Backoff: func(int, time.Duration) time.Duration { return time.Millisecond },
+		}),
+		option.WithOpenTelemetry(
+			sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+			sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		),
+	)
+	if _, err := client.Transactions.Get(context.Background(), "txn_1"); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 3 {
+		t.Fatalf("Expected 3 spans, got %d", len(ended))
+	}
+	call := ended[2]
+	for i, span := range ended {
+		if span.Name() != "Transactions.Get" || span.SpanKind() != trace.SpanKindClient {
+			t.Errorf("Unexpected span %q of kind %s", span.Name(), span.SpanKind())
+		}
+		if i < 2 {
+			if span.Parent().SpanID() != call.SpanContext().SpanID() {
+				t.Errorf("Expected attempt %d to be a child of the call span", i)
+			}
+			if !strings.Contains(traceparents[i], span.SpanContext().SpanID().String()) {
+				t.Errorf("Expected traceparent %q to carry the span of attempt %d", traceparents[i], i)
+			}
+		}
+	}
+	if status := ended[0].Status(); status.Code != codes.Error {
+		t.Errorf("Expected the failed attempt to have an error status, got %v", status)
+	}
+	attrs := attribute.NewSet(call.Attributes()...)
+	if v, _ := attrs.Value(option.OpenTelemetryRetryCountKey); v.AsInt64() != 1 {
+		t.Errorf("Expected a retry count of 1, got %v", v.Emit())
+	}
+	if v, _ := attrs.Value("url.template"); v.AsString() != "/transactions/{transactionId}" {
+		t.Errorf("Unexpected url.template %q", v.AsString())
+	}
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]uint64{}
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch agg := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				for _, point := range agg.DataPoints {
+					counts[m.Name] += point.Count
+				}
+			case metricdata.Sum[int64]:
+				for _, point := range agg.DataPoints {
+					counts[m.Name] += uint64(point.Value)
+				}
+			}
+		}
+	}
+	expected := map[string]uint64{
+		"jocall3.client.call.duration": 1,
+		"http.client.request.duration": 2,
+		"jocall3.client.retries":       1,
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("Expected metrics %v, got %v", expected, counts)
+	}
+}
+
+func TestLoggerRedaction(t *testing.T) {
+	var logs bytes.Buffer
+	var sent []byte
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					sent, _ = io.ReadAll(req.Body)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"userId":"usr_1","overallStatus":"pending","documents":[{"documentNumber":"X1234567"}]}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithBiometricToken("bio_secret_token"),
+		option.WithLogger(slog.New(slog.NewJSONHandler(&logs, nil)), slog.LevelInfo),
+	)
+	res, err := client.Identity.KYC.Submit(context.Background(), jocall3.IdentityKYCSubmitParams{
+		CountryOfIssue: jocall3.F("US"),
+		DocumentNumber: jocall3.F("X1234567"),
+		DocumentType:   jocall3.F(jocall3.IdentityKYCSubmitParamsDocumentTypePassport),
+		ExpirationDate: jocall3.F(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+		IssueDate:      jocall3.F(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if res.UserID != "usr_1" {
+		t.Errorf("Expected the response body to be decoded after logging, got %+v", res)
+	}
+	if !bytes.Contains(sent, []byte("X1234567")) {
+		t.Errorf("Expected the request body to be sent intact, got %s", sent)
+	}
+
+	output := logs.String()
+	for _, secret := range []string{"X1234567", "bio_secret_token"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("Expected %q to be redacted from the logs:\n%s", secret, output)
+		}
+	}
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 || records[0]["msg"] != "jocall3 request" || records[1]["msg"] != "jocall3 response" {
+		t.Fatalf("Unexpected records %v", records)
+	}
+	if records[0]["operation"] != "Identity.KYC.Submit" || records[1]["status"] != float64(200) {
+		t.Errorf("Unexpected records %v", records)
+	}
+	if headers, _ := records[0]["headers"].(map[string]interface{}); headers["Authorization"] != option.LogRedacted {
+		t.Errorf("Expected the authorization header to be redacted, got %v", headers)
+	}
+	if body, _ := records[0]["body"].(string); !strings.Contains(body, `"documentNumber":"[REDACTED]"`) || !strings.Contains(body, `"countryOfIssue":"US"`) {
+		t.Errorf("Unexpected request body %s", body)
+	}
+}
+
+func TestLoggerTruncation(t *testing.T) {
+	var logs bytes.Buffer
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"id":"txn_1","description":"` + strings.Repeat("a", 100) + `"}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithLogConfig(option.LogConfig{
+			Logger:          slog.New(slog.NewTextHandler(&logs, nil)),
+			Level:           slog.LevelInfo,
+			RedactJSONPaths: []string{"id"},
+			MaxBodyBytes:    20,
+		}),
+	)
+	res, err := client.Transactions.Get(context.Background(), "txn_1")
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if len(res.Description) != 100 {
+		t.Errorf("Expected the full response to be decoded, got %q", res.Description)
+	}
+	if !strings.Contains(logs.String(), `body="{\"id\":\"[REDACTED]\",\"…(truncated 116 bytes)"`) {
+		t.Errorf("Expected a truncated body in the logs:\n%s", logs.String())
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "cassettes", "rebalance.json")
+	statuses := []string{"analyzing", "completed"}
+	sent := 0
+	record := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					status := statuses[sent]
+					sent++
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"portfolioId":"port_1","rebalanceId":"reb_1","status":"` + status + `","accessToken":"tok_secret"}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithBiometricToken("bio_secret_token"),
+		option.WithRecorder(cassette, option.RecorderRecord),
+	)
+	params := jocall3.InvestmentPortfolioRebalanceParams{
+		TargetRiskTolerance: jocall3.F(jocall3.InvestmentPortfolioRebalanceParamsTargetRiskToleranceModerate),
+		DryRun:              jocall3.F(true),
+	}
+	for range statuses {
+		if _, err := record.Investments.Portfolios.Rebalance(context.Background(), "port_1", params); err != nil {
+			t.Fatalf("err should be nil: %s", err.Error())
+		}
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"bio_secret_token", "tok_secret"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("Expected %q to be scrubbed from the cassette:\n%s", secret, data)
+		}
+	}
+
+	replay := jocall3.NewClient(
+		option.WithBaseURL("http://replay.invalid/"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					t.Errorf("Expected no request to be sent, got %s %s", req.Method, req.URL)
+					return nil, errors.New("unexpected request")
+				},
+			},
+		}),
+		option.WithRecorder(cassette, option.RecorderReplayStrict),
+	)
+	var replayed []jocall3.InvestmentPortfolioRebalanceResponseStatus
+	for i := 0; i < 3; i++ {
+		res, err := replay.Investments.Portfolios.Rebalance(context.Background(), "port_1", params)
+		if err != nil {
+			t.Fatalf("err should be nil: %s", err.Error())
+		}
+		replayed = append(replayed, res.Status)
+	}
+	expected := []jocall3.InvestmentPortfolioRebalanceResponseStatus{"analyzing", "completed", "completed"}
+	if !reflect.DeepEqual(replayed, expected) {
+		t.Errorf("Expected %v to be replayed, got %v", expected, replayed)
+	}
+
+	params.DryRun = jocall3.F(false)
+	_, err = replay.Investments.Portfolios.Rebalance(context.Background(), "port_1", params)
+	var mismatch *option.RecorderMismatchError
+	if !errors.Is(err, option.ErrRecorderNoMatch) || !errors.As(err, &mismatch) {
+		t.Fatalf("Expected a mismatch error, got %v", err)
+	}
+	if mismatch.Method != http.MethodPost || !strings.Contains(mismatch.Body, `"dryRun":false`) {
+		t.Errorf("Unexpected mismatch %+v", mismatch)
+	}
+}
+
+func TestRecorderRedactsMultipartBodies(t *testing.T) {
+	cassette := filepath.Join(t.TempDir(), "kyc.json")
+	passport := bytes.Repeat([]byte{0xff, 0xd8, 'P', 'A', 'S', 'S'}, 100)
+	params := func() jocall3.IdentityKYCSubmitWithFilesParams {
+		return jocall3.IdentityKYCSubmitWithFilesParams{
+			CountryOfIssue:      jocall3.F("GB"),
+			DocumentNumber:      jocall3.F("P123456789SECRET"),
+			DocumentType:        jocall3.F(jocall3.IdentityKYCSubmitParamsDocumentTypePassport),
+			ExpirationDate:      jocall3.F(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+			IssueDate:           jocall3.F(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+			DocumentFrontImage:  jocall3.F(io.Reader(bytes.NewReader(passport))),
+			AdditionalDocuments: jocall3.F([]io.Reader{strings.NewReader("utility bill for 1 High Street")}),
+		}
+	}
+	record := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"overallStatus":"in_review"}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithRecorder(cassette, option.RecorderRecord),
+	)
+	if _, err := record.Identity.KYC.SubmitWithFiles(context.Background(), params()); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recorded struct {
+		Interactions []struct {
+			Request struct {
+				Header       http.Header `json:"header"`
+				Body         string      `json:"body"`
+				BodyEncoding string      `json:"bodyEncoding"`
+			} `json:"request"`
+		} `json:"interactions"`
+	}
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		t.Fatal(err)
+	}
+	body := recorded.Interactions[0].Request.Body
+	if recorded.Interactions[0].Request.BodyEncoding == "base64" {
+		decoded, _ := base64.StdEncoding.DecodeString(body)
+		body = string(decoded)
+	}
+	for _, secret := range []string{"P123456789SECRET", "PASS", "High Street"} {
+		if strings.Contains(body, secret) {
+			t.Errorf("Expected %q to be scrubbed from the cassette:\n%s", secret, body)
+		}
+	}
+	_, mediaParams, err := mime.ParseMediaType(recorded.Interactions[0].Request.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := map[string]string{}
+	reader := multipart.NewReader(strings.NewReader(body), mediaParams["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Expected the recorded body to stay valid multipart: %v", err)
+		}
+		content, _ := io.ReadAll(part)
+		fields[part.FormName()] = string(content)
+	}
+	expected := map[string]string{
+		"countryOfIssue":        "GB",
+		"documentNumber":        "[REDACTED]",
+		"documentType":          "passport",
+		"expirationDate":        "2030-01-01",
+		"issueDate":             "2020-01-01",
+		"documentFrontImage":    "[REDACTED 600 bytes]",
+		"additionalDocuments.0": "[REDACTED 30 bytes]",
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected recorded fields %v, got %v", expected, fields)
+	}
+
+	replay := jocall3.NewClient(
+		option.WithBaseURL("http://replay.invalid/"),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					t.Errorf("Expected no request to be sent, got %s %s", req.Method, req.URL)
+					return nil, errors.New("unexpected request")
+				},
+			},
+		}),
+		option.WithRecorder(cassette, option.RecorderReplayStrict),
+	)
+	res, err := replay.Identity.KYC.SubmitWithFiles(context.Background(), params())
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if res.OverallStatus != "in_review" {
+		t.Errorf("Unexpected replayed status %q", res.OverallStatus)
+	}
+}
+
+func TestResponseCacheRevalidation(t *testing.T) {
+	var conditions []string
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodGet {
+						return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+					}
+					conditions = append(conditions, req.Header.Get("If-None-Match"))
+					if req.Header.Get("If-None-Match") == `"v1"` {
+						return &http.Response{StatusCode: http.StatusNotModified, Header: http.Header{"Etag": []string{`"v1"`}}, Body: http.NoBody}, nil
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header: http.Header{
+							"Content-Type":  []string{"application/json"},
+							"Etag":          []string{`"v1"`},
+							"Last-Modified": []string{"Mon, 01 Jul 2024 09:00:00 GMT"},
+						},
+						Body: io.NopCloser(strings.NewReader(`{"quietHours":{"enabled":true,"startTime":"22:00"}}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithResponseCache(option.NewLRUCache(10)),
+	)
+
+	for i := 0; i < 2; i++ {
+		res, err := client.Notifications.Settings.Get(context.Background())
+		if err != nil {
+			t.Fatalf("err should be nil: %s", err.Error())
+		}
+		if !res.QuietHours.Enabled || res.QuietHours.StartTime != "22:00" {
+			t.Errorf("Expected the cached settings to be returned, got %+v", res.QuietHours)
+		}
+	}
+	client.Notifications.Settings.Update(context.Background(), jocall3.NotificationSettingUpdateParams{})
+	client.Notifications.Settings.Get(context.Background())
+
+	if expected := []string{"", `"v1"`, ""}; !reflect.DeepEqual(conditions, expected) {
+		t.Errorf("Expected If-None-Match headers %v, got %v", expected, conditions)
+	}
+}
+
+func TestResponseCacheMaxAge(t *testing.T) {
+	sent := 0
+	cache := option.NewLRUCache(1)
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					sent++
+					cacheControl := "max-age=60"
+					if strings.HasSuffix(req.URL.Path, "/budget_2") {
+						cacheControl = "no-store"
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}, "Cache-Control": []string{cacheControl}},
+						Body:       io.NopCloser(strings.NewReader(`{"id":"budget_1","name":"Groceries"}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithResponseCache(cache),
+	)
+
+	for i := 0; i < 3; i++ {
+		res, err := client.Budgets.Get(context.Background(), "budget_1")
+		if err != nil || res.Name != "Groceries" {
+			t.Fatalf("Unexpected result %+v, %v", res, err)
+		}
+	}
+	if sent != 1 {
+		t.Errorf("Expected fresh responses to be served from the cache, got %d requests", sent)
+	}
+	client.Budgets.Get(context.Background(), "budget_2")
+	client.Budgets.Get(context.Background(), "budget_2")
+	if sent != 3 || cache.Len() != 1 {
+		t.Errorf("Expected no-store responses not to be cached, got %d requests and %d entries", sent, cache.Len())
+	}
+}
+
+func TestMultipartUploadRetry(t *testing.T) {
+	var uploads []string
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+					if err != nil {
+						t.Fatalf("unexpected content type: %v", err)
+					}
+					reader := multipart.NewReader(req.Body, params["boundary"])
+					var parts []string
+					for {
+						part, err := reader.NextPart()
+						if err == io.EOF {
+							break
+						}
+						if err != nil {
+							t.Fatalf("unexpected error reading the body: %v", err)
+						}
+						data, _ := io.ReadAll(part)
+						parts = append(parts, part.FormName()+"="+part.FileName()+":"+string(data))
+					}
+					uploads = append(uploads, strings.Join(parts, ","))
+					status := http.StatusOK
+					if len(uploads)%2 == 1 {
+						status = http.StatusServiceUnavailable
+					}
+					return &http.Response{
+						StatusCode: status,
+						Header:     http.Header{"Content-Type": []string{"application/json"}, "Retry-After-Ms": []string{"1"}},
+						Body:       io.NopCloser(strings.NewReader(`{"disputeId":"dispute_1","status":"pending"}`)),
+					}, nil
+				},
+			},
+		}),
+	)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "receipt.txt")
+	if err := os.WriteFile(path, []byte("paid in full"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.Transactions.DisputeWithEvidence(context.Background(), "txn_1", jocall3.TransactionDisputeWithEvidenceParams{
+		Details: jocall3.F("Charged twice"),
+		Reason:  jocall3.F(jocall3.TransactionDisputeParamsReasonDuplicateCharge),
+		SupportingDocuments: jocall3.F([]io.Reader{
+			jocall3.FileOpenerParam(func() (io.ReadCloser, error) { return os.Open(path) }, "receipt.txt", "").Value,
+			jocall3.FileParam(strings.NewReader("statement"), "statement.csv", "text/csv").Value,
+		}),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if res.DisputeID != "dispute_1" {
+		t.Errorf("Expected the response to be decoded, got %+v", res)
+	}
+	expected := "details=:Charged twice,reason=:duplicate_charge,supportingDocuments.0=receipt.txt:paid in full,supportingDocuments.1=statement.csv:statement"
+	if len(uploads) != 2 || uploads[0] != expected || uploads[1] != expected {
+		t.Errorf("Expected the same body to be sent twice, got %q", uploads)
+	}
+
+	// A file that can only be read once makes the request impossible to retry.
+	uploads = nil
+	_, err = client.Transactions.DisputeWithEvidence(context.Background(), "txn_1", jocall3.TransactionDisputeWithEvidenceParams{
+		Details:             jocall3.F("Charged twice"),
+		Reason:              jocall3.F(jocall3.TransactionDisputeParamsReasonDuplicateCharge),
+		SupportingDocuments: jocall3.F([]io.Reader{io.MultiReader(strings.NewReader("once"))}),
+	})
+	var apierr *jocall3.Error
+	if !errors.As(err, &apierr) || apierr.StatusCode != http.StatusServiceUnavailable || len(uploads) != 1 {
+		t.Errorf("Expected a single attempt that fails with 503, got %v after %d attempts", err, len(uploads))
+	}
+}
+
+// gatedFile is a seekable file whose second Read waits for release, once
+// entered has been closed.
+type gatedFile struct {
+	*bytes.Reader
+	reads   int
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (f *gatedFile) Read(p []byte) (int, error) {
+	f.reads++
+	if f.reads == 2 {
+		close(f.entered)
+		<-f.release
+	}
+	return f.Reader.Read(p)
+}
+
+func TestMultipartUploadRetryClosesBodyLate(t *testing.T) {
+	// A transport may close the request body after RoundTrip returns, so the
+	// retry must not rewind the file while the first attempt still reads it.
+	content := bytes.Repeat([]byte("0123456789abcdef"), 16<<10)
+	file := &gatedFile{Reader: bytes.NewReader(content), entered: make(chan struct{}), release: make(chan struct{})}
+	var uploads [][]byte
+	client := jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					if len(uploads) == 0 {
+						uploads = append(uploads, nil)
+						go io.Copy(io.Discard, req.Body)
+						<-file.entered
+						go func() {
+							time.Sleep(10 * time.Millisecond)
+							close(file.release)
+							req.Body.Close()
+						}()
+						return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+					}
+					_, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+					part, err := multipart.NewReader(req.Body, params["boundary"]).NextPart()
+					if err != nil {
+						t.Fatalf("unexpected error reading the body: %v", err)
+					}
+					data, _ := io.ReadAll(part)
+					uploads = append(uploads, data)
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"disputeId":"dispute_1","status":"pending"}`)),
+					}, nil
+				},
+			},
+		}),
+		option.WithRetryPolicy(&option.BackoffRetryPolicy{
+			Backoff:          func(int, time.Duration) time.Duration { return 0 },
+			IgnoreRetryAfter: true,
+		}),
+	)
+
+	_, err := client.Transactions.DisputeWithEvidence(context.Background(), "txn_1", jocall3.TransactionDisputeWithEvidenceParams{
+		SupportingDocuments: jocall3.F([]io.Reader{jocall3.FileParam(file, "statement.txt", "text/plain").Value}),
+	})
+	if err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	if len(uploads) != 2 || !bytes.Equal(uploads[1], content) {
+		t.Errorf("Expected the retry to send the whole file, got %d attempts", len(uploads))
+	}
+}
+
+func TestFileOpenerParamRead(t *testing.T) {
+	opened := 0
+	f := jocall3.FileOpenerParam(func() (io.ReadCloser, error) {
+		opened++
+		return io.NopCloser(strings.NewReader("paid in full")), nil
+	}, "receipt.txt", "text/plain").Value
+
+	data, err := io.ReadAll(f)
+	if err != nil || string(data) != "paid in full" {
+		t.Fatalf("Expected the file contents, got %q: %v", data, err)
+	}
+	for i := 0; i < 2; i++ {
+		if n, err := f.Read(make([]byte, 8)); n != 0 || err != io.EOF {
+			t.Errorf("Expected io.EOF after the end of the file, got %d, %v", n, err)
+		}
+	}
+	if opened != 1 {
+		t.Errorf("Expected the file to be opened once, got %d", opened)
+	}
+}
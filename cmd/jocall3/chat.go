@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jocall3/go"
+)
+
+// runChat chats with the AI advisor, printing its replies as they stream in.
+// With --message it sends that message and exits; otherwise it reads a message
+// from each line of stdin until it ends or reads /exit. /new starts a new
+// conversation.
+func runChat(ctx context.Context, client *jocall3.Client, flags *flagSet, stdin io.Reader, stdout, stderr io.Writer) error {
+	message, once := flags.take("message")
+	sessionID, _ := flags.take("session-id")
+	if rest := flags.remaining(); len(rest) > 0 {
+		return usagef("unknown flag --%s", rest[0])
+	}
+	if once {
+		_, err := chatTurn(ctx, client, message, sessionID, stdout, stderr)
+		return err
+	}
+
+	fmt.Fprintln(stderr, "Chatting with the AI advisor. Type /new to start a new conversation and /exit to quit.")
+	lines := bufio.NewScanner(stdin)
+	for {
+		fmt.Fprint(stderr, "> ")
+		if !lines.Scan() {
+			fmt.Fprintln(stderr)
+			return lines.Err()
+		}
+		line := strings.TrimSpace(lines.Text())
+		switch line {
+		case "":
+			continue
+		case "/exit", "/quit":
+			return nil
+		case "/new":
+			sessionID = ""
+			continue
+		}
+		next, err := chatTurn(ctx, client, line, sessionID, stdout, stderr)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			fmt.Fprintln(stderr, "jocall3:", describe(err))
+			continue
+		}
+		sessionID = next
+	}
+}
+
+// chatTurn sends message and prints the reply, returning the session the
+// conversation continues in.
+func chatTurn(ctx context.Context, client *jocall3.Client, message, sessionID string, stdout, stderr io.Writer) (string, error) {
+	params := jocall3.AIAdvisorChatSendMessageParams{Message: jocall3.F(message)}
+	if sessionID != "" {
+		params.SessionID = jocall3.F(sessionID)
+	}
+	stream := client.AI.Advisor.Chat.SendMessageStreaming(ctx, params)
+	defer stream.Close()
+
+	for stream.Next() {
+		event := stream.Current()
+		switch event.Type {
+		case jocall3.AIAdvisorChatStreamEventTypeMessageStart:
+			if event.SessionID != "" {
+				sessionID = event.SessionID
+			}
+		case jocall3.AIAdvisorChatStreamEventTypeTextDelta:
+			fmt.Fprint(stdout, event.Delta)
+		case jocall3.AIAdvisorChatStreamEventTypeFunctionCallDelta:
+			if event.Name != "" {
+				fmt.Fprintf(stderr, "[the advisor asked to call %s]\n", event.Name)
+			}
+		case jocall3.AIAdvisorChatStreamEventTypeMessageStop:
+			fmt.Fprintln(stdout)
+			for _, insight := range event.ProactiveInsights {
+				fmt.Fprintf(stdout, "  * %s\n", insight.Title)
+			}
+		}
+	}
+	return sessionID, stream.Err()
+}
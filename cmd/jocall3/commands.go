@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+// resource is a command for a service of the client, such as "transactions"
+// or "ai advisor".
+type resource struct {
+	name     string
+	path     []string
+	children []*resource
+	actions  []*action
+	chat     bool
+}
+
+// action is a command that calls a method of a service.
+type action struct {
+	name     string
+	resource *resource
+	method   reflect.Value
+	// The AutoPaging variant of the method, for list methods.
+	pager reflect.Value
+	// The number of path parameters, such as the ID of a transaction.
+	args int
+	// The type of the params of the method, or nil if it takes none.
+	params reflect.Type
+	// Whether the method returns a result as well as an error.
+	result bool
+}
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	optionsType = reflect.TypeOf([]option.RequestOption(nil))
+	chatType    = reflect.TypeOf((*jocall3.AIAdvisorChatService)(nil))
+)
+
+// newTree returns the resource for client, with a child for each of its
+// services.
+func newTree(client *jocall3.Client) *resource {
+	return newResource("jocall3", nil, reflect.ValueOf(client))
+}
+
+func newResource(name string, path []string, service reflect.Value) *resource {
+	r := &resource{name: name, path: path, chat: service.Type() == chatType}
+	elem := service.Elem()
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Type().Field(i)
+		if !field.IsExported() || field.Type.Kind() != reflect.Pointer || !strings.HasSuffix(field.Type.Elem().Name(), "Service") {
+			continue
+		}
+		childName := kebab(field.Name)
+		r.children = append(r.children, newResource(childName, append(append([]string(nil), path...), childName), elem.Field(i)))
+	}
+	sort.Slice(r.children, func(i, j int) bool { return r.children[i].name < r.children[j].name })
+
+	if service.Type() == reflect.TypeOf((*jocall3.Client)(nil)) {
+		return r
+	}
+	for i := 0; i < service.NumMethod(); i++ {
+		method := service.Type().Method(i)
+		if strings.HasSuffix(method.Name, "AutoPaging") {
+			continue
+		}
+		a, ok := newAction(r, method.Name, service.Method(i))
+		if !ok {
+			continue
+		}
+		if pager := service.MethodByName(method.Name + "AutoPaging"); pager.IsValid() {
+			a.pager = pager
+		}
+		r.actions = append(r.actions, a)
+	}
+	sort.Slice(r.actions, func(i, j int) bool { return r.actions[i].name < r.actions[j].name })
+	return r
+}
+
+// newAction returns the action for a method, if its signature is one that can
+// be called from the command line: a context, then any path parameters, then
+// optional params, then request options, returning a result and an error or
+// only an error.
+func newAction(r *resource, name string, method reflect.Value) (*action, bool) {
+	t := method.Type()
+	if !t.IsVariadic() || t.NumIn() < 2 || t.In(0) != contextType || t.In(t.NumIn()-1) != optionsType {
+		return nil, false
+	}
+	a := &action{name: actionName(name), resource: r, method: method}
+	for i := 1; i < t.NumIn()-1; i++ {
+		in := t.In(i)
+		switch {
+		case in.Kind() == reflect.String && a.params == nil:
+			a.args++
+		case in.Kind() == reflect.Struct && strings.HasSuffix(in.Name(), "Params") && a.params == nil:
+			a.params = in
+		default:
+			return nil, false
+		}
+	}
+	switch {
+	case t.NumOut() == 1 && t.Out(0) == errorType:
+	case t.NumOut() == 2 && t.Out(1) == errorType:
+		a.result = true
+	default:
+		return nil, false
+	}
+	return a, true
+}
+
+// actionName returns the command name of a method: "GetMe" is "get-me", and
+// "New" is "create".
+func actionName(method string) string {
+	if method == "New" {
+		return "create"
+	}
+	if rest := strings.TrimPrefix(method, "New"); rest != method && unicode.IsUpper(rune(rest[0])) {
+		return "create-" + kebab(rest)
+	}
+	return kebab(method)
+}
+
+// kebab converts a Go or JSON name such as "APIKeys" or "startDate" to kebab
+// case, such as "api-keys" or "start-date".
+func kebab(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteByte('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// command is the result of resolving the arguments of a command line.
+type command struct {
+	resource *resource
+	action   *action
+	chat     bool
+	// The positional arguments after the action.
+	args []string
+	// An argument that names neither a resource nor an action.
+	unknown string
+}
+
+func resolve(root *resource, args []string) command {
+	r := root
+	for i, arg := range args {
+		if child := r.child(arg); child != nil {
+			r = child
+			continue
+		}
+		for _, a := range r.actions {
+			if a.name == arg {
+				return command{resource: r, action: a, args: args[i+1:]}
+			}
+		}
+		if arg == "help" {
+			return command{resource: r}
+		}
+		return command{resource: r, unknown: arg}
+	}
+	return command{resource: r, chat: r.chat}
+}
+
+func (r *resource) child(name string) *resource {
+	for _, child := range r.children {
+		if child.name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+func (c command) printHelp(w io.Writer) {
+	if c.action != nil {
+		c.action.printHelp(w)
+		return
+	}
+	r := c.resource
+	if len(r.path) == 0 {
+		fmt.Fprint(w, usage)
+	} else {
+		fmt.Fprintf(w, "Usage: jocall3 %s <command> [arguments] [flags]\n", strings.Join(r.path, " "))
+	}
+	if r.chat {
+		fmt.Fprintf(w, "\nWith no command, starts an interactive chat that streams the replies of the advisor.\nChat flags:\n  --message string      send one message, print the reply and exit\n  --session-id string   continue an earlier conversation\n")
+	}
+	if len(r.children) > 0 {
+		fmt.Fprintf(w, "\nResources:\n")
+		for _, child := range r.children {
+			fmt.Fprintf(w, "  %s\n", child.name)
+		}
+	}
+	if len(r.actions) > 0 {
+		fmt.Fprintf(w, "\nCommands:\n")
+		for _, a := range r.actions {
+			if a.pager.IsValid() {
+				fmt.Fprintf(w, "  %s  (lists every page)\n", a.synopsis())
+			} else {
+				fmt.Fprintf(w, "  %s\n", a.synopsis())
+			}
+		}
+	}
+}
+
+func (a *action) synopsis() string {
+	return a.name + strings.Repeat(" <id>", a.args)
+}
+
+func (a *action) printHelp(w io.Writer) {
+	fmt.Fprintf(w, "Usage: jocall3 %s %s [flags]\n", strings.Join(a.resource.path, " "), a.synopsis())
+	if a.params == nil {
+		return
+	}
+	fmt.Fprintf(w, "\nFlags:\n")
+	for _, p := range paramFields(a.params) {
+		required := ""
+		if p.required {
+			required = " (required)"
+		}
+		fmt.Fprintf(w, "  --%s %s%s\n", p.flag, p.kind(), required)
+	}
+}
+
+// run calls the method of the action and prints its result.
+func (a *action) run(ctx context.Context, args []string, flags *flagSet, g globals, out *printer) error {
+	if len(args) != a.args {
+		return usagef("%s takes %d argument(s), got %d", a.name, a.args, len(args))
+	}
+	in := []reflect.Value{reflect.ValueOf(ctx)}
+	for _, arg := range args {
+		in = append(in, reflect.ValueOf(arg))
+	}
+
+	var opts []option.RequestOption
+	if a.params != nil {
+		params, paramOpts, closers, err := buildParams(a.params, flags, g.data != "")
+		defer func() {
+			for _, c := range closers {
+				c.Close()
+			}
+		}()
+		if err != nil {
+			return err
+		}
+		in = append(in, params)
+		opts = append(opts, paramOpts...)
+	}
+	if rest := flags.remaining(); len(rest) > 0 {
+		return usagef("unknown flag --%s", rest[0])
+	}
+	if g.data != "" {
+		dataOpts, err := dataOptions(g.data)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, dataOpts...)
+	}
+	for _, opt := range opts {
+		in = append(in, reflect.ValueOf(opt))
+	}
+
+	if a.pager.IsValid() {
+		return out.list(pageItems(a.pager.Call(in), g.maxItems))
+	}
+	results := a.method.Call(in)
+	if err, _ := results[len(results)-1].Interface().(error); err != nil {
+		return err
+	}
+	if !a.result {
+		return nil
+	}
+	if raw, ok := rawList(results[0]); ok {
+		return out.list(func(yield func(string) bool) error {
+			for _, item := range raw {
+				if !yield(item) {
+					break
+				}
+			}
+			return nil
+		})
+	}
+	return out.one(rawJSON(results[0]))
+}
+
+// pageItems returns the raw JSON of the items of the auto-pager returned by
+// an AutoPaging method, stopping after max items if max is not zero.
+func pageItems(results []reflect.Value, max int) func(yield func(string) bool) error {
+	return func(yield func(string) bool) error {
+		pager := results[0]
+		next, current := pager.MethodByName("Next"), pager.MethodByName("Current")
+		for n := 0; max == 0 || n < max; n++ {
+			if !next.Call(nil)[0].Bool() {
+				break
+			}
+			if !yield(rawJSON(current.Call(nil)[0])) {
+				return nil
+			}
+		}
+		err, _ := pager.MethodByName("Err").Call(nil)[0].Interface().(error)
+		return err
+	}
+}
+
+// rawList returns the raw JSON of the items of a result that is a slice.
+func rawList(v reflect.Value) ([]string, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice {
+		return nil, false
+	}
+	items := make([]string, v.Len())
+	for i := range items {
+		items[i] = rawJSON(v.Index(i))
+	}
+	return items, true
+}
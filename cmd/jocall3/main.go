@@ -0,0 +1,248 @@
+// Command jocall3 is a command-line client for the jocall3 API.
+//
+// Every resource of the SDK is a subcommand, and every method of a resource is
+// an action of it:
+//
+//	jocall3 transactions list --category Dining --start-date 2024-06-01
+//	jocall3 transactions get txn_123
+//	jocall3 budgets create --name Groceries --total-amount 500 --period monthly --start-date 2024-06-01 --end-date 2024-06-30
+//	jocall3 ai advisor chat
+//
+// Path parameters, such as the ID of a transaction, are given as arguments
+// after the action. Request parameters are given as flags named after their
+// JSON or query names in kebab case. Parameters whose values are objects or
+// arrays of objects take JSON, and --data sets any part of a request body from
+// a JSON object. Run an action with --help to list its flags.
+//
+// List actions fetch every page unless --max-items says otherwise. Results are
+// printed as a table, or as JSON or CSV with --format. "jocall3 ai advisor
+// chat" with no action starts an interactive chat with the AI advisor that
+// streams its replies.
+//
+// The client reads the same environment variables as
+// [jocall3.DefaultClientOptions], such as JOCALL3_BASE_URL and 1231_API_KEY.
+// The --base-url and --api-key flags override them, and JOCALL3_FORMAT sets
+// the default output format.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+const usage = `Usage: jocall3 [flags] <resource>... <action> [arguments] [flags]
+
+Global flags:
+  --format, -o string   output format: table, json or csv (default table, or $JOCALL3_FORMAT)
+  --base-url string     the base URL of the API (default $JOCALL3_BASE_URL)
+  --api-key string      the API key (default $1231_API_KEY)
+  --max-items int       stop listing after this many items (default 0, meaning all)
+  --data json           a JSON object whose fields are set on the request body
+  --help, -h            show help for a command
+`
+
+// globals are the flags that every command accepts.
+type globals struct {
+	format   string
+	baseURL  string
+	apiKey   string
+	maxItems int
+	data     string
+	help     bool
+}
+
+// run runs the command given by args and returns its exit status.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	positional, flags, err := parseArgs(args)
+	if err != nil {
+		fmt.Fprintln(stderr, "jocall3:", err)
+		return 2
+	}
+	g, err := takeGlobals(flags)
+	if err != nil {
+		fmt.Fprintln(stderr, "jocall3:", err)
+		return 2
+	}
+	out, err := newPrinter(g.format, stdout)
+	if err != nil {
+		fmt.Fprintln(stderr, "jocall3:", err)
+		return 2
+	}
+
+	var opts []option.RequestOption
+	if g.baseURL != "" {
+		opts = append(opts, option.WithBaseURL(g.baseURL))
+	}
+	if g.apiKey != "" {
+		opts = append(opts, option.WithAPIKey(g.apiKey))
+	}
+	client := jocall3.NewClient(opts...)
+
+	cmd := resolve(newTree(client), positional)
+	switch {
+	case g.help || (cmd.action == nil && !cmd.chat):
+		if cmd.unknown != "" {
+			fmt.Fprintf(stderr, "jocall3: unknown command %q\n\n", cmd.unknown)
+			cmd.printHelp(stderr)
+			return 2
+		}
+		cmd.printHelp(stdout)
+		return 0
+	case cmd.chat:
+		err = runChat(ctx, client, flags, stdin, stdout, stderr)
+	default:
+		err = cmd.action.run(ctx, cmd.args, flags, g, out)
+	}
+	if err != nil {
+		var usageErr *usageError
+		if errors.As(err, &usageErr) {
+			fmt.Fprintf(stderr, "jocall3: %s\n\n", err)
+			cmd.printHelp(stderr)
+			return 2
+		}
+		fmt.Fprintln(stderr, "jocall3:", describe(err))
+		return 1
+	}
+	return 0
+}
+
+// usageError is an error in the arguments or flags of a command.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+func usagef(format string, args ...interface{}) error {
+	return &usageError{msg: fmt.Sprintf(format, args...)}
+}
+
+// describe returns a message for err that suits a terminal, using the message
+// from the body of API errors rather than the body itself.
+func describe(err error) string {
+	var apierr *jocall3.Error
+	if !errors.As(err, &apierr) || apierr.Message == "" {
+		return err.Error()
+	}
+	msg := fmt.Sprintf("%d %s", apierr.StatusCode, apierr.Message)
+	if apierr.Code != "" {
+		msg += " (" + apierr.Code + ")"
+	}
+	for _, field := range apierr.FieldErrors {
+		msg += fmt.Sprintf("\n  %s: %s", field.Field, field.Message)
+	}
+	if apierr.RequestID != "" {
+		msg += "\n  request ID: " + apierr.RequestID
+	}
+	return msg
+}
+
+// flagSet holds the flags of a command line in the order they were given.
+type flagSet struct {
+	names  []string
+	values map[string]string
+}
+
+func (f *flagSet) take(names ...string) (string, bool) {
+	for _, name := range names {
+		if v, ok := f.values[name]; ok {
+			delete(f.values, name)
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// remaining returns the names of the flags that have not been taken, in the
+// order they were given.
+func (f *flagSet) remaining() []string {
+	var names []string
+	for _, name := range f.names {
+		if _, ok := f.values[name]; ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseArgs splits args into positional arguments and flags. Flags may come
+// anywhere, as --name value, --name=value, or --name alone for true when the
+// next argument is another flag or there is none. A lone -- ends the flags.
+func parseArgs(args []string) ([]string, *flagSet, error) {
+	var positional []string
+	flags := &flagSet{values: map[string]string{}}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			positional = append(positional, arg)
+			continue
+		}
+		name := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if j := strings.IndexByte(name, '='); j >= 0 {
+			name, value, hasValue = name[:j], name[j+1:], true
+		}
+		if name == "" {
+			return nil, nil, fmt.Errorf("bad flag %q", arg)
+		}
+		if !hasValue {
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				value = args[i+1]
+				i++
+			} else {
+				value = "true"
+			}
+		}
+		if name == "h" || name == "help" {
+			// Help never takes a value, so that "--help list" still shows help.
+			if value != "true" {
+				positional = append(positional, value)
+			}
+			value = "true"
+		}
+		if _, dup := flags.values[name]; dup {
+			return nil, nil, fmt.Errorf("flag --%s given more than once", name)
+		}
+		flags.names = append(flags.names, name)
+		flags.values[name] = value
+	}
+	return positional, flags, nil
+}
+
+func takeGlobals(flags *flagSet) (g globals, err error) {
+	g.format = os.Getenv("JOCALL3_FORMAT")
+	if v, ok := flags.take("format", "o"); ok {
+		g.format = v
+	}
+	g.baseURL, _ = flags.take("base-url")
+	g.apiKey, _ = flags.take("api-key")
+	g.data, _ = flags.take("data")
+	if v, ok := flags.take("max-items"); ok {
+		if g.maxItems, err = strconv.Atoi(v); err != nil || g.maxItems < 0 {
+			return g, fmt.Errorf("--max-items must be a non-negative integer")
+		}
+	}
+	if v, ok := flags.take("help", "h"); ok {
+		g.help = v == "true"
+	}
+	return g, nil
+}
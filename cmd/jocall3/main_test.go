@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jocall3/go/jocall3test"
+)
+
+func runCLI(t *testing.T, srv *jocall3test.Server, stdin string, args ...string) (code int, stdout, stderr string) {
+	t.Helper()
+	var out, errOut bytes.Buffer
+	args = append([]string{"--base-url", srv.URL}, args...)
+	code = run(context.Background(), args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+func seedTransactions(srv *jocall3test.Server) {
+	srv.Seed(jocall3test.Transactions,
+		map[string]interface{}{"id": "txn_1", "accountId": "acc_1", "amount": -42.1, "category": "Dining", "date": "2024-06-01", "description": "Bistro"},
+		map[string]interface{}{"id": "txn_2", "accountId": "acc_1", "amount": -10, "category": "Groceries", "date": "2024-06-02", "description": "Market"},
+		map[string]interface{}{"id": "txn_3", "accountId": "acc_1", "amount": -8.5, "category": "Dining", "date": "2024-06-03", "description": "Cafe, downtown"},
+	)
+}
+
+func TestListAutoPagingCSV(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	seedTransactions(srv)
+
+	code, stdout, stderr := runCLI(t, srv, "", "transactions", "list", "--category", "Dining", "--limit", "1", "-o", "csv")
+	if code != 0 {
+		t.Fatalf("Expected success, got %d: %s", code, stderr)
+	}
+	want := "accountId,amount,category,date,description,id\n" +
+		"acc_1,-42.1,Dining,2024-06-01,Bistro,txn_1\n" +
+		"acc_1,-8.5,Dining,2024-06-03,\"Cafe, downtown\",txn_3\n"
+	if stdout != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, stdout)
+	}
+	if n := len(srv.Requests()); n < 2 {
+		t.Errorf("Expected every page to be fetched, got %d requests", n)
+	}
+}
+
+func TestListMaxItemsJSON(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	seedTransactions(srv)
+
+	code, stdout, stderr := runCLI(t, srv, "", "transactions", "list", "--max-items", "2", "--format=json")
+	if code != 0 {
+		t.Fatalf("Expected success, got %d: %s", code, stderr)
+	}
+	var items []map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &items); err != nil {
+		t.Fatalf("Expected a JSON array, got %q: %v", stdout, err)
+	}
+	if len(items) != 2 || items[1]["id"] != "txn_2" {
+		t.Errorf("Expected the first two transactions, got %v", items)
+	}
+}
+
+func TestGetTable(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	seedTransactions(srv)
+
+	code, stdout, stderr := runCLI(t, srv, "", "transactions", "get", "txn_3")
+	if code != 0 {
+		t.Fatalf("Expected success, got %d: %s", code, stderr)
+	}
+	for _, line := range []string{"FIELD        VALUE", "category     Dining", "description  Cafe, downtown"} {
+		if !strings.Contains(stdout, line) {
+			t.Errorf("Expected the table to contain %q, got\n%s", line, stdout)
+		}
+	}
+}
+
+func TestCreate(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "", "budgets", "create",
+		"--name", "Groceries", "--total-amount", "500.00", "--period", "monthly",
+		"--start-date", "2024-06-01", "--end-date", "2024-06-30",
+		"--categories", `[{"name":"Food","allocated":400}]`, "-o", "json")
+	if code != 0 {
+		t.Fatalf("Expected success, got %d: %s", code, stderr)
+	}
+	var budget map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &budget); err != nil || budget["name"] != "Groceries" {
+		t.Fatalf("Expected the created budget, got %q", stdout)
+	}
+
+	var body map[string]interface{}
+	json.Unmarshal(srv.Requests()[0].Body, &body)
+	categories, _ := body["categories"].([]interface{})
+	if body["totalAmount"] != 500.0 || body["startDate"] != "2024-06-01" || len(categories) != 1 {
+		t.Errorf("Unexpected request body %v", body)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+
+	code, _, stderr := runCLI(t, srv, "", "budgets", "create", "--name", "Groceries")
+	if code != 2 || !strings.Contains(stderr, "missing required flags --end-date, --period, --start-date, --total-amount") {
+		t.Errorf("Expected the missing flags to be reported, got %d: %s", code, stderr)
+	}
+	code, _, stderr = runCLI(t, srv, "", "transactions", "frobnicate")
+	if code != 2 || !strings.Contains(stderr, `unknown command "frobnicate"`) {
+		t.Errorf("Expected an unknown command, got %d: %s", code, stderr)
+	}
+	code, _, stderr = runCLI(t, srv, "", "transactions", "list", "--colour", "red")
+	if code != 2 || !strings.Contains(stderr, "unknown flag --colour") {
+		t.Errorf("Expected an unknown flag, got %d: %s", code, stderr)
+	}
+	if len(srv.Requests()) != 0 {
+		t.Errorf("Expected no requests to be sent, got %d", len(srv.Requests()))
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+
+	code, stdout, stderr := runCLI(t, srv, "", "transactions", "get", "txn_404")
+	if code != 1 || stdout != "" || !strings.Contains(stderr, "404") || !strings.Contains(stderr, "(not_found)") {
+		t.Errorf("Expected the API error, got %d: %q %q", code, stdout, stderr)
+	}
+}
+
+func TestChat(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	var sessions []interface{}
+	srv.Handle("POST /ai/advisor/chat", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		sessions = append(sessions, body["sessionId"])
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "data: {\"type\":\"message_start\",\"sessionId\":\"sess_1\"}\n\n")
+		for _, word := range []string{"You spent ", "$42 ", "on ", body["message"].(string), "."} {
+			fmt.Fprintf(w, "data: {\"type\":\"text_delta\",\"delta\":%q}\n\n", word)
+		}
+		io.WriteString(w, "data: {\"type\":\"message_stop\"}\n\n")
+	})
+
+	code, stdout, stderr := runCLI(t, srv, "dining\ncoffee\n/exit\n", "ai", "advisor", "chat")
+	if code != 0 {
+		t.Fatalf("Expected success, got %d: %s", code, stderr)
+	}
+	if stdout != "You spent $42 on dining.\nYou spent $42 on coffee.\n" {
+		t.Errorf("Unexpected replies %q", stdout)
+	}
+	if len(sessions) != 2 || sessions[0] != nil || sessions[1] != "sess_1" {
+		t.Errorf("Expected the second message to continue the session, got %v", sessions)
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/tidwall/gjson"
+)
+
+// The widest a value is printed in a table.
+const maxCellWidth = 48
+
+// printer writes results in an output format.
+type printer struct {
+	format string
+	w      io.Writer
+}
+
+func newPrinter(format string, w io.Writer) (*printer, error) {
+	switch format {
+	case "":
+		format = "table"
+	case "table", "json", "csv":
+	default:
+		return nil, fmt.Errorf("unknown format %q; use table, json or csv", format)
+	}
+	return &printer{format: format, w: w}, nil
+}
+
+// one prints a single object: as indented JSON, as a table of its fields and
+// values, or as CSV with a header row and one record.
+func (p *printer) one(raw string) error {
+	switch p.format {
+	case "json":
+		return p.json(raw)
+	case "csv":
+		return p.csv([]string{raw})
+	}
+	obj := gjson.Parse(raw)
+	if !obj.IsObject() {
+		_, err := fmt.Fprintln(p.w, cell(obj, false))
+		return err
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "FIELD\tVALUE\n")
+	obj.ForEach(func(key, value gjson.Result) bool {
+		fmt.Fprintf(tw, "%s\t%s\n", key.String(), cell(value, true))
+		return true
+	})
+	return tw.Flush()
+}
+
+// list prints the objects yielded by items: as a JSON array, or as a table or
+// CSV with a column for every field. JSON is written as items arrive; the
+// other formats need every item to know their columns.
+func (p *printer) list(items func(yield func(string) bool) error) error {
+	if p.format == "json" {
+		first := true
+		var writeErr error
+		err := items(func(raw string) bool {
+			sep := ",\n"
+			if first {
+				sep, first = "[\n", false
+			}
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, []byte(raw), "  ", "  "); err != nil {
+				buf.Reset()
+				buf.WriteString(raw)
+			}
+			_, writeErr = fmt.Fprintf(p.w, "%s  %s", sep, buf.Bytes())
+			return writeErr == nil
+		})
+		if writeErr != nil {
+			return writeErr
+		}
+		if first {
+			fmt.Fprint(p.w, "[")
+		}
+		fmt.Fprint(p.w, "\n]\n")
+		return err
+	}
+
+	var rows []string
+	err := items(func(raw string) bool {
+		rows = append(rows, raw)
+		return true
+	})
+	if p.format == "csv" {
+		if writeErr := p.csv(rows); writeErr != nil {
+			return writeErr
+		}
+		return err
+	}
+	if writeErr := p.table(rows); writeErr != nil {
+		return writeErr
+	}
+	return err
+}
+
+func (p *printer) json(raw string) error {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(raw), "", "  "); err != nil {
+		buf.Reset()
+		buf.WriteString(raw)
+	}
+	buf.WriteByte('\n')
+	_, err := p.w.Write(buf.Bytes())
+	return err
+}
+
+func (p *printer) table(rows []string) error {
+	columns := columnsOf(rows)
+	if len(columns) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = strings.ToUpper(kebab(column))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, raw := range rows {
+		obj := fieldsOf(raw)
+		cells := make([]string, len(columns))
+		for i, column := range columns {
+			cells[i] = cell(obj[column], true)
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (p *printer) csv(rows []string) error {
+	columns := columnsOf(rows)
+	if len(columns) == 0 {
+		return nil
+	}
+	w := csv.NewWriter(p.w)
+	w.Write(columns)
+	for _, raw := range rows {
+		obj := fieldsOf(raw)
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = cell(obj[column], false)
+		}
+		w.Write(record)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// columnsOf returns the fields of the objects in rows, in the order they are
+// first seen.
+func columnsOf(rows []string) []string {
+	var columns []string
+	seen := map[string]bool{}
+	for _, raw := range rows {
+		gjson.Parse(raw).ForEach(func(key, _ gjson.Result) bool {
+			if name := key.String(); !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+			return true
+		})
+	}
+	return columns
+}
+
+func fieldsOf(raw string) map[string]gjson.Result {
+	fields := map[string]gjson.Result{}
+	gjson.Parse(raw).ForEach(func(key, value gjson.Result) bool {
+		fields[key.String()] = value
+		return true
+	})
+	return fields
+}
+
+// cell returns the text of a value: strings without quotes, nulls as nothing,
+// and objects and arrays as compact JSON. For tables, the text is kept on one
+// line and shortened.
+func cell(v gjson.Result, short bool) string {
+	var s string
+	switch {
+	case !v.Exists(), v.Type == gjson.Null:
+		return ""
+	case v.Type == gjson.String:
+		s = v.String()
+	case v.Type == gjson.JSON:
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, []byte(v.Raw)); err != nil {
+			s = v.Raw
+		} else {
+			s = buf.String()
+		}
+	default:
+		s = v.Raw
+	}
+	if !short {
+		return s
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	if utf8.RuneCountInString(s) > maxCellWidth {
+		s = string([]rune(s)[:maxCellWidth-1]) + "…"
+	}
+	return s
+}
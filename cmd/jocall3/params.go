@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	decimalType = reflect.TypeOf(jocall3.Decimal{})
+	readerType  = reflect.TypeOf((*io.Reader)(nil)).Elem()
+)
+
+// paramField is a field of a params struct that can be set with a flag.
+type paramField struct {
+	index    int
+	name     string
+	flag     string
+	value    reflect.Type
+	required bool
+	// Whether the field is sent in the request body, rather than the query.
+	body bool
+}
+
+// kind describes the values the flag of the field takes.
+func (p paramField) kind() string {
+	switch {
+	case p.value == timeType:
+		return "date"
+	case p.value == decimalType:
+		return "decimal"
+	case p.value == readerType:
+		return "file"
+	case p.value.Kind() == reflect.Slice && p.value.Elem() == readerType:
+		return "files"
+	case p.value.Kind() == reflect.Slice && scalar(p.value.Elem()):
+		return p.value.Elem().Kind().String() + ",..."
+	case scalar(p.value):
+		return p.value.Kind().String()
+	}
+	return "json"
+}
+
+func scalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int64, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// paramFields returns the fields of a params struct that are param.Field
+// values, named after their JSON, query or form names.
+func paramFields(t reflect.Type) []paramField {
+	var fields []paramField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		value, ok := f.Type.FieldByName("Value")
+		if !ok || !strings.HasPrefix(f.Type.Name(), "Field[") {
+			continue
+		}
+		tag, body := f.Tag.Get("json"), true
+		if tag == "" {
+			tag = f.Tag.Get("form")
+		}
+		if tag == "" {
+			tag, body = f.Tag.Get("query"), false
+		}
+		name, rest, _ := strings.Cut(tag, ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		fields = append(fields, paramField{
+			index:    i,
+			name:     name,
+			flag:     kebab(name),
+			value:    value.Type,
+			required: strings.Contains(rest, "required"),
+			body:     body,
+		})
+	}
+	return fields
+}
+
+// buildParams returns params of type t set from flags, and the request options
+// that set the fields that take JSON. The flags it uses are taken from flags.
+// Required fields may be left out when the body is given with --data. The
+// returned closers close the files opened for file fields.
+func buildParams(t reflect.Type, flags *flagSet, data bool) (params reflect.Value, opts []option.RequestOption, closers []io.Closer, err error) {
+	params = reflect.New(t).Elem()
+	var missing []string
+	for _, p := range paramFields(t) {
+		s, ok := flags.take(p.flag)
+		if !ok {
+			if p.required && !data {
+				missing = append(missing, "--"+p.flag)
+			}
+			continue
+		}
+		if p.kind() == "json" {
+			if !p.body {
+				return params, opts, closers, usagef("--%s cannot be set from the command line", p.flag)
+			}
+			var v interface{}
+			if err := json.Unmarshal([]byte(s), &v); err != nil {
+				return params, opts, closers, usagef("--%s must be JSON: %v", p.flag, err)
+			}
+			opts = append(opts, option.WithJSONSet(p.name, v))
+			continue
+		}
+		v, files, err := parseValue(p.value, s)
+		closers = append(closers, files...)
+		if err != nil {
+			return params, opts, closers, usagef("--%s: %v", p.flag, err)
+		}
+		field := params.Field(p.index)
+		field.FieldByName("Value").Set(v)
+		field.FieldByName("Present").SetBool(true)
+	}
+	if len(missing) > 0 {
+		return params, opts, closers, usagef("missing required flags %s", strings.Join(missing, ", "))
+	}
+	return params, opts, closers, nil
+}
+
+// parseValue parses s as a value of type t. Lists are separated by commas, and
+// files are named by their paths.
+func parseValue(t reflect.Type, s string) (reflect.Value, []io.Closer, error) {
+	switch {
+	case t == timeType:
+		for _, layout := range []string{"2006-01-02", time.RFC3339} {
+			if v, err := time.Parse(layout, s); err == nil {
+				return reflect.ValueOf(v), nil, nil
+			}
+		}
+		return reflect.Value{}, nil, fmt.Errorf("cannot parse %q as a date such as 2024-06-30", s)
+	case t == decimalType:
+		d, err := jocall3.NewDecimal(s)
+		return reflect.ValueOf(d), nil, err
+	case t == readerType:
+		f, err := os.Open(s)
+		if err != nil {
+			return reflect.Value{}, nil, err
+		}
+		return reflect.ValueOf(f).Convert(readerType), []io.Closer{f}, nil
+	case t.Kind() == reflect.Slice:
+		list := reflect.MakeSlice(t, 0, 0)
+		var closers []io.Closer
+		for _, item := range strings.Split(s, ",") {
+			v, files, err := parseValue(t.Elem(), strings.TrimSpace(item))
+			closers = append(closers, files...)
+			if err != nil {
+				return reflect.Value{}, closers, err
+			}
+			list = reflect.Append(list, v)
+		}
+		return list, closers, nil
+	}
+
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return v, nil, fmt.Errorf("cannot parse %q as a boolean", s)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return v, nil, fmt.Errorf("cannot parse %q as an integer", s)
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return v, nil, fmt.Errorf("cannot parse %q as a number", s)
+		}
+		v.SetFloat(n)
+	default:
+		return v, nil, fmt.Errorf("unsupported type %s", t)
+	}
+	return v, nil, nil
+}
+
+// dataOptions returns request options that set each field of the JSON object
+// data on the request body.
+func dataOptions(data string) ([]option.RequestOption, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return nil, usagef("--data must be a JSON object: %v", err)
+	}
+	var opts []option.RequestOption
+	for key, value := range fields {
+		opts = append(opts, option.WithJSONSet(key, value))
+	}
+	return opts, nil
+}
+
+// rawJSON returns the JSON of a response object, as the API sent it when it
+// is available.
+func rawJSON(v reflect.Value) string {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "null"
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Struct {
+		if meta := v.FieldByName("JSON"); meta.IsValid() {
+			if raw := meta.MethodByName("RawJSON"); raw.IsValid() {
+				if s := raw.Call(nil)[0].String(); s != "" {
+					return s
+				}
+			}
+		}
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return "null"
+	}
+	return string(data)
+}
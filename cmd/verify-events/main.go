@@ -0,0 +1,110 @@
+// Command verify-events checks that every event in an event log can be
+// upcast to the current schema of its type and decoded, so that a release
+// that changes event structs can be checked against real data before it is
+// deployed.
+//
+// Usage:
+//
+//	verify-events [-after N] [-v] <log directory>
+//	verify-events [-after N] [-v] -dsn URL
+//
+// The log directory is that of an events.FileStore. With -dsn, the tool reads
+// the events table of the PostgreSQL database at URL instead, in the order of
+// its sequence column; the database must have the schema migrations applied.
+// Either is only read, so the tool can be run against a live store. With
+// -after, N is a FileStore sequence number or an events table sequence
+// respectively. It prints a summary of
+// the event types and schema versions it found, and each event that failed.
+// It exits with status 1 if any event failed and 2 if the log could not be
+// read.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+
+	"github.com/jocall3/go/internal/database"
+	"github.com/jocall3/go/pkg/events"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("verify-events", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	after := fs.Uint64("after", 0, "only verify events with sequence numbers greater than `N`")
+	verbose := fs.Bool("v", false, "list the count of each event type and schema version")
+	dsn := fs.String("dsn", "", "verify the events table of the PostgreSQL database at `URL` instead of a log directory")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: verify-events [-after N] [-v] <log directory>\n")
+		fmt.Fprintf(stderr, "       verify-events [-after N] [-v] -dsn URL\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	// Exactly one of a log directory and a database.
+	if *dsn == "" && fs.NArg() != 1 || *dsn != "" && fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	registry := events.DefaultRegistry()
+	if err := registry.Validate(); err != nil {
+		fmt.Fprintf(stderr, "verify-events: the event registry is incomplete:\n%v\n", err)
+		return 1
+	}
+	var src events.RawScanner = events.FileLog{Dir: fs.Arg(0)}
+	if *dsn != "" {
+		db, err := sql.Open("pgx", *dsn)
+		if err != nil {
+			fmt.Fprintf(stderr, "verify-events: %v\n", err)
+			return 2
+		}
+		defer db.Close()
+		src = database.NewPostgresEventStore(db, registry)
+	}
+	report, err := registry.Verify(ctx, src, events.Sequence(*after))
+	if err != nil {
+		fmt.Fprintf(stderr, "verify-events: %v\n", err)
+		return 2
+	}
+
+	if *verbose {
+		types := make([]string, 0, len(report.Versions))
+		for t := range report.Versions {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		for _, t := range types {
+			versions := make([]int, 0, len(report.Versions[t]))
+			for v := range report.Versions[t] {
+				versions = append(versions, v)
+			}
+			sort.Ints(versions)
+			for _, v := range versions {
+				fmt.Fprintf(stdout, "%-48s v%d  %d\n", t, v, report.Versions[t][v])
+			}
+		}
+	}
+	for _, f := range report.Failures {
+		fmt.Fprintf(stdout, "FAIL  sequence %d  %s v%d: %v\n", f.Sequence, f.Type, f.Version, f.Err)
+	}
+	fmt.Fprintf(stdout, "%d events checked, %d failed\n", report.Checked, len(report.Failures))
+	if !report.OK() {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jocall3/go/pkg/events"
+)
+
+func runVerify(args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(context.Background(), args, &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+// writeLog appends events to a new FileStore log in a temporary directory,
+// encoding them with codec, and returns the directory.
+func writeLog(t *testing.T, codec events.Codec, evs ...events.Event) string {
+	t.Helper()
+	dir := t.TempDir()
+	store, err := events.OpenFileStore(dir, events.FileStoreOptions{Codec: codec})
+	if err != nil {
+		t.Fatalf("OpenFileStore: %v", err)
+	}
+	defer store.Close()
+	for _, e := range evs {
+		if _, err := store.Append(context.Background(), e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestVerifyValidLog(t *testing.T) {
+	account := uuid.New()
+	dir := writeLog(t, events.DefaultRegistry(),
+		events.NewAccountCreated(account, uuid.New(), "USD", "customer"),
+		events.NewAccountCredited(account, uuid.New(), 2, 500, 500, "deposit"),
+		events.NewProposalCreated("alice", "Lower fees", "", time.Now().UTC(), time.Now().UTC().Add(time.Hour), nil),
+	)
+
+	code, stdout, stderr := runVerify("-v", dir)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s%s", code, stdout, stderr)
+	}
+	for _, want := range []string{"AccountCreated", "AccountCredited", "governance.proposal.created", "3 events checked, 0 failed"} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("expected the output to contain %q, got:\n%s", want, stdout)
+		}
+	}
+
+	if code, stdout, _ = runVerify("-after", "2", dir); code != 0 || !strings.Contains(stdout, "1 events checked") {
+		t.Fatalf("expected -after to skip the first events, got %d:\n%s", code, stdout)
+	}
+}
+
+func TestVerifyFailingEvents(t *testing.T) {
+	// A newer release stored AccountCredited at schema version 2, which the
+	// current registry does not know how to read.
+	newer := events.NewRegistry()
+	newer.Register(&events.AccountCreated{}, 1)
+	newer.Register(&events.AccountCredited{}, 2)
+	newer.RegisterUpcaster("AccountCredited", 1, func(p map[string]interface{}) (map[string]interface{}, error) { return p, nil })
+
+	account := uuid.New()
+	dir := writeLog(t, newer,
+		events.NewAccountCreated(account, uuid.New(), "USD", "customer"),
+		events.NewAccountCredited(account, uuid.New(), 2, 500, 500, "deposit"),
+	)
+
+	code, stdout, stderr := runVerify(dir)
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d: %s%s", code, stdout, stderr)
+	}
+	if !strings.Contains(stdout, "FAIL  sequence 2  AccountCredited v2") || !strings.Contains(stdout, "2 events checked, 1 failed") {
+		t.Fatalf("unexpected output:\n%s", stdout)
+	}
+}
+
+func TestVerifyUsageAndUnreadableLog(t *testing.T) {
+	for _, args := range [][]string{nil, {"-bogus", "dir"}, {"one", "two"}, {"-dsn", "postgres://localhost/events", "dir"}} {
+		if code, _, stderr := runVerify(args...); code != 2 || !strings.Contains(stderr, "Usage: verify-events") {
+			t.Fatalf("expected usage and exit code 2 for %q, got %d: %s", args, code, stderr)
+		}
+	}
+
+	if code, _, stderr := runVerify(filepath.Join(t.TempDir(), "missing")); code != 2 || !strings.Contains(stderr, "verify-events:") {
+		t.Fatalf("expected exit code 2 for a missing log, got %d: %s", code, stderr)
+	}
+	if code, _, stderr := runVerify("-dsn", "postgres://127.0.0.1:1/events?connect_timeout=5"); code != 2 || !strings.Contains(stderr, "verify-events:") {
+		t.Fatalf("expected exit code 2 for an unreachable database, got %d: %s", code, stderr)
+	}
+
+	// Damage a record of a segment that is not the last, which is
+	// corruption rather than a write in progress.
+	account := uuid.New()
+	dir := t.TempDir()
+	store, err := events.OpenFileStore(dir, events.FileStoreOptions{Codec: events.DefaultRegistry(), SegmentSize: 512})
+	if err != nil {
+		t.Fatalf("OpenFileStore: %v", err)
+	}
+	for v := 1; v <= 6; v++ {
+		if _, err := store.Append(context.Background(), events.NewAccountCredited(account, uuid.New(), v, 1, int64(v), "deposit")); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+	store.Close()
+	segments, _ := filepath.Glob(filepath.Join(dir, "*.log"))
+	if len(segments) < 2 {
+		t.Fatalf("expected several segments, got %d", len(segments))
+	}
+	data, err := os.ReadFile(segments[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-2] ^= 0xff
+	if err := os.WriteFile(segments[0], data, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if code, _, stderr := runVerify(dir); code != 2 || !strings.Contains(stderr, "corrupt") {
+		t.Fatalf("expected exit code 2 for a corrupt log, got %d: %s", code, stderr)
+	}
+}
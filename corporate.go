@@ -6,6 +6,7 @@ import (
 	"context"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -55,15 +56,15 @@ type CorporatePerformSanctionScreeningResponse struct {
 	// Details of any potential or exact matches found.
 	MatchDetails []CorporatePerformSanctionScreeningResponseMatchDetail `json:"matchDetails,required"`
 	// True if any potential matches were found on sanction lists.
-	MatchFound interface{} `json:"matchFound,required"`
+	MatchFound bool `json:"matchFound,required"`
 	// Unique identifier for this screening operation.
-	ScreeningID interface{} `json:"screeningId,required"`
+	ScreeningID string `json:"screeningId,required"`
 	// Timestamp when the screening was performed.
-	ScreeningTimestamp interface{} `json:"screeningTimestamp,required"`
+	ScreeningTimestamp time.Time `json:"screeningTimestamp,required" format:"date-time"`
 	// Overall status of the screening result.
 	Status CorporatePerformSanctionScreeningResponseStatus `json:"status,required"`
 	// An optional message providing more context on the status.
-	Message interface{}                                   `json:"message"`
+	Message string                                        `json:"message"`
 	JSON    corporatePerformSanctionScreeningResponseJSON `json:"-"`
 }
 
@@ -90,15 +91,15 @@ func (r corporatePerformSanctionScreeningResponseJSON) RawJSON() string {
 
 type CorporatePerformSanctionScreeningResponseMatchDetail struct {
 	// Name of the sanction list where a match was found.
-	ListName interface{} `json:"listName"`
+	ListName string `json:"listName"`
 	// The name on the sanction list that matched.
-	MatchedName interface{} `json:"matchedName"`
+	MatchedName string `json:"matchedName"`
 	// Optional: URL to public record of the sanction list entry.
-	PublicURL interface{} `json:"publicUrl"`
+	PublicURL string `json:"publicUrl"`
 	// Reason for the match (e.g., exact name, alias, partial match).
-	Reason interface{} `json:"reason"`
+	Reason string `json:"reason"`
 	// Match confidence score (0-1).
-	Score interface{}                                              `json:"score"`
+	Score float64                                                  `json:"score"`
 	JSON  corporatePerformSanctionScreeningResponseMatchDetailJSON `json:"-"`
 }
 
@@ -143,17 +144,17 @@ func (r CorporatePerformSanctionScreeningResponseStatus) IsKnown() bool {
 type CorporatePerformSanctionScreeningParams struct {
 	// Two-letter ISO country code related to the entity (e.g., country of residence,
 	// registration).
-	Country param.Field[interface{}] `json:"country,required"`
+	Country param.Field[string] `json:"country,required"`
 	// The type of entity being screened.
 	EntityType param.Field[CorporatePerformSanctionScreeningParamsEntityType] `json:"entityType,required"`
 	// Full name of the individual or organization to screen.
-	Name    param.Field[interface{}]  `json:"name,required"`
+	Name    param.Field[string]       `json:"name,required"`
 	Address param.Field[AddressParam] `json:"address"`
 	// Date of birth for individuals (YYYY-MM-DD).
-	DateOfBirth param.Field[interface{}] `json:"dateOfBirth"`
+	DateOfBirth param.Field[time.Time] `json:"dateOfBirth" format:"date"`
 	// Optional: Any government-issued identification number (e.g., passport, national
 	// ID).
-	IdentificationNumber param.Field[interface{}] `json:"identificationNumber"`
+	IdentificationNumber param.Field[string] `json:"identificationNumber"`
 }
 
 func (r CorporatePerformSanctionScreeningParams) MarshalJSON() (data []byte, err error) {
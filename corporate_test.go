@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -26,18 +27,18 @@ func TestCorporatePerformSanctionScreeningWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Corporate.PerformSanctionScreening(context.TODO(), jocall3.CorporatePerformSanctionScreeningParams{
-		Country:    jocall3.F[any]("US"),
+		Country:    jocall3.F("US"),
 		EntityType: jocall3.F(jocall3.CorporatePerformSanctionScreeningParamsEntityTypeIndividual),
-		Name:       jocall3.F[any]("John Doe"),
+		Name:       jocall3.F("John Doe"),
 		Address: jocall3.F(jocall3.AddressParam{
-			City:    jocall3.F[any]("Anytown"),
-			Country: jocall3.F[any]("USA"),
-			State:   jocall3.F[any]("CA"),
-			Street:  jocall3.F[any]("123 Main St"),
-			Zip:     jocall3.F[any]("90210"),
+			City:    jocall3.F("Anytown"),
+			Country: jocall3.F("USA"),
+			State:   jocall3.F("CA"),
+			Street:  jocall3.F("123 Main St"),
+			Zip:     jocall3.F("90210"),
 		}),
-		DateOfBirth:          jocall3.F[any]("1970-01-01"),
-		IdentificationNumber: jocall3.F[any](map[string]interface{}{}),
+		DateOfBirth:          jocall3.F(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)),
+		IdentificationNumber: jocall3.F("string"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // CorporateAnomalyService contains methods and other services that help with
@@ -38,51 +41,72 @@ func NewCorporateAnomalyService(opts ...option.RequestOption) (r *CorporateAnoma
 // Retrieves a comprehensive list of AI-detected financial anomalies across
 // transactions, payments, and corporate cards that require immediate review and
 // potential action to mitigate risk and ensure compliance.
-func (r *CorporateAnomalyService) List(ctx context.Context, query CorporateAnomalyListParams, opts ...option.RequestOption) (res *CorporateAnomalyListResponse, err error) {
+func (r *CorporateAnomalyService) List(ctx context.Context, query CorporateAnomalyListParams, opts ...option.RequestOption) (res *pagination.Page[FinancialAnomaly], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "corporate/anomalies"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a comprehensive list of AI-detected financial anomalies across
+// transactions, payments, and corporate cards that require immediate review and
+// potential action to mitigate risk and ensure compliance.
+func (r *CorporateAnomalyService) ListAutoPaging(ctx context.Context, query CorporateAnomalyListParams, opts ...option.RequestOption) *pagination.PageAutoPager[FinancialAnomaly] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Updates the review status of a specific financial anomaly, allowing compliance
 // officers to mark it as dismissed, resolved, or escalate for further
 // investigation after thorough AI-assisted and human review.
-func (r *CorporateAnomalyService) UpdateStatus(ctx context.Context, anomalyID interface{}, body CorporateAnomalyUpdateStatusParams, opts ...option.RequestOption) (res *FinancialAnomaly, err error) {
+func (r *CorporateAnomalyService) UpdateStatus(ctx context.Context, anomalyID string, body CorporateAnomalyUpdateStatusParams, opts ...option.RequestOption) (res *FinancialAnomaly, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("corporate/anomalies/%v/status", anomalyID)
+	if anomalyID == "" {
+		err = errors.New("missing required anomalyId parameter")
+		return
+	}
+	path := fmt.Sprintf("corporate/anomalies/%s/status", anomalyID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
 
 type FinancialAnomaly struct {
 	// Unique identifier for the detected anomaly.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// AI's confidence in its detection of the anomaly (0-1).
-	AIConfidenceScore interface{} `json:"aiConfidenceScore,required"`
+	AIConfidenceScore float64 `json:"aiConfidenceScore,required"`
 	// A brief summary of the anomaly.
-	Description interface{} `json:"description,required"`
+	Description string `json:"description,required"`
 	// The ID of the specific entity (e.g., transaction, user, card) the anomaly is
 	// linked to.
-	EntityID interface{} `json:"entityId,required"`
+	EntityID string `json:"entityId,required"`
 	// The type of financial entity related to the anomaly.
 	EntityType FinancialAnomalyEntityType `json:"entityType,required"`
 	// AI-recommended immediate action to address the anomaly.
-	RecommendedAction interface{} `json:"recommendedAction,required"`
+	RecommendedAction string `json:"recommendedAction,required"`
 	// AI-assigned risk score (0-100), higher is more risky.
-	RiskScore interface{} `json:"riskScore,required"`
+	RiskScore float64 `json:"riskScore,required"`
 	// AI-assessed severity of the anomaly.
 	Severity FinancialAnomalySeverity `json:"severity,required"`
 	// Current review status of the anomaly.
 	Status FinancialAnomalyStatus `json:"status,required"`
 	// Timestamp when the anomaly was detected.
-	Timestamp interface{} `json:"timestamp,required"`
+	Timestamp time.Time `json:"timestamp,required" format:"date-time"`
 	// Detailed context and reasoning behind the anomaly detection.
-	Details interface{} `json:"details"`
+	Details string `json:"details"`
 	// List of IDs of other transactions or entities related to this anomaly.
-	RelatedTransactions []interface{} `json:"relatedTransactions,nullable"`
+	RelatedTransactions []string `json:"relatedTransactions,nullable"`
 	// Notes recorded during the resolution or dismissal of the anomaly.
-	ResolutionNotes interface{}          `json:"resolutionNotes"`
+	ResolutionNotes string               `json:"resolutionNotes"`
 	JSON            financialAnomalyJSON `json:"-"`
 }
 
@@ -171,41 +195,19 @@ func (r FinancialAnomalyStatus) IsKnown() bool {
 	return false
 }
 
-type CorporateAnomalyListResponse struct {
-	Data []FinancialAnomaly               `json:"data"`
-	JSON corporateAnomalyListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// corporateAnomalyListResponseJSON contains the JSON metadata for the struct
-// [CorporateAnomalyListResponse]
-type corporateAnomalyListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *CorporateAnomalyListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r corporateAnomalyListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type CorporateAnomalyListParams struct {
 	// End date for filtering results (inclusive, YYYY-MM-DD).
-	EndDate param.Field[interface{}] `query:"endDate"`
+	EndDate param.Field[time.Time] `query:"endDate" format:"date"`
 	// Filter anomalies by the type of financial entity they are related to.
 	EntityType param.Field[CorporateAnomalyListParamsEntityType] `query:"entityType"`
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 	// Filter anomalies by their AI-assessed severity level.
 	Severity param.Field[CorporateAnomalyListParamsSeverity] `query:"severity"`
 	// Start date for filtering results (inclusive, YYYY-MM-DD).
-	StartDate param.Field[interface{}] `query:"startDate"`
+	StartDate param.Field[time.Time] `query:"startDate" format:"date"`
 	// Filter anomalies by their current review status.
 	Status param.Field[CorporateAnomalyListParamsStatus] `query:"status"`
 }
@@ -279,7 +281,7 @@ type CorporateAnomalyUpdateStatusParams struct {
 	// The new status for the financial anomaly.
 	Status param.Field[CorporateAnomalyUpdateStatusParamsStatus] `json:"status,required"`
 	// Optional notes regarding the resolution or dismissal of the anomaly.
-	ResolutionNotes param.Field[interface{}] `json:"resolutionNotes"`
+	ResolutionNotes param.Field[string] `json:"resolutionNotes"`
 }
 
 func (r CorporateAnomalyUpdateStatusParams) MarshalJSON() (data []byte, err error) {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -26,12 +27,12 @@ func TestCorporateAnomalyListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Corporate.Anomalies.List(context.TODO(), jocall3.CorporateAnomalyListParams{
-		EndDate:    jocall3.F[any]("2024-12-31"),
+		EndDate:    jocall3.F(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
 		EntityType: jocall3.F(jocall3.CorporateAnomalyListParamsEntityTypeTransaction),
-		Limit:      jocall3.F[any](map[string]interface{}{}),
-		Offset:     jocall3.F[any](map[string]interface{}{}),
+		Limit:      jocall3.F(int64(0)),
+		Offset:     jocall3.F(int64(0)),
 		Severity:   jocall3.F(jocall3.CorporateAnomalyListParamsSeverityCritical),
-		StartDate:  jocall3.F[any]("2024-01-01"),
+		StartDate:  jocall3.F(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
 		Status:     jocall3.F(jocall3.CorporateAnomalyListParamsStatusNew),
 	})
 	if err != nil {
@@ -60,7 +61,7 @@ func TestCorporateAnomalyUpdateStatusWithOptionalParams(t *testing.T) {
 		"anom_risk-2024-07-21-D1E2F3",
 		jocall3.CorporateAnomalyUpdateStatusParams{
 			Status:          jocall3.F(jocall3.CorporateAnomalyUpdateStatusParamsStatusResolved),
-			ResolutionNotes: jocall3.F[any]("Confirmed legitimate transaction after contacting vendor. Marked as resolved."),
+			ResolutionNotes: jocall3.F("Confirmed legitimate transaction after contacting vendor. Marked as resolved."),
 		},
 	)
 	if err != nil {
@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // CorporateCardService contains methods and other services that help with
@@ -38,11 +41,28 @@ func NewCorporateCardService(opts ...option.RequestOption) (r *CorporateCardServ
 // Retrieves a comprehensive list of all physical and virtual corporate cards
 // associated with the user's organization, including their status, assigned
 // holder, and current spending controls.
-func (r *CorporateCardService) List(ctx context.Context, query CorporateCardListParams, opts ...option.RequestOption) (res *CorporateCardListResponse, err error) {
+func (r *CorporateCardService) List(ctx context.Context, query CorporateCardListParams, opts ...option.RequestOption) (res *pagination.Page[CorporateCard], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "corporate/cards"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a comprehensive list of all physical and virtual corporate cards
+// associated with the user's organization, including their status, assigned
+// holder, and current spending controls.
+func (r *CorporateCardService) ListAutoPaging(ctx context.Context, query CorporateCardListParams, opts ...option.RequestOption) *pagination.PageAutoPager[CorporateCard] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Creates and issues a new virtual corporate card with specified spending limits,
@@ -58,57 +78,85 @@ func (r *CorporateCardService) NewVirtual(ctx context.Context, body CorporateCar
 // Immediately changes the frozen status of a corporate card, preventing or
 // allowing transactions in real-time, critical for security and expense
 // management.
-func (r *CorporateCardService) Freeze(ctx context.Context, cardID interface{}, body CorporateCardFreezeParams, opts ...option.RequestOption) (res *CorporateCard, err error) {
+func (r *CorporateCardService) Freeze(ctx context.Context, cardID string, body CorporateCardFreezeParams, opts ...option.RequestOption) (res *CorporateCard, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("corporate/cards/%v/freeze", cardID)
+	if cardID == "" {
+		err = errors.New("missing required cardId parameter")
+		return
+	}
+	path := fmt.Sprintf("corporate/cards/%s/freeze", cardID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
 	return
 }
 
 // Retrieves a paginated list of transactions made with a specific corporate card,
 // including AI categorization and compliance flags.
-func (r *CorporateCardService) ListTransactions(ctx context.Context, cardID interface{}, query CorporateCardListTransactionsParams, opts ...option.RequestOption) (res *PaginatedTransactions, err error) {
+func (r *CorporateCardService) ListTransactions(ctx context.Context, cardID string, query CorporateCardListTransactionsParams, opts ...option.RequestOption) (res *pagination.Page[Transaction], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("corporate/cards/%v/transactions", cardID)
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
+	if cardID == "" {
+		err = errors.New("missing required cardId parameter")
+		return
+	}
+	path := fmt.Sprintf("corporate/cards/%s/transactions", cardID)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a paginated list of transactions made with a specific corporate card,
+// including AI categorization and compliance flags.
+func (r *CorporateCardService) ListTransactionsAutoPaging(ctx context.Context, cardID string, query CorporateCardListTransactionsParams, opts ...option.RequestOption) *pagination.PageAutoPager[Transaction] {
+	return pagination.NewPageAutoPager(r.ListTransactions(ctx, cardID, query, opts...))
 }
 
 // Updates the sophisticated spending controls, limits, and policy overrides for a
 // specific corporate card, enabling real-time adjustments for security and budget
 // adherence.
-func (r *CorporateCardService) UpdateControls(ctx context.Context, cardID interface{}, body CorporateCardUpdateControlsParams, opts ...option.RequestOption) (res *CorporateCard, err error) {
+func (r *CorporateCardService) UpdateControls(ctx context.Context, cardID string, body CorporateCardUpdateControlsParams, opts ...option.RequestOption) (res *CorporateCard, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("corporate/cards/%v/controls", cardID)
+	if cardID == "" {
+		err = errors.New("missing required cardId parameter")
+		return
+	}
+	path := fmt.Sprintf("corporate/cards/%s/controls", cardID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
 
 type CorporateCard struct {
 	// Unique identifier for the corporate card.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Masked card number for display purposes.
-	CardNumberMask interface{} `json:"cardNumberMask,required"`
+	CardNumberMask string `json:"cardNumberMask,required"`
 	// Type of the card (physical or virtual).
 	CardType CorporateCardCardType `json:"cardType,required"`
 	// Granular spending controls for a corporate card.
 	Controls CorporateCardControls `json:"controls,required"`
 	// Timestamp when the card was created.
-	CreatedDate interface{} `json:"createdDate,required"`
+	CreatedDate time.Time `json:"createdDate,required" format:"date-time"`
 	// Currency of the card's limits and transactions.
-	Currency interface{} `json:"currency,required"`
+	Currency string `json:"currency,required"`
 	// Expiration date of the card (YYYY-MM-DD).
-	ExpirationDate interface{} `json:"expirationDate,required"`
+	ExpirationDate time.Time `json:"expirationDate,required" format:"date"`
 	// If true, the card is temporarily frozen and cannot be used.
-	Frozen interface{} `json:"frozen,required"`
+	Frozen bool `json:"frozen,required"`
 	// Name of the card holder.
-	HolderName interface{} `json:"holderName,required"`
+	HolderName string `json:"holderName,required"`
 	// Current status of the card.
 	Status CorporateCardStatus `json:"status,required"`
 	// Optional: ID of the employee associated with this card.
-	AssociatedEmployeeID interface{} `json:"associatedEmployeeId"`
+	AssociatedEmployeeID string `json:"associatedEmployeeId"`
 	// Optional: ID of the overarching spending policy applied to this card.
-	SpendingPolicyID interface{}       `json:"spendingPolicyId"`
+	SpendingPolicyID string            `json:"spendingPolicyId"`
 	JSON             corporateCardJSON `json:"-"`
 }
 
@@ -175,24 +223,24 @@ func (r CorporateCardStatus) IsKnown() bool {
 // Granular spending controls for a corporate card.
 type CorporateCardControls struct {
 	// If true, ATM cash withdrawals are allowed.
-	AtmWithdrawals interface{} `json:"atmWithdrawals"`
+	AtmWithdrawals bool `json:"atmWithdrawals"`
 	// If true, contactless payments are allowed.
-	ContactlessPayments interface{} `json:"contactlessPayments"`
+	ContactlessPayments bool `json:"contactlessPayments"`
 	// Maximum spending limit per day (null for no limit).
-	DailyLimit interface{} `json:"dailyLimit"`
+	DailyLimit Decimal `json:"dailyLimit"`
 	// If true, international transactions are allowed.
-	InternationalTransactions interface{} `json:"internationalTransactions"`
+	InternationalTransactions bool `json:"internationalTransactions"`
 	// List of allowed merchant categories. If empty, all are allowed unless explicitly
 	// denied.
-	MerchantCategoryRestrictions []interface{} `json:"merchantCategoryRestrictions,nullable"`
+	MerchantCategoryRestrictions []string `json:"merchantCategoryRestrictions,nullable"`
 	// Maximum spending limit per month (null for no limit).
-	MonthlyLimit interface{} `json:"monthlyLimit"`
+	MonthlyLimit Decimal `json:"monthlyLimit"`
 	// If true, online transactions are allowed.
-	OnlineTransactions interface{} `json:"onlineTransactions"`
+	OnlineTransactions bool `json:"onlineTransactions"`
 	// Maximum amount for a single transaction (null for no limit).
-	SingleTransactionLimit interface{} `json:"singleTransactionLimit"`
+	SingleTransactionLimit Decimal `json:"singleTransactionLimit"`
 	// List of allowed vendors/merchants by name.
-	VendorRestrictions []interface{}             `json:"vendorRestrictions,nullable"`
+	VendorRestrictions []string                  `json:"vendorRestrictions,nullable"`
 	JSON               corporateCardControlsJSON `json:"-"`
 }
 
@@ -223,57 +271,35 @@ func (r corporateCardControlsJSON) RawJSON() string {
 // Granular spending controls for a corporate card.
 type CorporateCardControlsParam struct {
 	// If true, ATM cash withdrawals are allowed.
-	AtmWithdrawals param.Field[interface{}] `json:"atmWithdrawals"`
+	AtmWithdrawals param.Field[bool] `json:"atmWithdrawals"`
 	// If true, contactless payments are allowed.
-	ContactlessPayments param.Field[interface{}] `json:"contactlessPayments"`
+	ContactlessPayments param.Field[bool] `json:"contactlessPayments"`
 	// Maximum spending limit per day (null for no limit).
-	DailyLimit param.Field[interface{}] `json:"dailyLimit"`
+	DailyLimit param.Field[Decimal] `json:"dailyLimit"`
 	// If true, international transactions are allowed.
-	InternationalTransactions param.Field[interface{}] `json:"internationalTransactions"`
+	InternationalTransactions param.Field[bool] `json:"internationalTransactions"`
 	// List of allowed merchant categories. If empty, all are allowed unless explicitly
 	// denied.
-	MerchantCategoryRestrictions param.Field[[]interface{}] `json:"merchantCategoryRestrictions"`
+	MerchantCategoryRestrictions param.Field[[]string] `json:"merchantCategoryRestrictions"`
 	// Maximum spending limit per month (null for no limit).
-	MonthlyLimit param.Field[interface{}] `json:"monthlyLimit"`
+	MonthlyLimit param.Field[Decimal] `json:"monthlyLimit"`
 	// If true, online transactions are allowed.
-	OnlineTransactions param.Field[interface{}] `json:"onlineTransactions"`
+	OnlineTransactions param.Field[bool] `json:"onlineTransactions"`
 	// Maximum amount for a single transaction (null for no limit).
-	SingleTransactionLimit param.Field[interface{}] `json:"singleTransactionLimit"`
+	SingleTransactionLimit param.Field[Decimal] `json:"singleTransactionLimit"`
 	// List of allowed vendors/merchants by name.
-	VendorRestrictions param.Field[[]interface{}] `json:"vendorRestrictions"`
+	VendorRestrictions param.Field[[]string] `json:"vendorRestrictions"`
 }
 
 func (r CorporateCardControlsParam) MarshalJSON() (data []byte, err error) {
 	return apijson.MarshalRoot(r)
 }
 
-type CorporateCardListResponse struct {
-	Data []CorporateCard               `json:"data"`
-	JSON corporateCardListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// corporateCardListResponseJSON contains the JSON metadata for the struct
-// [CorporateCardListResponse]
-type corporateCardListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *CorporateCardListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r corporateCardListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type CorporateCardListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [CorporateCardListParams]'s query parameters as
@@ -289,15 +315,15 @@ type CorporateCardNewVirtualParams struct {
 	// Granular spending controls for a corporate card.
 	Controls param.Field[CorporateCardControlsParam] `json:"controls,required"`
 	// Expiration date for the virtual card (YYYY-MM-DD).
-	ExpirationDate param.Field[interface{}] `json:"expirationDate,required"`
+	ExpirationDate param.Field[time.Time] `json:"expirationDate,required" format:"date"`
 	// Name to appear on the virtual card.
-	HolderName param.Field[interface{}] `json:"holderName,required"`
+	HolderName param.Field[string] `json:"holderName,required"`
 	// Brief description of the virtual card's purpose.
-	Purpose param.Field[interface{}] `json:"purpose,required"`
+	Purpose param.Field[string] `json:"purpose,required"`
 	// Optional: ID of the employee or department this card is for.
-	AssociatedEmployeeID param.Field[interface{}] `json:"associatedEmployeeId"`
+	AssociatedEmployeeID param.Field[string] `json:"associatedEmployeeId"`
 	// Optional: ID of a spending policy to link with this virtual card.
-	SpendingPolicyID param.Field[interface{}] `json:"spendingPolicyId"`
+	SpendingPolicyID param.Field[string] `json:"spendingPolicyId"`
 }
 
 func (r CorporateCardNewVirtualParams) MarshalJSON() (data []byte, err error) {
@@ -306,7 +332,7 @@ func (r CorporateCardNewVirtualParams) MarshalJSON() (data []byte, err error) {
 
 type CorporateCardFreezeParams struct {
 	// Set to `true` to freeze the card, `false` to unfreeze.
-	Freeze param.Field[interface{}] `json:"freeze,required"`
+	Freeze param.Field[bool] `json:"freeze,required"`
 }
 
 func (r CorporateCardFreezeParams) MarshalJSON() (data []byte, err error) {
@@ -315,13 +341,13 @@ func (r CorporateCardFreezeParams) MarshalJSON() (data []byte, err error) {
 
 type CorporateCardListTransactionsParams struct {
 	// End date for filtering results (inclusive, YYYY-MM-DD).
-	EndDate param.Field[interface{}] `query:"endDate"`
+	EndDate param.Field[time.Time] `query:"endDate" format:"date"`
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 	// Start date for filtering results (inclusive, YYYY-MM-DD).
-	StartDate param.Field[interface{}] `query:"startDate"`
+	StartDate param.Field[time.Time] `query:"startDate" format:"date"`
 }
 
 // URLQuery serializes [CorporateCardListTransactionsParams]'s query parameters as
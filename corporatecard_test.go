@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -26,8 +27,8 @@ func TestCorporateCardListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Corporate.Cards.List(context.TODO(), jocall3.CorporateCardListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -52,21 +53,21 @@ func TestCorporateCardNewVirtualWithOptionalParams(t *testing.T) {
 	)
 	_, err := client.Corporate.Cards.NewVirtual(context.TODO(), jocall3.CorporateCardNewVirtualParams{
 		Controls: jocall3.F(jocall3.CorporateCardControlsParam{
-			AtmWithdrawals:               jocall3.F[any](false),
-			ContactlessPayments:          jocall3.F[any](false),
-			DailyLimit:                   jocall3.F[any](500),
-			InternationalTransactions:    jocall3.F[any](false),
-			MerchantCategoryRestrictions: jocall3.F([]interface{}{"Advertising"}),
-			MonthlyLimit:                 jocall3.F[any](1000),
-			OnlineTransactions:           jocall3.F[any](true),
-			SingleTransactionLimit:       jocall3.F[any](200),
-			VendorRestrictions:           jocall3.F([]interface{}{"Facebook Ads", "Google Ads"}),
+			AtmWithdrawals:               jocall3.F(false),
+			ContactlessPayments:          jocall3.F(false),
+			DailyLimit:                   jocall3.F(jocall3.MustDecimal("500")),
+			InternationalTransactions:    jocall3.F(false),
+			MerchantCategoryRestrictions: jocall3.F([]string{"Advertising"}),
+			MonthlyLimit:                 jocall3.F(jocall3.MustDecimal("1000")),
+			OnlineTransactions:           jocall3.F(true),
+			SingleTransactionLimit:       jocall3.F(jocall3.MustDecimal("200")),
+			VendorRestrictions:           jocall3.F([]string{"Facebook Ads", "Google Ads"}),
 		}),
-		ExpirationDate:       jocall3.F[any]("2025-12-31"),
-		HolderName:           jocall3.F[any]("Marketing Campaign Q4"),
-		Purpose:              jocall3.F[any]("Online advertising for Q4 campaigns"),
-		AssociatedEmployeeID: jocall3.F[any]("emp_marketing_01"),
-		SpendingPolicyID:     jocall3.F[any]("policy_marketing_fixed"),
+		ExpirationDate:       jocall3.F(time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)),
+		HolderName:           jocall3.F("Marketing Campaign Q4"),
+		Purpose:              jocall3.F("Online advertising for Q4 campaigns"),
+		AssociatedEmployeeID: jocall3.F("emp_marketing_01"),
+		SpendingPolicyID:     jocall3.F("policy_marketing_fixed"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -93,7 +94,7 @@ func TestCorporateCardFreeze(t *testing.T) {
 		context.TODO(),
 		"corp_card_xyz987654",
 		jocall3.CorporateCardFreezeParams{
-			Freeze: jocall3.F[any](true),
+			Freeze: jocall3.F(true),
 		},
 	)
 	if err != nil {
@@ -121,10 +122,10 @@ func TestCorporateCardListTransactionsWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"corp_card_xyz987654",
 		jocall3.CorporateCardListTransactionsParams{
-			EndDate:   jocall3.F[any]("2024-12-31"),
-			Limit:     jocall3.F[any](map[string]interface{}{}),
-			Offset:    jocall3.F[any](map[string]interface{}{}),
-			StartDate: jocall3.F[any]("2024-01-01"),
+			EndDate:   jocall3.F(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+			Limit:     jocall3.F(int64(0)),
+			Offset:    jocall3.F(int64(0)),
+			StartDate: jocall3.F(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
 		},
 	)
 	if err != nil {
@@ -153,15 +154,15 @@ func TestCorporateCardUpdateControlsWithOptionalParams(t *testing.T) {
 		"corp_card_xyz987654",
 		jocall3.CorporateCardUpdateControlsParams{
 			CorporateCardControls: jocall3.CorporateCardControlsParam{
-				AtmWithdrawals:               jocall3.F[any](true),
-				ContactlessPayments:          jocall3.F[any](true),
-				DailyLimit:                   jocall3.F[any](750),
-				InternationalTransactions:    jocall3.F[any](true),
-				MerchantCategoryRestrictions: jocall3.F([]interface{}{"Software Subscriptions", "Conferences"}),
-				MonthlyLimit:                 jocall3.F[any](3000),
-				OnlineTransactions:           jocall3.F[any](true),
-				SingleTransactionLimit:       jocall3.F[any](1000),
-				VendorRestrictions:           jocall3.F([]interface{}{"Amazon", "Uber"}),
+				AtmWithdrawals:               jocall3.F(true),
+				ContactlessPayments:          jocall3.F(true),
+				DailyLimit:                   jocall3.F(jocall3.MustDecimal("750")),
+				InternationalTransactions:    jocall3.F(true),
+				MerchantCategoryRestrictions: jocall3.F([]string{"Software Subscriptions", "Conferences"}),
+				MonthlyLimit:                 jocall3.F(jocall3.MustDecimal("3000")),
+				OnlineTransactions:           jocall3.F(true),
+				SingleTransactionLimit:       jocall3.F(jocall3.MustDecimal("1000")),
+				VendorRestrictions:           jocall3.F([]string{"Amazon", "Uber"}),
 			},
 		},
 	)
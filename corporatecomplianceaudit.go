@@ -4,9 +4,11 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -44,16 +46,20 @@ func (r *CorporateComplianceAuditService) Request(ctx context.Context, body Corp
 }
 
 // Retrieves the full report generated by an AI-driven compliance audit.
-func (r *CorporateComplianceAuditService) GetReport(ctx context.Context, auditID interface{}, opts ...option.RequestOption) (res *CorporateComplianceAuditGetReportResponse, err error) {
+func (r *CorporateComplianceAuditService) GetReport(ctx context.Context, auditID string, opts ...option.RequestOption) (res *CorporateComplianceAuditGetReportResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("corporate/compliance/audits/%v/report", auditID)
+	if auditID == "" {
+		err = errors.New("missing required auditId parameter")
+		return
+	}
+	path := fmt.Sprintf("corporate/compliance/audits/%s/report", auditID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
 
 type CorporateComplianceAuditRequestResponse struct {
 	// Unique identifier for the initiated audit.
-	AuditID interface{} `json:"auditId"`
+	AuditID string `json:"auditId"`
 	// Current status of the audit.
 	Status CorporateComplianceAuditRequestResponseStatus `json:"status"`
 	JSON   corporateComplianceAuditRequestResponseJSON   `json:"-"`
@@ -96,13 +102,13 @@ func (r CorporateComplianceAuditRequestResponseStatus) IsKnown() bool {
 
 type CorporateComplianceAuditGetReportResponse struct {
 	// Timestamp when the audit report was generated.
-	AuditDate interface{} `json:"auditDate,required"`
+	AuditDate time.Time `json:"auditDate,required" format:"date-time"`
 	// Unique identifier for the compliance audit.
-	AuditID interface{} `json:"auditId,required"`
+	AuditID string `json:"auditId,required"`
 	// Detailed findings, observations, and recommendations.
 	Findings []CorporateComplianceAuditGetReportResponseFinding `json:"findings,required"`
 	// Overall compliance score (0-100), higher is better.
-	OverallComplianceScore interface{} `json:"overallComplianceScore,required"`
+	OverallComplianceScore float64 `json:"overallComplianceScore,required"`
 	// The period covered by this audit report.
 	PeriodCovered CorporateComplianceAuditGetReportResponsePeriodCovered `json:"periodCovered,required"`
 	// AI-generated actionable recommendations to improve compliance.
@@ -110,7 +116,7 @@ type CorporateComplianceAuditGetReportResponse struct {
 	// Current status of the audit.
 	Status CorporateComplianceAuditGetReportResponseStatus `json:"status,required"`
 	// A high-level summary of the audit findings.
-	Summary interface{}                                   `json:"summary,required"`
+	Summary string                                        `json:"summary,required"`
 	JSON    corporateComplianceAuditGetReportResponseJSON `json:"-"`
 }
 
@@ -138,9 +144,9 @@ func (r corporateComplianceAuditGetReportResponseJSON) RawJSON() string {
 }
 
 type CorporateComplianceAuditGetReportResponseFinding struct {
-	Description interface{} `json:"description"`
+	Description string `json:"description"`
 	// IDs of entities related to this finding (e.g., transaction IDs).
-	RelatedEntities []interface{}                                             `json:"relatedEntities,nullable"`
+	RelatedEntities []string                                                  `json:"relatedEntities,nullable"`
 	Severity        CorporateComplianceAuditGetReportResponseFindingsSeverity `json:"severity"`
 	Type            CorporateComplianceAuditGetReportResponseFindingsType     `json:"type"`
 	JSON            corporateComplianceAuditGetReportResponseFindingJSON      `json:"-"`
@@ -200,8 +206,8 @@ func (r CorporateComplianceAuditGetReportResponseFindingsType) IsKnown() bool {
 
 // The period covered by this audit report.
 type CorporateComplianceAuditGetReportResponsePeriodCovered struct {
-	EndDate   interface{}                                                `json:"endDate"`
-	StartDate interface{}                                                `json:"startDate"`
+	EndDate   time.Time                                                  `json:"endDate" format:"date"`
+	StartDate time.Time                                                  `json:"startDate" format:"date"`
 	JSON      corporateComplianceAuditGetReportResponsePeriodCoveredJSON `json:"-"`
 }
 
@@ -243,13 +249,13 @@ type CorporateComplianceAuditRequestParams struct {
 	// The scope of the audit (e.g., all transactions, specific accounts).
 	AuditScope param.Field[CorporateComplianceAuditRequestParamsAuditScope] `json:"auditScope,required"`
 	// End date for the audit period (inclusive).
-	EndDate param.Field[interface{}] `json:"endDate,required"`
+	EndDate param.Field[time.Time] `json:"endDate,required" format:"date"`
 	// List of regulatory frameworks against which to audit.
 	RegulatoryFrameworks param.Field[[]CorporateComplianceAuditRequestParamsRegulatoryFramework] `json:"regulatoryFrameworks,required"`
 	// Start date for the audit period (inclusive).
-	StartDate param.Field[interface{}] `json:"startDate,required"`
+	StartDate param.Field[time.Time] `json:"startDate,required" format:"date"`
 	// Optional: Any additional context or specific areas of concern for the AI.
-	AdditionalContext param.Field[interface{}] `json:"additionalContext"`
+	AdditionalContext param.Field[string] `json:"additionalContext"`
 }
 
 func (r CorporateComplianceAuditRequestParams) MarshalJSON() (data []byte, err error) {
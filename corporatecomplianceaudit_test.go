@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -27,10 +28,10 @@ func TestCorporateComplianceAuditRequestWithOptionalParams(t *testing.T) {
 	)
 	_, err := client.Corporate.Compliance.Audits.Request(context.TODO(), jocall3.CorporateComplianceAuditRequestParams{
 		AuditScope:           jocall3.F(jocall3.CorporateComplianceAuditRequestParamsAuditScopeAllTransactions),
-		EndDate:              jocall3.F[any]("2024-06-30"),
+		EndDate:              jocall3.F(time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)),
 		RegulatoryFrameworks: jocall3.F([]jocall3.CorporateComplianceAuditRequestParamsRegulatoryFramework{jocall3.CorporateComplianceAuditRequestParamsRegulatoryFrameworkAml, jocall3.CorporateComplianceAuditRequestParamsRegulatoryFrameworkPciDss}),
-		StartDate:            jocall3.F[any]("2024-01-01"),
-		AdditionalContext:    jocall3.F[any](map[string]interface{}{}),
+		StartDate:            jocall3.F(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		AdditionalContext:    jocall3.F("string"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // CorporateRiskFraudRuleService contains methods and other services that help with
@@ -47,9 +50,13 @@ func (r *CorporateRiskFraudRuleService) New(ctx context.Context, body CorporateR
 
 // Updates an existing custom AI-powered fraud detection rule, modifying its
 // criteria, actions, or status.
-func (r *CorporateRiskFraudRuleService) Update(ctx context.Context, ruleID interface{}, body CorporateRiskFraudRuleUpdateParams, opts ...option.RequestOption) (res *FraudRule, err error) {
+func (r *CorporateRiskFraudRuleService) Update(ctx context.Context, ruleID string, body CorporateRiskFraudRuleUpdateParams, opts ...option.RequestOption) (res *FraudRule, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("corporate/risk/fraud/rules/%v", ruleID)
+	if ruleID == "" {
+		err = errors.New("missing required ruleId parameter")
+		return
+	}
+	path := fmt.Sprintf("corporate/risk/fraud/rules/%s", ruleID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
@@ -57,39 +64,60 @@ func (r *CorporateRiskFraudRuleService) Update(ctx context.Context, ruleID inter
 // Retrieves a list of AI-powered fraud detection rules currently active for the
 // organization, including their parameters, thresholds, and associated actions
 // (e.g., flag, block, alert).
-func (r *CorporateRiskFraudRuleService) List(ctx context.Context, query CorporateRiskFraudRuleListParams, opts ...option.RequestOption) (res *CorporateRiskFraudRuleListResponse, err error) {
+func (r *CorporateRiskFraudRuleService) List(ctx context.Context, query CorporateRiskFraudRuleListParams, opts ...option.RequestOption) (res *pagination.Page[FraudRule], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "corporate/risk/fraud/rules"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of AI-powered fraud detection rules currently active for the
+// organization, including their parameters, thresholds, and associated actions
+// (e.g., flag, block, alert).
+func (r *CorporateRiskFraudRuleService) ListAutoPaging(ctx context.Context, query CorporateRiskFraudRuleListParams, opts ...option.RequestOption) *pagination.PageAutoPager[FraudRule] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Deletes a specific custom AI-powered fraud detection rule.
-func (r *CorporateRiskFraudRuleService) Delete(ctx context.Context, ruleID interface{}, opts ...option.RequestOption) (err error) {
+func (r *CorporateRiskFraudRuleService) Delete(ctx context.Context, ruleID string, opts ...option.RequestOption) (err error) {
 	opts = slices.Concat(r.Options, opts)
 	opts = append([]option.RequestOption{option.WithHeader("Accept", "*/*")}, opts...)
-	path := fmt.Sprintf("corporate/risk/fraud/rules/%v", ruleID)
+	if ruleID == "" {
+		err = errors.New("missing required ruleId parameter")
+		return
+	}
+	path := fmt.Sprintf("corporate/risk/fraud/rules/%s", ruleID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, nil, opts...)
 	return
 }
 
 type FraudRule struct {
 	// Unique identifier for the fraud detection rule.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Action to take when a fraud rule is triggered.
 	Action FraudRuleAction `json:"action,required"`
 	// Timestamp when the rule was created.
-	CreatedAt interface{} `json:"createdAt,required"`
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
 	// Identifier of who created the rule (e.g., user ID, 'system:ai-risk-engine').
-	CreatedBy interface{} `json:"createdBy,required"`
+	CreatedBy string `json:"createdBy,required"`
 	// Criteria that define when a fraud rule should trigger.
 	Criteria FraudRuleCriteria `json:"criteria,required"`
 	// Detailed description of what the rule detects.
-	Description interface{} `json:"description,required"`
+	Description string `json:"description,required"`
 	// Timestamp when the rule was last updated.
-	LastUpdated interface{} `json:"lastUpdated,required"`
+	LastUpdated time.Time `json:"lastUpdated,required" format:"date-time"`
 	// Name of the fraud rule.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// Severity level when this rule is triggered.
 	Severity FraudRuleSeverity `json:"severity,required"`
 	// Current status of the rule.
@@ -159,11 +187,11 @@ func (r FraudRuleStatus) IsKnown() bool {
 // Action to take when a fraud rule is triggered.
 type FraudRuleAction struct {
 	// Details or instructions for the action.
-	Details interface{} `json:"details,required"`
+	Details string `json:"details,required"`
 	// Type of action to perform.
 	Type FraudRuleActionType `json:"type,required"`
 	// The team or department to notify for alerts/reviews.
-	TargetTeam interface{}         `json:"targetTeam"`
+	TargetTeam string              `json:"targetTeam"`
 	JSON       fraudRuleActionJSON `json:"-"`
 }
 
@@ -206,11 +234,11 @@ func (r FraudRuleActionType) IsKnown() bool {
 // Action to take when a fraud rule is triggered.
 type FraudRuleActionParam struct {
 	// Details or instructions for the action.
-	Details param.Field[interface{}] `json:"details,required"`
+	Details param.Field[string] `json:"details,required"`
 	// Type of action to perform.
 	Type param.Field[FraudRuleActionType] `json:"type,required"`
 	// The team or department to notify for alerts/reviews.
-	TargetTeam param.Field[interface{}] `json:"targetTeam"`
+	TargetTeam param.Field[string] `json:"targetTeam"`
 }
 
 func (r FraudRuleActionParam) MarshalJSON() (data []byte, err error) {
@@ -220,25 +248,25 @@ func (r FraudRuleActionParam) MarshalJSON() (data []byte, err error) {
 // Criteria that define when a fraud rule should trigger.
 type FraudRuleCriteria struct {
 	// Number of days an account must be inactive for the rule to apply.
-	AccountInactivityDays interface{} `json:"accountInactivityDays"`
+	AccountInactivityDays int64 `json:"accountInactivityDays"`
 	// List of ISO 2-letter country codes for transaction origin.
-	CountryOfOrigin []interface{} `json:"countryOfOrigin,nullable"`
+	CountryOfOrigin []string `json:"countryOfOrigin,nullable"`
 	// Minimum geographic distance (in km) from recent activity for anomaly.
-	GeographicDistanceKm interface{} `json:"geographicDistanceKm"`
+	GeographicDistanceKm float64 `json:"geographicDistanceKm"`
 	// Number of days since last user login for anomaly detection.
-	LastLoginDays interface{} `json:"lastLoginDays"`
+	LastLoginDays int64 `json:"lastLoginDays"`
 	// If true, rule applies only if no prior travel notification was made.
-	NoTravelNotification interface{} `json:"noTravelNotification"`
+	NoTravelNotification bool `json:"noTravelNotification"`
 	// Minimum number of payments in a timeframe.
-	PaymentCountMin interface{} `json:"paymentCountMin"`
+	PaymentCountMin int64 `json:"paymentCountMin"`
 	// List of risk levels for recipient countries.
 	RecipientCountryRiskLevel []FraudRuleCriteriaRecipientCountryRiskLevel `json:"recipientCountryRiskLevel,nullable"`
 	// If true, recipient must be a new payee.
-	RecipientNew interface{} `json:"recipientNew"`
+	RecipientNew bool `json:"recipientNew"`
 	// Timeframe in hours for payment count or other event aggregations.
-	TimeframeHours interface{} `json:"timeframeHours"`
+	TimeframeHours int64 `json:"timeframeHours"`
 	// Minimum transaction amount to consider.
-	TransactionAmountMin interface{} `json:"transactionAmountMin"`
+	TransactionAmountMin Decimal `json:"transactionAmountMin"`
 	// Specific transaction type (e.g., debit, credit).
 	TransactionType FraudRuleCriteriaTransactionType `json:"transactionType,nullable"`
 	JSON            fraudRuleCriteriaJSON            `json:"-"`
@@ -306,25 +334,25 @@ func (r FraudRuleCriteriaTransactionType) IsKnown() bool {
 // Criteria that define when a fraud rule should trigger.
 type FraudRuleCriteriaParam struct {
 	// Number of days an account must be inactive for the rule to apply.
-	AccountInactivityDays param.Field[interface{}] `json:"accountInactivityDays"`
+	AccountInactivityDays param.Field[int64] `json:"accountInactivityDays"`
 	// List of ISO 2-letter country codes for transaction origin.
-	CountryOfOrigin param.Field[[]interface{}] `json:"countryOfOrigin"`
+	CountryOfOrigin param.Field[[]string] `json:"countryOfOrigin"`
 	// Minimum geographic distance (in km) from recent activity for anomaly.
-	GeographicDistanceKm param.Field[interface{}] `json:"geographicDistanceKm"`
+	GeographicDistanceKm param.Field[float64] `json:"geographicDistanceKm"`
 	// Number of days since last user login for anomaly detection.
-	LastLoginDays param.Field[interface{}] `json:"lastLoginDays"`
+	LastLoginDays param.Field[int64] `json:"lastLoginDays"`
 	// If true, rule applies only if no prior travel notification was made.
-	NoTravelNotification param.Field[interface{}] `json:"noTravelNotification"`
+	NoTravelNotification param.Field[bool] `json:"noTravelNotification"`
 	// Minimum number of payments in a timeframe.
-	PaymentCountMin param.Field[interface{}] `json:"paymentCountMin"`
+	PaymentCountMin param.Field[int64] `json:"paymentCountMin"`
 	// List of risk levels for recipient countries.
 	RecipientCountryRiskLevel param.Field[[]FraudRuleCriteriaRecipientCountryRiskLevel] `json:"recipientCountryRiskLevel"`
 	// If true, recipient must be a new payee.
-	RecipientNew param.Field[interface{}] `json:"recipientNew"`
+	RecipientNew param.Field[bool] `json:"recipientNew"`
 	// Timeframe in hours for payment count or other event aggregations.
-	TimeframeHours param.Field[interface{}] `json:"timeframeHours"`
+	TimeframeHours param.Field[int64] `json:"timeframeHours"`
 	// Minimum transaction amount to consider.
-	TransactionAmountMin param.Field[interface{}] `json:"transactionAmountMin"`
+	TransactionAmountMin param.Field[Decimal] `json:"transactionAmountMin"`
 	// Specific transaction type (e.g., debit, credit).
 	TransactionType param.Field[FraudRuleCriteriaTransactionType] `json:"transactionType"`
 }
@@ -333,37 +361,15 @@ func (r FraudRuleCriteriaParam) MarshalJSON() (data []byte, err error) {
 	return apijson.MarshalRoot(r)
 }
 
-type CorporateRiskFraudRuleListResponse struct {
-	Data []FraudRule                            `json:"data"`
-	JSON corporateRiskFraudRuleListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// corporateRiskFraudRuleListResponseJSON contains the JSON metadata for the struct
-// [CorporateRiskFraudRuleListResponse]
-type corporateRiskFraudRuleListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *CorporateRiskFraudRuleListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r corporateRiskFraudRuleListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type CorporateRiskFraudRuleNewParams struct {
 	// Action to take when a fraud rule is triggered.
 	Action param.Field[FraudRuleActionParam] `json:"action,required"`
 	// Criteria that define when a fraud rule should trigger.
 	Criteria param.Field[FraudRuleCriteriaParam] `json:"criteria,required"`
 	// Detailed description of what the rule detects.
-	Description param.Field[interface{}] `json:"description,required"`
+	Description param.Field[string] `json:"description,required"`
 	// Name of the new fraud rule.
-	Name param.Field[interface{}] `json:"name,required"`
+	Name param.Field[string] `json:"name,required"`
 	// Severity level when this rule is triggered.
 	Severity param.Field[CorporateRiskFraudRuleNewParamsSeverity] `json:"severity,required"`
 	// Initial status of the rule.
@@ -415,9 +421,9 @@ type CorporateRiskFraudRuleUpdateParams struct {
 	// Criteria that define when a fraud rule should trigger.
 	Criteria param.Field[FraudRuleCriteriaParam] `json:"criteria"`
 	// Updated description of what the rule detects.
-	Description param.Field[interface{}] `json:"description"`
+	Description param.Field[string] `json:"description"`
 	// Updated name of the fraud rule.
-	Name param.Field[interface{}] `json:"name"`
+	Name param.Field[string] `json:"name"`
 	// Updated severity level.
 	Severity param.Field[CorporateRiskFraudRuleUpdateParamsSeverity] `json:"severity"`
 	// Updated status of the rule.
@@ -465,9 +471,9 @@ func (r CorporateRiskFraudRuleUpdateParamsStatus) IsKnown() bool {
 
 type CorporateRiskFraudRuleListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [CorporateRiskFraudRuleListParams]'s query parameters as
@@ -27,25 +27,25 @@ func TestCorporateRiskFraudRuleNewWithOptionalParams(t *testing.T) {
 	)
 	_, err := client.Corporate.Risk.Fraud.Rules.New(context.TODO(), jocall3.CorporateRiskFraudRuleNewParams{
 		Action: jocall3.F(jocall3.FraudRuleActionParam{
-			Details:    jocall3.F[any]("Hold payment, notify sender for additional verification, and escalate to compliance."),
+			Details:    jocall3.F("Hold payment, notify sender for additional verification, and escalate to compliance."),
 			Type:       jocall3.F(jocall3.FraudRuleActionTypeAutoReview),
-			TargetTeam: jocall3.F[any]("Fraud Prevention Team"),
+			TargetTeam: jocall3.F("Fraud Prevention Team"),
 		}),
 		Criteria: jocall3.F(jocall3.FraudRuleCriteriaParam{
-			AccountInactivityDays:     jocall3.F[any](90),
-			CountryOfOrigin:           jocall3.F([]interface{}{"US", "CA"}),
-			GeographicDistanceKm:      jocall3.F[any](5000),
-			LastLoginDays:             jocall3.F[any](7),
-			NoTravelNotification:      jocall3.F[any](true),
-			PaymentCountMin:           jocall3.F[any](3),
+			AccountInactivityDays:     jocall3.F(int64(90)),
+			CountryOfOrigin:           jocall3.F([]string{"US", "CA"}),
+			GeographicDistanceKm:      jocall3.F(5000.0),
+			LastLoginDays:             jocall3.F(int64(7)),
+			NoTravelNotification:      jocall3.F(true),
+			PaymentCountMin:           jocall3.F(int64(3)),
 			RecipientCountryRiskLevel: jocall3.F([]jocall3.FraudRuleCriteriaRecipientCountryRiskLevel{jocall3.FraudRuleCriteriaRecipientCountryRiskLevelHigh, jocall3.FraudRuleCriteriaRecipientCountryRiskLevelVeryHigh}),
-			RecipientNew:              jocall3.F[any](true),
-			TimeframeHours:            jocall3.F[any](24),
-			TransactionAmountMin:      jocall3.F[any](5000),
+			RecipientNew:              jocall3.F(true),
+			TimeframeHours:            jocall3.F(int64(24)),
+			TransactionAmountMin:      jocall3.F(jocall3.MustDecimal("5000")),
 			TransactionType:           jocall3.F(jocall3.FraudRuleCriteriaTransactionTypeDebit),
 		}),
-		Description: jocall3.F[any]("Detects multiple international payments to new beneficiaries in high-risk countries within a short timeframe."),
-		Name:        jocall3.F[any]("Suspicious International Payment Pattern"),
+		Description: jocall3.F("Detects multiple international payments to new beneficiaries in high-risk countries within a short timeframe."),
+		Name:        jocall3.F("Suspicious International Payment Pattern"),
 		Severity:    jocall3.F(jocall3.CorporateRiskFraudRuleNewParamsSeverityCritical),
 		Status:      jocall3.F(jocall3.CorporateRiskFraudRuleNewParamsStatusActive),
 	})
@@ -75,25 +75,25 @@ func TestCorporateRiskFraudRuleUpdateWithOptionalParams(t *testing.T) {
 		"fraud_rule_high_value_inactive",
 		jocall3.CorporateRiskFraudRuleUpdateParams{
 			Action: jocall3.F(jocall3.FraudRuleActionParam{
-				Details:    jocall3.F[any]("Flag for manual review only, do not block."),
+				Details:    jocall3.F("Flag for manual review only, do not block."),
 				Type:       jocall3.F(jocall3.FraudRuleActionTypeBlock),
-				TargetTeam: jocall3.F[any]("Fraud Prevention Team"),
+				TargetTeam: jocall3.F("Fraud Prevention Team"),
 			}),
 			Criteria: jocall3.F(jocall3.FraudRuleCriteriaParam{
-				AccountInactivityDays:     jocall3.F[any](60),
-				CountryOfOrigin:           jocall3.F([]interface{}{"US", "CA"}),
-				GeographicDistanceKm:      jocall3.F[any](5000),
-				LastLoginDays:             jocall3.F[any](7),
-				NoTravelNotification:      jocall3.F[any](true),
-				PaymentCountMin:           jocall3.F[any](3),
+				AccountInactivityDays:     jocall3.F(int64(60)),
+				CountryOfOrigin:           jocall3.F([]string{"US", "CA"}),
+				GeographicDistanceKm:      jocall3.F(5000.0),
+				LastLoginDays:             jocall3.F(int64(7)),
+				NoTravelNotification:      jocall3.F(true),
+				PaymentCountMin:           jocall3.F(int64(3)),
 				RecipientCountryRiskLevel: jocall3.F([]jocall3.FraudRuleCriteriaRecipientCountryRiskLevel{jocall3.FraudRuleCriteriaRecipientCountryRiskLevelLow}),
-				RecipientNew:              jocall3.F[any](true),
-				TimeframeHours:            jocall3.F[any](24),
-				TransactionAmountMin:      jocall3.F[any](7500),
+				RecipientNew:              jocall3.F(true),
+				TimeframeHours:            jocall3.F(int64(24)),
+				TransactionAmountMin:      jocall3.F(jocall3.MustDecimal("7500")),
 				TransactionType:           jocall3.F(jocall3.FraudRuleCriteriaTransactionTypeDebit),
 			}),
-			Description: jocall3.F[any]("Revised logic for flagging high value transactions from dormant accounts."),
-			Name:        jocall3.F[any]("Revised High Value Transaction Rule"),
+			Description: jocall3.F("Revised logic for flagging high value transactions from dormant accounts."),
+			Name:        jocall3.F("Revised High Value Transaction Rule"),
 			Severity:    jocall3.F(jocall3.CorporateRiskFraudRuleUpdateParamsSeverityHigh),
 			Status:      jocall3.F(jocall3.CorporateRiskFraudRuleUpdateParamsStatusInactive),
 		},
@@ -120,8 +120,8 @@ func TestCorporateRiskFraudRuleListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Corporate.Risk.Fraud.Rules.List(context.TODO(), jocall3.CorporateRiskFraudRuleListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
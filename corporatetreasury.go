@@ -6,6 +6,7 @@ import (
 	"context"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/requestconfig"
@@ -54,9 +55,9 @@ type CorporateTreasuryGetLiquidityPositionsResponse struct {
 	// Details on short-term investments contributing to liquidity.
 	ShortTermInvestments CorporateTreasuryGetLiquidityPositionsResponseShortTermInvestments `json:"shortTermInvestments,required"`
 	// Timestamp of the liquidity snapshot.
-	SnapshotTime interface{} `json:"snapshotTime,required"`
+	SnapshotTime time.Time `json:"snapshotTime,required" format:"date-time"`
 	// Total value of all liquid assets across the organization.
-	TotalLiquidAssets interface{}                                        `json:"totalLiquidAssets,required"`
+	TotalLiquidAssets Decimal                                            `json:"totalLiquidAssets,required"`
 	JSON              corporateTreasuryGetLiquidityPositionsResponseJSON `json:"-"`
 }
 
@@ -83,8 +84,8 @@ func (r corporateTreasuryGetLiquidityPositionsResponseJSON) RawJSON() string {
 }
 
 type CorporateTreasuryGetLiquidityPositionsResponseAccountTypeBreakdown struct {
-	Amount interface{}                                                            `json:"amount"`
-	Type   interface{}                                                            `json:"type"`
+	Amount Decimal                                                                `json:"amount"`
+	Type   string                                                                 `json:"type"`
 	JSON   corporateTreasuryGetLiquidityPositionsResponseAccountTypeBreakdownJSON `json:"-"`
 }
 
@@ -108,7 +109,7 @@ func (r corporateTreasuryGetLiquidityPositionsResponseAccountTypeBreakdownJSON)
 
 // AI's overall assessment of liquidity.
 type CorporateTreasuryGetLiquidityPositionsResponseAILiquidityAssessment struct {
-	Message interface{}                                                               `json:"message"`
+	Message string                                                                    `json:"message"`
 	Status  CorporateTreasuryGetLiquidityPositionsResponseAILiquidityAssessmentStatus `json:"status"`
 	JSON    corporateTreasuryGetLiquidityPositionsResponseAILiquidityAssessmentJSON   `json:"-"`
 }
@@ -149,9 +150,9 @@ func (r CorporateTreasuryGetLiquidityPositionsResponseAILiquidityAssessmentStatu
 }
 
 type CorporateTreasuryGetLiquidityPositionsResponseCurrencyBreakdown struct {
-	Amount     interface{}                                                         `json:"amount"`
-	Currency   interface{}                                                         `json:"currency"`
-	Percentage interface{}                                                         `json:"percentage"`
+	Amount     Decimal                                                             `json:"amount"`
+	Currency   string                                                              `json:"currency"`
+	Percentage float64                                                             `json:"percentage"`
 	JSON       corporateTreasuryGetLiquidityPositionsResponseCurrencyBreakdownJSON `json:"-"`
 }
 
@@ -176,8 +177,8 @@ func (r corporateTreasuryGetLiquidityPositionsResponseCurrencyBreakdownJSON) Raw
 
 // Details on short-term investments contributing to liquidity.
 type CorporateTreasuryGetLiquidityPositionsResponseShortTermInvestments struct {
-	MaturingNext30Days interface{}                                                            `json:"maturingNext30Days"`
-	TotalValue         interface{}                                                            `json:"totalValue"`
+	MaturingNext30Days Decimal                                                                `json:"maturingNext30Days"`
+	TotalValue         Decimal                                                                `json:"totalValue"`
 	JSON               corporateTreasuryGetLiquidityPositionsResponseShortTermInvestmentsJSON `json:"-"`
 }
 
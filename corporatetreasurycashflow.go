@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
@@ -48,19 +49,19 @@ type CorporateTreasuryCashFlowGetForecastResponse struct {
 	// AI-generated recommendations for treasury optimization.
 	AIRecommendations []AIInsight `json:"aiRecommendations,required"`
 	// The currency of the forecast.
-	Currency interface{} `json:"currency,required"`
+	Currency string `json:"currency,required"`
 	// Unique identifier for the cash flow forecast report.
-	ForecastID interface{} `json:"forecastId,required"`
+	ForecastID string `json:"forecastId,required"`
 	// Forecast of cash inflows by source.
 	InflowForecast CorporateTreasuryCashFlowGetForecastResponseInflowForecast `json:"inflowForecast,required"`
 	// AI-assessed risk score for liquidity (0-100, lower is better).
-	LiquidityRiskScore interface{} `json:"liquidityRiskScore,required"`
+	LiquidityRiskScore float64 `json:"liquidityRiskScore,required"`
 	// Forecast of cash outflows by category.
 	OutflowForecast CorporateTreasuryCashFlowGetForecastResponseOutflowForecast `json:"outflowForecast,required"`
 	// Overall assessment of the projected cash flow.
 	OverallStatus CorporateTreasuryCashFlowGetForecastResponseOverallStatus `json:"overallStatus,required"`
 	// The period covered by the forecast.
-	Period interface{} `json:"period,required"`
+	Period string `json:"period,required"`
 	// Projected cash balances at key dates, potentially across different scenarios.
 	ProjectedBalances []CorporateTreasuryCashFlowGetForecastResponseProjectedBalance `json:"projectedBalances,required"`
 	JSON              corporateTreasuryCashFlowGetForecastResponseJSON               `json:"-"`
@@ -93,7 +94,7 @@ func (r corporateTreasuryCashFlowGetForecastResponseJSON) RawJSON() string {
 // Forecast of cash inflows by source.
 type CorporateTreasuryCashFlowGetForecastResponseInflowForecast struct {
 	BySource       []CorporateTreasuryCashFlowGetForecastResponseInflowForecastBySource `json:"bySource"`
-	TotalProjected interface{}                                                          `json:"totalProjected"`
+	TotalProjected Decimal                                                              `json:"totalProjected"`
 	JSON           corporateTreasuryCashFlowGetForecastResponseInflowForecastJSON       `json:"-"`
 }
 
@@ -116,8 +117,8 @@ func (r corporateTreasuryCashFlowGetForecastResponseInflowForecastJSON) RawJSON(
 }
 
 type CorporateTreasuryCashFlowGetForecastResponseInflowForecastBySource struct {
-	Amount interface{}                                                            `json:"amount"`
-	Source interface{}                                                            `json:"source"`
+	Amount Decimal                                                                `json:"amount"`
+	Source string                                                                 `json:"source"`
 	JSON   corporateTreasuryCashFlowGetForecastResponseInflowForecastBySourceJSON `json:"-"`
 }
 
@@ -142,7 +143,7 @@ func (r corporateTreasuryCashFlowGetForecastResponseInflowForecastBySourceJSON)
 // Forecast of cash outflows by category.
 type CorporateTreasuryCashFlowGetForecastResponseOutflowForecast struct {
 	ByCategory     []CorporateTreasuryCashFlowGetForecastResponseOutflowForecastByCategory `json:"byCategory"`
-	TotalProjected interface{}                                                             `json:"totalProjected"`
+	TotalProjected Decimal                                                                 `json:"totalProjected"`
 	JSON           corporateTreasuryCashFlowGetForecastResponseOutflowForecastJSON         `json:"-"`
 }
 
@@ -165,8 +166,8 @@ func (r corporateTreasuryCashFlowGetForecastResponseOutflowForecastJSON) RawJSON
 }
 
 type CorporateTreasuryCashFlowGetForecastResponseOutflowForecastByCategory struct {
-	Amount   interface{}                                                               `json:"amount"`
-	Category interface{}                                                               `json:"category"`
+	Amount   Decimal                                                                   `json:"amount"`
+	Category string                                                                    `json:"category"`
 	JSON     corporateTreasuryCashFlowGetForecastResponseOutflowForecastByCategoryJSON `json:"-"`
 }
 
@@ -207,8 +208,8 @@ func (r CorporateTreasuryCashFlowGetForecastResponseOverallStatus) IsKnown() boo
 }
 
 type CorporateTreasuryCashFlowGetForecastResponseProjectedBalance struct {
-	Date          interface{}                                                           `json:"date"`
-	ProjectedCash interface{}                                                           `json:"projectedCash"`
+	Date          time.Time                                                             `json:"date" format:"date"`
+	ProjectedCash Decimal                                                               `json:"projectedCash"`
 	Scenario      CorporateTreasuryCashFlowGetForecastResponseProjectedBalancesScenario `json:"scenario"`
 	JSON          corporateTreasuryCashFlowGetForecastResponseProjectedBalanceJSON      `json:"-"`
 }
@@ -251,10 +252,10 @@ func (r CorporateTreasuryCashFlowGetForecastResponseProjectedBalancesScenario) I
 type CorporateTreasuryCashFlowGetForecastParams struct {
 	// The number of days into the future for which to generate the cash flow forecast
 	// (e.g., 30, 90, 180).
-	ForecastHorizonDays param.Field[interface{}] `query:"forecastHorizonDays"`
+	ForecastHorizonDays param.Field[int64] `query:"forecastHorizonDays"`
 	// If true, the forecast will include best-case and worst-case scenario analysis
 	// alongside the most likely projection.
-	IncludeScenarioAnalysis param.Field[interface{}] `query:"includeScenarioAnalysis"`
+	IncludeScenarioAnalysis param.Field[bool] `query:"includeScenarioAnalysis"`
 }
 
 // URLQuery serializes [CorporateTreasuryCashFlowGetForecastParams]'s query
@@ -26,8 +26,8 @@ func TestCorporateTreasuryCashFlowGetForecastWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Corporate.Treasury.CashFlow.GetForecast(context.TODO(), jocall3.CorporateTreasuryCashFlowGetForecastParams{
-		ForecastHorizonDays:     jocall3.F[any](map[string]interface{}{}),
-		IncludeScenarioAnalysis: jocall3.F[any](map[string]interface{}{}),
+		ForecastHorizonDays:     jocall3.F(int64(0)),
+		IncludeScenarioAnalysis: jocall3.F(true),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -0,0 +1,219 @@
+package jocall3
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jocall3/go/option"
+)
+
+// DefaultLoginRefreshBefore is how long before it expires a [LoginCredentials]
+// access token is replaced, unless [LoginCredentials.RefreshBefore] says
+// otherwise.
+const DefaultLoginRefreshBefore = time.Minute
+
+// LoginCredentials is an [option.CredentialsProvider] that authenticates
+// requests with access tokens obtained from [UserService.Login].
+//
+// The access token is cached and replaced by logging in again shortly before
+// it expires, or when a request is rejected with it. If logging in before the
+// token expires fails, the token is used until it does. A token whose login
+// response gives no lifetime, or one shorter than RefreshBefore, is used until
+// a request is rejected with it. Concurrent requests that need a new token
+// share a single login. The login request itself is sent without the provider.
+//
+//	creds := jocall3.NewLoginCredentials(client.Users, jocall3.UserLoginParams{
+//		Email:    jocall3.F("alice@example.com"),
+//		Password: jocall3.F(password),
+//	})
+//	client = jocall3.NewClient(option.WithCredentialsProvider(creds))
+type LoginCredentials struct {
+	// How long before it expires an access token is replaced. Zero means
+	// [DefaultLoginRefreshBefore].
+	RefreshBefore time.Duration
+
+	users  *UserService
+	params func(ctx context.Context) (UserLoginParams, error)
+	now    func() time.Time
+
+	mu    sync.Mutex
+	token string
+	// expiry is when token expires, or zero if it is used until rejected.
+	expiry  time.Time
+	pending *credentialsCall
+}
+
+// credentialsCall is a login that callers needing a new token wait for.
+type credentialsCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// NewLoginCredentials returns a provider that logs in to users with params.
+func NewLoginCredentials(users *UserService, params UserLoginParams) *LoginCredentials {
+	return NewLoginCredentialsFunc(users, func(context.Context) (UserLoginParams, error) { return params, nil })
+}
+
+// NewLoginCredentialsFunc returns a provider that logs in to users with the
+// params returned by params, which is called for every login. It suits
+// accounts that need a fresh multi-factor authentication code each time.
+func NewLoginCredentialsFunc(users *UserService, params func(ctx context.Context) (UserLoginParams, error)) *LoginCredentials {
+	return &LoginCredentials{users: users, params: params, now: time.Now}
+}
+
+// Token implements [option.CredentialsProvider]. It returns the cached access
+// token, logging in first if there is none or it is about to expire.
+func (c *LoginCredentials) Token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	if c.token != "" && (c.expiry.IsZero() || c.now().Before(c.expiry.Add(-c.refreshBefore()))) {
+		token := c.token
+		c.mu.Unlock()
+		return token, nil
+	}
+	cached, expiry := c.token, c.expiry
+	token, err := c.login(ctx)
+	if err != nil && cached != "" && c.now().Before(expiry) {
+		// The token is about to expire but has not yet, so a failed login
+		// need not fail the request.
+		return cached, nil
+	}
+	return token, err
+}
+
+// Refresh implements [option.CredentialsProvider]. It logs in again, unless
+// the cached token has already replaced rejected.
+func (c *LoginCredentials) Refresh(ctx context.Context, rejected string) (string, error) {
+	c.mu.Lock()
+	if c.token != "" && c.token != rejected && (c.expiry.IsZero() || c.now().Before(c.expiry)) {
+		token := c.token
+		c.mu.Unlock()
+		return token, nil
+	}
+	return c.login(ctx)
+}
+
+// login logs in, or waits for a login that is already under way, with c.mu
+// held on entry.
+func (c *LoginCredentials) login(ctx context.Context) (string, error) {
+	call := c.pending
+	if call == nil {
+		call = &credentialsCall{done: make(chan struct{})}
+		c.pending = call
+		// The login is shared by every caller waiting for it, so it must not be
+		// cancelled along with the context of the one that started it.
+		go c.doLogin(context.WithoutCancel(ctx), call)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+func (c *LoginCredentials) doLogin(ctx context.Context, call *credentialsCall) {
+	defer close(call.done)
+	start := c.now()
+	token, expiresIn, err := c.fetch(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = nil
+	if err != nil {
+		call.err = err
+		return
+	}
+	c.token = token
+	c.expiry = time.Time{}
+	if lifetime := time.Duration(expiresIn) * time.Second; lifetime > c.refreshBefore() {
+		c.expiry = start.Add(lifetime)
+	}
+	call.token = token
+}
+
+func (c *LoginCredentials) refreshBefore() time.Duration {
+	if c.RefreshBefore == 0 {
+		return DefaultLoginRefreshBefore
+	}
+	return c.RefreshBefore
+}
+
+func (c *LoginCredentials) fetch(ctx context.Context) (string, int64, error) {
+	params, err := c.params(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+	res, err := c.users.Login(ctx, params, option.WithCredentialsProvider(nil))
+	if err != nil {
+		return "", 0, fmt.Errorf("jocall3: login failed: %w", err)
+	}
+	if res.AccessToken == "" {
+		return "", 0, errors.New("jocall3: login returned no access token")
+	}
+	return res.AccessToken, res.ExpiresIn, nil
+}
+
+// BiometricCredentials is an [option.CredentialsProvider] for step-up
+// operations, which need a fresh biometric token for every request.
+//
+// For each token, it obtains a one-time biometric signature from sign, such as
+// a prompt on the user's device, and verifies it with
+// [UserMeBiometricService.Verify]. The token is the biometricToken returned by
+// the verification if there is one, and the verified signature otherwise. It
+// is meant to be passed to the calls that need it, rather than set on the
+// client:
+//
+//	bio := jocall3.NewBiometricCredentials(client.Users.Me.Biometrics, sign)
+//	client.Payments.International.Initiate(ctx, params, option.WithCredentialsProvider(bio))
+type BiometricCredentials struct {
+	biometrics *UserMeBiometricService
+	sign       func(ctx context.Context) (UserMeBiometricVerifyParams, error)
+	opts       []option.RequestOption
+}
+
+// NewBiometricCredentials returns a provider that verifies the signatures
+// returned by sign with biometrics. The verification requests are sent with the
+// options of biometrics followed by opts; they must be authenticated as the
+// user, for example by a [LoginCredentials] set on the client.
+func NewBiometricCredentials(biometrics *UserMeBiometricService, sign func(ctx context.Context) (UserMeBiometricVerifyParams, error), opts ...option.RequestOption) *BiometricCredentials {
+	return &BiometricCredentials{biometrics: biometrics, sign: sign, opts: opts}
+}
+
+// Token implements [option.CredentialsProvider]. Every call asks for and
+// verifies a new biometric signature.
+func (c *BiometricCredentials) Token(ctx context.Context) (string, error) {
+	params, err := c.sign(ctx)
+	if err != nil {
+		return "", err
+	}
+	res, err := c.biometrics.Verify(ctx, params, c.opts...)
+	if err != nil {
+		return "", fmt.Errorf("jocall3: biometric verification failed: %w", err)
+	}
+	if res.VerificationStatus != UserMeBiometricVerifyResponseVerificationStatusSuccess {
+		if res.Message != "" {
+			return "", fmt.Errorf("jocall3: biometric verification %s: %s", res.VerificationStatus, res.Message)
+		}
+		return "", fmt.Errorf("jocall3: biometric verification %s", res.VerificationStatus)
+	}
+	if token, ok := res.JSON.ExtraFields["biometricToken"]; ok && token.Raw() != "" {
+		var s string
+		if err := json.Unmarshal([]byte(token.Raw()), &s); err == nil && s != "" {
+			return s, nil
+		}
+	}
+	return params.BiometricSignature.Value, nil
+}
+
+// Refresh implements [option.CredentialsProvider]. Biometric tokens are used
+// once, so it asks for a new one as [BiometricCredentials.Token] does.
+func (c *BiometricCredentials) Refresh(ctx context.Context, rejected string) (string, error) {
+	return c.Token(ctx)
+}
@@ -0,0 +1,206 @@
+package jocall3_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+// credentialsServer answers logins with numbered tokens and accepts other
+// requests only with the latest one.
+type credentialsServer struct {
+	mu        sync.Mutex
+	logins    int
+	expiresIn int
+	requests  []string
+}
+
+func (s *credentialsServer) client(opts ...option.RequestOption) *jocall3.Client {
+	return jocall3.NewClient(append([]option.RequestOption{
+		option.WithMaxRetries(0),
+		option.WithHTTPClient(&http.Client{Transport: &closureTransport{fn: s.roundTrip}}),
+	}, opts...)...)
+}
+
+func (s *credentialsServer) roundTrip(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	auth := req.Header.Get("Authorization")
+	s.requests = append(s.requests, req.Method+" "+req.URL.Path+" "+auth)
+	respond := func(status int, body string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	}
+	switch req.URL.Path {
+	case "/users/login":
+		if auth != "" {
+			return respond(http.StatusBadRequest, `{"message":"the login request must not be authenticated"}`)
+		}
+		s.logins++
+		return respond(http.StatusOK, fmt.Sprintf(`{"accessToken":"tok%d","expiresIn":%d,"refreshToken":"r","tokenType":"Bearer"}`, s.logins, s.expiresIn))
+	case "/users/me/biometrics/verify":
+		return respond(http.StatusOK, `{"verificationStatus":"success","biometricToken":"bio-token"}`)
+	}
+	if auth != fmt.Sprintf("Bearer tok%d", s.logins) && auth != "Bearer bio-token" {
+		return respond(http.StatusUnauthorized, `{"message":"token expired"}`)
+	}
+	return respond(http.StatusOK, `{"id":"budget_1","name":"Groceries"}`)
+}
+
+func (s *credentialsServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logins++
+}
+
+func TestLoginCredentials(t *testing.T) {
+	srv := &credentialsServer{expiresIn: 3600}
+	client := srv.client()
+	creds := jocall3.NewLoginCredentials(client.Users, jocall3.UserLoginParams{
+		Email:    jocall3.F("alice@example.com"),
+		Password: jocall3.F("secret"),
+	})
+	client = srv.client(option.WithCredentialsProvider(creds))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Budgets.Get(context.Background(), "budget_1"); err != nil {
+			t.Fatalf("err should be nil: %s", err.Error())
+		}
+	}
+	if srv.logins != 1 {
+		t.Errorf("Expected the access token to be reused, got %d logins", srv.logins)
+	}
+
+	// The server revokes the token; concurrent requests that are rejected with
+	// it share a single login, and are each retried once.
+	srv.expire()
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Budgets.Get(context.Background(), "budget_1"); err != nil {
+				failures.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if failures.Load() != 0 {
+		t.Errorf("Expected every request to succeed after the refresh, got %d failures", failures.Load())
+	}
+	if srv.logins != 3 {
+		t.Errorf("Expected the refreshes to be coalesced into one login, got %d logins", srv.logins-2)
+	}
+}
+
+func TestLoginCredentialsRefreshBeforeExpiry(t *testing.T) {
+	srv := &credentialsServer{expiresIn: 60}
+	logins := 0
+	creds := jocall3.NewLoginCredentialsFunc(srv.client().Users, func(context.Context) (jocall3.UserLoginParams, error) {
+		logins++
+		if logins > 2 {
+			return jocall3.UserLoginParams{}, errors.New("login unavailable")
+		}
+		return jocall3.UserLoginParams{}, nil
+	})
+	// The token is due to be replaced a millisecond after it is obtained.
+	creds.RefreshBefore = time.Minute - time.Millisecond
+	client := srv.client(option.WithCredentialsProvider(creds))
+
+	for i := 0; i < 4; i++ {
+		time.Sleep(2 * time.Millisecond)
+		if _, err := client.Budgets.Get(context.Background(), "budget_1"); err != nil {
+			t.Fatalf("err should be nil: %s", err.Error())
+		}
+	}
+	if srv.logins != 2 || logins != 4 {
+		t.Errorf("Expected the token to be replaced before every request, got %d of %d logins", srv.logins, logins)
+	}
+	// The last logins failed, so the token that has not yet expired was kept.
+	if last := srv.requests[len(srv.requests)-1]; last != "GET /budgets/budget_1 Bearer tok2" {
+		t.Errorf("Expected the unexpired token to be used, got %s", last)
+	}
+}
+
+func TestLoginCredentialsWithoutExpiry(t *testing.T) {
+	for _, expiresIn := range []int{0, 30} {
+		srv := &credentialsServer{expiresIn: expiresIn}
+		creds := jocall3.NewLoginCredentials(srv.client().Users, jocall3.UserLoginParams{})
+		client := srv.client(option.WithCredentialsProvider(creds))
+
+		for i := 0; i < 3; i++ {
+			if _, err := client.Budgets.Get(context.Background(), "budget_1"); err != nil {
+				t.Fatalf("expiresIn %d: err should be nil: %s", expiresIn, err.Error())
+			}
+		}
+		if srv.logins != 1 {
+			t.Errorf("expiresIn %d: expected the token to be used until rejected, got %d logins", expiresIn, srv.logins)
+		}
+		srv.expire()
+		if _, err := client.Budgets.Get(context.Background(), "budget_1"); err != nil {
+			t.Fatalf("expiresIn %d: err should be nil: %s", expiresIn, err.Error())
+		}
+		if srv.logins != 3 {
+			t.Errorf("expiresIn %d: expected a rejected token to be replaced, got %d logins", expiresIn, srv.logins-1)
+		}
+	}
+}
+
+func TestLoginCredentialsFailure(t *testing.T) {
+	srv := &credentialsServer{}
+	creds := jocall3.NewLoginCredentialsFunc(srv.client().Users, func(context.Context) (jocall3.UserLoginParams, error) {
+		return jocall3.UserLoginParams{}, errors.New("no MFA code")
+	})
+	client := srv.client(option.WithMaxRetries(2), option.WithCredentialsProvider(creds))
+
+	_, err := client.Budgets.Get(context.Background(), "budget_1")
+	var credentialsErr *option.CredentialsError
+	if !errors.As(err, &credentialsErr) || !strings.Contains(err.Error(), "no MFA code") {
+		t.Fatalf("Expected a credentials error, got %v", err)
+	}
+	if len(srv.requests) != 0 {
+		t.Errorf("Expected no request to be sent or retried, got %v", srv.requests)
+	}
+}
+
+func TestBiometricCredentials(t *testing.T) {
+	srv := &credentialsServer{expiresIn: 3600}
+	creds := jocall3.NewLoginCredentials(srv.client().Users, jocall3.UserLoginParams{})
+	client := srv.client(option.WithCredentialsProvider(creds))
+
+	signatures := 0
+	bio := jocall3.NewBiometricCredentials(client.Users.Me.Biometrics, func(context.Context) (jocall3.UserMeBiometricVerifyParams, error) {
+		signatures++
+		return jocall3.UserMeBiometricVerifyParams{
+			BiometricSignature: jocall3.F("signature"),
+			BiometricType:      jocall3.F(jocall3.UserMeBiometricVerifyParamsBiometricTypeFingerprint),
+			DeviceID:           jocall3.F("device_1"),
+		}, nil
+	})
+
+	if _, err := client.Budgets.Get(context.Background(), "budget_1", option.WithCredentialsProvider(bio)); err != nil {
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+	expected := []string{
+		"POST /users/login ",
+		"POST /users/me/biometrics/verify Bearer tok1",
+		"GET /budgets/budget_1 Bearer bio-token",
+	}
+	if signatures != 1 || strings.Join(srv.requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected the step-up request to use a verified biometric token, got %q", srv.requests)
+	}
+}
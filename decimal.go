@@ -0,0 +1,230 @@
+package jocall3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact base-10 number used for monetary amounts and other values
+// where binary floating point would lose precision. The zero value is 0.
+//
+// Decimal values are immutable; arithmetic methods return a new value. They
+// encode to JSON as bare numbers, and decode from either JSON numbers or
+// numeric strings, preserving the scale that the API sent (so "10.50" stays
+// "10.50" rather than becoming 10.5).
+type Decimal struct {
+	// unscaled holds the digits of the value without the decimal point. A nil
+	// pointer represents zero.
+	unscaled *big.Int
+	// scale is the number of digits after the decimal point.
+	scale int32
+}
+
+// maxDecimalExponent bounds the exponent and the scale of a parsed [Decimal].
+// Without it, a value such as "1e2000000000" would make parsing compute a
+// power of ten with billions of digits.
+const maxDecimalExponent = 10000
+
+// NewDecimal parses a decimal string such as "1234.56", "-0.01" or "1.5e3".
+// Exponents and scales beyond ±10000 are rejected as out of range.
+func NewDecimal(value string) (Decimal, error) {
+	s := strings.TrimSpace(value)
+	if s == "" {
+		return Decimal{}, errors.New("jocall3: cannot parse empty string as decimal")
+	}
+
+	exp := int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("jocall3: cannot parse %q as decimal", value)
+		}
+		if e > maxDecimalExponent || e < -maxDecimalExponent {
+			return Decimal{}, fmt.Errorf("jocall3: decimal %q is out of range", value)
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	sign := ""
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		if s[0] == '-' {
+			sign = "-"
+		}
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	digits := intPart + fracPart
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Decimal{}, fmt.Errorf("jocall3: cannot parse %q as decimal", value)
+	}
+
+	unscaled, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("jocall3: cannot parse %q as decimal", value)
+	}
+
+	scale := int64(len(fracPart)) - exp
+	if scale > maxDecimalExponent {
+		return Decimal{}, fmt.Errorf("jocall3: decimal %q is out of range", value)
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{unscaled: unscaled, scale: int32(scale)}, nil
+}
+
+// MustDecimal is like [NewDecimal] but panics if the value cannot be parsed. It
+// is intended for constants, for example jocall3.F(jocall3.MustDecimal("25.00")).
+func MustDecimal(value string) Decimal {
+	d, err := NewDecimal(value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromInt returns the integer value as a [Decimal].
+func DecimalFromInt(value int64) Decimal {
+	return Decimal{unscaled: big.NewInt(value)}
+}
+
+// DecimalFromFloat returns the shortest decimal representation of a float64
+// that round-trips to the same value.
+func DecimalFromFloat(value float64) Decimal {
+	d, err := NewDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		// NaN and infinities have no decimal representation.
+		return Decimal{}
+	}
+	return d
+}
+
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(n), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// rescale returns the unscaled value of d expressed with the given, larger
+// scale.
+func (d Decimal) rescale(scale int32) *big.Int {
+	if scale == d.scale {
+		return d.int()
+	}
+	return new(big.Int).Mul(d.int(), pow10(int64(scale-d.scale)))
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int32 { return d.scale }
+
+// Sign returns -1, 0 or +1 depending on the sign of d.
+func (d Decimal) Sign() int { return d.int().Sign() }
+
+// IsZero reports whether d is equal to zero.
+func (d Decimal) IsZero() bool { return d.Sign() == 0 }
+
+// Cmp compares d and other and returns -1, 0 or +1. Values that differ only
+// in scale, such as 1.5 and 1.50, compare as equal.
+func (d Decimal) Cmp(other Decimal) int {
+	scale := max(d.scale, other.scale)
+	return d.rescale(scale).Cmp(other.rescale(scale))
+}
+
+// Equal reports whether d and other represent the same number.
+func (d Decimal) Equal(other Decimal) bool { return d.Cmp(other) == 0 }
+
+// Add returns d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{unscaled: new(big.Int).Add(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{unscaled: new(big.Int).Sub(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Rat returns d as an exact rational number.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.int(), pow10(int64(d.scale)))
+}
+
+// Float64 returns the nearest float64 value to d. Use it for display or
+// statistics only; keep amounts as [Decimal] when doing arithmetic.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// String returns d in plain decimal notation, keeping its scale.
+func (d Decimal) String() string {
+	digits := d.int().String()
+	if d.scale == 0 {
+		return digits
+	}
+
+	sign := ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	if pad := int(d.scale) - len(digits) + 1; pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	split := len(digits) - int(d.scale)
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// MarshalJSON encodes d as a bare JSON number.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number or a string containing a number. A JSON
+// null leaves d unchanged.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return err
+		}
+		data = []byte(s)
+	}
+	return d.UnmarshalText(data)
+}
+
+// MarshalText encodes d in plain decimal notation.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses d from its textual representation.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := NewDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,172 @@
+package jocall3_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+)
+
+func TestDecimalParse(t *testing.T) {
+	tests := map[string]string{
+		"0":          "0",
+		"12.50":      "12.50",
+		"-0.01":      "-0.01",
+		"+7":         "7",
+		".5":         "0.5",
+		"1.5e3":      "1500",
+		"1.25E-2":    "0.0125",
+		" 100.000 ":  "100.000",
+		"-123456789": "-123456789",
+	}
+	for in, want := range tests {
+		d, err := jocall3.NewDecimal(in)
+		if err != nil {
+			t.Fatalf("NewDecimal(%q) returned error: %v", in, err)
+		}
+		if got := d.String(); got != want {
+			t.Fatalf("NewDecimal(%q).String() = %q, want %q", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "-", "1.2.3", "12a", "1e", "NaN"} {
+		if _, err := jocall3.NewDecimal(in); err == nil {
+			t.Fatalf("NewDecimal(%q) expected an error", in)
+		}
+	}
+}
+
+func TestDecimalParseOutOfRange(t *testing.T) {
+	for _, in := range []string{"1e2000000000", "1e-2000000000", "1e10001", "1e-10001", "0." + strings.Repeat("0", 10001)} {
+		if _, err := jocall3.NewDecimal(in); err == nil || !strings.Contains(err.Error(), "out of range") {
+			t.Fatalf("NewDecimal(%.20q) expected an out of range error, got %v", in, err)
+		}
+	}
+
+	var d jocall3.Decimal
+	if err := json.Unmarshal([]byte(`1e2000000000`), &d); err == nil {
+		t.Fatal("expected decoding a huge exponent to fail")
+	}
+
+	for in, want := range map[string]string{"1e10000": "1" + strings.Repeat("0", 10000), "1e-10000": "0." + strings.Repeat("0", 9999) + "1"} {
+		d, err := jocall3.NewDecimal(in)
+		if err != nil {
+			t.Fatalf("NewDecimal(%q) returned error: %v", in, err)
+		}
+		if d.String() != want {
+			t.Fatalf("NewDecimal(%q) returned the wrong value", in)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a := jocall3.MustDecimal("0.1")
+	b := jocall3.MustDecimal("0.20")
+	if got := a.Add(b).String(); got != "0.30" {
+		t.Fatalf("0.1 + 0.20 = %s, want 0.30", got)
+	}
+	if got := a.Sub(b).String(); got != "-0.10" {
+		t.Fatalf("0.1 - 0.20 = %s, want -0.10", got)
+	}
+	if !jocall3.MustDecimal("1.5").Equal(jocall3.MustDecimal("1.500")) {
+		t.Fatalf("expected 1.5 to equal 1.500")
+	}
+	if jocall3.MustDecimal("-2").Cmp(jocall3.DecimalFromInt(1)) != -1 {
+		t.Fatalf("expected -2 < 1")
+	}
+	if got := jocall3.DecimalFromFloat(19.99).String(); got != "19.99" {
+		t.Fatalf("DecimalFromFloat(19.99) = %s", got)
+	}
+	var zero jocall3.Decimal
+	if !zero.IsZero() || zero.String() != "0" || zero.Neg().Sign() != 0 {
+		t.Fatalf("expected zero value to be 0")
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var v struct {
+		Number jocall3.Decimal `json:"number"`
+		String jocall3.Decimal `json:"string"`
+		Null   jocall3.Decimal `json:"null"`
+	}
+	err := json.Unmarshal([]byte(`{"number":1234.50,"string":"0.0000001","null":null}`), &v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Number.String() != "1234.50" || v.String.String() != "0.0000001" || !v.Null.IsZero() {
+		t.Fatalf("unexpected decoded values: %s %s %s", v.Number, v.String, v.Null)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != `{"number":1234.50,"string":0.0000001,"null":0}` {
+		t.Fatalf("unexpected encoding: %s", out)
+	}
+}
+
+func TestTypedModelDecoding(t *testing.T) {
+	raw := `{
+		"id": "txn_1",
+		"accountId": "acc_1",
+		"amount": "-42.10",
+		"category": "Groceries",
+		"currency": "USD",
+		"date": "2024-07-20",
+		"description": "Whole Foods",
+		"type": "expense",
+		"aiCategoryConfidence": 0.92,
+		"tags": ["food", "weekly"],
+		"futureField": {"nested": true}
+	}`
+	var txn jocall3.Transaction
+	if err := json.Unmarshal([]byte(raw), &txn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if txn.ID != "txn_1" || txn.AccountID != "acc_1" {
+		t.Fatalf("unexpected ids: %q %q", txn.ID, txn.AccountID)
+	}
+	if !txn.Amount.Equal(jocall3.MustDecimal("-42.1")) {
+		t.Fatalf("unexpected amount: %s", txn.Amount)
+	}
+	if !txn.Date.Equal(time.Date(2024, 7, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date: %s", txn.Date)
+	}
+	if txn.AICategoryConfidence != 0.92 || len(txn.Tags) != 2 || txn.Tags[1] != "weekly" {
+		t.Fatalf("unexpected fields: %v %v", txn.AICategoryConfidence, txn.Tags)
+	}
+	if txn.JSON.RawJSON() != raw {
+		t.Fatalf("expected RawJSON to preserve the original payload")
+	}
+	if extra := txn.JSON.ExtraFields["futureField"]; extra.Raw() != `{"nested": true}` {
+		t.Fatalf("expected unknown field to be kept, got %q", extra.Raw())
+	}
+	if !txn.JSON.PostedDate.IsMissing() {
+		t.Fatalf("expected postedDate to be reported missing")
+	}
+}
+
+func TestTypedParamEncoding(t *testing.T) {
+	params := jocall3.TransactionListParams{
+		MinAmount: jocall3.F(jocall3.MustDecimal("10.00")),
+		StartDate: jocall3.F(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)),
+		Limit:     jocall3.F(int64(25)),
+	}
+	if got := params.URLQuery().Encode(); got != "limit=25&minAmount=10.00&startDate=2024-01-31" {
+		t.Fatalf("unexpected query: %s", got)
+	}
+
+	body, err := json.Marshal(jocall3.BudgetNewParams{
+		Name:        jocall3.F("Trip"),
+		TotalAmount: jocall3.F(jocall3.MustDecimal("1500.25")),
+		StartDate:   jocall3.F(time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(body) != `{"name":"Trip","startDate":"2024-09-01","totalAmount":1500.25}` {
+		t.Fatalf("unexpected body: %s", body)
+	}
+}
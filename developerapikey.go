@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // DeveloperAPIKeyService contains methods and other services that help with
@@ -45,38 +48,57 @@ func (r *DeveloperAPIKeyService) New(ctx context.Context, body DeveloperAPIKeyNe
 }
 
 // Retrieves a list of API keys issued to the authenticated developer application.
-func (r *DeveloperAPIKeyService) List(ctx context.Context, query DeveloperAPIKeyListParams, opts ...option.RequestOption) (res *DeveloperAPIKeyListResponse, err error) {
+func (r *DeveloperAPIKeyService) List(ctx context.Context, query DeveloperAPIKeyListParams, opts ...option.RequestOption) (res *pagination.Page[APIKey], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "developers/api-keys"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of API keys issued to the authenticated developer application.
+func (r *DeveloperAPIKeyService) ListAutoPaging(ctx context.Context, query DeveloperAPIKeyListParams, opts ...option.RequestOption) *pagination.PageAutoPager[APIKey] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Revokes an existing API key, disabling its access immediately.
-func (r *DeveloperAPIKeyService) Revoke(ctx context.Context, keyID interface{}, opts ...option.RequestOption) (err error) {
+func (r *DeveloperAPIKeyService) Revoke(ctx context.Context, keyID string, opts ...option.RequestOption) (err error) {
 	opts = slices.Concat(r.Options, opts)
 	opts = append([]option.RequestOption{option.WithHeader("Accept", "*/*")}, opts...)
-	path := fmt.Sprintf("developers/api-keys/%v", keyID)
+	if keyID == "" {
+		err = errors.New("missing required keyId parameter")
+		return
+	}
+	path := fmt.Sprintf("developers/api-keys/%s", keyID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, nil, opts...)
 	return
 }
 
 type APIKey struct {
 	// Unique identifier for the API key.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Timestamp when the API key was created.
-	CreatedAt interface{} `json:"createdAt,required"`
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
 	// The non-secret prefix of the API key, used for identification.
-	Prefix interface{} `json:"prefix,required"`
+	Prefix string `json:"prefix,required"`
 	// List of permissions granted to this API key.
-	Scopes []interface{} `json:"scopes,required"`
+	Scopes []string `json:"scopes,required"`
 	// Current status of the API key.
 	Status APIKeyStatus `json:"status,required"`
 	// Timestamp when the API key will expire, if set.
-	ExpiresAt interface{} `json:"expiresAt"`
+	ExpiresAt time.Time `json:"expiresAt" format:"date-time"`
 	// Timestamp of the last time this API key was used.
-	LastUsed interface{} `json:"lastUsed"`
-	JSON     apiKeyJSON  `json:"-"`
+	LastUsed time.Time  `json:"lastUsed" format:"date-time"`
+	JSON     apiKeyJSON `json:"-"`
 }
 
 // apiKeyJSON contains the JSON metadata for the struct [APIKey]
@@ -117,36 +139,14 @@ func (r APIKeyStatus) IsKnown() bool {
 	return false
 }
 
-type DeveloperAPIKeyListResponse struct {
-	Data []APIKey                        `json:"data"`
-	JSON developerAPIKeyListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// developerAPIKeyListResponseJSON contains the JSON metadata for the struct
-// [DeveloperAPIKeyListResponse]
-type developerAPIKeyListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *DeveloperAPIKeyListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r developerAPIKeyListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type DeveloperAPIKeyNewParams struct {
 	// A descriptive name for the API key.
-	Name param.Field[interface{}] `json:"name,required"`
+	Name param.Field[string] `json:"name,required"`
 	// List of permissions to grant to this API key.
-	Scopes param.Field[[]interface{}] `json:"scopes,required"`
+	Scopes param.Field[[]string] `json:"scopes,required"`
 	// Optional: Number of days until the API key expires. If omitted, it will not
 	// expire.
-	ExpiresInDays param.Field[interface{}] `json:"expiresInDays"`
+	ExpiresInDays param.Field[int64] `json:"expiresInDays"`
 }
 
 func (r DeveloperAPIKeyNewParams) MarshalJSON() (data []byte, err error) {
@@ -155,9 +155,9 @@ func (r DeveloperAPIKeyNewParams) MarshalJSON() (data []byte, err error) {
 
 type DeveloperAPIKeyListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [DeveloperAPIKeyListParams]'s query parameters as
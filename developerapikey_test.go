@@ -26,9 +26,9 @@ func TestDeveloperAPIKeyNewWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Developers.APIKeys.New(context.TODO(), jocall3.DeveloperAPIKeyNewParams{
-		Name:          jocall3.F[any]("My Analytics Service Key"),
-		Scopes:        jocall3.F([]interface{}{"read:accounts", "read:transactions"}),
-		ExpiresInDays: jocall3.F[any](90),
+		Name:          jocall3.F("My Analytics Service Key"),
+		Scopes:        jocall3.F([]string{"read:accounts", "read:transactions"}),
+		ExpiresInDays: jocall3.F(int64(90)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -52,8 +52,8 @@ func TestDeveloperAPIKeyListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Developers.APIKeys.List(context.TODO(), jocall3.DeveloperAPIKeyListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // DeveloperWebhookService contains methods and other services that help with
@@ -47,9 +50,13 @@ func (r *DeveloperWebhookService) New(ctx context.Context, body DeveloperWebhook
 
 // Modifies an existing webhook subscription, allowing changes to the callback URL,
 // subscribed events, or activation status.
-func (r *DeveloperWebhookService) Update(ctx context.Context, subscriptionID interface{}, body DeveloperWebhookUpdateParams, opts ...option.RequestOption) (res *WebhookSubscription, err error) {
+func (r *DeveloperWebhookService) Update(ctx context.Context, subscriptionID string, body DeveloperWebhookUpdateParams, opts ...option.RequestOption) (res *WebhookSubscription, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("developers/webhooks/%v", subscriptionID)
+	if subscriptionID == "" {
+		err = errors.New("missing required subscriptionId parameter")
+		return
+	}
+	path := fmt.Sprintf("developers/webhooks/%s", subscriptionID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
@@ -57,41 +64,62 @@ func (r *DeveloperWebhookService) Update(ctx context.Context, subscriptionID int
 // Retrieves a list of all active webhook subscriptions for the authenticated
 // developer application, detailing endpoint URLs, subscribed events, and current
 // status.
-func (r *DeveloperWebhookService) List(ctx context.Context, query DeveloperWebhookListParams, opts ...option.RequestOption) (res *DeveloperWebhookListResponse, err error) {
+func (r *DeveloperWebhookService) List(ctx context.Context, query DeveloperWebhookListParams, opts ...option.RequestOption) (res *pagination.Page[WebhookSubscription], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "developers/webhooks"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of all active webhook subscriptions for the authenticated
+// developer application, detailing endpoint URLs, subscribed events, and current
+// status.
+func (r *DeveloperWebhookService) ListAutoPaging(ctx context.Context, query DeveloperWebhookListParams, opts ...option.RequestOption) *pagination.PageAutoPager[WebhookSubscription] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Deletes an existing webhook subscription, stopping all future event
 // notifications to the specified callback URL.
-func (r *DeveloperWebhookService) Delete(ctx context.Context, subscriptionID interface{}, opts ...option.RequestOption) (err error) {
+func (r *DeveloperWebhookService) Delete(ctx context.Context, subscriptionID string, opts ...option.RequestOption) (err error) {
 	opts = slices.Concat(r.Options, opts)
 	opts = append([]option.RequestOption{option.WithHeader("Accept", "*/*")}, opts...)
-	path := fmt.Sprintf("developers/webhooks/%v", subscriptionID)
+	if subscriptionID == "" {
+		err = errors.New("missing required subscriptionId parameter")
+		return
+	}
+	path := fmt.Sprintf("developers/webhooks/%s", subscriptionID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, nil, opts...)
 	return
 }
 
 type WebhookSubscription struct {
 	// Unique identifier for the webhook subscription.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// The URL where webhook events will be sent.
-	CallbackURL interface{} `json:"callbackUrl,required"`
+	CallbackURL string `json:"callbackUrl,required"`
 	// Timestamp when the subscription was created.
-	CreatedAt interface{} `json:"createdAt,required"`
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
 	// List of event types subscribed to.
-	Events []interface{} `json:"events,required"`
+	Events []string `json:"events,required"`
 	// Current status of the webhook subscription.
 	Status WebhookSubscriptionStatus `json:"status,required"`
 	// Number of consecutive failed delivery attempts.
-	FailureCount interface{} `json:"failureCount"`
+	FailureCount int64 `json:"failureCount"`
 	// Timestamp of the last successful webhook delivery.
-	LastTriggered interface{} `json:"lastTriggered"`
+	LastTriggered time.Time `json:"lastTriggered" format:"date-time"`
 	// The shared secret used to sign webhook payloads, for verification. Only returned
 	// on creation.
-	Secret interface{}             `json:"secret"`
+	Secret string                  `json:"secret"`
 	JSON   webhookSubscriptionJSON `json:"-"`
 }
 
@@ -135,36 +163,14 @@ func (r WebhookSubscriptionStatus) IsKnown() bool {
 	return false
 }
 
-type DeveloperWebhookListResponse struct {
-	Data []WebhookSubscription            `json:"data"`
-	JSON developerWebhookListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// developerWebhookListResponseJSON contains the JSON metadata for the struct
-// [DeveloperWebhookListResponse]
-type developerWebhookListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *DeveloperWebhookListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r developerWebhookListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type DeveloperWebhookNewParams struct {
 	// The URL to which webhook events will be sent.
-	CallbackURL param.Field[interface{}] `json:"callbackUrl,required"`
+	CallbackURL param.Field[string] `json:"callbackUrl,required"`
 	// List of event types to subscribe to.
-	Events param.Field[[]interface{}] `json:"events,required"`
+	Events param.Field[[]string] `json:"events,required"`
 	// Optional: A custom shared secret for verifying webhook payloads. If omitted, one
 	// will be generated.
-	Secret param.Field[interface{}] `json:"secret"`
+	Secret param.Field[string] `json:"secret"`
 }
 
 func (r DeveloperWebhookNewParams) MarshalJSON() (data []byte, err error) {
@@ -173,9 +179,9 @@ func (r DeveloperWebhookNewParams) MarshalJSON() (data []byte, err error) {
 
 type DeveloperWebhookUpdateParams struct {
 	// Updated URL where webhook events will be sent.
-	CallbackURL param.Field[interface{}] `json:"callbackUrl"`
+	CallbackURL param.Field[string] `json:"callbackUrl"`
 	// Updated list of event types subscribed to.
-	Events param.Field[[]interface{}] `json:"events"`
+	Events param.Field[[]string] `json:"events"`
 	// Updated status of the webhook subscription.
 	Status param.Field[DeveloperWebhookUpdateParamsStatus] `json:"status"`
 }
@@ -203,9 +209,9 @@ func (r DeveloperWebhookUpdateParamsStatus) IsKnown() bool {
 
 type DeveloperWebhookListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [DeveloperWebhookListParams]'s query parameters as
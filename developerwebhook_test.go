@@ -26,9 +26,9 @@ func TestDeveloperWebhookNewWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Developers.Webhooks.New(context.TODO(), jocall3.DeveloperWebhookNewParams{
-		CallbackURL: jocall3.F[any]("https://my-analytics-app.com/webhooks/transactions"),
-		Events:      jocall3.F([]interface{}{"transaction.created", "transaction.updated"}),
-		Secret:      jocall3.F[any]("my_custom_webhook_secret_123"),
+		CallbackURL: jocall3.F("https://my-analytics-app.com/webhooks/transactions"),
+		Events:      jocall3.F([]string{"transaction.created", "transaction.updated"}),
+		Secret:      jocall3.F("my_custom_webhook_secret_123"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -55,8 +55,8 @@ func TestDeveloperWebhookUpdateWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"whsub_devtool_finance_events",
 		jocall3.DeveloperWebhookUpdateParams{
-			CallbackURL: jocall3.F[any]("https://my-new-app.com/webhooks/demobank-events"),
-			Events:      jocall3.F([]interface{}{"transaction.created", "user.login_failed"}),
+			CallbackURL: jocall3.F("https://my-new-app.com/webhooks/demobank-events"),
+			Events:      jocall3.F([]string{"transaction.created", "user.login_failed"}),
 			Status:      jocall3.F(jocall3.DeveloperWebhookUpdateParamsStatusPaused),
 		},
 	)
@@ -82,8 +82,8 @@ func TestDeveloperWebhookListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Developers.Webhooks.List(context.TODO(), jocall3.DeveloperWebhookListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
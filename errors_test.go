@@ -0,0 +1,244 @@
+package jocall3_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+func errorClient(status int, header http.Header, body string) *jocall3.Client {
+	return jocall3.NewClient(
+		option.WithMaxRetries(0),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					if header == nil {
+						header = http.Header{}
+					}
+					header.Set("Content-Type", "application/json")
+					return &http.Response{
+						StatusCode: status,
+						Header:     header,
+						Body:       io.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			},
+		}),
+	)
+}
+
+func TestErrorValidation(t *testing.T) {
+	client := errorClient(http.StatusUnprocessableEntity, http.Header{"X-Request-Id": {"req_9"}},
+		`{"error":{"code":"validation_failed","message":"Request validation failed","details":{"amount":"must be positive"}}}`)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+
+	var validation *jocall3.ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("Expected a validation error, got %#v", err)
+	}
+	if !errors.Is(err, jocall3.ErrValidation) || errors.Is(err, jocall3.ErrNotFound) {
+		t.Errorf("Expected the error to be of the validation class only")
+	}
+	if validation.Code != "validation_failed" || validation.Message != "Request validation failed" || validation.RequestID != "req_9" {
+		t.Errorf("Unexpected code %q, message %q or request ID %q", validation.Code, validation.Message, validation.RequestID)
+	}
+	want := []jocall3.FieldError{{Field: "amount", Message: "must be positive"}}
+	if !reflect.DeepEqual(validation.FieldErrors, want) {
+		t.Errorf("Expected field errors %v, got %v", want, validation.FieldErrors)
+	}
+
+	var apierr *jocall3.Error
+	if !errors.As(err, &apierr) || apierr.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Expected the error to still be a *jocall3.Error, got %#v", err)
+	}
+	var notFound *jocall3.NotFoundError
+	if errors.As(err, &notFound) {
+		t.Errorf("Expected the error not to be a not found error")
+	}
+}
+
+func TestErrorClasses(t *testing.T) {
+	tests := []struct {
+		status int
+		class  error
+		as     func(error) bool
+	}{
+		{http.StatusUnauthorized, jocall3.ErrAuthentication, func(err error) bool { var e *jocall3.AuthenticationError; return errors.As(err, &e) }},
+		{http.StatusForbidden, jocall3.ErrForbidden, func(err error) bool { var e *jocall3.ForbiddenError; return errors.As(err, &e) }},
+		{http.StatusNotFound, jocall3.ErrNotFound, func(err error) bool { var e *jocall3.NotFoundError; return errors.As(err, &e) }},
+		{http.StatusConflict, jocall3.ErrConflict, func(err error) bool { var e *jocall3.ConflictError; return errors.As(err, &e) }},
+		{http.StatusBadRequest, jocall3.ErrValidation, func(err error) bool { var e *jocall3.ValidationError; return errors.As(err, &e) }},
+		{http.StatusTooManyRequests, jocall3.ErrRateLimited, func(err error) bool { var e *jocall3.RateLimitError; return errors.As(err, &e) }},
+	}
+	for _, test := range tests {
+		client := errorClient(test.status, nil, `{"code":"x","message":"y"}`)
+		_, err := client.Transactions.Get(context.Background(), "txn_1")
+		if !errors.Is(err, test.class) {
+			t.Errorf("%d: expected errors.Is(err, %v)", test.status, test.class)
+		}
+		if !test.as(err) {
+			t.Errorf("%d: expected errors.As to the typed error", test.status)
+		}
+	}
+
+	client := errorClient(http.StatusInternalServerError, nil, `{}`)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+	for _, test := range tests {
+		if errors.Is(err, test.class) {
+			t.Errorf("500: expected the error not to be %v", test.class)
+		}
+	}
+}
+
+func TestErrorCodes(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		is     []error
+		isNot  []error
+	}{
+		{
+			http.StatusUnprocessableEntity,
+			`{"error":{"code":"insufficient_funds","message":"Available balance is 12.50 USD"}}`,
+			[]error{jocall3.ErrInsufficientFunds, jocall3.ErrValidation},
+			[]error{jocall3.ErrKYCRequired},
+		},
+		{
+			http.StatusConflict,
+			`{"code":"INSUFFICIENT_BALANCE","message":"Balance too low"}`,
+			[]error{jocall3.ErrInsufficientFunds, jocall3.ErrConflict},
+			[]error{jocall3.ErrKYCRequired, jocall3.ErrValidation},
+		},
+		{
+			http.StatusForbidden,
+			`{"error":{"code":"KYC_REQUIRED","message":"Complete identity verification to send international payments"}}`,
+			[]error{jocall3.ErrKYCRequired, jocall3.ErrForbidden},
+			[]error{jocall3.ErrInsufficientFunds, jocall3.ErrAuthentication},
+		},
+		{
+			http.StatusForbidden,
+			`{"code":"permission_denied","message":"Not allowed"}`,
+			[]error{jocall3.ErrForbidden},
+			[]error{jocall3.ErrInsufficientFunds, jocall3.ErrKYCRequired},
+		},
+	}
+	for _, test := range tests {
+		client := errorClient(test.status, nil, test.body)
+		_, err := client.Payments.International.Initiate(context.Background(), jocall3.PaymentInternationalInitiateParams{})
+		for _, target := range test.is {
+			if !errors.Is(err, target) {
+				t.Errorf("%s: expected errors.Is(err, %v)", test.body, target)
+			}
+		}
+		for _, target := range test.isNot {
+			if errors.Is(err, target) {
+				t.Errorf("%s: expected the error not to be %v", test.body, target)
+			}
+		}
+	}
+
+	client := errorClient(http.StatusForbidden, nil, `{"error":{"code":"kyc_required","message":"Verify your identity"}}`)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+	var forbidden *jocall3.ForbiddenError
+	if !errors.As(err, &forbidden) || forbidden.Code != "kyc_required" || forbidden.Message != "Verify your identity" {
+		t.Errorf("Expected a forbidden error with the KYC code, got %#v", err)
+	}
+}
+
+func TestErrorRateLimit(t *testing.T) {
+	client := errorClient(http.StatusTooManyRequests, http.Header{"Retry-After": {"30"}},
+		`{"code":"rate_limit_exceeded","message":"Slow down","requestId":"req_1"}`)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+
+	var rateLimit *jocall3.RateLimitError
+	if !errors.As(err, &rateLimit) {
+		t.Fatalf("Expected a rate limit error, got %#v", err)
+	}
+	if rateLimit.RetryAfter != 30*time.Second {
+		t.Errorf("Expected a retry after of 30s, got %v", rateLimit.RetryAfter)
+	}
+	if rateLimit.RequestID != "req_1" {
+		t.Errorf("Expected the request ID from the body, got %q", rateLimit.RequestID)
+	}
+}
+
+func TestErrorFieldErrorList(t *testing.T) {
+	client := errorClient(http.StatusBadRequest, nil,
+		`{"code":"bad_request","errors":[{"field":"email","message":"is invalid","code":"format"},{"loc":["body","password"],"msg":"too short"}]}`)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+
+	var validation *jocall3.ValidationError
+	if !errors.As(err, &validation) {
+		t.Fatalf("Expected a validation error, got %#v", err)
+	}
+	want := []jocall3.FieldError{
+		{Field: "email", Message: "is invalid", Code: "format"},
+		{Field: "body.password", Message: "too short"},
+	}
+	if !reflect.DeepEqual(validation.FieldErrors, want) {
+		t.Errorf("Expected field errors %v, got %v", want, validation.FieldErrors)
+	}
+}
+
+func TestErrorBodyNotJSON(t *testing.T) {
+	client := errorClient(http.StatusBadGateway, nil, `<html>Bad Gateway</html>`)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+
+	var apierr *jocall3.Error
+	if !errors.As(err, &apierr) {
+		t.Fatalf("Expected an API error, got %#v", err)
+	}
+	if apierr.StatusCode != http.StatusBadGateway || apierr.JSON.RawJSON() != `<html>Bad Gateway</html>` {
+		t.Errorf("Unexpected status %d or body %q", apierr.StatusCode, apierr.JSON.RawJSON())
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	errDial := errors.New("connection refused")
+	client := jocall3.NewClient(
+		option.WithMaxRetries(0),
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					return nil, errDial
+				},
+			},
+		}),
+	)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+
+	var transportErr *jocall3.TransportError
+	if !errors.As(err, &transportErr) || !errors.Is(err, errDial) {
+		t.Fatalf("Expected a transport error wrapping the dial error, got %#v", err)
+	}
+	var apierr *jocall3.Error
+	var decodeErr *jocall3.DecodeError
+	if errors.As(err, &apierr) || errors.As(err, &decodeErr) {
+		t.Errorf("Expected the transport error to be neither an API nor a decode error")
+	}
+}
+
+func TestDecodeError(t *testing.T) {
+	client := errorClient(http.StatusOK, nil, `{"id": "txn_1",`)
+	_, err := client.Transactions.Get(context.Background(), "txn_1")
+
+	var decodeErr *jocall3.DecodeError
+	if !errors.As(err, &decodeErr) {
+		t.Fatalf("Expected a decode error, got %#v", err)
+	}
+	if decodeErr.StatusCode != http.StatusOK || string(decodeErr.Body) != `{"id": "txn_1",` {
+		t.Errorf("Unexpected status %d or body %q", decodeErr.StatusCode, decodeErr.Body)
+	}
+	var transportErr *jocall3.TransportError
+	if errors.As(err, &transportErr) {
+		t.Errorf("Expected the decode error not to be a transport error")
+	}
+}
@@ -0,0 +1,66 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jocall3/go"
+)
+
+// The columns of a CSV export, in order.
+var csvHeader = []string{
+	"id",
+	"account_id",
+	"date",
+	"posted_date",
+	"description",
+	"payee",
+	"category",
+	"amount",
+	"currency",
+	"type",
+	"notes",
+	"disputed",
+	"dispute_status",
+	"tags",
+}
+
+// writeCSV writes txns as RFC 4180 CSV: a header row, then one record per
+// transaction, with CRLF line endings. Dates are written as YYYY-MM-DD and
+// tags are joined with semicolons.
+func writeCSV(w io.Writer, txns []jocall3.Transaction, opts Options) error {
+	out := csv.NewWriter(w)
+	out.UseCRLF = true
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, txn := range txns {
+		posted := ""
+		if !txn.PostedDate.IsZero() {
+			posted = txn.PostedDate.Format("2006-01-02")
+		}
+		err := out.Write([]string{
+			txn.ID,
+			txn.AccountID,
+			txn.Date.Format("2006-01-02"),
+			posted,
+			txn.Description,
+			payee(txn),
+			txn.Category,
+			txn.Amount.String(),
+			txn.Currency,
+			string(txn.Type),
+			txn.Notes,
+			strconv.FormatBool(disputed(txn)),
+			string(txn.DisputeStatus),
+			strings.Join(txn.Tags, ";"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
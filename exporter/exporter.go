@@ -0,0 +1,212 @@
+// Package exporter writes transactions in the formats accounting tools import:
+// OFX 2.x, QIF and CSV.
+//
+// [ExportTransactions] and [ExportPending] page through the API and write every
+// transaction in one go:
+//
+//	err := exporter.ExportTransactions(ctx, w, exporter.FormatOFX, client.Transactions, jocall3.TransactionListParams{}, exporter.Options{
+//		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+//		End:   time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+//	})
+//
+// Transactions are written in a stable order: by date, then posted date, then
+// ID, so exporting the same transactions twice gives the same file.
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
+)
+
+// Format is a file format transactions can be written in.
+type Format string
+
+const (
+	// FormatOFX is Open Financial Exchange 2.2, as XML.
+	FormatOFX Format = "ofx"
+	// FormatQIF is the Quicken Interchange Format, for a bank account.
+	FormatQIF Format = "qif"
+	// FormatCSV is comma-separated values as described by RFC 4180, with a
+	// header row.
+	FormatCSV Format = "csv"
+)
+
+func (r Format) IsKnown() bool {
+	switch r {
+	case FormatOFX, FormatQIF, FormatCSV:
+		return true
+	}
+	return false
+}
+
+// Options control which transactions are written and describe the account
+// they belong to.
+type Options struct {
+	// Only transactions on or after this date are written, if it is not zero.
+	// Dates are compared as calendar dates, ignoring the time of day.
+	Start time.Time
+	// Only transactions on or before this date are written, if it is not zero.
+	End time.Time
+
+	// The ID of the account, for formats that name it. Defaults to the account of
+	// the first transaction.
+	AccountID string
+	// The ISO 4217 currency of the account. Defaults to the currency of the first
+	// transaction, or USD if there are none.
+	Currency string
+	// The routing number of the bank, for OFX. Defaults to "000000000".
+	BankID string
+	// The type of the account for OFX, such as CHECKING, SAVINGS or CREDITLINE.
+	// Defaults to CHECKING.
+	AccountType string
+	// The balance of the account at the end of the export, for OFX, which
+	// requires one. Defaults to zero.
+	Balance jocall3.Decimal
+	// The time the file is written at, for OFX. Defaults to the current time.
+	GeneratedAt time.Time
+}
+
+// ExportTransactions writes every transaction matching params to w in format,
+// paging through [jocall3.TransactionService.List]. The date range of opts is
+// sent as the StartDate and EndDate of params unless those are already set.
+func ExportTransactions(ctx context.Context, w io.Writer, format Format, transactions *jocall3.TransactionService, params jocall3.TransactionListParams, opts Options, reqOpts ...option.RequestOption) error {
+	if !format.IsKnown() {
+		return fmt.Errorf("exporter: unknown format %q", format)
+	}
+	if !params.StartDate.Present && !opts.Start.IsZero() {
+		params.StartDate = jocall3.F(opts.Start)
+	}
+	if !params.EndDate.Present && !opts.End.IsZero() {
+		params.EndDate = jocall3.F(opts.End)
+	}
+	return Export(w, format, transactions.ListAutoPaging(ctx, params, reqOpts...), opts)
+}
+
+// ExportPending writes the pending transactions of an account to w in format,
+// paging through [jocall3.AccountTransactionService.GetPending].
+func ExportPending(ctx context.Context, w io.Writer, format Format, transactions *jocall3.AccountTransactionService, accountID string, opts Options, reqOpts ...option.RequestOption) error {
+	if !format.IsKnown() {
+		return fmt.Errorf("exporter: unknown format %q", format)
+	}
+	if opts.AccountID == "" {
+		opts.AccountID = accountID
+	}
+	return Export(w, format, transactions.GetPendingAutoPaging(ctx, accountID, jocall3.AccountTransactionGetPendingParams{}, reqOpts...), opts)
+}
+
+// Export reads every transaction from pager and writes them to w in format.
+// Nothing is written if paging fails.
+func Export(w io.Writer, format Format, pager *pagination.PageAutoPager[jocall3.Transaction], opts Options) error {
+	var txns []jocall3.Transaction
+	for pager.Next() {
+		txns = append(txns, pager.Current())
+	}
+	if err := pager.Err(); err != nil {
+		return err
+	}
+	return Write(w, format, txns, opts)
+}
+
+// Write writes the transactions in txns that fall within the date range of
+// opts to w in format.
+func Write(w io.Writer, format Format, txns []jocall3.Transaction, opts Options) error {
+	txns = prepare(txns, opts)
+	opts = withDefaults(opts, txns)
+	switch format {
+	case FormatOFX:
+		return writeOFX(w, txns, opts)
+	case FormatQIF:
+		return writeQIF(w, txns, opts)
+	case FormatCSV:
+		return writeCSV(w, txns, opts)
+	}
+	return fmt.Errorf("exporter: unknown format %q", format)
+}
+
+// prepare returns the transactions within the date range of opts, sorted by
+// date, posted date and ID.
+func prepare(txns []jocall3.Transaction, opts Options) []jocall3.Transaction {
+	out := make([]jocall3.Transaction, 0, len(txns))
+	for _, txn := range txns {
+		if inRange(txn.Date, opts) {
+			out = append(out, txn)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if c := compareDates(a.Date, b.Date); c != 0 {
+			return c < 0
+		}
+		if c := compareDates(a.PostedDate, b.PostedDate); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	})
+	return out
+}
+
+func inRange(date time.Time, opts Options) bool {
+	if !opts.Start.IsZero() && compareDates(date, opts.Start) < 0 {
+		return false
+	}
+	if !opts.End.IsZero() && compareDates(date, opts.End) > 0 {
+		return false
+	}
+	return true
+}
+
+// compareDates compares the calendar dates of a and b.
+func compareDates(a, b time.Time) int {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	switch {
+	case ay != by:
+		return ay - by
+	case am != bm:
+		return int(am) - int(bm)
+	}
+	return ad - bd
+}
+
+func withDefaults(opts Options, txns []jocall3.Transaction) Options {
+	if opts.AccountID == "" && len(txns) > 0 {
+		opts.AccountID = txns[0].AccountID
+	}
+	if opts.Currency == "" {
+		opts.Currency = "USD"
+		if len(txns) > 0 && txns[0].Currency != "" {
+			opts.Currency = txns[0].Currency
+		}
+	}
+	if opts.BankID == "" {
+		opts.BankID = "000000000"
+	}
+	if opts.AccountType == "" {
+		opts.AccountType = "CHECKING"
+	}
+	if opts.GeneratedAt.IsZero() {
+		opts.GeneratedAt = time.Now()
+	}
+	return opts
+}
+
+// disputed reports whether a dispute has been raised about txn.
+func disputed(txn jocall3.Transaction) bool {
+	return txn.DisputeStatus != "" && txn.DisputeStatus != jocall3.TransactionDisputeStatusNone
+}
+
+// payee returns the name of the merchant of txn, or its description if it has
+// no merchant.
+func payee(txn jocall3.Transaction) string {
+	if txn.MerchantDetails.Name != "" {
+		return txn.MerchantDetails.Name
+	}
+	return txn.Description
+}
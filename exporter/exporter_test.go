@@ -0,0 +1,177 @@
+package exporter_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/exporter"
+	"github.com/jocall3/go/jocall3test"
+	"github.com/jocall3/go/option"
+)
+
+func seed(srv *jocall3test.Server) {
+	srv.Seed(jocall3test.Accounts, map[string]interface{}{"id": "acc_1"})
+	srv.Seed(jocall3test.Transactions,
+		map[string]interface{}{"id": "txn_3", "accountId": "acc_1", "amount": -12.5, "currency": "USD", "category": "Groceries", "date": "2024-03-05", "postedDate": "2024-03-06", "description": "Corner shop", "type": "expense", "notes": "milk, eggs"},
+		map[string]interface{}{"id": "txn_1", "accountId": "acc_1", "amount": 2500, "currency": "USD", "category": "Salary", "date": "2024-03-01", "postedDate": "2024-03-01", "description": "Payroll", "type": "income"},
+		map[string]interface{}{"id": "txn_2", "accountId": "acc_1", "amount": -80, "currency": "USD", "category": "Dining", "date": "2024-03-05", "postedDate": "2024-03-05", "description": "Bistro", "type": "expense", "disputeStatus": "pending", "merchantDetails": map[string]interface{}{"name": "Le Bistro"}},
+		map[string]interface{}{"id": "txn_4", "accountId": "acc_1", "amount": -9.99, "currency": "USD", "category": "Subscriptions", "date": "2024-04-02", "description": "Streaming", "type": "expense"},
+		map[string]interface{}{"id": "txn_5", "accountId": "acc_1", "amount": -3, "currency": "USD", "category": "Coffee", "date": "2024-02-28", "postedDate": "2024-02-28", "description": "Cafe", "type": "expense"},
+	)
+}
+
+func march() exporter.Options {
+	return exporter.Options{
+		Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	seed(srv)
+	client := srv.Client()
+
+	var buf bytes.Buffer
+	err := exporter.ExportTransactions(context.Background(), &buf, exporter.FormatCSV, client.Transactions, jocall3.TransactionListParams{Limit: jocall3.F(int64(2))}, march())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "id,account_id,date,posted_date,description,payee,category,amount,currency,type,notes,disputed,dispute_status,tags\r\n" +
+		"txn_1,acc_1,2024-03-01,2024-03-01,Payroll,Payroll,Salary,2500,USD,income,,false,,\r\n" +
+		"txn_2,acc_1,2024-03-05,2024-03-05,Bistro,Le Bistro,Dining,-80,USD,expense,,true,pending,\r\n" +
+		"txn_3,acc_1,2024-03-05,2024-03-06,Corner shop,Corner shop,Groceries,-12.5,USD,expense,\"milk, eggs\",false,,\r\n"
+	if buf.String() != want {
+		t.Errorf("Expected\n%q\ngot\n%q", want, buf.String())
+	}
+
+	query := srv.Requests()[0].Query
+	if query.Get("startDate") != "2024-03-01" || query.Get("endDate") != "2024-03-31" {
+		t.Errorf("Expected the date range to be sent to the API, got %v", query)
+	}
+}
+
+func TestExportQIF(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	seed(srv)
+	client := srv.Client()
+
+	var buf bytes.Buffer
+	err := exporter.ExportTransactions(context.Background(), &buf, exporter.FormatQIF, client.Transactions, jocall3.TransactionListParams{}, march())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "!Type:Bank\n" +
+		"D03/01/2024\nT2500\nC*\nPPayroll\nLSalary\n^\n" +
+		"D03/05/2024\nT-80\nC*\nPLe Bistro\nM[Disputed]\nLDining\n^\n" +
+		"D03/05/2024\nT-12.5\nC*\nPCorner shop\nMmilk, eggs\nLGroceries\n^\n"
+	if buf.String() != want {
+		t.Errorf("Expected\n%s\ngot\n%s", want, buf.String())
+	}
+}
+
+func TestExportOFX(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	seed(srv)
+	client := srv.Client()
+
+	opts := march()
+	opts.GeneratedAt = time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+	opts.Balance = jocall3.MustDecimal("1234.56")
+	var buf bytes.Buffer
+	err := exporter.ExportTransactions(context.Background(), &buf, exporter.FormatOFX, client.Transactions, jocall3.TransactionListParams{}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`+"\n"+`<?OFX OFXHEADER="200" VERSION="220"`) {
+		t.Errorf("Expected an OFX 2.2 header, got %q", buf.String()[:100])
+	}
+
+	var doc struct {
+		Statement struct {
+			CurDef string `xml:"CURDEF"`
+			AcctID string `xml:"BANKACCTFROM>ACCTID"`
+			Start  string `xml:"BANKTRANLIST>DTSTART"`
+			End    string `xml:"BANKTRANLIST>DTEND"`
+			Txns   []struct {
+				Type     string `xml:"TRNTYPE"`
+				Posted   string `xml:"DTPOSTED"`
+				Amount   string `xml:"TRNAMT"`
+				FITID    string `xml:"FITID"`
+				Name     string `xml:"NAME"`
+				Memo     string `xml:"MEMO"`
+				Category string `xml:"JOCALL3.CATEGORY"`
+				Disputed string `xml:"JOCALL3.DISPUTED"`
+			} `xml:"BANKTRANLIST>STMTTRN"`
+			Balance string `xml:"LEDGERBAL>BALAMT"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	stmt := doc.Statement
+	if stmt.CurDef != "USD" || stmt.AcctID != "acc_1" || stmt.Start != "20240301" || stmt.End != "20240331" || stmt.Balance != "1234.56" {
+		t.Errorf("Unexpected statement %+v", stmt)
+	}
+	var ids []string
+	for _, txn := range stmt.Txns {
+		ids = append(ids, txn.FITID)
+	}
+	if strings.Join(ids, ",") != "txn_1,txn_2,txn_3" {
+		t.Fatalf("Expected transactions txn_1,txn_2,txn_3, got %v", ids)
+	}
+	if txn := stmt.Txns[1]; txn.Type != "DEBIT" || txn.Amount != "-80" || txn.Name != "Le Bistro" || txn.Category != "Dining" || txn.Disputed != "Y" {
+		t.Errorf("Unexpected disputed transaction %+v", txn)
+	}
+	if txn := stmt.Txns[2]; txn.Posted != "20240306" || txn.Memo != "milk, eggs" || txn.Disputed != "N" {
+		t.Errorf("Unexpected transaction %+v", txn)
+	}
+	if stmt.Txns[0].Type != "CREDIT" {
+		t.Errorf("Expected income to be a credit, got %s", stmt.Txns[0].Type)
+	}
+}
+
+func TestExportPending(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	seed(srv)
+	client := srv.Client()
+
+	var buf bytes.Buffer
+	err := exporter.ExportPending(context.Background(), &buf, exporter.FormatCSV, client.Accounts.Transactions, "acc_1", exporter.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\r\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[1], "txn_4,acc_1,2024-04-02,,Streaming,") {
+		t.Errorf("Expected only the pending transaction, got %q", buf.String())
+	}
+}
+
+func TestExportPagingError(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	seed(srv)
+	srv.InjectFault(jocall3test.Fault{Method: http.MethodGet, Path: "/transactions", Status: http.StatusInternalServerError})
+	client := srv.Client(option.WithMaxRetries(0))
+
+	var buf bytes.Buffer
+	err := exporter.ExportTransactions(context.Background(), &buf, exporter.FormatCSV, client.Transactions, jocall3.TransactionListParams{}, exporter.Options{})
+	var apierr *jocall3.Error
+	if !errors.As(err, &apierr) {
+		t.Fatalf("Expected the API error, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be written, got %q", buf.String())
+	}
+}
@@ -0,0 +1,189 @@
+package exporter
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jocall3/go"
+)
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>` + "\n" +
+	`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+
+// The layout of OFX dates. Transactions only have a calendar date.
+const ofxDate = "20060102"
+
+type ofxDocument struct {
+	XMLName   xml.Name        `xml:"OFX"`
+	SignOn    ofxSignOn       `xml:"SIGNONMSGSRSV1>SONRS"`
+	Statement ofxStatementTrn `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignOn struct {
+	Status   ofxStatus `xml:"STATUS"`
+	DTServer string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxStatementTrn struct {
+	TrnUID    string       `xml:"TRNUID"`
+	Status    ofxStatus    `xml:"STATUS"`
+	Statement ofxStatement `xml:"STMTRS"`
+}
+
+type ofxStatement struct {
+	CurDef       string           `xml:"CURDEF"`
+	Account      ofxAccount       `xml:"BANKACCTFROM"`
+	Transactions ofxTranList      `xml:"BANKTRANLIST"`
+	LedgerBal    ofxLedgerBalance `xml:"LEDGERBAL"`
+}
+
+type ofxAccount struct {
+	BankID   string `xml:"BANKID"`
+	AcctID   string `xml:"ACCTID"`
+	AcctType string `xml:"ACCTTYPE"`
+}
+
+type ofxTranList struct {
+	DTStart      string           `xml:"DTSTART"`
+	DTEnd        string           `xml:"DTEND"`
+	Transactions []ofxTransaction `xml:"STMTTRN"`
+}
+
+type ofxLedgerBalance struct {
+	BalAmt string `xml:"BALAMT"`
+	DTAsOf string `xml:"DTASOF"`
+}
+
+// ofxTransaction is a STMTTRN aggregate. OFX has no elements for categories or
+// disputes, so they are written as elements with the JOCALL3 prefix that the
+// specification sets aside for extensions, after the standard ones.
+type ofxTransaction struct {
+	TrnType       string `xml:"TRNTYPE"`
+	DTPosted      string `xml:"DTPOSTED"`
+	DTUser        string `xml:"DTUSER"`
+	TrnAmt        string `xml:"TRNAMT"`
+	FITID         string `xml:"FITID"`
+	Name          string `xml:"NAME,omitempty"`
+	Memo          string `xml:"MEMO,omitempty"`
+	Category      string `xml:"JOCALL3.CATEGORY,omitempty"`
+	Disputed      string `xml:"JOCALL3.DISPUTED"`
+	DisputeStatus string `xml:"JOCALL3.DISPUTESTATUS,omitempty"`
+}
+
+// writeOFX writes txns as an OFX 2.2 bank statement.
+//
+// Each transaction has its ID as FITID, its payee as NAME and its notes as
+// MEMO. Its category and whether it is disputed are written in the extension
+// elements JOCALL3.CATEGORY, JOCALL3.DISPUTED and JOCALL3.DISPUTESTATUS, which
+// importers that do not know them skip.
+func writeOFX(w io.Writer, txns []jocall3.Transaction, opts Options) error {
+	doc := ofxDocument{
+		SignOn: ofxSignOn{
+			Status:   ofxStatus{Code: 0, Severity: "INFO"},
+			DTServer: opts.GeneratedAt.UTC().Format("20060102150405"),
+			Language: "ENG",
+		},
+		Statement: ofxStatementTrn{
+			TrnUID: "0",
+			Status: ofxStatus{Code: 0, Severity: "INFO"},
+			Statement: ofxStatement{
+				CurDef: opts.Currency,
+				Account: ofxAccount{
+					BankID:   opts.BankID,
+					AcctID:   opts.AccountID,
+					AcctType: opts.AccountType,
+				},
+				LedgerBal: ofxLedgerBalance{
+					BalAmt: opts.Balance.String(),
+					DTAsOf: opts.GeneratedAt.UTC().Format("20060102150405"),
+				},
+			},
+		},
+	}
+
+	start, end := opts.Start, opts.End
+	if start.IsZero() && len(txns) > 0 {
+		start = txns[0].Date
+	}
+	if end.IsZero() && len(txns) > 0 {
+		end = txns[len(txns)-1].Date
+	}
+	list := &doc.Statement.Statement.Transactions
+	list.DTStart = ofxDateOr(start, opts.GeneratedAt)
+	list.DTEnd = ofxDateOr(end, opts.GeneratedAt)
+
+	for _, txn := range txns {
+		posted := txn.PostedDate
+		if posted.IsZero() {
+			posted = txn.Date
+		}
+		list.Transactions = append(list.Transactions, ofxTransaction{
+			TrnType:       ofxTransactionType(txn),
+			DTPosted:      posted.Format(ofxDate),
+			DTUser:        txn.Date.Format(ofxDate),
+			TrnAmt:        txn.Amount.String(),
+			FITID:         txn.ID,
+			Name:          truncate(payee(txn), 32),
+			Memo:          truncate(txn.Notes, 255),
+			Category:      txn.Category,
+			Disputed:      ofxBool(disputed(txn)),
+			DisputeStatus: string(txn.DisputeStatus),
+		})
+	}
+
+	if _, err := io.WriteString(w, ofxHeader); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ofxTransactionType returns the TRNTYPE of txn.
+func ofxTransactionType(txn jocall3.Transaction) string {
+	switch txn.Type {
+	case jocall3.TransactionTypeTransfer:
+		return "XFER"
+	case jocall3.TransactionTypeBillPayment:
+		return "PAYMENT"
+	}
+	if txn.Amount.Sign() < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+func ofxBool(b bool) string {
+	if b {
+		return "Y"
+	}
+	return "N"
+}
+
+func ofxDateOr(t, fallback time.Time) string {
+	if t.IsZero() {
+		t = fallback
+	}
+	return t.Format(ofxDate)
+}
+
+// truncate shortens s to at most n characters, as OFX limits the length of
+// some elements.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,48 @@
+package exporter
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/jocall3/go"
+)
+
+// writeQIF writes txns as a QIF bank account register.
+//
+// Each transaction has its date (D), amount (T), payee (P), notes as its memo
+// (M) and category (L). Posted transactions are marked cleared (C*). QIF has no
+// field for disputes, so the memo of a disputed transaction starts with
+// "[Disputed]".
+func writeQIF(w io.Writer, txns []jocall3.Transaction, opts Options) error {
+	out := bufio.NewWriter(w)
+	out.WriteString("!Type:Bank\n")
+	for _, txn := range txns {
+		qifLine(out, 'D', txn.Date.Format("01/02/2006"))
+		qifLine(out, 'T', txn.Amount.String())
+		if !txn.PostedDate.IsZero() {
+			qifLine(out, 'C', "*")
+		}
+		qifLine(out, 'P', payee(txn))
+		memo := txn.Notes
+		if disputed(txn) {
+			memo = strings.TrimSpace("[Disputed] " + memo)
+		}
+		qifLine(out, 'M', memo)
+		qifLine(out, 'L', txn.Category)
+		out.WriteString("^\n")
+	}
+	return out.Flush()
+}
+
+// qifLine writes a field, leaving it out if it is empty. QIF fields cannot span
+// lines, so line breaks in value are replaced with spaces.
+func qifLine(out *bufio.Writer, code byte, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return
+	}
+	out.WriteByte(code)
+	out.WriteString(value)
+	out.WriteByte('\n')
+}
@@ -36,10 +36,24 @@ func Float(value float64) param.Field[float64] { return F(value) }
 func Bool(value bool) param.Field[bool] { return F(value) }
 
 // FileParam is a param field helper which helps files with a mime content-type.
+// An empty contentType is detected from the file name or, failing that, from
+// the contents of the file. If reader is an [io.Seeker], it is read from the
+// start every time it is sent, so that requests holding it can be retried.
 func FileParam(reader io.Reader, filename string, contentType string) param.Field[io.Reader] {
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		return F[io.Reader](&seekableFile{&file{seeker, filename, contentType}, seeker})
+	}
 	return F[io.Reader](&file{reader, filename, contentType})
 }
 
+// FileOpenerParam is a param field helper for files that can be opened more
+// than once, such as files on disk. The file is opened with open every time it
+// is sent, including when a request is retried, and closed once it has been
+// read. An empty contentType is detected as for [FileParam].
+func FileOpenerParam(open func() (io.ReadCloser, error), filename string, contentType string) param.Field[io.Reader] {
+	return F[io.Reader](&openerFile{open: open, name: filename, contentType: contentType})
+}
+
 type file struct {
 	io.Reader
 	name        string
@@ -48,3 +62,43 @@ type file struct {
 
 func (f *file) ContentType() string { return f.contentType }
 func (f *file) Filename() string    { return f.name }
+
+type seekableFile struct {
+	*file
+	io.Seeker
+}
+
+type openerFile struct {
+	open        func() (io.ReadCloser, error)
+	name        string
+	contentType string
+	rc          io.ReadCloser
+	eof         bool
+}
+
+func (f *openerFile) ContentType() string          { return f.contentType }
+func (f *openerFile) Filename() string             { return f.name }
+func (f *openerFile) Open() (io.ReadCloser, error) { return f.open() }
+
+// Read reads the file from a single opening of it, for callers that treat it as
+// a plain [io.Reader]. Request bodies use Open instead.
+func (f *openerFile) Read(p []byte) (int, error) {
+	if f.eof {
+		return 0, io.EOF
+	}
+	if f.rc == nil {
+		rc, err := f.open()
+		if err != nil {
+			return 0, err
+		}
+		f.rc = rc
+	}
+	n, err := f.rc.Read(p)
+	if err == io.EOF {
+		// Keep reporting EOF rather than reading from the closed file or
+		// opening it again.
+		f.rc.Close()
+		f.rc, f.eof = nil, true
+	}
+	return n, err
+}
@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // GoalService contains methods and other services that help with interacting with
@@ -45,57 +48,85 @@ func (r *GoalService) New(ctx context.Context, body GoalNewParams, opts ...optio
 
 // Retrieves detailed information for a specific financial goal, including current
 // progress, AI strategic plan, and related insights.
-func (r *GoalService) Get(ctx context.Context, goalID interface{}, opts ...option.RequestOption) (res *FinancialGoal, err error) {
+func (r *GoalService) Get(ctx context.Context, goalID string, opts ...option.RequestOption) (res *FinancialGoal, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("goals/%v", goalID)
+	if goalID == "" {
+		err = errors.New("missing required goalId parameter")
+		return
+	}
+	path := fmt.Sprintf("goals/%s", goalID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
 
 // Updates the parameters of an existing financial goal, such as target amount,
 // date, or contributing accounts. This may trigger an AI plan recalculation.
-func (r *GoalService) Update(ctx context.Context, goalID interface{}, body GoalUpdateParams, opts ...option.RequestOption) (res *FinancialGoal, err error) {
+func (r *GoalService) Update(ctx context.Context, goalID string, body GoalUpdateParams, opts ...option.RequestOption) (res *FinancialGoal, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("goals/%v", goalID)
+	if goalID == "" {
+		err = errors.New("missing required goalId parameter")
+		return
+	}
+	path := fmt.Sprintf("goals/%s", goalID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
 
 // Retrieves a list of all financial goals defined by the user, including their
 // progress and associated AI plans.
-func (r *GoalService) List(ctx context.Context, query GoalListParams, opts ...option.RequestOption) (res *GoalListResponse, err error) {
+func (r *GoalService) List(ctx context.Context, query GoalListParams, opts ...option.RequestOption) (res *pagination.Page[FinancialGoal], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "goals"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of all financial goals defined by the user, including their
+// progress and associated AI plans.
+func (r *GoalService) ListAutoPaging(ctx context.Context, query GoalListParams, opts ...option.RequestOption) *pagination.PageAutoPager[FinancialGoal] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Deletes a specific financial goal from the user's profile.
-func (r *GoalService) Delete(ctx context.Context, goalID interface{}, opts ...option.RequestOption) (err error) {
+func (r *GoalService) Delete(ctx context.Context, goalID string, opts ...option.RequestOption) (err error) {
 	opts = slices.Concat(r.Options, opts)
 	opts = append([]option.RequestOption{option.WithHeader("Accept", "*/*")}, opts...)
-	path := fmt.Sprintf("goals/%v", goalID)
+	if goalID == "" {
+		err = errors.New("missing required goalId parameter")
+		return
+	}
+	path := fmt.Sprintf("goals/%s", goalID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, nil, opts...)
 	return
 }
 
 type FinancialGoal struct {
 	// Unique identifier for the financial goal.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// The current amount saved or invested towards the goal.
-	CurrentAmount interface{} `json:"currentAmount,required"`
+	CurrentAmount Decimal `json:"currentAmount,required"`
 	// Timestamp when the goal's status or progress was last updated.
-	LastUpdated interface{} `json:"lastUpdated,required"`
+	LastUpdated time.Time `json:"lastUpdated,required" format:"date-time"`
 	// Name of the financial goal.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// Percentage completion of the goal.
-	ProgressPercentage interface{} `json:"progressPercentage,required"`
+	ProgressPercentage float64 `json:"progressPercentage,required"`
 	// Current status of the goal's progress.
 	Status FinancialGoalStatus `json:"status,required"`
 	// The target monetary amount for the goal.
-	TargetAmount interface{} `json:"targetAmount,required"`
+	TargetAmount Decimal `json:"targetAmount,required"`
 	// The target completion date for the goal.
-	TargetDate interface{} `json:"targetDate,required"`
+	TargetDate time.Time `json:"targetDate,required" format:"date"`
 	// Type of financial goal.
 	Type FinancialGoalType `json:"type,required"`
 	// AI-driven insights and recommendations related to this goal.
@@ -103,7 +134,7 @@ type FinancialGoal struct {
 	// AI-generated strategic plan for achieving the goal.
 	AIStrategicPlan FinancialGoalAIStrategicPlan `json:"aiStrategicPlan"`
 	// List of account IDs contributing to this goal.
-	ContributingAccounts []interface{} `json:"contributingAccounts,nullable"`
+	ContributingAccounts []string `json:"contributingAccounts,nullable"`
 	// Recommended or chosen risk tolerance for investments related to this goal.
 	RiskTolerance FinancialGoalRiskTolerance `json:"riskTolerance,nullable"`
 	JSON          financialGoalJSON          `json:"-"`
@@ -178,9 +209,9 @@ func (r FinancialGoalType) IsKnown() bool {
 
 // AI-generated strategic plan for achieving the goal.
 type FinancialGoalAIStrategicPlan struct {
-	PlanID  interface{}                        `json:"planId"`
+	PlanID  string                             `json:"planId"`
 	Steps   []FinancialGoalAIStrategicPlanStep `json:"steps"`
-	Summary interface{}                        `json:"summary"`
+	Summary string                             `json:"summary"`
 	JSON    financialGoalAIStrategicPlanJSON   `json:"-"`
 }
 
@@ -203,9 +234,9 @@ func (r financialGoalAIStrategicPlanJSON) RawJSON() string {
 }
 
 type FinancialGoalAIStrategicPlanStep struct {
-	Description interface{}                             `json:"description"`
+	Description string                                  `json:"description"`
 	Status      FinancialGoalAIStrategicPlanStepsStatus `json:"status"`
-	Title       interface{}                             `json:"title"`
+	Title       string                                  `json:"title"`
 	JSON        financialGoalAIStrategicPlanStepJSON    `json:"-"`
 }
 
@@ -260,43 +291,21 @@ func (r FinancialGoalRiskTolerance) IsKnown() bool {
 	return false
 }
 
-type GoalListResponse struct {
-	Data []FinancialGoal      `json:"data"`
-	JSON goalListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// goalListResponseJSON contains the JSON metadata for the struct
-// [GoalListResponse]
-type goalListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *GoalListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r goalListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type GoalNewParams struct {
 	// Name of the new financial goal.
-	Name param.Field[interface{}] `json:"name,required"`
+	Name param.Field[string] `json:"name,required"`
 	// The target monetary amount for the goal.
-	TargetAmount param.Field[interface{}] `json:"targetAmount,required"`
+	TargetAmount param.Field[Decimal] `json:"targetAmount,required"`
 	// The target completion date for the goal.
-	TargetDate param.Field[interface{}] `json:"targetDate,required"`
+	TargetDate param.Field[time.Time] `json:"targetDate,required" format:"date"`
 	// Type of financial goal.
 	Type param.Field[GoalNewParamsType] `json:"type,required"`
 	// Optional: List of account IDs initially contributing to this goal.
-	ContributingAccounts param.Field[[]interface{}] `json:"contributingAccounts"`
+	ContributingAccounts param.Field[[]string] `json:"contributingAccounts"`
 	// If true, AI will automatically generate a strategic plan for the goal.
-	GenerateAIPlan param.Field[interface{}] `json:"generateAIPlan"`
+	GenerateAIPlan param.Field[bool] `json:"generateAIPlan"`
 	// Optional: Initial amount to contribute to the goal.
-	InitialContribution param.Field[interface{}] `json:"initialContribution"`
+	InitialContribution param.Field[Decimal] `json:"initialContribution"`
 	// Desired risk tolerance for investments related to this goal.
 	RiskTolerance param.Field[GoalNewParamsRiskTolerance] `json:"riskTolerance"`
 }
@@ -344,19 +353,19 @@ func (r GoalNewParamsRiskTolerance) IsKnown() bool {
 
 type GoalUpdateParams struct {
 	// Updated list of account IDs contributing to this goal.
-	ContributingAccounts param.Field[[]interface{}] `json:"contributingAccounts"`
+	ContributingAccounts param.Field[[]string] `json:"contributingAccounts"`
 	// If true, AI will recalculate and update the strategic plan for the goal.
-	GenerateAIPlan param.Field[interface{}] `json:"generateAIPlan"`
+	GenerateAIPlan param.Field[bool] `json:"generateAIPlan"`
 	// Updated name of the financial goal.
-	Name param.Field[interface{}] `json:"name"`
+	Name param.Field[string] `json:"name"`
 	// Updated risk tolerance for investments related to this goal.
 	RiskTolerance param.Field[GoalUpdateParamsRiskTolerance] `json:"riskTolerance"`
 	// Updated status of the goal's progress.
 	Status param.Field[GoalUpdateParamsStatus] `json:"status"`
 	// The updated target monetary amount for the goal.
-	TargetAmount param.Field[interface{}] `json:"targetAmount"`
+	TargetAmount param.Field[Decimal] `json:"targetAmount"`
 	// The updated target completion date for the goal.
-	TargetDate param.Field[interface{}] `json:"targetDate"`
+	TargetDate param.Field[time.Time] `json:"targetDate" format:"date"`
 }
 
 func (r GoalUpdateParams) MarshalJSON() (data []byte, err error) {
@@ -402,9 +411,9 @@ func (r GoalUpdateParamsStatus) IsKnown() bool {
 
 type GoalListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [GoalListParams]'s query parameters as `url.Values`.
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -26,13 +27,13 @@ func TestGoalNewWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Goals.New(context.TODO(), jocall3.GoalNewParams{
-		Name:                 jocall3.F[any]("Dream Vacation Fund"),
-		TargetAmount:         jocall3.F[any](15000),
-		TargetDate:           jocall3.F[any]("2026-06-30"),
+		Name:                 jocall3.F("Dream Vacation Fund"),
+		TargetAmount:         jocall3.F(jocall3.MustDecimal("15000")),
+		TargetDate:           jocall3.F(time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)),
 		Type:                 jocall3.F(jocall3.GoalNewParamsTypeLargePurchase),
-		ContributingAccounts: jocall3.F([]interface{}{map[string]interface{}{}}),
-		GenerateAIPlan:       jocall3.F[any](true),
-		InitialContribution:  jocall3.F[any](1000),
+		ContributingAccounts: jocall3.F([]string{"string"}),
+		GenerateAIPlan:       jocall3.F(true),
+		InitialContribution:  jocall3.F(jocall3.MustDecimal("1000")),
 		RiskTolerance:        jocall3.F(jocall3.GoalNewParamsRiskToleranceConservative),
 	})
 	if err != nil {
@@ -82,13 +83,13 @@ func TestGoalUpdateWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"goal_retirement_2050",
 		jocall3.GoalUpdateParams{
-			ContributingAccounts: jocall3.F([]interface{}{map[string]interface{}{}}),
-			GenerateAIPlan:       jocall3.F[any](true),
-			Name:                 jocall3.F[any]("Revised Retirement Fund Goal"),
+			ContributingAccounts: jocall3.F([]string{"string"}),
+			GenerateAIPlan:       jocall3.F(true),
+			Name:                 jocall3.F("Revised Retirement Fund Goal"),
 			RiskTolerance:        jocall3.F(jocall3.GoalUpdateParamsRiskToleranceConservative),
 			Status:               jocall3.F(jocall3.GoalUpdateParamsStatusPaused),
-			TargetAmount:         jocall3.F[any](1200000),
-			TargetDate:           jocall3.F[any]("2029-12-31"),
+			TargetAmount:         jocall3.F(jocall3.MustDecimal("1200000")),
+			TargetDate:           jocall3.F(time.Date(2029, 12, 31, 0, 0, 0, 0, time.UTC)),
 		},
 	)
 	if err != nil {
@@ -113,8 +114,8 @@ func TestGoalListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Goals.List(context.TODO(), jocall3.GoalListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
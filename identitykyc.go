@@ -4,9 +4,12 @@ package jocall3
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"slices"
+	"time"
 
+	"github.com/jocall3/go/internal/apiform"
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
@@ -51,17 +54,29 @@ func (r *IdentityKYCService) Submit(ctx context.Context, body IdentityKYCSubmitP
 	return
 }
 
+// Submits Know Your Customer (KYC) documentation like
+// [IdentityKYCService.Submit], but uploads the document images as a
+// multipart/form-data request instead of base64 encoding them into JSON. Files
+// are streamed rather than held in memory; the request is only retried if every
+// file can be read again, see [FileParam] and [FileOpenerParam].
+func (r *IdentityKYCService) SubmitWithFiles(ctx context.Context, body IdentityKYCSubmitWithFilesParams, opts ...option.RequestOption) (res *KYCStatus, err error) {
+	opts = slices.Concat(r.Options, opts)
+	path := "identity/kyc/submit"
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
+	return
+}
+
 type KYCStatus struct {
 	// Timestamp of the last KYC document submission.
-	LastSubmissionDate interface{} `json:"lastSubmissionDate,required"`
+	LastSubmissionDate time.Time `json:"lastSubmissionDate,required" format:"date-time"`
 	// Overall status of the KYC verification process.
 	OverallStatus KYCStatusOverallStatus `json:"overallStatus,required"`
 	// List of actions required from the user if status is 'requires_more_info'.
-	RequiredActions []interface{} `json:"requiredActions,required"`
+	RequiredActions []string `json:"requiredActions,required"`
 	// The ID of the user whose KYC status is being retrieved.
-	UserID interface{} `json:"userId,required"`
+	UserID string `json:"userId,required"`
 	// Reason for rejection if status is 'rejected'.
-	RejectionReason interface{} `json:"rejectionReason"`
+	RejectionReason string `json:"rejectionReason"`
 	// The KYC verification tier achieved (e.g., for different service levels).
 	VerifiedTier KYCStatusVerifiedTier `json:"verifiedTier,nullable"`
 	JSON         kycStatusJSON         `json:"-"`
@@ -126,22 +141,22 @@ func (r KYCStatusVerifiedTier) IsKnown() bool {
 
 type IdentityKYCSubmitParams struct {
 	// The two-letter ISO country code where the document was issued.
-	CountryOfIssue param.Field[interface{}] `json:"countryOfIssue,required"`
+	CountryOfIssue param.Field[string] `json:"countryOfIssue,required"`
 	// The identification number on the document.
-	DocumentNumber param.Field[interface{}] `json:"documentNumber,required"`
+	DocumentNumber param.Field[string] `json:"documentNumber,required"`
 	// The type of KYC document being submitted.
 	DocumentType param.Field[IdentityKYCSubmitParamsDocumentType] `json:"documentType,required"`
 	// The expiration date of the document (YYYY-MM-DD).
-	ExpirationDate param.Field[interface{}] `json:"expirationDate,required"`
+	ExpirationDate param.Field[time.Time] `json:"expirationDate,required" format:"date"`
 	// The issue date of the document (YYYY-MM-DD).
-	IssueDate param.Field[interface{}] `json:"issueDate,required"`
+	IssueDate param.Field[time.Time] `json:"issueDate,required" format:"date"`
 	// Array of additional documents (e.g., utility bills) as base64 encoded images.
-	AdditionalDocuments param.Field[[]interface{}] `json:"additionalDocuments"`
+	AdditionalDocuments param.Field[[]string] `json:"additionalDocuments"`
 	// Base64 encoded image of the back of the document (if applicable).
-	DocumentBackImage param.Field[interface{}] `json:"documentBackImage"`
+	DocumentBackImage param.Field[string] `json:"documentBackImage"`
 	// Base64 encoded image of the front of the document. Use 'application/json' with
 	// base64 string, or 'multipart/form-data' for direct file upload.
-	DocumentFrontImage param.Field[interface{}] `json:"documentFrontImage"`
+	DocumentFrontImage param.Field[string] `json:"documentFrontImage"`
 }
 
 func (r IdentityKYCSubmitParams) MarshalJSON() (data []byte, err error) {
@@ -167,3 +182,26 @@ func (r IdentityKYCSubmitParamsDocumentType) IsKnown() bool {
 	}
 	return false
 }
+
+type IdentityKYCSubmitWithFilesParams struct {
+	// The two-letter ISO country code where the document was issued.
+	CountryOfIssue param.Field[string] `json:"countryOfIssue,required"`
+	// The identification number on the document.
+	DocumentNumber param.Field[string] `json:"documentNumber,required"`
+	// The type of KYC document being submitted.
+	DocumentType param.Field[IdentityKYCSubmitParamsDocumentType] `json:"documentType,required"`
+	// The expiration date of the document (YYYY-MM-DD).
+	ExpirationDate param.Field[time.Time] `json:"expirationDate,required" format:"date"`
+	// The issue date of the document (YYYY-MM-DD).
+	IssueDate param.Field[time.Time] `json:"issueDate,required" format:"date"`
+	// Additional documents (e.g., utility bills).
+	AdditionalDocuments param.Field[[]io.Reader] `json:"additionalDocuments" format:"binary"`
+	// Image of the back of the document (if applicable).
+	DocumentBackImage param.Field[io.Reader] `json:"documentBackImage" format:"binary"`
+	// Image of the front of the document.
+	DocumentFrontImage param.Field[io.Reader] `json:"documentFrontImage" format:"binary"`
+}
+
+func (r IdentityKYCSubmitWithFilesParams) MarshalMultipartBody() (*apiform.Body, error) {
+	return apiform.NewRootBody(r)
+}
@@ -3,10 +3,13 @@
 package jocall3_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -48,14 +51,45 @@ func TestIdentityKYCSubmitWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Identity.KYC.Submit(context.TODO(), jocall3.IdentityKYCSubmitParams{
-		CountryOfIssue:      jocall3.F[any]("US"),
-		DocumentNumber:      jocall3.F[any]("ABC12345"),
+		CountryOfIssue:      jocall3.F("US"),
+		DocumentNumber:      jocall3.F("ABC12345"),
 		DocumentType:        jocall3.F(jocall3.IdentityKYCSubmitParamsDocumentTypeDriversLicense),
-		ExpirationDate:      jocall3.F[any]("2030-01-01"),
-		IssueDate:           jocall3.F[any]("2020-01-01"),
-		AdditionalDocuments: jocall3.F([]interface{}{map[string]interface{}{}}),
-		DocumentBackImage:   jocall3.F[any]("base64encoded_image_of_drivers_license_back"),
-		DocumentFrontImage:  jocall3.F[any]("base64encoded_image_of_drivers_license_front"),
+		ExpirationDate:      jocall3.F(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+		IssueDate:           jocall3.F(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		AdditionalDocuments: jocall3.F([]string{"string"}),
+		DocumentBackImage:   jocall3.F("base64encoded_image_of_drivers_license_back"),
+		DocumentFrontImage:  jocall3.F("base64encoded_image_of_drivers_license_front"),
+	})
+	if err != nil {
+		var apierr *jocall3.Error
+		if errors.As(err, &apierr) {
+			t.Log(string(apierr.DumpRequest(true)))
+		}
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+}
+
+func TestIdentityKYCSubmitWithFilesWithOptionalParams(t *testing.T) {
+	t.Skip("Prism tests are disabled")
+	baseURL := "http://localhost:4010"
+	if envURL, ok := os.LookupEnv("TEST_API_BASE_URL"); ok {
+		baseURL = envURL
+	}
+	if !testutil.CheckTestServer(t, baseURL) {
+		return
+	}
+	client := jocall3.NewClient(
+		option.WithBaseURL(baseURL),
+	)
+	_, err := client.Identity.KYC.SubmitWithFiles(context.TODO(), jocall3.IdentityKYCSubmitWithFilesParams{
+		CountryOfIssue:      jocall3.F("US"),
+		DocumentNumber:      jocall3.F("ABC12345"),
+		DocumentType:        jocall3.F(jocall3.IdentityKYCSubmitParamsDocumentTypeDriversLicense),
+		ExpirationDate:      jocall3.F(time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)),
+		IssueDate:           jocall3.F(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		AdditionalDocuments: jocall3.F([]io.Reader{io.Reader(bytes.NewBuffer([]byte("some file contents")))}),
+		DocumentBackImage:   jocall3.F(io.Reader(bytes.NewBuffer([]byte("some file contents")))),
+		DocumentFrontImage:  jocall3.F(io.Reader(bytes.NewBuffer([]byte("some file contents")))),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httputil"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 )
@@ -18,6 +19,20 @@ type Error struct {
 	StatusCode int
 	Request    *http.Request
 	Response   *http.Response
+
+	// The machine-readable error code from the response body, such as
+	// "not_found", or empty if there is none.
+	Code string `json:"-"`
+	// The human-readable message from the response body.
+	Message string `json:"-"`
+	// The problems with individual request fields, for validation errors.
+	FieldErrors []FieldError `json:"-"`
+	// The ID the API assigned to the request, from the response body or the
+	// X-Request-Id header.
+	RequestID string `json:"-"`
+	// How long the API asked to wait before the request is retried, from the
+	// Retry-After header or the response body, or zero if it did not say.
+	RetryAfter time.Duration `json:"-"`
 }
 
 // errorJSON contains the JSON metadata for the struct [Error]
@@ -0,0 +1,302 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// FieldError is a problem with one field of a request, as reported by a
+// validation error.
+type FieldError struct {
+	// The name or path of the field, such as "amount" or "recipient.iban".
+	Field string
+	// Why the value of the field was rejected.
+	Message string
+	// The machine-readable code of the problem, or empty if there is none.
+	Code string
+}
+
+// The classes of API errors, for use with [errors.Is]:
+//
+//	if errors.Is(err, jocall3.ErrNotFound) {
+//		// ...
+//	}
+var (
+	ErrAuthentication = errors.New("authentication failed")
+	ErrForbidden      = errors.New("forbidden")
+	ErrNotFound       = errors.New("not found")
+	ErrConflict       = errors.New("conflict")
+	ErrValidation     = errors.New("validation failed")
+	ErrRateLimited    = errors.New("rate limited")
+)
+
+// The errors that the API reports with a specific code, for use with
+// [errors.Is] whatever the status of the response:
+//
+//	if errors.Is(err, jocall3.ErrInsufficientFunds) {
+//		// ...
+//	}
+//
+// An error with one of these codes also matches the class of its status, such
+// as [ErrValidation] or [ErrForbidden].
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrKYCRequired       = errors.New("KYC required")
+)
+
+// errorCodes maps the codes of the API's errors, normalized by normalizeCode,
+// to the sentinels they match.
+var errorCodes = map[string]error{
+	"insufficient_funds":             ErrInsufficientFunds,
+	"insufficient_balance":           ErrInsufficientFunds,
+	"kyc_required":                   ErrKYCRequired,
+	"kyc_verification_required":      ErrKYCRequired,
+	"identity_verification_required": ErrKYCRequired,
+}
+
+// normalizeCode lets codes such as "INSUFFICIENT_FUNDS" and
+// "insufficient-funds" match the same sentinel.
+func normalizeCode(code string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(code)), "-", "_")
+}
+
+// AuthenticationError is the form of an [Error] for a 401 Unauthorized
+// response, returned by [errors.As].
+type AuthenticationError Error
+
+// ForbiddenError is the form of an [Error] for a 403 Forbidden response,
+// returned by [errors.As].
+type ForbiddenError Error
+
+// NotFoundError is the form of an [Error] for a 404 Not Found response,
+// returned by [errors.As].
+type NotFoundError Error
+
+// ConflictError is the form of an [Error] for a 409 Conflict response,
+// returned by [errors.As].
+type ConflictError Error
+
+// ValidationError is the form of an [Error] for a 400 Bad Request or 422
+// Unprocessable Entity response, returned by [errors.As]. Its FieldErrors say
+// which fields of the request were rejected.
+type ValidationError Error
+
+// RateLimitError is the form of an [Error] for a 429 Too Many Requests
+// response, returned by [errors.As]. Its RetryAfter says how long to wait
+// before trying again.
+type RateLimitError Error
+
+func (e *AuthenticationError) Error() string { return (*Error)(e).Error() }
+func (e *AuthenticationError) Unwrap() error { return (*Error)(e) }
+func (e *ForbiddenError) Error() string      { return (*Error)(e).Error() }
+func (e *ForbiddenError) Unwrap() error      { return (*Error)(e) }
+func (e *NotFoundError) Error() string       { return (*Error)(e).Error() }
+func (e *NotFoundError) Unwrap() error       { return (*Error)(e) }
+func (e *ConflictError) Error() string       { return (*Error)(e).Error() }
+func (e *ConflictError) Unwrap() error       { return (*Error)(e) }
+func (e *ValidationError) Error() string     { return (*Error)(e).Error() }
+func (e *ValidationError) Unwrap() error     { return (*Error)(e) }
+func (e *RateLimitError) Error() string      { return (*Error)(e).Error() }
+func (e *RateLimitError) Unwrap() error      { return (*Error)(e) }
+
+// class returns the sentinel of the class of the error, or nil if its status
+// has none.
+func (r *Error) class() error {
+	switch r.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrAuthentication
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ErrValidation
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// Is reports whether target is the sentinel of the class of the error, such as
+// [ErrNotFound] for a 404 response, or the sentinel of its code, such as
+// [ErrInsufficientFunds].
+func (r *Error) Is(target error) bool {
+	if class := r.class(); class != nil && class == target {
+		return true
+	}
+	sentinel := errorCodes[normalizeCode(r.Code)]
+	return sentinel != nil && sentinel == target
+}
+
+// As sets target to the typed form of the error, such as a *[NotFoundError]
+// for a 404 response, if target points to a variable of that type.
+func (r *Error) As(target interface{}) bool {
+	switch target := target.(type) {
+	case **AuthenticationError:
+		if r.class() == ErrAuthentication {
+			*target = (*AuthenticationError)(r)
+			return true
+		}
+	case **ForbiddenError:
+		if r.class() == ErrForbidden {
+			*target = (*ForbiddenError)(r)
+			return true
+		}
+	case **NotFoundError:
+		if r.class() == ErrNotFound {
+			*target = (*NotFoundError)(r)
+			return true
+		}
+	case **ConflictError:
+		if r.class() == ErrConflict {
+			*target = (*ConflictError)(r)
+			return true
+		}
+	case **ValidationError:
+		if r.class() == ErrValidation {
+			*target = (*ValidationError)(r)
+			return true
+		}
+	case **RateLimitError:
+		if r.class() == ErrRateLimited {
+			*target = (*RateLimitError)(r)
+			return true
+		}
+	}
+	return false
+}
+
+// New returns the error for the response res to req, whose body has already
+// been read into body. The code, message, field errors and request ID are
+// taken from body when it is JSON, either at its top level or inside an
+// "error" object; a body that is not JSON is kept as it is.
+func New(req *http.Request, res *http.Response, body []byte) *Error {
+	r := &Error{Request: req, Response: res, StatusCode: res.StatusCode}
+	if !gjson.ValidBytes(body) {
+		r.JSON.raw = string(body)
+		r.RequestID = requestIDHeader(res)
+		return r
+	}
+	if err := r.UnmarshalJSON(body); err != nil {
+		r.JSON.raw = string(body)
+	}
+
+	root := gjson.ParseBytes(body)
+	detail := root
+	if envelope := root.Get("error"); envelope.IsObject() {
+		detail = envelope
+	} else if envelope.Type == gjson.String {
+		r.Message = envelope.String()
+	}
+
+	r.Code = firstString(detail, "code", "error_code", "type")
+	if msg := firstString(detail, "message", "detail", "error_description"); msg != "" {
+		r.Message = msg
+	}
+	r.RequestID = firstString(detail, "requestId", "request_id")
+	if r.RequestID == "" {
+		r.RequestID = firstString(root, "requestId", "request_id")
+	}
+	if r.RequestID == "" {
+		r.RequestID = requestIDHeader(res)
+	}
+	if v := detail.Get("retryAfter"); v.Exists() {
+		r.RetryAfter = time.Duration(v.Float() * float64(time.Second))
+	} else if v := detail.Get("retry_after"); v.Exists() {
+		r.RetryAfter = time.Duration(v.Float() * float64(time.Second))
+	}
+	for _, key := range []string{"details", "errors", "fieldErrors", "field_errors"} {
+		if v := detail.Get(key); v.Exists() {
+			r.FieldErrors = append(r.FieldErrors, fieldErrors(v)...)
+		}
+	}
+	return r
+}
+
+// fieldErrors reads field errors given either as an object of field names to
+// messages, or as an array of objects that name a field.
+func fieldErrors(v gjson.Result) (out []FieldError) {
+	switch {
+	case v.IsObject():
+		v.ForEach(func(key, value gjson.Result) bool {
+			if value.IsArray() {
+				for _, msg := range value.Array() {
+					out = append(out, FieldError{Field: key.String(), Message: msg.String()})
+				}
+			} else if value.IsObject() {
+				out = append(out, FieldError{Field: key.String(), Message: firstString(value, "message", "msg"), Code: firstString(value, "code")})
+			} else {
+				out = append(out, FieldError{Field: key.String(), Message: value.String()})
+			}
+			return true
+		})
+	case v.IsArray():
+		v.ForEach(func(_, value gjson.Result) bool {
+			if !value.IsObject() {
+				return true
+			}
+			field := firstString(value, "field", "path", "param", "pointer")
+			if loc := value.Get("loc"); field == "" && loc.IsArray() {
+				var parts []string
+				for _, part := range loc.Array() {
+					parts = append(parts, part.String())
+				}
+				field = strings.Join(parts, ".")
+			}
+			out = append(out, FieldError{Field: field, Message: firstString(value, "message", "msg", "detail"), Code: firstString(value, "code")})
+			return true
+		})
+	}
+	return out
+}
+
+func firstString(v gjson.Result, keys ...string) string {
+	for _, key := range keys {
+		switch field := v.Get(key); field.Type {
+		case gjson.String:
+			return field.String()
+		case gjson.Number:
+			return strconv.FormatInt(field.Int(), 10)
+		}
+	}
+	return ""
+}
+
+func requestIDHeader(res *http.Response) string {
+	if id := res.Header.Get("X-Request-Id"); id != "" {
+		return id
+	}
+	return res.Header.Get("Request-Id")
+}
+
+// TransportError is returned when a request could not be sent or its response
+// could not be received, for example because a connection failed. It wraps
+// the error of the HTTP client, which is often a *url.Error.
+type TransportError struct {
+	Err error
+}
+
+func (e *TransportError) Error() string { return e.Err.Error() }
+func (e *TransportError) Unwrap() error { return e.Err }
+
+// DecodeError is returned when the API responded with success but the body of
+// the response could not be decoded into the result of a method.
+type DecodeError struct {
+	// The status code of the response.
+	StatusCode int
+	// The Content-Type of the response.
+	ContentType string
+	// The body of the response.
+	Body []byte
+	Err  error
+}
+
+func (e *DecodeError) Error() string { return e.Err.Error() }
+func (e *DecodeError) Unwrap() error { return e.Err }
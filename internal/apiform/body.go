@@ -0,0 +1,178 @@
+package apiform
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Opener is implemented by files that can be opened again, such as a file on
+// disk named by its path. Every time such a file is encoded, it is opened, read
+// and closed.
+type Opener interface {
+	Open() (io.ReadCloser, error)
+}
+
+// BodyMarshaler is implemented by params that are sent as a streamed
+// multipart/form-data body.
+type BodyMarshaler interface {
+	MarshalMultipartBody() (*Body, error)
+}
+
+// ErrNotRewindable is returned by [Body.Reader] when the body holds a file
+// that has already been read and can be read again neither with [io.Seeker]
+// nor with [Opener].
+var ErrNotRewindable = errors.New("apiform: multipart body cannot be read again")
+
+// Body is a multipart/form-data request body that is encoded as it is read,
+// so that files are streamed rather than held in memory.
+type Body struct {
+	value         interface{}
+	boundary      string
+	notRewindable []string
+
+	mu    sync.Mutex
+	read  bool
+	first io.ReadCloser
+	// encoding is closed when the last encoding of the body started by a
+	// reader has stopped.
+	encoding chan struct{}
+}
+
+// NewRootBody returns a body that encodes value as [MarshalRoot] does. Values
+// that cannot be encoded are reported here, before any file is read.
+func NewRootBody(value interface{}) (*Body, error) {
+	writer := &formWriter{Writer: multipart.NewWriter(io.Discard), scan: true}
+	e := &encoder{root: true, dateFormat: time.RFC3339}
+	if err := e.marshal(value, writer); err != nil {
+		return nil, err
+	}
+	return &Body{value: value, boundary: writer.Boundary(), notRewindable: writer.notRewindable}, nil
+}
+
+// ContentType returns the Content-Type header of the body, with its boundary.
+func (b *Body) ContentType() string {
+	return "multipart/form-data; boundary=" + b.boundary
+}
+
+// Rewindable reports whether every file in the body can be read again, so
+// that [Body.Reader] can be called more than once.
+func (b *Body) Rewindable() bool {
+	return len(b.notRewindable) == 0
+}
+
+// Reader returns a reader of the encoded body. Encoding starts in another
+// goroutine when the reader is first read, and stops when it is closed.
+// Calling Reader again encodes the body again from the start, which fails with
+// [ErrNotRewindable] if it holds a file that cannot be read again. Encoding
+// again waits for the previous encoding to stop, since both read the same
+// files and an HTTP transport may close a request body after the round trip
+// has returned.
+func (b *Body) Reader() (io.ReadCloser, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.read && !b.Rewindable() {
+		return nil, fmt.Errorf("%w: %s", ErrNotRewindable, strings.Join(b.notRewindable, ", "))
+	}
+	b.read = true
+	return &bodyReader{body: b}, nil
+}
+
+// Read reads the body as encoded by the first call to [Body.Reader], which
+// lets a Body be used wherever an [io.Reader] is expected.
+func (b *Body) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	if b.first == nil {
+		b.mu.Unlock()
+		r, err := b.Reader()
+		if err != nil {
+			return 0, err
+		}
+		b.mu.Lock()
+		b.first = r
+	}
+	r := b.first
+	b.mu.Unlock()
+	return r.Read(p)
+}
+
+// bodyReader encodes a body into a pipe once it is first read, so that a
+// request that is never sent does not leave a goroutine behind.
+type bodyReader struct {
+	body    *Body
+	mu      sync.Mutex
+	pipe    *io.PipeReader
+	closed  bool
+	closing chan struct{}
+	done    chan struct{}
+}
+
+func (r *bodyReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return 0, io.ErrClosedPipe
+	}
+	if r.pipe == nil {
+		var pw *io.PipeWriter
+		r.pipe, pw = io.Pipe()
+		writer := multipart.NewWriter(pw)
+		if err := writer.SetBoundary(r.body.boundary); err != nil {
+			r.mu.Unlock()
+			return 0, err
+		}
+		r.closing = make(chan struct{})
+		r.done = make(chan struct{})
+		r.body.mu.Lock()
+		previous := r.body.encoding
+		r.body.encoding = r.done
+		r.body.mu.Unlock()
+		go func(closing, done chan struct{}) {
+			defer close(done)
+			if previous != nil {
+				select {
+				case <-previous:
+				case <-closing:
+					pw.CloseWithError(io.ErrClosedPipe)
+					return
+				}
+			}
+			err := MarshalRoot(r.body.value, writer)
+			if err == nil {
+				err = writer.Close()
+			}
+			pw.CloseWithError(err)
+		}(r.closing, r.done)
+	}
+	pipe := r.pipe
+	r.mu.Unlock()
+	return pipe.Read(p)
+}
+
+// Close stops the encoding and waits for it to stop, so that the files of the
+// body are no longer read once Close returns.
+func (r *bodyReader) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pipe == nil || r.closed {
+		r.closed = true
+		return nil
+	}
+	r.closed = true
+	close(r.closing)
+	err := r.pipe.Close()
+	<-r.done
+	return err
+}
+
+func rewindable(reader io.Reader) bool {
+	switch reader.(type) {
+	case Opener, io.Seeker:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package apiform
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+type bodyFile struct {
+	*bytes.Reader
+	name string
+}
+
+func (f bodyFile) Filename() string { return f.name }
+
+type BodyStruct struct {
+	Name  string      `form:"name"`
+	File  io.Reader   `form:"file"`
+	Files []io.Reader `form:"files"`
+}
+
+func readBody(t *testing.T, body *Body) map[string][2]string {
+	t.Helper()
+	r, err := body.Reader()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer r.Close()
+	_, params, err := mime.ParseMediaType(body.ContentType())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parts := map[string][2]string{}
+	reader := multipart.NewReader(r, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		parts[part.FormName()] = [2]string{part.Header.Get("Content-Type"), string(data)}
+	}
+}
+
+func TestBodyRewind(t *testing.T) {
+	body, err := NewRootBody(BodyStruct{
+		Name:  "receipt",
+		File:  bodyFile{bytes.NewReader([]byte("%PDF-1.7 receipt")), "receipt.pdf"},
+		Files: []io.Reader{strings.NewReader("\x89PNG\r\n\x1a\nimage")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !body.Rewindable() {
+		t.Fatalf("expected a body of seekable files to be rewindable")
+	}
+
+	expected := map[string][2]string{
+		"name":    {"", "receipt"},
+		"file":    {"application/pdf", "%PDF-1.7 receipt"},
+		"files.0": {"image/png", "\x89PNG\r\n\x1a\nimage"},
+	}
+	for i := 0; i < 2; i++ {
+		parts := readBody(t, body)
+		for name, want := range expected {
+			if got := parts[name]; got[1] != want[1] || (want[0] != "" && got[0] != want[0]) {
+				t.Errorf("read %d: expected part %s to be %q, got %q", i, name, want, got)
+			}
+		}
+	}
+}
+
+func TestBodyNotRewindable(t *testing.T) {
+	body, err := NewRootBody(BodyStruct{File: io.MultiReader(strings.NewReader("once"))})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Rewindable() {
+		t.Fatalf("expected a body with a plain reader not to be rewindable")
+	}
+	if parts := readBody(t, body); parts["file"][1] != "once" || parts["file"][0] != "text/plain; charset=utf-8" {
+		t.Errorf("unexpected file part %q", parts["file"])
+	}
+	if _, err := body.Reader(); !errors.Is(err, ErrNotRewindable) || !strings.Contains(err.Error(), "file") {
+		t.Errorf("expected ErrNotRewindable naming the file, got %v", err)
+	}
+}
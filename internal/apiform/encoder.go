@@ -1,9 +1,12 @@
 package apiform
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
+	"net/http"
 	"net/textproto"
 	"path"
 	"reflect"
@@ -20,12 +23,21 @@ var encoders sync.Map // map[encoderEntry]encoderFunc
 
 func Marshal(value interface{}, writer *multipart.Writer) error {
 	e := &encoder{dateFormat: time.RFC3339}
-	return e.marshal(value, writer)
+	return e.marshal(value, &formWriter{Writer: writer})
 }
 
 func MarshalRoot(value interface{}, writer *multipart.Writer) error {
 	e := &encoder{root: true, dateFormat: time.RFC3339}
-	return e.marshal(value, writer)
+	return e.marshal(value, &formWriter{Writer: writer})
+}
+
+// formWriter is the multipart writer that a value is encoded to.
+type formWriter struct {
+	*multipart.Writer
+	// When scanning, files are checked rather than read, and the writer
+	// records whether they can all be read again.
+	scan          bool
+	notRewindable []string
 }
 
 type encoder struct {
@@ -33,7 +45,7 @@ type encoder struct {
 	root       bool
 }
 
-type encoderFunc func(key string, value reflect.Value, writer *multipart.Writer) error
+type encoderFunc func(key string, value reflect.Value, writer *formWriter) error
 
 type encoderField struct {
 	tag parsedStructTag
@@ -47,7 +59,7 @@ type encoderEntry struct {
 	root       bool
 }
 
-func (e *encoder) marshal(value interface{}, writer *multipart.Writer) error {
+func (e *encoder) marshal(value interface{}, writer *formWriter) error {
 	val := reflect.ValueOf(value)
 	if !val.IsValid() {
 		return nil
@@ -77,7 +89,7 @@ func (e *encoder) typeEncoder(t reflect.Type) encoderFunc {
 		f  encoderFunc
 	)
 	wg.Add(1)
-	fi, loaded := encoders.LoadOrStore(entry, encoderFunc(func(key string, v reflect.Value, writer *multipart.Writer) error {
+	fi, loaded := encoders.LoadOrStore(entry, encoderFunc(func(key string, v reflect.Value, writer *formWriter) error {
 		wg.Wait()
 		return f(key, v, writer)
 	}))
@@ -105,7 +117,7 @@ func (e *encoder) newTypeEncoder(t reflect.Type) encoderFunc {
 		inner := t.Elem()
 
 		innerEncoder := e.typeEncoder(inner)
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			if !v.IsValid() || v.IsNil() {
 				return nil
 			}
@@ -129,34 +141,34 @@ func (e *encoder) newPrimitiveTypeEncoder(t reflect.Type) encoderFunc {
 	// Note that we could use `gjson` to encode these types but it would complicate our
 	// code more and this current code shouldn't cause any issues
 	case reflect.String:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, v.String())
 		}
 	case reflect.Bool:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			if v.Bool() {
 				return writer.WriteField(key, "true")
 			}
 			return writer.WriteField(key, "false")
 		}
 	case reflect.Int, reflect.Int16, reflect.Int32, reflect.Int64:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, strconv.FormatInt(v.Int(), 10))
 		}
 	case reflect.Uint, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, strconv.FormatUint(v.Uint(), 10))
 		}
 	case reflect.Float32:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, strconv.FormatFloat(v.Float(), 'f', -1, 32))
 		}
 	case reflect.Float64:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return writer.WriteField(key, strconv.FormatFloat(v.Float(), 'f', -1, 64))
 		}
 	default:
-		return func(key string, v reflect.Value, writer *multipart.Writer) error {
+		return func(key string, v reflect.Value, writer *formWriter) error {
 			return fmt.Errorf("unknown type received at primitive encoder: %s", t.String())
 		}
 	}
@@ -165,7 +177,7 @@ func (e *encoder) newPrimitiveTypeEncoder(t reflect.Type) encoderFunc {
 func (e *encoder) newArrayTypeEncoder(t reflect.Type) encoderFunc {
 	itemEncoder := e.typeEncoder(t.Elem())
 
-	return func(key string, v reflect.Value, writer *multipart.Writer) error {
+	return func(key string, v reflect.Value, writer *formWriter) error {
 		if key != "" {
 			key = key + "."
 		}
@@ -242,7 +254,7 @@ func (e *encoder) newStructTypeEncoder(t reflect.Type) encoderFunc {
 		return encoderFields[i].tag.name < encoderFields[j].tag.name
 	})
 
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		if key != "" {
 			key = key + "."
 		}
@@ -270,7 +282,7 @@ func (e *encoder) newFieldTypeEncoder(t reflect.Type) encoderFunc {
 	f, _ := t.FieldByName("Value")
 	enc := e.typeEncoder(f.Type)
 
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		present := value.FieldByName("Present")
 		if !present.Bool() {
 			return nil
@@ -289,13 +301,13 @@ func (e *encoder) newFieldTypeEncoder(t reflect.Type) encoderFunc {
 
 func (e *encoder) newTimeTypeEncoder() encoderFunc {
 	format := e.dateFormat
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		return writer.WriteField(key, value.Convert(reflect.TypeOf(time.Time{})).Interface().(time.Time).Format(format))
 	}
 }
 
 func (e encoder) newInterfaceEncoder() encoderFunc {
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		value = value.Elem()
 		if !value.IsValid() {
 			return nil
@@ -311,10 +323,13 @@ func escapeQuotes(s string) string {
 }
 
 func (e *encoder) newReaderTypeEncoder() encoderFunc {
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		reader := value.Convert(reflect.TypeOf((*io.Reader)(nil)).Elem()).Interface().(io.Reader)
+		if reader == nil {
+			return nil
+		}
 		filename := "anonymous_file"
-		contentType := "application/octet-stream"
+		contentType := ""
 		if named, ok := reader.(interface{ Filename() string }); ok {
 			filename = named.Filename()
 		} else if named, ok := reader.(interface{ Name() string }); ok {
@@ -324,6 +339,43 @@ func (e *encoder) newReaderTypeEncoder() encoderFunc {
 			contentType = typed.ContentType()
 		}
 
+		if writer.scan {
+			if !rewindable(reader) {
+				writer.notRewindable = append(writer.notRewindable, key)
+			}
+			return nil
+		}
+
+		// Read files from the start every time they are encoded, so that a body
+		// can be encoded again to retry a request.
+		if opener, ok := reader.(Opener); ok {
+			rc, err := opener.Open()
+			if err != nil {
+				return err
+			}
+			defer rc.Close()
+			reader = rc
+		} else if seeker, ok := reader.(io.Seeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+		}
+
+		if contentType == "" {
+			contentType = mime.TypeByExtension(path.Ext(filename))
+		}
+		if contentType == "" {
+			// Sniff the content type from the first bytes of the file, as
+			// browsers do.
+			buffered := bufio.NewReaderSize(reader, 512)
+			head, err := buffered.Peek(512)
+			if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+				return err
+			}
+			contentType = http.DetectContentType(head)
+			reader = buffered
+		}
+
 		// Below is taken almost 1-for-1 from [multipart.CreateFormFile]
 		h := make(textproto.MIMEHeader)
 		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(key), escapeQuotes(filename)))
@@ -339,7 +391,7 @@ func (e *encoder) newReaderTypeEncoder() encoderFunc {
 
 // Given a []byte of json (may either be an empty object or an object that already contains entries)
 // encode all of the entries in the map to the json byte array.
-func (e *encoder) encodeMapEntries(key string, v reflect.Value, writer *multipart.Writer) error {
+func (e *encoder) encodeMapEntries(key string, v reflect.Value, writer *formWriter) error {
 	type mapPair struct {
 		key   string
 		value reflect.Value
@@ -377,7 +429,7 @@ func (e *encoder) encodeMapEntries(key string, v reflect.Value, writer *multipar
 }
 
 func (e *encoder) newMapEncoder(t reflect.Type) encoderFunc {
-	return func(key string, value reflect.Value, writer *multipart.Writer) error {
+	return func(key string, value reflect.Value, writer *formWriter) error {
 		return e.encodeMapEntries(key, value, writer)
 	}
 }
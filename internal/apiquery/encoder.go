@@ -1,6 +1,7 @@
 package apiquery
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -81,10 +82,24 @@ func marshalerEncoder(key string, value reflect.Value) []Pair {
 	return []Pair{{key, string(s)}}
 }
 
+func textMarshalerEncoder(key string, value reflect.Value) []Pair {
+	if value.Kind() == reflect.Pointer && value.IsNil() {
+		return nil
+	}
+	s, err := value.Interface().(encoding.TextMarshaler).MarshalText()
+	if err != nil {
+		return nil
+	}
+	return []Pair{{key, string(s)}}
+}
+
 func (e *encoder) newTypeEncoder(t reflect.Type) encoderFunc {
 	if t.ConvertibleTo(reflect.TypeOf(time.Time{})) {
 		return e.newTimeTypeEncoder(t)
 	}
+	if !e.root && t.Implements(reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()) {
+		return textMarshalerEncoder
+	}
 	if !e.root && t.Implements(reflect.TypeOf((*json.Marshaler)(nil)).Elem()) {
 		return marshalerEncoder
 	}
@@ -1,6 +1,7 @@
 package apiquery
 
 import (
+	"fmt"
 	"net/url"
 	"testing"
 	"time"
@@ -85,6 +86,21 @@ type UnionTime time.Time
 
 func (UnionTime) union() {}
 
+type TextAmount struct {
+	units int64
+	cents int64
+}
+
+func (a TextAmount) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%02d", a.units, a.cents)), nil
+}
+
+type TextMarshalers struct {
+	Min     TextAmount  `query:"min"`
+	Max     *TextAmount `query:"max"`
+	Missing *TextAmount `query:"missing"`
+}
+
 type DeeplyNested struct {
 	A DeeplyNested1 `query:"a"`
 }
@@ -265,6 +281,15 @@ var tests = map[string]struct {
 		QuerySettings{},
 	},
 
+	"text_marshalers": {
+		`max=100.00&min=12.50`,
+		TextMarshalers{
+			Min: TextAmount{12, 50},
+			Max: &TextAmount{100, 0},
+		},
+		QuerySettings{},
+	},
+
 	"deeply_nested_brackets": {
 		`a[b][c][d]=hello`,
 		DeeplyNested{
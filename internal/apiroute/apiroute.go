@@ -0,0 +1,206 @@
+// Package apiroute maps the requests made by the SDK back to the API operations
+// they belong to, so that middleware can group requests by route template
+// rather than by their concrete path.
+package apiroute
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Route is an operation of the API.
+type Route struct {
+	// The HTTP method, in upper case.
+	Method string
+	// The path template, such as "/budgets/{budgetId}".
+	Template string
+	// The service method that calls the operation, relative to the client, such
+	// as "Budgets.Get".
+	Operation string
+
+	segments []string
+}
+
+// Lookup returns the route that a request with the given method and path was
+// made for. The path may carry the path of the base URL as a prefix. When
+// several templates match, the one with the most literal segments wins, so that
+// "/transactions/recurring" is not mistaken for "/transactions/{transactionId}".
+func Lookup(method, path string) (Route, bool) {
+	method = strings.ToUpper(method)
+	segments := split(path)
+
+	best, bestLiterals := -1, -1
+	for i := range routes {
+		route := &routes[i]
+		if route.Method != method || len(route.segments) > len(segments) {
+			continue
+		}
+		tail := segments[len(segments)-len(route.segments):]
+		literals := 0
+		for j, want := range route.segments {
+			if isParam(want) {
+				continue
+			}
+			if want != tail[j] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+		if literals > bestLiterals || (literals == bestLiterals && best >= 0 && len(route.segments) > len(routes[best].segments)) {
+			best, bestLiterals = i, literals
+		}
+	}
+	if best < 0 {
+		return Route{}, false
+	}
+	return routes[best], true
+}
+
+// Template returns the template of the route that a request with the given
+// method and path was made for. For paths that match no known route, segments
+// that look like identifiers, that is those containing a digit, are replaced by
+// "{id}" so that the result stays bounded.
+func Template(method, path string) string {
+	if route, ok := Lookup(method, path); ok {
+		return route.Template
+	}
+	segments := split(path)
+	for i, segment := range segments {
+		if strings.IndexFunc(segment, unicode.IsDigit) >= 0 {
+			segments[i] = "{id}"
+		}
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func split(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func init() {
+	for i := range routes {
+		routes[i].segments = split(routes[i].Template)
+	}
+}
+
+// routes lists every operation in api.md.
+var routes = []Route{
+	{Method: "POST", Template: "/users/login", Operation: "Users.Login"},
+	{Method: "POST", Template: "/users/register", Operation: "Users.Register"},
+	{Method: "POST", Template: "/users/password-reset/confirm", Operation: "Users.PasswordReset.Confirm"},
+	{Method: "POST", Template: "/users/password-reset/initiate", Operation: "Users.PasswordReset.Initiate"},
+	{Method: "GET", Template: "/users/me", Operation: "Users.Me.Get"},
+	{Method: "PUT", Template: "/users/me", Operation: "Users.Me.Update"},
+	{Method: "GET", Template: "/users/me/preferences", Operation: "Users.Me.Preferences.Get"},
+	{Method: "PUT", Template: "/users/me/preferences", Operation: "Users.Me.Preferences.Update"},
+	{Method: "GET", Template: "/users/me/devices", Operation: "Users.Me.Devices.List"},
+	{Method: "DELETE", Template: "/users/me/devices/{deviceId}", Operation: "Users.Me.Devices.Deregister"},
+	{Method: "POST", Template: "/users/me/devices", Operation: "Users.Me.Devices.Register"},
+	{Method: "DELETE", Template: "/users/me/biometrics", Operation: "Users.Me.Biometrics.Deregister"},
+	{Method: "POST", Template: "/users/me/biometrics/enroll", Operation: "Users.Me.Biometrics.Enroll"},
+	{Method: "GET", Template: "/users/me/biometrics", Operation: "Users.Me.Biometrics.Status"},
+	{Method: "POST", Template: "/users/me/biometrics/verify", Operation: "Users.Me.Biometrics.Verify"},
+	{Method: "POST", Template: "/accounts/link", Operation: "Accounts.Link"},
+	{Method: "GET", Template: "/accounts/{accountId}/details", Operation: "Accounts.GetDetails"},
+	{Method: "GET", Template: "/accounts/me", Operation: "Accounts.GetMe"},
+	{Method: "GET", Template: "/accounts/{accountId}/statements", Operation: "Accounts.GetStatements"},
+	{Method: "GET", Template: "/accounts/{accountId}/transactions/pending", Operation: "Accounts.Transactions.GetPending"},
+	{Method: "GET", Template: "/accounts/{accountId}/overdraft-settings", Operation: "Accounts.OverdraftSettings.GetOverdraftSettings"},
+	{Method: "PUT", Template: "/accounts/{accountId}/overdraft-settings", Operation: "Accounts.OverdraftSettings.UpdateOverdraftSettings"},
+	{Method: "GET", Template: "/transactions/{transactionId}", Operation: "Transactions.Get"},
+	{Method: "GET", Template: "/transactions", Operation: "Transactions.List"},
+	{Method: "PUT", Template: "/transactions/{transactionId}/categorize", Operation: "Transactions.Categorize"},
+	{Method: "POST", Template: "/transactions/{transactionId}/dispute", Operation: "Transactions.Dispute"},
+	{Method: "PUT", Template: "/transactions/{transactionId}/notes", Operation: "Transactions.UpdateNotes"},
+	{Method: "POST", Template: "/transactions/recurring", Operation: "Transactions.Recurring.New"},
+	{Method: "GET", Template: "/transactions/recurring", Operation: "Transactions.Recurring.List"},
+	{Method: "GET", Template: "/transactions/insights/spending-trends", Operation: "Transactions.Insights.GetSpendingTrends"},
+	{Method: "POST", Template: "/budgets", Operation: "Budgets.New"},
+	{Method: "GET", Template: "/budgets/{budgetId}", Operation: "Budgets.Get"},
+	{Method: "PUT", Template: "/budgets/{budgetId}", Operation: "Budgets.Update"},
+	{Method: "GET", Template: "/budgets", Operation: "Budgets.List"},
+	{Method: "DELETE", Template: "/budgets/{budgetId}", Operation: "Budgets.Delete"},
+	{Method: "POST", Template: "/investments/portfolios", Operation: "Investments.Portfolios.New"},
+	{Method: "GET", Template: "/investments/portfolios/{portfolioId}", Operation: "Investments.Portfolios.Get"},
+	{Method: "PUT", Template: "/investments/portfolios/{portfolioId}", Operation: "Investments.Portfolios.Update"},
+	{Method: "GET", Template: "/investments/portfolios", Operation: "Investments.Portfolios.List"},
+	{Method: "POST", Template: "/investments/portfolios/{portfolioId}/rebalance", Operation: "Investments.Portfolios.Rebalance"},
+	{Method: "GET", Template: "/investments/assets/search", Operation: "Investments.Assets.Search"},
+	{Method: "GET", Template: "/ai/advisor/tools", Operation: "AI.Advisor.ListTools"},
+	{Method: "GET", Template: "/ai/advisor/chat/history", Operation: "AI.Advisor.Chat.GetHistory"},
+	{Method: "POST", Template: "/ai/advisor/chat", Operation: "AI.Advisor.Chat.SendMessage"},
+	{Method: "POST", Template: "/ai/oracle/simulate/advanced", Operation: "AI.Oracle.Simulate.RunAdvanced"},
+	{Method: "POST", Template: "/ai/oracle/simulate", Operation: "AI.Oracle.Simulate.RunStandard"},
+	{Method: "GET", Template: "/ai/oracle/simulations/{simulationId}", Operation: "AI.Oracle.Simulations.Get"},
+	{Method: "GET", Template: "/ai/oracle/simulations", Operation: "AI.Oracle.Simulations.List"},
+	{Method: "DELETE", Template: "/ai/oracle/simulations/{simulationId}", Operation: "AI.Oracle.Simulations.Delete"},
+	{Method: "GET", Template: "/ai/incubator/pitches", Operation: "AI.Incubator.ListPitches"},
+	{Method: "GET", Template: "/ai/incubator/pitch/{pitchId}/details", Operation: "AI.Incubator.Pitch.GetDetails"},
+	{Method: "POST", Template: "/ai/incubator/pitch", Operation: "AI.Incubator.Pitch.Submit"},
+	{Method: "PUT", Template: "/ai/incubator/pitch/{pitchId}/feedback", Operation: "AI.Incubator.Pitch.SubmitFeedback"},
+	{Method: "GET", Template: "/ai/ads", Operation: "AI.Ads.ListGenerated"},
+	{Method: "GET", Template: "/ai/ads/operations/{operationId}", Operation: "AI.Ads.GetStatus"},
+	{Method: "POST", Template: "/ai/ads/generate/advanced", Operation: "AI.Ads.Generate.Advanced"},
+	{Method: "POST", Template: "/ai/ads/generate", Operation: "AI.Ads.Generate.Standard"},
+	{Method: "POST", Template: "/corporate/sanction-screening", Operation: "Corporate.PerformSanctionScreening"},
+	{Method: "GET", Template: "/corporate/cards", Operation: "Corporate.Cards.List"},
+	{Method: "POST", Template: "/corporate/cards/virtual", Operation: "Corporate.Cards.NewVirtual"},
+	{Method: "POST", Template: "/corporate/cards/{cardId}/freeze", Operation: "Corporate.Cards.Freeze"},
+	{Method: "GET", Template: "/corporate/cards/{cardId}/transactions", Operation: "Corporate.Cards.ListTransactions"},
+	{Method: "PUT", Template: "/corporate/cards/{cardId}/controls", Operation: "Corporate.Cards.UpdateControls"},
+	{Method: "GET", Template: "/corporate/anomalies", Operation: "Corporate.Anomalies.List"},
+	{Method: "PUT", Template: "/corporate/anomalies/{anomalyId}/status", Operation: "Corporate.Anomalies.UpdateStatus"},
+	{Method: "POST", Template: "/corporate/compliance/audits", Operation: "Corporate.Compliance.Audits.Request"},
+	{Method: "GET", Template: "/corporate/compliance/audits/{auditId}/report", Operation: "Corporate.Compliance.Audits.GetReport"},
+	{Method: "GET", Template: "/corporate/treasury/liquidity-positions", Operation: "Corporate.Treasury.GetLiquidityPositions"},
+	{Method: "GET", Template: "/corporate/treasury/cash-flow/forecast", Operation: "Corporate.Treasury.CashFlow.GetForecast"},
+	{Method: "POST", Template: "/corporate/risk/fraud/rules", Operation: "Corporate.Risk.Fraud.Rules.New"},
+	{Method: "PUT", Template: "/corporate/risk/fraud/rules/{ruleId}", Operation: "Corporate.Risk.Fraud.Rules.Update"},
+	{Method: "GET", Template: "/corporate/risk/fraud/rules", Operation: "Corporate.Risk.Fraud.Rules.List"},
+	{Method: "DELETE", Template: "/corporate/risk/fraud/rules/{ruleId}", Operation: "Corporate.Risk.Fraud.Rules.Delete"},
+	{Method: "GET", Template: "/web3/nfts", Operation: "Web3.GetNFTs"},
+	{Method: "GET", Template: "/web3/wallets", Operation: "Web3.Wallets.List"},
+	{Method: "POST", Template: "/web3/wallets", Operation: "Web3.Wallets.Connect"},
+	{Method: "GET", Template: "/web3/wallets/{walletId}/balances", Operation: "Web3.Wallets.GetBalances"},
+	{Method: "POST", Template: "/web3/transactions/initiate", Operation: "Web3.Transactions.InitiateTransfer"},
+	{Method: "POST", Template: "/payments/international/initiate", Operation: "Payments.International.Initiate"},
+	{Method: "GET", Template: "/payments/international/{paymentId}/status", Operation: "Payments.International.GetStatus"},
+	{Method: "POST", Template: "/payments/fx/convert", Operation: "Payments.Fx.Convert"},
+	{Method: "GET", Template: "/payments/fx/rates", Operation: "Payments.Fx.GetRates"},
+	{Method: "POST", Template: "/sustainability/carbon-offsets", Operation: "Sustainability.PurchaseCarbonOffsets"},
+	{Method: "GET", Template: "/sustainability/carbon-footprint", Operation: "Sustainability.GetCarbonFootprint"},
+	{Method: "GET", Template: "/sustainability/investments/impact", Operation: "Sustainability.Investments.AnalyzeImpact"},
+	{Method: "GET", Template: "/lending/applications/{applicationId}", Operation: "Lending.Applications.Get"},
+	{Method: "POST", Template: "/lending/applications", Operation: "Lending.Applications.Submit"},
+	{Method: "GET", Template: "/lending/offers/pre-approved", Operation: "Lending.Offers.ListPreApproved"},
+	{Method: "POST", Template: "/developers/webhooks", Operation: "Developers.Webhooks.New"},
+	{Method: "PUT", Template: "/developers/webhooks/{subscriptionId}", Operation: "Developers.Webhooks.Update"},
+	{Method: "GET", Template: "/developers/webhooks", Operation: "Developers.Webhooks.List"},
+	{Method: "DELETE", Template: "/developers/webhooks/{subscriptionId}", Operation: "Developers.Webhooks.Delete"},
+	{Method: "POST", Template: "/developers/api-keys", Operation: "Developers.APIKeys.New"},
+	{Method: "GET", Template: "/developers/api-keys", Operation: "Developers.APIKeys.List"},
+	{Method: "DELETE", Template: "/developers/api-keys/{keyId}", Operation: "Developers.APIKeys.Revoke"},
+	{Method: "GET", Template: "/identity/kyc/status", Operation: "Identity.KYC.GetStatus"},
+	{Method: "POST", Template: "/identity/kyc/submit", Operation: "Identity.KYC.Submit"},
+	{Method: "POST", Template: "/goals", Operation: "Goals.New"},
+	{Method: "GET", Template: "/goals/{goalId}", Operation: "Goals.Get"},
+	{Method: "PUT", Template: "/goals/{goalId}", Operation: "Goals.Update"},
+	{Method: "GET", Template: "/goals", Operation: "Goals.List"},
+	{Method: "DELETE", Template: "/goals/{goalId}", Operation: "Goals.Delete"},
+	{Method: "GET", Template: "/notifications/me", Operation: "Notifications.ListUserNotifications"},
+	{Method: "POST", Template: "/notifications/{notificationId}/mark-read", Operation: "Notifications.MarkAsRead"},
+	{Method: "GET", Template: "/notifications/settings", Operation: "Notifications.Settings.Get"},
+	{Method: "PUT", Template: "/notifications/settings", Operation: "Notifications.Settings.Update"},
+	{Method: "GET", Template: "/marketplace/products", Operation: "Marketplace.Products.List"},
+	{Method: "POST", Template: "/marketplace/products/{productId}/impact-simulate", Operation: "Marketplace.Products.SimulateImpact"},
+	{Method: "POST", Template: "/marketplace/offers/{offerId}/redeem", Operation: "Marketplace.Offers.Redeem"},
+}
@@ -0,0 +1,40 @@
+package apiroute
+
+import "testing"
+
+func TestLookup(t *testing.T) {
+	tests := map[string]struct {
+		method    string
+		path      string
+		operation string
+		template  string
+	}{
+		"literal":          {"get", "/transactions", "Transactions.List", "/transactions"},
+		"param":            {"GET", "/transactions/txn_123", "Transactions.Get", "/transactions/{transactionId}"},
+		"literal wins":     {"GET", "/transactions/recurring", "Transactions.Recurring.List", "/transactions/recurring"},
+		"method":           {"DELETE", "/budgets/budget_1", "Budgets.Delete", "/budgets/{budgetId}"},
+		"base url prefix":  {"GET", "/v1/accounts/acc_1/transactions/pending", "Accounts.Transactions.GetPending", "/accounts/{accountId}/transactions/pending"},
+		"trailing slash":   {"GET", "/goals/", "Goals.List", "/goals"},
+		"nested resources": {"PUT", "/corporate/risk/fraud/rules/rule_9", "Corporate.Risk.Fraud.Rules.Update", "/corporate/risk/fraud/rules/{ruleId}"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			route, ok := Lookup(test.method, test.path)
+			if !ok {
+				t.Fatalf("expected %s %s to match a route", test.method, test.path)
+			}
+			if route.Operation != test.operation || route.Template != test.template {
+				t.Errorf("expected %s %s, got %s %s", test.operation, test.template, route.Operation, route.Template)
+			}
+		})
+	}
+}
+
+func TestTemplateUnknownRoute(t *testing.T) {
+	if _, ok := Lookup("GET", "/unknown/abc123/things"); ok {
+		t.Fatal("expected no route to match")
+	}
+	if got := Template("GET", "/unknown/abc123/things"); got != "/unknown/{id}/things" {
+		t.Errorf("unexpected template %q", got)
+	}
+}
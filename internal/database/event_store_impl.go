@@ -1,16 +1,17 @@
-```go
 package database
 
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
-	"github.comcom/your-project-name/internal/domain/events" // Assuming events package is in internal/domain
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/jocall3/go/pkg/events"
 )
 
 const (
@@ -34,27 +35,47 @@ const (
 	`
 
 	selectEventsByAggregateIDSQL = `
-		SELECT event_id, aggregate_id, aggregate_type, event_type, payload, version, created_at
+		SELECT event_type, payload
 		FROM events
-		WHERE aggregate_id = $1
+		WHERE aggregate_id = $1 AND version > $2
 		ORDER BY version ASC;
 	`
+
+	selectRawEventsSQL = `
+		SELECT sequence, event_type, payload
+		FROM events
+		WHERE sequence > $1
+		ORDER BY sequence ASC;
+	`
 )
 
-// PostgresEventStore is a PostgreSQL-based implementation of the events.Store interface.
+// PostgresEventStore is a PostgreSQL-based event store for domain events.
 // It provides an append-only, atomic, and concurrency-safe way to store and retrieve domain events.
+// Event payloads are encoded with an events.Codec, normally an *events.Registry.
 type PostgresEventStore struct {
-	db *sql.DB
+	db    *sql.DB
+	codec events.Codec
 }
 
+// Compile-time checks that PostgresEventStore can be read by the events
+// package's loaders and tools.
+var (
+	_ events.AggregateStore = (*PostgresEventStore)(nil)
+	_ events.RawScanner     = (*PostgresEventStore)(nil)
+)
+
 // NewPostgresEventStore creates a new instance of PostgresEventStore.
-// It requires a connected *sql.DB instance.
-func NewPostgresEventStore(db *sql.DB) *PostgresEventStore {
+// It requires a connected *sql.DB instance and the codec for event payloads.
+func NewPostgresEventStore(db *sql.DB, codec events.Codec) *PostgresEventStore {
 	if db == nil {
 		panic("database connection cannot be nil")
 	}
+	if codec == nil {
+		panic("event codec cannot be nil")
+	}
 	return &PostgresEventStore{
-		db: db,
+		db:    db,
+		codec: codec,
 	}
 }
 
@@ -62,7 +83,8 @@ func NewPostgresEventStore(db *sql.DB) *PostgresEventStore {
 // It enforces optimistic concurrency control by checking the expectedVersion against
 // the aggregate's current version in the database. The entire operation is performed
 // within a single atomic transaction.
-// If the expected version does not match the stored version, it returns events.ErrConcurrency.
+// Each event's header is given its version, and an event ID and timestamp if it has none.
+// If the expected version does not match the stored version, it returns events.ErrConflict.
 func (s *PostgresEventStore) Save(
 	ctx context.Context,
 	aggregateID string,
@@ -88,7 +110,7 @@ func (s *PostgresEventStore) Save(
 
 	if currentVersion != expectedVersion {
 		return fmt.Errorf("concurrency error for aggregate %s: expected version %d, but got %d: %w",
-			aggregateID, expectedVersion, currentVersion, events.ErrConcurrency)
+			aggregateID, expectedVersion, currentVersion, events.ErrConflict)
 	}
 
 	// 2. Prepare the insert statement once.
@@ -102,23 +124,37 @@ func (s *PostgresEventStore) Save(
 	nextVersion := currentVersion + 1
 	for _, event := range newEvents {
 		// Ensure event metadata is consistent with the save request.
-		event.AggregateID = aggregateID
-		event.AggregateType = aggregateType
-		event.Version = nextVersion
-		event.CreatedAt = time.Now().UTC()
-		if event.ID == uuid.Nil {
-			event.ID = uuid.New()
+		header := event.Header()
+		header.Version = nextVersion
+		if header.EventID == uuid.Nil {
+			header.EventID = uuid.New()
+		}
+		if header.Timestamp.IsZero() {
+			header.Timestamp = time.Now().UTC()
+		}
+		eventType, payload, err := s.codec.Encode(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event %s for aggregate %s: %w", header.EventID, aggregateID, err)
+		}
+		row := storedEvent{
+			EventID:       header.EventID,
+			AggregateID:   aggregateID,
+			AggregateType: aggregateType,
+			EventType:     eventType,
+			Payload:       payload,
+			Version:       nextVersion,
+			CreatedAt:     time.Now().UTC(),
 		}
 
-		_, err := stmt.ExecContext(
+		_, err = stmt.ExecContext(
 			ctx,
-			event.ID,
-			event.AggregateID,
-			event.AggregateType,
-			event.EventType,
-			event.Payload,
-			event.Version,
-			event.CreatedAt,
+			row.EventID,
+			row.AggregateID,
+			string(row.AggregateType),
+			row.EventType,
+			row.Payload,
+			row.Version,
+			row.CreatedAt,
 		)
 
 		if err != nil {
@@ -127,10 +163,16 @@ func (s *PostgresEventStore) Save(
 			var pgErr *pgconn.PgError
 			if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
 				return fmt.Errorf("database concurrency conflict for aggregate %s at version %d: %w",
-					aggregateID, event.Version, events.ErrConcurrency)
+					aggregateID, row.Version, events.ErrConflict)
 			}
 			return fmt.Errorf("failed to execute event insert for aggregate %s: %w", aggregateID, err)
 		}
+
+		// Record the event in the outbox within the same transaction, so that it
+		// is published if and only if it is committed.
+		if err := writeOutbox(ctx, tx, row); err != nil {
+			return fmt.Errorf("failed to write event %s to the outbox for aggregate %s: %w", row.EventID, aggregateID, err)
+		}
 		nextVersion++
 	}
 
@@ -142,10 +184,17 @@ func (s *PostgresEventStore) Save(
 	return nil
 }
 
-// Load retrieves all events for a given aggregate ID, ordered by version.
+// Load retrieves all events for a given aggregate ID, ordered by version, decoded with the store's codec.
 // If no events are found for the aggregate, it returns an empty slice and no error.
 func (s *PostgresEventStore) Load(ctx context.Context, aggregateID string) ([]events.Event, error) {
-	rows, err := s.db.QueryContext(ctx, selectEventsByAggregateIDSQL, aggregateID)
+	return s.LoadByAggregate(ctx, aggregateID, 0)
+}
+
+// LoadByAggregate retrieves the events of an aggregate with a version greater than after, ordered by version.
+// It implements events.AggregateStore, so an events.AggregateLoader can restore aggregates saved here from
+// a snapshot and replay only the events since.
+func (s *PostgresEventStore) LoadByAggregate(ctx context.Context, aggregateID string, after events.Sequence) ([]events.Event, error) {
+	rows, err := s.db.QueryContext(ctx, selectEventsByAggregateIDSQL, aggregateID, int64(after))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query events for aggregate %s: %w", aggregateID, err)
 	}
@@ -153,18 +202,15 @@ func (s *PostgresEventStore) Load(ctx context.Context, aggregateID string) ([]ev
 
 	var loadedEvents []events.Event
 	for rows.Next() {
-		var event events.Event
-		if err := rows.Scan(
-			&event.ID,
-			&event.AggregateID,
-			&event.AggregateType,
-			&event.EventType,
-			&event.Payload,
-			&event.Version,
-			&event.CreatedAt,
-		); err != nil {
+		var eventType string
+		var payload []byte
+		if err := rows.Scan(&eventType, &payload); err != nil {
 			return nil, fmt.Errorf("failed to scan event row for aggregate %s: %w", aggregateID, err)
 		}
+		event, err := s.codec.Decode(eventType, payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s event for aggregate %s: %w", eventType, aggregateID, err)
+		}
 		loadedEvents = append(loadedEvents, event)
 	}
 
@@ -177,6 +223,73 @@ func (s *PostgresEventStore) Load(ctx context.Context, aggregateID string) ([]ev
 	return loadedEvents, nil
 }
 
+// ScanRaw calls fn with every event with a sequence greater than after, in sequence order, without decoding it,
+// stopping at the first error fn returns. It implements events.RawScanner, so events.Registry.Verify can check
+// the events stored here. The sequence is the one the events table numbers its rows with; it may have gaps.
+func (s *PostgresEventStore) ScanRaw(ctx context.Context, after events.Sequence, fn func(events.RawEvent) error) error {
+	rows, err := s.db.QueryContext(ctx, selectRawEventsSQL, int64(after))
+	if err != nil {
+		return fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sequence int64
+		var event events.RawEvent
+		if err := rows.Scan(&sequence, &event.Type, &event.Data); err != nil {
+			return fmt.Errorf("failed to scan event row: %w", err)
+		}
+		event.Sequence = events.Sequence(sequence)
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating event rows: %w", err)
+	}
+	return nil
+}
+
+// storedEvent is an event as it is written to the events table.
+type storedEvent struct {
+	EventID       uuid.UUID
+	AggregateID   string
+	AggregateType events.AggregateType
+	EventType     string
+	Payload       []byte
+	Version       int
+	CreatedAt     time.Time
+}
+
+// writeOutbox records event in the outbox as part of tx. The row carries the
+// complete message to publish, so the relay never needs to read the events
+// table.
+func writeOutbox(ctx context.Context, tx *sql.Tx, event storedEvent) error {
+	aggregateType := string(event.AggregateType)
+	message, err := json.Marshal(outboxMessage{
+		EventID:       event.EventID.String(),
+		AggregateID:   event.AggregateID,
+		AggregateType: aggregateType,
+		EventType:     event.EventType,
+		Version:       event.Version,
+		CreatedAt:     event.CreatedAt,
+		Payload:       json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode outbox message: %w", err)
+	}
+	_, err = tx.ExecContext(ctx, insertOutboxSQL,
+		event.EventID,
+		event.AggregateID,
+		aggregateType,
+		event.EventType,
+		OutboxSubject(aggregateType, event.EventType),
+		message,
+		event.CreatedAt,
+	)
+	return err
+}
+
 // getCurrentVersion fetches the latest version number for an aggregate within a transaction.
 // It uses the transaction to ensure a consistent read (read-committed isolation level is sufficient).
 // If no events exist for the aggregate, it returns 0.
@@ -193,5 +306,3 @@ func (s *PostgresEventStore) getCurrentVersion(ctx context.Context, tx *sql.Tx,
 	}
 	return version, nil
 }
-
-```
\ No newline at end of file
@@ -0,0 +1,184 @@
+//go:build postgres
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/jocall3/go/pkg/events"
+)
+
+func credit(accountID uuid.UUID, amount int64) *events.AccountCredited {
+	// Save assigns the version.
+	return events.NewAccountCredited(accountID, uuid.New(), 0, amount, 0, "deposit")
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		t.Fatalf("failed to count %s: %v", table, err)
+	}
+	return n
+}
+
+func TestPostgresEventStoreSaveWritesOutbox(t *testing.T) {
+	db, pool := newTestDB(t)
+	store := NewPostgresEventStore(db, events.DefaultRegistry())
+	ctx := context.Background()
+	account := uuid.New()
+	saved := []events.Event{credit(account, 100), credit(account, 50)}
+
+	if err := store.Save(ctx, account.String(), events.AccountAggregate, 0, saved); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := store.Load(ctx, account.String())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(loaded))
+	}
+	for i, e := range loaded {
+		if e.Header().EventID != saved[i].Header().EventID || e.Header().Version != i+1 || e.(*events.AccountCredited).Amount != saved[i].(*events.AccountCredited).Amount {
+			t.Errorf("Event %d did not round-trip: %+v", i, e)
+		}
+	}
+
+	// Every saved event has its outbox row, carrying the message to publish.
+	now := time.Now().UTC().Add(time.Second)
+	pub := &fakePublisher{}
+	if n, err := newTestRelay(pool, pub, &now).RelayOnce(ctx); err != nil || n != 2 {
+		t.Fatalf("Expected the 2 saved events to be published, got %d: %v", n, err)
+	}
+	for i, published := range pub.published {
+		var m outboxMessage
+		if err := json.Unmarshal([]byte(published), &m); err != nil {
+			t.Fatalf("Invalid outbox message %s: %v", published, err)
+		}
+		if m.EventID != saved[i].Header().EventID.String() || m.AggregateID != account.String() || m.AggregateType != "Account" ||
+			m.EventType != "AccountCredited" || m.Version != i+1 {
+			t.Errorf("Unexpected outbox message %d: %+v", i, m)
+		}
+		decoded, err := events.DefaultRegistry().Decode(m.EventType, m.Payload)
+		if err != nil || decoded.Header().EventID != saved[i].Header().EventID {
+			t.Errorf("Expected the payload to decode to the saved event, got %+v: %v", decoded, err)
+		}
+	}
+	var subject string
+	if err := db.QueryRow(`SELECT DISTINCT subject FROM event_outbox`).Scan(&subject); err != nil || subject != "events.account.AccountCredited" {
+		t.Errorf("Unexpected outbox subject %q: %v", subject, err)
+	}
+
+	// A stale writer is rejected, and writes nothing to either table.
+	if err := store.Save(ctx, account.String(), events.AccountAggregate, 1, []events.Event{credit(account, 1)}); !errors.Is(err, events.ErrConflict) {
+		t.Fatalf("Expected ErrConflict, got %v", err)
+	}
+	if n, m := countRows(t, db, "events"), countRows(t, db, "event_outbox"); n != 2 || m != 2 {
+		t.Errorf("Expected 2 events and 2 outbox rows after the conflict, got %d and %d", n, m)
+	}
+}
+
+func TestPostgresEventStoreSaveIsAtomicWithOutbox(t *testing.T) {
+	db, _ := newTestDB(t)
+	store := NewPostgresEventStore(db, events.DefaultRegistry())
+	ctx := context.Background()
+	account := uuid.New()
+
+	// The outbox already holds a row with the event ID of the second event,
+	// so writing that event's outbox row fails after both event rows and the
+	// first outbox row have been written.
+	batch := []events.Event{credit(account, 100), credit(account, 50)}
+	taken := batch[1].Header().EventID
+	_, err := db.Exec(insertOutboxSQL, taken, "other", "Account", "AccountCredited", "events.account.AccountCredited", []byte(`{}`), time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.Save(ctx, account.String(), events.AccountAggregate, 0, batch)
+	if err == nil || !strings.Contains(err.Error(), "event "+taken.String()+" to the outbox") {
+		t.Fatalf("Expected the outbox write of event %s to fail the save, got %v", taken, err)
+	}
+	if n := countRows(t, db, "events"); n != 0 {
+		t.Errorf("Expected the events to be rolled back with the outbox, got %d stored", n)
+	}
+	if n := countRows(t, db, "event_outbox"); n != 1 {
+		t.Errorf("Expected only the existing outbox row, got %d rows", n)
+	}
+	if loaded, err := store.Load(ctx, account.String()); err != nil || len(loaded) != 0 {
+		t.Errorf("Expected no events for the aggregate, got %d: %v", len(loaded), err)
+	}
+
+	// With the conflicting row gone, the same events save at the same version.
+	if _, err := db.Exec(`DELETE FROM event_outbox`); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, account.String(), events.AccountAggregate, 0, batch); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if n, m := countRows(t, db, "events"), countRows(t, db, "event_outbox"); n != 2 || m != 2 {
+		t.Errorf("Expected 2 events and 2 outbox rows, got %d and %d", n, m)
+	}
+}
+
+func TestPostgresEventStoreLoadsAggregatesFromSnapshots(t *testing.T) {
+	db, _ := newTestDB(t)
+	store := NewPostgresEventStore(db, events.DefaultRegistry())
+	ctx := context.Background()
+	account := uuid.New()
+	if err := store.Save(ctx, account.String(), events.AccountAggregate, 0,
+		[]events.Event{credit(account, 10), credit(account, 10), credit(account, 10), credit(account, 10)}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loader := events.NewAggregateLoader(store, events.NewMemorySnapshotStore(),
+		func() events.Aggregate { return &creditTotal{} }, events.SnapshotOptions{Every: 3})
+
+	if agg, version, err := loader.Load(ctx, account.String()); err != nil || version != 4 || agg.(*creditTotal).applied != 4 {
+		t.Fatalf("Expected the full history to be replayed to version 4, got %+v at %d: %v", agg, version, err)
+	}
+	if err := store.Save(ctx, account.String(), events.AccountAggregate, 4, []events.Event{credit(account, 5)}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	agg, version, err := loader.Load(ctx, account.String())
+	if err != nil || version != 5 || agg.(*creditTotal).Total != 45 || agg.(*creditTotal).applied != 1 {
+		t.Errorf("Expected one event to be replayed on the snapshot, got %+v at %d: %v", agg, version, err)
+	}
+}
+
+func TestPostgresEventStoreScanRaw(t *testing.T) {
+	db, _ := newTestDB(t)
+	store := NewPostgresEventStore(db, events.DefaultRegistry())
+	ctx := context.Background()
+	first, second := uuid.New(), uuid.New()
+	for _, account := range []uuid.UUID{first, second} {
+		if err := store.Save(ctx, account.String(), events.AccountAggregate, 0, []events.Event{credit(account, 10), credit(account, 5)}); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+
+	var scanned []events.Sequence
+	if err := store.ScanRaw(ctx, 0, func(e events.RawEvent) error {
+		scanned = append(scanned, e.Sequence)
+		return nil
+	}); err != nil || len(scanned) != 4 {
+		t.Fatalf("Expected 4 events to be scanned, got %v: %v", scanned, err)
+	}
+	for i := 1; i < len(scanned); i++ {
+		if scanned[i] <= scanned[i-1] {
+			t.Fatalf("Expected increasing sequences, got %v", scanned)
+		}
+	}
+
+	report, err := events.DefaultRegistry().Verify(ctx, store, scanned[1])
+	if err != nil || report.Checked != 2 || !report.OK() {
+		t.Errorf("Expected the last 2 events to verify, got %+v: %v", report, err)
+	}
+}
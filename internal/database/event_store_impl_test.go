@@ -0,0 +1,210 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/jocall3/go/pkg/events"
+)
+
+// fakeEventsDB is a database/sql connector holding an in-memory events table,
+// numbered from 1 in the order the events were added. It understands only the
+// queries that read events, and records the positions each read started after.
+type fakeEventsDB struct {
+	mu     sync.Mutex
+	rows   []fakeEventRow
+	afters []int64
+}
+
+type fakeEventRow struct {
+	aggregateID string
+	eventType   string
+	payload     []byte
+	version     int64
+}
+
+// add stores event as the next version of its aggregate, as Save would.
+func (db *fakeEventsDB) add(t *testing.T, event events.Event) {
+	t.Helper()
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	header := event.Header()
+	aggregateID := header.AggregateID.String()
+	header.Version = 1
+	for _, r := range db.rows {
+		if r.aggregateID == aggregateID {
+			header.Version++
+		}
+	}
+	eventType, payload, err := events.DefaultRegistry().Encode(event)
+	if err != nil {
+		t.Fatalf("failed to encode event: %v", err)
+	}
+	db.rows = append(db.rows, fakeEventRow{aggregateID, eventType, payload, int64(header.Version)})
+}
+
+func (db *fakeEventsDB) Connect(ctx context.Context) (driver.Conn, error) {
+	return fakeEventsConn{db}, nil
+}
+func (db *fakeEventsDB) Driver() driver.Driver { return nil }
+
+// fakeEventsConn is a connection to a fakeEventsDB. It answers queries
+// directly, so it never prepares statements or begins transactions.
+type fakeEventsConn struct {
+	db *fakeEventsDB
+}
+
+func (c fakeEventsConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("unexpected prepare of %q", query)
+}
+func (c fakeEventsConn) Close() error { return nil }
+func (c fakeEventsConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("unexpected transaction")
+}
+
+func (c fakeEventsConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	out := &fakeEventRows{}
+	switch query {
+	case selectEventsByAggregateIDSQL:
+		aggregateID, after := args[0].Value.(string), args[1].Value.(int64)
+		c.db.afters = append(c.db.afters, after)
+		out.columns = []string{"event_type", "payload"}
+		for _, r := range c.db.rows {
+			if r.aggregateID == aggregateID && r.version > after {
+				out.rows = append(out.rows, []driver.Value{r.eventType, r.payload})
+			}
+		}
+	case selectRawEventsSQL:
+		after := args[0].Value.(int64)
+		c.db.afters = append(c.db.afters, after)
+		out.columns = []string{"sequence", "event_type", "payload"}
+		for i, r := range c.db.rows {
+			if sequence := int64(i + 1); sequence > after {
+				out.rows = append(out.rows, []driver.Value{sequence, r.eventType, r.payload})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unexpected query %q", query)
+	}
+	return out, nil
+}
+
+type fakeEventRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeEventRows) Columns() []string { return r.columns }
+func (r *fakeEventRows) Close() error      { return nil }
+
+func (r *fakeEventRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+// creditTotal is an events.Aggregate summing the credits of an account. It
+// counts the events applied to it, to tell how much of the history a load
+// replayed.
+type creditTotal struct {
+	Total   int64
+	applied int
+}
+
+func (c *creditTotal) Apply(event events.Event) error {
+	c.Total += event.(*events.AccountCredited).Amount
+	c.applied++
+	return nil
+}
+
+func (c *creditTotal) MarshalSnapshot() ([]byte, error) { return []byte(fmt.Sprint(c.Total)), nil }
+
+func (c *creditTotal) UnmarshalSnapshot(data []byte) error {
+	_, err := fmt.Sscan(string(data), &c.Total)
+	return err
+}
+
+func TestEventStoreLoadsAggregatesFromSnapshots(t *testing.T) {
+	fake := &fakeEventsDB{}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+	store := NewPostgresEventStore(db, events.DefaultRegistry())
+	account := uuid.New()
+	for i := 0; i < 4; i++ {
+		fake.add(t, events.NewAccountCredited(account, uuid.New(), 0, 10, 0, "deposit"))
+	}
+	loader := events.NewAggregateLoader(store, events.NewMemorySnapshotStore(),
+		func() events.Aggregate { return &creditTotal{} }, events.SnapshotOptions{Every: 3})
+	ctx := context.Background()
+
+	// The first load replays the full history and takes a snapshot.
+	agg, version, err := loader.Load(ctx, account.String())
+	if err != nil || version != 4 || agg.(*creditTotal).Total != 40 || agg.(*creditTotal).applied != 4 {
+		t.Fatalf("Expected the full history to be replayed to version 4, got %+v at %d: %v", agg, version, err)
+	}
+
+	// The next load restores the snapshot and reads only the events after it.
+	fake.add(t, events.NewAccountCredited(account, uuid.New(), 0, 5, 0, "deposit"))
+	agg, version, err = loader.Load(ctx, account.String())
+	if err != nil || version != 5 || agg.(*creditTotal).Total != 45 || agg.(*creditTotal).applied != 1 {
+		t.Fatalf("Expected one event to be replayed on the snapshot, got %+v at %d: %v", agg, version, err)
+	}
+	if fmt.Sprint(fake.afters) != "[0 4]" {
+		t.Errorf("Expected the loads to start after versions [0 4], got %v", fake.afters)
+	}
+
+	// Load still returns the full history.
+	all, err := store.Load(ctx, account.String())
+	if err != nil || len(all) != 5 {
+		t.Errorf("Expected Load to return all 5 events, got %d: %v", len(all), err)
+	}
+}
+
+func TestEventStoreScanRaw(t *testing.T) {
+	fake := &fakeEventsDB{}
+	db := sql.OpenDB(fake)
+	defer db.Close()
+	store := NewPostgresEventStore(db, events.DefaultRegistry())
+	first, second := uuid.New(), uuid.New()
+	for _, account := range []uuid.UUID{first, second, first} {
+		fake.add(t, events.NewAccountCredited(account, uuid.New(), 0, 10, 0, "deposit"))
+	}
+
+	var scanned []events.Sequence
+	err := store.ScanRaw(context.Background(), 1, func(e events.RawEvent) error {
+		if e.Type != "AccountCredited" || len(e.Data) == 0 {
+			t.Errorf("Unexpected raw event %+v", e)
+		}
+		scanned = append(scanned, e.Sequence)
+		return nil
+	})
+	if err != nil || fmt.Sprint(scanned) != "[2 3]" {
+		t.Fatalf("Expected the events after sequence 1, got %v: %v", scanned, err)
+	}
+
+	// Verify reads the store like any other log.
+	report, err := events.DefaultRegistry().Verify(context.Background(), store, 0)
+	if err != nil || report.Checked != 3 || !report.OK() {
+		t.Errorf("Expected the 3 events to verify, got %+v: %v", report, err)
+	}
+
+	stop := errors.New("stop")
+	calls := 0
+	err = store.ScanRaw(context.Background(), 0, func(events.RawEvent) error { calls++; return stop })
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Expected the scan to stop at the first error, got %d calls: %v", calls, err)
+	}
+}
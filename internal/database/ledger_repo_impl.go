@@ -1,4 +1,8 @@
-```go
+//go:build ignore
+
+// The ledger repository expects a ledger domain package that is not part of
+// this module, so it is left out of the build until that package exists.
+
 package database
 
 import (
@@ -285,5 +289,3 @@ func (r *LedgerRepositoryImpl) getTransactionsBetween(ctx context.Context, ledge
 
 	return transactions, nil
 }
-
-```
\ No newline at end of file
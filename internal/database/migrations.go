@@ -1,4 +1,3 @@
-```go
 package database
 
 import (
@@ -130,9 +129,97 @@ var allMigrations = Migrations{
 			return nil
 		},
 	},
+	{
+		Version:     2,
+		Description: "Create event_outbox table for the transactional outbox",
+		Up: func(tx *sql.Tx) error {
+			// event_outbox: events saved by the event store and waiting to be
+			// published by the outbox relay. message holds the exact bytes to
+			// publish. The partial indexes keep the relay's queries on the
+			// unpublished rows, and cleanup on the published ones.
+			_, err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS event_outbox (
+					id BIGSERIAL PRIMARY KEY,
+					event_id UUID NOT NULL UNIQUE,
+					aggregate_id TEXT NOT NULL,
+					aggregate_type TEXT NOT NULL,
+					event_type TEXT NOT NULL,
+					subject TEXT NOT NULL,
+					message BYTEA NOT NULL,
+					created_at TIMESTAMPTZ NOT NULL,
+					attempts INT NOT NULL DEFAULT 0,
+					next_attempt_at TIMESTAMPTZ NOT NULL,
+					last_error TEXT,
+					published_at TIMESTAMPTZ
+				);
+				CREATE INDEX IF NOT EXISTS idx_event_outbox_pending ON event_outbox(aggregate_id, id) WHERE published_at IS NULL;
+				CREATE INDEX IF NOT EXISTS idx_event_outbox_published ON event_outbox(published_at) WHERE published_at IS NOT NULL;
+			`)
+			if err != nil {
+				return fmt.Errorf("failed to create event_outbox table: %w", err)
+			}
+			return nil
+		},
+		Down: func(tx *sql.Tx) error {
+			_, err := tx.Exec(`DROP TABLE IF EXISTS event_outbox;`)
+			return err
+		},
+	},
+	{
+		Version:     3,
+		Description: "Create events table for the event store",
+		Up: func(tx *sql.Tx) error {
+			// events: the append-only log of domain events written by
+			// PostgresEventStore. The unique (aggregate_id, version) constraint
+			// is the database-level guard against two writers appending the
+			// same version of an aggregate.
+			_, err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS events (
+					event_id UUID PRIMARY KEY,
+					aggregate_id TEXT NOT NULL,
+					aggregate_type TEXT NOT NULL,
+					event_type TEXT NOT NULL,
+					payload JSONB NOT NULL,
+					version INT NOT NULL,
+					created_at TIMESTAMPTZ NOT NULL,
+					CONSTRAINT uq_events_aggregate_version UNIQUE (aggregate_id, version)
+				);
+			`)
+			if err != nil {
+				return fmt.Errorf("failed to create events table: %w", err)
+			}
+			return nil
+		},
+		Down: func(tx *sql.Tx) error {
+			_, err := tx.Exec(`DROP TABLE IF EXISTS events;`)
+			return err
+		},
+	},
+	{
+		Version:     4,
+		Description: "Number the events in the order they were stored",
+		Up: func(tx *sql.Tx) error {
+			// sequence orders the whole events table for readers that scan it
+			// rather than one aggregate, such as cmd/verify-events. Numbers are
+			// unique and increasing but may have gaps, and existing rows are
+			// numbered in no particular order.
+			_, err := tx.Exec(`
+				ALTER TABLE events ADD COLUMN IF NOT EXISTS sequence BIGSERIAL;
+				CREATE UNIQUE INDEX IF NOT EXISTS idx_events_sequence ON events (sequence);
+			`)
+			if err != nil {
+				return fmt.Errorf("failed to add the events sequence: %w", err)
+			}
+			return nil
+		},
+		Down: func(tx *sql.Tx) error {
+			_, err := tx.Exec(`ALTER TABLE events DROP COLUMN IF EXISTS sequence;`)
+			return err
+		},
+	},
 	// --- ADD NEW MIGRATIONS HERE ---
 	// {
-	// 	Version:     2,
+	// 	Version:     5,
 	// 	Description: "Add users table and link to accounts",
 	// 	Up: func(tx *sql.Tx) error { ... },
 	// 	Down: func(tx *sql.Tx) error { ... },
@@ -235,5 +322,3 @@ func ApplyMigrations(db *sql.DB) error {
 	log.Println("Database migrations finished successfully.")
 	return nil
 }
-
-```
\ No newline at end of file
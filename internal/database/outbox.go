@@ -0,0 +1,393 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// The transactional outbox.
+//
+// PostgresEventStore.Save writes every event it persists to the event_outbox
+// table in the same transaction as the event itself. An event is therefore in
+// the outbox if and only if it was committed, and a crash between the commit
+// and the publish can no longer lose a notification: the OutboxRelay finds
+// the row on its next pass and publishes it. Delivery is at-least-once; a
+// relay that crashes or loses its database connection after publishing but
+// before recording it publishes the event again, so consumers must
+// deduplicate by event ID.
+
+// SQL queries for the outbox.
+const (
+	insertOutboxSQL = `
+		INSERT INTO event_outbox (event_id, aggregate_id, aggregate_type, event_type, subject, message, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7);
+	`
+
+	// tryOutboxLockSQL takes a transaction-scoped advisory lock, so that only
+	// one relay claims rows at a time. Two relays claiming concurrently could
+	// both claim the same rows.
+	tryOutboxLockSQL = `SELECT pg_try_advisory_xact_lock($1);`
+
+	// selectOutboxBatchSQL returns the oldest unpublished rows that may be
+	// published now. A row is held back while any earlier unpublished row of
+	// the same aggregate is claimed or waiting out a backoff, which keeps each
+	// aggregate's events in order without stalling the others.
+	selectOutboxBatchSQL = `
+		SELECT o.id, o.event_id, o.aggregate_id, o.subject, o.message, o.attempts
+		FROM event_outbox o
+		WHERE o.published_at IS NULL
+		  AND NOT EXISTS (
+			SELECT 1 FROM event_outbox b
+			WHERE b.aggregate_id = o.aggregate_id
+			  AND b.published_at IS NULL
+			  AND b.id <= o.id
+			  AND b.next_attempt_at > $1
+		  )
+		ORDER BY o.id ASC
+		LIMIT $2;
+	`
+
+	// claimOutboxSQL claims rows for a relay by moving their next attempt to
+	// the end of the claim, which selectOutboxBatchSQL then treats like a
+	// backoff.
+	claimOutboxSQL = `
+		UPDATE event_outbox SET next_attempt_at = $2 WHERE id = ANY($1);
+	`
+
+	// releaseOutboxSQL hands back the unpublished rows of a claim that is
+	// still held, so they can be published straight away.
+	releaseOutboxSQL = `
+		UPDATE event_outbox SET next_attempt_at = $2
+		WHERE id = ANY($1) AND published_at IS NULL AND next_attempt_at = $3;
+	`
+
+	markOutboxPublishedSQL = `
+		UPDATE event_outbox SET published_at = $2, last_error = NULL WHERE id = $1;
+	`
+
+	markOutboxFailedSQL = `
+		UPDATE event_outbox SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3 WHERE id = $1;
+	`
+
+	deletePublishedOutboxSQL = `
+		DELETE FROM event_outbox WHERE published_at IS NOT NULL AND published_at < $1;
+	`
+)
+
+// outboxMessage is the message published for an event.
+type outboxMessage struct {
+	EventID       string          `json:"eventId"`
+	AggregateID   string          `json:"aggregateId"`
+	AggregateType string          `json:"aggregateType"`
+	EventType     string          `json:"eventType"`
+	Version       int             `json:"version"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// OutboxSubject returns the subject an event is published on, such as
+// "events.account.AccountCredited".
+func OutboxSubject(aggregateType, eventType string) string {
+	return "events." + strings.ToLower(aggregateType) + "." + eventType
+}
+
+// OutboxRelayConfig configures an OutboxRelay. Zero fields take the defaults
+// noted on them.
+type OutboxRelayConfig struct {
+	// BatchSize is the most rows published in one pass. Defaults to 100.
+	BatchSize int
+	// PollInterval is how long the relay waits after a pass that found
+	// nothing to publish. Defaults to 1s.
+	PollInterval time.Duration
+	// BackoffMin and BackoffMax bound the exponential backoff after a failed
+	// publish of a row. They default to 1s and 5m.
+	BackoffMin time.Duration
+	BackoffMax time.Duration
+	// Retention is how long published rows are kept before they are deleted.
+	// Defaults to 24h.
+	Retention time.Duration
+	// CleanupInterval is how often published rows are deleted. Defaults to
+	// 10m.
+	CleanupInterval time.Duration
+	// ClaimTimeout is how long the rows taken by a pass are reserved for
+	// it. A row the relay has not published by then, because it crashed or
+	// is still busy, is published again by the next pass, so the timeout
+	// must comfortably exceed the time it takes to publish a batch.
+	// Defaults to 1m.
+	ClaimTimeout time.Duration
+	// LockKey is the advisory lock key that elects the active relay. Relays
+	// sharing an outbox table must use the same key.
+	LockKey int64
+}
+
+// DefaultOutboxLockKey is the advisory lock key used when none is configured.
+const DefaultOutboxLockKey int64 = 0x6f7574626f78 // "outbox"
+
+func (c OutboxRelayConfig) withDefaults() OutboxRelayConfig {
+	if c.BatchSize <= 0 {
+		c.BatchSize = 100
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.BackoffMin <= 0 {
+		c.BackoffMin = time.Second
+	}
+	if c.BackoffMax < c.BackoffMin {
+		c.BackoffMax = 5 * time.Minute
+		if c.BackoffMax < c.BackoffMin {
+			c.BackoffMax = c.BackoffMin
+		}
+	}
+	if c.Retention <= 0 {
+		c.Retention = 24 * time.Hour
+	}
+	if c.CleanupInterval <= 0 {
+		c.CleanupInterval = 10 * time.Minute
+	}
+	if c.ClaimTimeout <= 0 {
+		c.ClaimTimeout = time.Minute
+	}
+	if c.LockKey == 0 {
+		c.LockKey = DefaultOutboxLockKey
+	}
+	return c
+}
+
+// OutboxDB is the database access an OutboxRelay needs. It is satisfied by
+// *pgxpool.Pool.
+type OutboxDB interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// OutboxPublisher sends the messages relayed from the outbox to the message
+// bus.
+type OutboxPublisher interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+}
+
+// OutboxRelay publishes the events in the outbox through an OutboxPublisher.
+//
+// Each pass claims the oldest publishable rows in a short transaction that
+// holds an advisory lock, publishes them in order outside any transaction and
+// marks each one published as it goes. Any number of relays may run: a claim
+// reserves its rows for the claim timeout, and the later events of a claimed
+// row's aggregate wait for it, so each aggregate's events are published in the
+// order they were saved. A row whose publish fails is retried with
+// exponential backoff, and its aggregate waits for it in the same way.
+// Published rows are deleted once they are older than the retention period.
+type OutboxRelay struct {
+	db        OutboxDB
+	publisher OutboxPublisher
+	cfg       OutboxRelayConfig
+	logger    *slog.Logger
+	now       func() time.Time
+}
+
+// NewOutboxRelay creates a relay that reads the outbox through db and
+// publishes through publisher.
+func NewOutboxRelay(db OutboxDB, publisher OutboxPublisher, cfg OutboxRelayConfig, logger *slog.Logger) *OutboxRelay {
+	if db == nil || publisher == nil {
+		panic("outbox relay requires a database and a publisher")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &OutboxRelay{
+		db:        db,
+		publisher: publisher,
+		cfg:       cfg.withDefaults(),
+		logger:    logger,
+		now:       func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Run relays the outbox until ctx is done, which it returns as nil. Failed
+// passes are logged and retried after the poll interval; they never stop the
+// relay, as the rows stay in the outbox until they are published.
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	r.logger.Info("Outbox relay started", "batch_size", r.cfg.BatchSize, "poll_interval", r.cfg.PollInterval.String())
+	nextCleanup := r.now()
+	for {
+		if now := r.now(); !now.Before(nextCleanup) {
+			if deleted, err := r.Cleanup(ctx); err != nil {
+				r.logger.Warn("Failed to clean up the outbox", "error", err)
+			} else if deleted > 0 {
+				r.logger.Info("Cleaned up published outbox rows", "deleted", deleted)
+			}
+			nextCleanup = now.Add(r.cfg.CleanupInterval)
+		}
+
+		published, err := r.RelayOnce(ctx)
+		if ctx.Err() != nil {
+			r.logger.Info("Outbox relay stopped")
+			return nil
+		}
+		if err != nil {
+			r.logger.Error("Outbox relay pass failed", "error", err)
+		}
+		// A full batch suggests more rows are waiting; go straight on.
+		if err == nil && published >= r.cfg.BatchSize {
+			continue
+		}
+
+		select {
+		case <-time.After(r.cfg.PollInterval):
+		case <-ctx.Done():
+			r.logger.Info("Outbox relay stopped")
+			return nil
+		}
+	}
+}
+
+// outboxRow is an unpublished outbox row.
+type outboxRow struct {
+	id          int64
+	eventID     string
+	aggregateID string
+	subject     string
+	message     []byte
+	attempts    int
+}
+
+// RelayOnce makes one pass over the outbox and returns the number of events
+// it published. It publishes nothing and returns 0 if another relay holds the
+// lock.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	now := r.now()
+	// Postgres keeps microseconds; the release matches the claim exactly.
+	claimedUntil := now.Add(r.cfg.ClaimTimeout).Truncate(time.Microsecond)
+	batch, err := r.claim(ctx, now, claimedUntil)
+	if err != nil || len(batch) == 0 {
+		return 0, err
+	}
+
+	// Rows are marked even if ctx is done, so that what was published is not
+	// published again.
+	markCtx := context.WithoutCancel(ctx)
+	// Once a publish fails, the rest of that aggregate's rows wait for it.
+	blocked := make(map[string]bool)
+	var release []int64
+	var passErr error
+	published := 0
+	for _, row := range batch {
+		if passErr != nil || ctx.Err() != nil || blocked[row.aggregateID] {
+			release = append(release, row.id)
+			continue
+		}
+		if err := r.publisher.Publish(ctx, row.subject, row.message); err != nil {
+			if ctx.Err() != nil {
+				release = append(release, row.id)
+				continue
+			}
+			blocked[row.aggregateID] = true
+			delay := r.backoff(row.attempts + 1)
+			r.logger.Warn("Failed to publish outbox event, will retry",
+				"event_id", row.eventID, "aggregate_id", row.aggregateID, "attempt", row.attempts+1,
+				"retry_in", delay.String(), "error", err)
+			if _, err := r.db.Exec(markCtx, markOutboxFailedSQL, row.id, now.Add(delay), err.Error()); err != nil {
+				passErr = fmt.Errorf("failed to record outbox publish failure for event %s: %w", row.eventID, err)
+				release = append(release, row.id)
+			}
+			continue
+		}
+		if _, err := r.db.Exec(markCtx, markOutboxPublishedSQL, row.id, r.now()); err != nil {
+			passErr = fmt.Errorf("failed to mark outbox event %s published: %w", row.eventID, err)
+			release = append(release, row.id)
+			continue
+		}
+		published++
+	}
+
+	if len(release) > 0 {
+		if _, err := r.db.Exec(markCtx, releaseOutboxSQL, release, now, claimedUntil); err != nil && passErr == nil {
+			passErr = fmt.Errorf("failed to release claimed outbox rows: %w", err)
+		}
+	}
+	if passErr != nil {
+		return published, passErr
+	}
+	return published, ctx.Err()
+}
+
+// claim takes the outbox lock and claims the next batch of rows until
+// claimedUntil. It claims nothing if another relay holds the lock.
+func (r *OutboxRelay) claim(ctx context.Context, now, claimedUntil time.Time) ([]outboxRow, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin outbox transaction: %w", err)
+	}
+	// Rollback is a no-op if the transaction is committed.
+	defer tx.Rollback(context.WithoutCancel(ctx))
+
+	var locked bool
+	if err := tx.QueryRow(ctx, tryOutboxLockSQL, r.cfg.LockKey).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("failed to take the outbox lock: %w", err)
+	}
+	if !locked {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, selectOutboxBatchSQL, now, r.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the outbox: %w", err)
+	}
+	var batch []outboxRow
+	var ids []int64
+	for rows.Next() {
+		var row outboxRow
+		if err := rows.Scan(&row.id, &row.eventID, &row.aggregateID, &row.subject, &row.message, &row.attempts); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan outbox row: %w", err)
+		}
+		batch = append(batch, row)
+		ids = append(ids, row.id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox rows: %w", err)
+	}
+	if len(batch) == 0 {
+		return nil, nil
+	}
+
+	if _, err := tx.Exec(ctx, claimOutboxSQL, ids, claimedUntil); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox rows: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit outbox claim: %w", err)
+	}
+	return batch, nil
+}
+
+// backoff returns the delay before the given attempt at publishing a row.
+func (r *OutboxRelay) backoff(attempt int) time.Duration {
+	delay := r.cfg.BackoffMin
+	for i := 1; i < attempt && delay < r.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > r.cfg.BackoffMax {
+		delay = r.cfg.BackoffMax
+	}
+	return delay
+}
+
+// Cleanup deletes the rows published longer ago than the retention period and
+// returns how many it deleted.
+func (r *OutboxRelay) Cleanup(ctx context.Context) (int64, error) {
+	tag, err := r.db.Exec(ctx, deletePublishedOutboxSQL, r.now().Add(-r.cfg.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete published outbox rows: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
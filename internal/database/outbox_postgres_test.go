@@ -0,0 +1,261 @@
+//go:build postgres
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// failingMarkDB is an OutboxDB that fails to mark rows published while fail
+// is set.
+type failingMarkDB struct {
+	OutboxDB
+	fail bool
+}
+
+func (db *failingMarkDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if db.fail && sql == markOutboxPublishedSQL {
+		return pgconn.CommandTag{}, errors.New("connection reset")
+	}
+	return db.OutboxDB.Exec(ctx, sql, args...)
+}
+
+// addOutboxRow inserts an outbox row for aggregateID, publishable from at,
+// with the real insert query, and returns the message it carries.
+func addOutboxRow(t *testing.T, pool *pgxpool.Pool, aggregateID string, at time.Time) string {
+	t.Helper()
+	eventID := uuid.New()
+	message := fmt.Sprintf(`{"eventId":%q}`, eventID)
+	_, err := pool.Exec(context.Background(), insertOutboxSQL,
+		eventID, aggregateID, "Account", "AccountCredited", OutboxSubject("Account", "AccountCredited"), []byte(message), at)
+	if err != nil {
+		t.Fatalf("failed to insert outbox row: %v", err)
+	}
+	return message
+}
+
+// outboxState is the delivery state of an outbox row.
+type outboxState struct {
+	attempts    int
+	nextAttempt time.Time
+	lastError   *string
+	publishedAt *time.Time
+}
+
+func rowState(t *testing.T, pool *pgxpool.Pool, message string) outboxState {
+	t.Helper()
+	var s outboxState
+	err := pool.QueryRow(context.Background(),
+		`SELECT attempts, next_attempt_at, last_error, published_at FROM event_outbox WHERE message = $1`, []byte(message)).
+		Scan(&s.attempts, &s.nextAttempt, &s.lastError, &s.publishedAt)
+	if err != nil {
+		t.Fatalf("failed to read outbox row: %v", err)
+	}
+	return s
+}
+
+func TestPostgresOutboxRelayPublishesInOrder(t *testing.T) {
+	_, pool := newTestDB(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var want []string
+	for _, agg := range []string{"acc-a", "acc-b", "acc-a", "acc-b"} {
+		want = append(want, addOutboxRow(t, pool, agg, now))
+	}
+	// A row whose first attempt is not due yet is left alone.
+	addOutboxRow(t, pool, "acc-c", now.Add(time.Minute))
+	pub := &fakePublisher{}
+	relay := newTestRelay(pool, pub, &now)
+	relay.cfg.BatchSize = 3
+	ctx := context.Background()
+
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 3 {
+		t.Fatalf("Expected a full batch of 3 events to be published, got %d: %v", n, err)
+	}
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("Expected the last event to be published, got %d: %v", n, err)
+	}
+	if fmt.Sprint(pub.published) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, pub.published)
+	}
+	if s := rowState(t, pool, want[0]); s.publishedAt == nil || !s.publishedAt.Equal(now) || s.attempts != 0 {
+		t.Errorf("Expected the row to be marked published, got %+v", s)
+	}
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 0 {
+		t.Errorf("Expected nothing left to publish, got %d: %v", n, err)
+	}
+}
+
+func TestPostgresOutboxRelayBacksOffAndKeepsAggregateOrder(t *testing.T) {
+	_, pool := newTestDB(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	first := addOutboxRow(t, pool, "acc-a", now)
+	other := addOutboxRow(t, pool, "acc-b", now)
+	second := addOutboxRow(t, pool, "acc-a", now)
+	failures := 2
+	pub := &fakePublisher{fail: func(message string) error {
+		if message == first && failures > 0 {
+			failures--
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}}
+	relay := newTestRelay(pool, pub, &now)
+	ctx := context.Background()
+
+	// The first event of acc-a fails, so its second event waits; acc-b is
+	// not held up.
+	if n, _ := relay.RelayOnce(ctx); n != 1 || fmt.Sprint(pub.published) != fmt.Sprint([]string{other}) {
+		t.Fatalf("Expected only acc-b's event to be published, got %v", pub.published)
+	}
+	if s := rowState(t, pool, first); s.attempts != 1 || !s.nextAttempt.Equal(now.Add(time.Second)) ||
+		s.lastError == nil || *s.lastError != "broker unavailable" || s.publishedAt != nil {
+		t.Errorf("Expected a 1s backoff after the first failure, got %+v", s)
+	}
+	// The query itself holds back the rest of acc-a during the backoff.
+	if n, _ := relay.RelayOnce(ctx); n != 0 {
+		t.Errorf("Expected nothing to be published during the backoff, got %d", n)
+	}
+	if s := rowState(t, pool, second); s.attempts != 0 || s.publishedAt != nil {
+		t.Errorf("Expected acc-a's second event to be left untouched, got %+v", s)
+	}
+
+	// The second failure doubles the backoff.
+	now = now.Add(time.Second)
+	relay.RelayOnce(ctx)
+	if s := rowState(t, pool, first); s.attempts != 2 || !s.nextAttempt.Equal(now.Add(2*time.Second)) {
+		t.Errorf("Expected a 2s backoff after the second failure, got %+v", s)
+	}
+
+	now = now.Add(2 * time.Second)
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 2 {
+		t.Fatalf("Expected acc-a's events to be published, got %d: %v", n, err)
+	}
+	want := []string{other, first, second}
+	if fmt.Sprint(pub.published) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, pub.published)
+	}
+	if s := rowState(t, pool, first); s.publishedAt == nil || s.lastError != nil {
+		t.Errorf("Expected the error to be cleared once published, got %+v", s)
+	}
+}
+
+func TestPostgresOutboxRelayRepublishesWhenMarkingFails(t *testing.T) {
+	_, pool := newTestDB(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	first := addOutboxRow(t, pool, "acc-a", now)
+	second := addOutboxRow(t, pool, "acc-b", now)
+	db := &failingMarkDB{OutboxDB: pool, fail: true}
+	pub := &fakePublisher{}
+	relay := newTestRelay(db, pub, &now)
+
+	if _, err := relay.RelayOnce(context.Background()); err == nil {
+		t.Fatal("Expected the failed mark to be reported")
+	}
+	// The rest of the claim is handed back, not left to time out.
+	for _, message := range []string{first, second} {
+		if s := rowState(t, pool, message); s.publishedAt != nil || !s.nextAttempt.Equal(now) {
+			t.Fatalf("Expected the row to be released unpublished, got %+v", s)
+		}
+	}
+	db.fail = false
+	if n, err := relay.RelayOnce(context.Background()); err != nil || n != 2 {
+		t.Fatalf("Expected both events to be published, got %d: %v", n, err)
+	}
+	want := []string{first, first, second}
+	if fmt.Sprint(pub.published) != fmt.Sprint(want) || rowState(t, pool, first).publishedAt == nil {
+		t.Errorf("Expected at-least-once delivery %v, got %v", want, pub.published)
+	}
+}
+
+func TestPostgresOutboxRelayRepublishesAfterAClaimTimesOut(t *testing.T) {
+	_, pool := newTestDB(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	first := addOutboxRow(t, pool, "acc-a", now)
+	second := addOutboxRow(t, pool, "acc-a", now)
+	other := addOutboxRow(t, pool, "acc-b", now)
+	pub := &fakePublisher{}
+	relay := newTestRelay(pool, pub, &now)
+	ctx := context.Background()
+
+	// A relay claims acc-a's first event and crashes before publishing it.
+	crashed := newTestRelay(pool, &fakePublisher{}, &now)
+	crashed.cfg.BatchSize = 1
+	if batch, err := crashed.claim(ctx, now, now.Add(crashed.cfg.ClaimTimeout)); err != nil || len(batch) != 1 {
+		t.Fatalf("Expected one row to be claimed, got %v: %v", batch, err)
+	}
+
+	// acc-a waits for the claim; acc-b is not held up.
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 1 || fmt.Sprint(pub.published) != fmt.Sprint([]string{other}) {
+		t.Fatalf("Expected only acc-b's event to be published, got %v: %v", pub.published, err)
+	}
+
+	now = now.Add(relay.cfg.ClaimTimeout)
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 2 {
+		t.Fatalf("Expected acc-a's events to be published once the claim timed out, got %d: %v", n, err)
+	}
+	want := []string{other, first, second}
+	if fmt.Sprint(pub.published) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, pub.published)
+	}
+}
+
+func TestPostgresOutboxRelaySkipsWhileAnotherRelayHoldsTheLock(t *testing.T) {
+	_, pool := newTestDB(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	addOutboxRow(t, pool, "acc-a", now)
+	pub := &fakePublisher{}
+	relay := newTestRelay(pool, pub, &now)
+	ctx := context.Background()
+
+	other, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, DefaultOutboxLockKey); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 0 || len(pub.published) != 0 {
+		t.Errorf("Expected nothing to be published without the lock, got %d: %v", n, err)
+	}
+
+	// The lock is released with the transaction that holds it.
+	other.Rollback(ctx)
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 1 {
+		t.Errorf("Expected the event to be published once the lock is free, got %d: %v", n, err)
+	}
+}
+
+func TestPostgresOutboxRelayCleanup(t *testing.T) {
+	_, pool := newTestDB(t)
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		addOutboxRow(t, pool, "acc-a", now)
+	}
+	relay := newTestRelay(pool, &fakePublisher{}, &now)
+	if n, err := relay.RelayOnce(context.Background()); err != nil || n != 3 {
+		t.Fatalf("Expected 3 events to be published, got %d: %v", n, err)
+	}
+	pending := addOutboxRow(t, pool, "acc-a", now)
+
+	now = now.Add(30 * time.Minute)
+	if deleted, err := relay.Cleanup(context.Background()); err != nil || deleted != 0 {
+		t.Fatalf("Expected nothing to be deleted within the retention period, got %d: %v", deleted, err)
+	}
+	now = now.Add(2 * time.Hour)
+	deleted, err := relay.Cleanup(context.Background())
+	if err != nil || deleted != 3 {
+		t.Fatalf("Expected the 3 published rows to be deleted, got %d: %v", deleted, err)
+	}
+	var left []byte
+	if err := pool.QueryRow(context.Background(), `SELECT message FROM event_outbox`).Scan(&left); err != nil || string(left) != pending {
+		t.Errorf("Expected only the unpublished row to remain, got %s: %v", left, err)
+	}
+}
@@ -0,0 +1,474 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeOutboxDB is an OutboxDB holding an in-memory event_outbox table.
+// It understands only the outbox queries. Transactions work on a copy of the
+// table that replaces it on commit. While markErr is set, marking a row
+// published fails with it.
+type fakeOutboxDB struct {
+	mu        sync.Mutex
+	rows      []fakeOutboxRow
+	locked    bool
+	commitErr error
+	markErr   error
+}
+
+type fakeOutboxRow struct {
+	id          int64
+	eventID     string
+	aggregateID string
+	subject     string
+	message     []byte
+	attempts    int
+	nextAttempt time.Time
+	lastError   string
+	publishedAt *time.Time
+}
+
+func (db *fakeOutboxDB) add(aggregateID string, at time.Time) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	id := int64(len(db.rows) + 1)
+	db.rows = append(db.rows, fakeOutboxRow{
+		id:          id,
+		eventID:     fmt.Sprintf("evt-%d", id),
+		aggregateID: aggregateID,
+		subject:     OutboxSubject("Account", "AccountCredited"),
+		message:     []byte(fmt.Sprintf(`{"eventId":"evt-%d"}`, id)),
+		nextAttempt: at,
+	})
+}
+
+func (db *fakeOutboxDB) row(id int64) fakeOutboxRow {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return db.rows[id-1]
+}
+
+func (db *fakeOutboxDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	switch sql {
+	case deletePublishedOutboxSQL:
+		before := args[0].(time.Time)
+		kept := db.rows[:0]
+		var deleted int
+		for _, r := range db.rows {
+			if r.publishedAt != nil && r.publishedAt.Before(before) {
+				deleted++
+				continue
+			}
+			kept = append(kept, r)
+		}
+		db.rows = kept
+		return pgconn.NewCommandTag(fmt.Sprintf("DELETE %d", deleted)), nil
+	case markOutboxPublishedSQL:
+		if db.markErr != nil {
+			return pgconn.CommandTag{}, db.markErr
+		}
+	}
+	return updateOutbox(db.rows, sql, args)
+}
+
+func (db *fakeOutboxDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, fmt.Errorf("unexpected query %q", sql)
+}
+
+func (db *fakeOutboxDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return fakeRow{err: fmt.Errorf("unexpected query %q", sql)}
+}
+
+func (db *fakeOutboxDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return &fakeOutboxTx{db: db, rows: append([]fakeOutboxRow(nil), db.rows...)}, nil
+}
+
+// fakeOutboxTx is a transaction on a fakeOutboxDB. The embedded pgx.Tx is
+// nil; the relay does not use the methods it would provide.
+type fakeOutboxTx struct {
+	pgx.Tx
+	db     *fakeOutboxDB
+	rows   []fakeOutboxRow
+	locked bool
+	done   bool
+}
+
+func (tx *fakeOutboxTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if sql != tryOutboxLockSQL {
+		return fakeRow{err: fmt.Errorf("unexpected query %q", sql)}
+	}
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	if !tx.db.locked {
+		tx.db.locked, tx.locked = true, true
+	}
+	return fakeRow{values: []any{tx.locked}}
+}
+
+func (tx *fakeOutboxTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if sql != selectOutboxBatchSQL {
+		return nil, fmt.Errorf("unexpected query %q", sql)
+	}
+	now, limit := args[0].(time.Time), args[1].(int)
+	waiting := make(map[string]bool)
+	var out [][]any
+	for _, r := range tx.rows {
+		if r.publishedAt != nil {
+			continue
+		}
+		if r.nextAttempt.After(now) {
+			waiting[r.aggregateID] = true
+		}
+		if waiting[r.aggregateID] || len(out) == limit {
+			continue
+		}
+		out = append(out, []any{r.id, r.eventID, r.aggregateID, r.subject, r.message, r.attempts})
+	}
+	return &fakeRows{rows: out}, nil
+}
+
+func (tx *fakeOutboxTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if sql != claimOutboxSQL {
+		return pgconn.CommandTag{}, fmt.Errorf("unexpected query %q", sql)
+	}
+	return updateOutbox(tx.rows, sql, args)
+}
+
+// updateOutbox runs one of the relay's updates on rows.
+func updateOutbox(rows []fakeOutboxRow, sql string, args []any) (pgconn.CommandTag, error) {
+	var ids []int64
+	switch id := args[0].(type) {
+	case int64:
+		ids = []int64{id}
+	case []int64:
+		ids = id
+	}
+	updated := 0
+	for i := range rows {
+		r := &rows[i]
+		if !slices.Contains(ids, r.id) {
+			continue
+		}
+		switch sql {
+		case claimOutboxSQL:
+			r.nextAttempt = args[1].(time.Time)
+		case releaseOutboxSQL:
+			if r.publishedAt != nil || !r.nextAttempt.Equal(args[2].(time.Time)) {
+				continue
+			}
+			r.nextAttempt = args[1].(time.Time)
+		case markOutboxPublishedSQL:
+			at := args[1].(time.Time)
+			r.publishedAt, r.lastError = &at, ""
+		case markOutboxFailedSQL:
+			r.attempts++
+			r.nextAttempt, r.lastError = args[1].(time.Time), args[2].(string)
+		default:
+			return pgconn.CommandTag{}, fmt.Errorf("unexpected query %q", sql)
+		}
+		updated++
+	}
+	return pgconn.NewCommandTag(fmt.Sprintf("UPDATE %d", updated)), nil
+}
+
+func (tx *fakeOutboxTx) Commit(ctx context.Context) error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.release()
+	if tx.db.commitErr != nil {
+		return tx.db.commitErr
+	}
+	tx.db.rows = tx.rows
+	return nil
+}
+
+func (tx *fakeOutboxTx) Rollback(ctx context.Context) error {
+	tx.db.mu.Lock()
+	defer tx.db.mu.Unlock()
+	tx.release()
+	return nil
+}
+
+func (tx *fakeOutboxTx) release() {
+	if !tx.done && tx.locked {
+		tx.db.locked = false
+	}
+	tx.done = true
+}
+
+type fakeRow struct {
+	values []any
+	err    error
+}
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return scanValues(r.values, dest)
+}
+
+// fakeRows is the result of a query. The embedded pgx.Rows is nil; the relay
+// does not use the methods it would provide.
+type fakeRows struct {
+	pgx.Rows
+	rows [][]any
+	i    int
+}
+
+func (r *fakeRows) Next() bool             { r.i++; return r.i <= len(r.rows) }
+func (r *fakeRows) Scan(dest ...any) error { return scanValues(r.rows[r.i-1], dest) }
+func (r *fakeRows) Close()                 {}
+func (r *fakeRows) Err() error             { return nil }
+
+func scanValues(values, dest []any) error {
+	for i, v := range values {
+		switch d := dest[i].(type) {
+		case *bool:
+			*d = v.(bool)
+		case *int:
+			*d = v.(int)
+		case *int64:
+			*d = v.(int64)
+		case *string:
+			*d = v.(string)
+		case *[]byte:
+			*d = v.([]byte)
+		default:
+			return fmt.Errorf("cannot scan into %T", dest[i])
+		}
+	}
+	return nil
+}
+
+// fakePublisher is an OutboxPublisher that records what it publishes. A
+// publish fails if fail returns an error for its message.
+type fakePublisher struct {
+	published []string
+	fail      func(message string) error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, subject string, data []byte) error {
+	if p.fail != nil {
+		if err := p.fail(string(data)); err != nil {
+			return err
+		}
+	}
+	p.published = append(p.published, string(data))
+	return nil
+}
+
+func newTestRelay(db OutboxDB, pub *fakePublisher, now *time.Time) *OutboxRelay {
+	r := NewOutboxRelay(db, pub, OutboxRelayConfig{BackoffMin: time.Second, BackoffMax: 4 * time.Second, Retention: time.Hour},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+	r.now = func() time.Time { return *now }
+	return r
+}
+
+func message(id int) string {
+	return fmt.Sprintf(`{"eventId":"evt-%d"}`, id)
+}
+
+func TestOutboxRelayPublishesInOrder(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := &fakeOutboxDB{}
+	for _, agg := range []string{"acc-a", "acc-b", "acc-a", "acc-b"} {
+		db.add(agg, now)
+	}
+	// A row whose first attempt is not due yet is left alone.
+	db.add("acc-c", now.Add(time.Minute))
+	pub := &fakePublisher{}
+	relay := newTestRelay(db, pub, &now)
+	relay.cfg.BatchSize = 3
+	ctx := context.Background()
+
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 3 {
+		t.Fatalf("Expected a full batch of 3 events to be published, got %d: %v", n, err)
+	}
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("Expected the last event to be published, got %d: %v", n, err)
+	}
+	want := []string{message(1), message(2), message(3), message(4)}
+	if fmt.Sprint(pub.published) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, pub.published)
+	}
+	if row := db.row(1); row.publishedAt == nil || !row.publishedAt.Equal(now) || row.attempts != 0 {
+		t.Errorf("Expected the row to be marked published, got %+v", row)
+	}
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 0 {
+		t.Errorf("Expected nothing left to publish, got %d: %v", n, err)
+	}
+}
+
+func TestOutboxRelayBacksOffAndKeepsAggregateOrder(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := &fakeOutboxDB{}
+	for _, agg := range []string{"acc-a", "acc-b", "acc-a"} {
+		db.add(agg, now)
+	}
+	failures := 2
+	pub := &fakePublisher{fail: func(message string) error {
+		if message == `{"eventId":"evt-1"}` && failures > 0 {
+			failures--
+			return errors.New("broker unavailable")
+		}
+		return nil
+	}}
+	relay := newTestRelay(db, pub, &now)
+	ctx := context.Background()
+
+	// The first event of acc-a fails, so its second event waits; acc-b is
+	// not held up.
+	if n, _ := relay.RelayOnce(ctx); n != 1 || fmt.Sprint(pub.published) != fmt.Sprint([]string{message(2)}) {
+		t.Fatalf("Expected only acc-b's event to be published, got %v", pub.published)
+	}
+	if row := db.row(1); row.attempts != 1 || !row.nextAttempt.Equal(now.Add(time.Second)) || row.lastError != "broker unavailable" {
+		t.Errorf("Expected a 1s backoff after the first failure, got %+v", row)
+	}
+	if n, _ := relay.RelayOnce(ctx); n != 0 {
+		t.Errorf("Expected nothing to be published during the backoff, got %d", n)
+	}
+
+	// The second failure doubles the backoff.
+	now = now.Add(time.Second)
+	relay.RelayOnce(ctx)
+	if row := db.row(1); row.attempts != 2 || !row.nextAttempt.Equal(now.Add(2*time.Second)) {
+		t.Errorf("Expected a 2s backoff after the second failure, got %+v", row)
+	}
+
+	now = now.Add(2 * time.Second)
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 2 {
+		t.Fatalf("Expected acc-a's events to be published, got %d: %v", n, err)
+	}
+	want := []string{message(2), message(1), message(3)}
+	if fmt.Sprint(pub.published) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, pub.published)
+	}
+}
+
+func TestOutboxRelayPublishesNothingWhenTheClaimFails(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := &fakeOutboxDB{commitErr: errors.New("connection reset")}
+	db.add("acc-a", now)
+	pub := &fakePublisher{}
+	relay := newTestRelay(db, pub, &now)
+
+	if _, err := relay.RelayOnce(context.Background()); err == nil || len(pub.published) != 0 {
+		t.Fatalf("Expected the failed claim to be reported before publishing, got %v: %v", pub.published, err)
+	}
+	db.commitErr = nil
+	if n, err := relay.RelayOnce(context.Background()); err != nil || n != 1 {
+		t.Fatalf("Expected the event to be published, got %d: %v", n, err)
+	}
+}
+
+func TestOutboxRelayRepublishesWhenMarkingFails(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := &fakeOutboxDB{markErr: errors.New("connection reset")}
+	db.add("acc-a", now)
+	db.add("acc-b", now)
+	pub := &fakePublisher{}
+	relay := newTestRelay(db, pub, &now)
+
+	// The pass stops at the first row it cannot mark and hands the rest of
+	// its claim back.
+	if _, err := relay.RelayOnce(context.Background()); err == nil {
+		t.Fatal("Expected the failed mark to be reported")
+	}
+	if fmt.Sprint(pub.published) != fmt.Sprint([]string{message(1)}) {
+		t.Fatalf("Expected only the first event to be published, got %v", pub.published)
+	}
+	db.markErr = nil
+	if n, err := relay.RelayOnce(context.Background()); err != nil || n != 2 {
+		t.Fatalf("Expected both events to be published, got %d: %v", n, err)
+	}
+	want := []string{message(1), message(1), message(2)}
+	if fmt.Sprint(pub.published) != fmt.Sprint(want) || db.row(1).publishedAt == nil {
+		t.Errorf("Expected at-least-once delivery %v, got %v", want, pub.published)
+	}
+}
+
+func TestOutboxRelayRepublishesAfterAClaimTimesOut(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := &fakeOutboxDB{}
+	for _, agg := range []string{"acc-a", "acc-a", "acc-b"} {
+		db.add(agg, now)
+	}
+	pub := &fakePublisher{}
+	relay := newTestRelay(db, pub, &now)
+	ctx := context.Background()
+
+	// A relay claims acc-a's first event and crashes before publishing it.
+	crashed := newTestRelay(db, &fakePublisher{}, &now)
+	crashed.cfg.BatchSize = 1
+	if batch, err := crashed.claim(ctx, now, now.Add(crashed.cfg.ClaimTimeout)); err != nil || len(batch) != 1 {
+		t.Fatalf("Expected one row to be claimed, got %v: %v", batch, err)
+	}
+
+	// acc-a waits for the claim; acc-b is not held up.
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 1 || fmt.Sprint(pub.published) != fmt.Sprint([]string{message(3)}) {
+		t.Fatalf("Expected only acc-b's event to be published, got %v: %v", pub.published, err)
+	}
+
+	now = now.Add(relay.cfg.ClaimTimeout)
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 2 {
+		t.Fatalf("Expected acc-a's events to be published once the claim timed out, got %d: %v", n, err)
+	}
+	want := []string{message(3), message(1), message(2)}
+	if fmt.Sprint(pub.published) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, pub.published)
+	}
+}
+
+func TestOutboxRelaySkipsWhileAnotherRelayHoldsTheLock(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := &fakeOutboxDB{locked: true}
+	db.add("acc-a", now)
+	pub := &fakePublisher{}
+	relay := newTestRelay(db, pub, &now)
+
+	if n, err := relay.RelayOnce(context.Background()); err != nil || n != 0 || len(pub.published) != 0 {
+		t.Errorf("Expected nothing to be published without the lock, got %d: %v", n, err)
+	}
+}
+
+func TestOutboxRelayCleanup(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	db := &fakeOutboxDB{}
+	for i := 0; i < 3; i++ {
+		db.add("acc-a", now)
+	}
+	relay := newTestRelay(db, &fakePublisher{}, &now)
+	relay.RelayOnce(context.Background())
+	db.add("acc-a", now)
+
+	now = now.Add(2 * time.Hour)
+	deleted, err := relay.Cleanup(context.Background())
+	if err != nil || deleted != 3 {
+		t.Fatalf("Expected the 3 published rows to be deleted, got %d: %v", deleted, err)
+	}
+	var ids []int
+	for _, r := range db.rows {
+		ids = append(ids, int(r.id))
+	}
+	sort.Ints(ids)
+	if fmt.Sprint(ids) != "[4]" {
+		t.Errorf("Expected only the unpublished row to remain, got %v", ids)
+	}
+}
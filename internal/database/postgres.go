@@ -1,4 +1,3 @@
-```go
 package database
 
 import (
@@ -114,5 +113,3 @@ func Close(pool *pgxpool.Pool) {
 		pool.Close()
 	}
 }
-### END_OF_FILE_COMPLETED ###
-```
\ No newline at end of file
@@ -0,0 +1,70 @@
+//go:build postgres
+
+package database
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// The tests in files built with the postgres tag run the package's SQL
+// against the PostgreSQL server at TEST_POSTGRES_DSN:
+//
+//	TEST_POSTGRES_DSN=postgres://localhost/postgres go test -tags postgres ./internal/database
+
+// newTestDB creates an empty database with every migration applied, and
+// returns a database/sql handle and a pgx pool on it. The database is dropped
+// when the test ends.
+func newTestDB(t *testing.T) (*sql.DB, *pgxpool.Pool) {
+	t.Helper()
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN is not set")
+	}
+	ctx := context.Background()
+
+	admin, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("failed to connect to %s: %v", dsn, err)
+	}
+	name := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := admin.Exec(ctx, "CREATE DATABASE "+name); err != nil {
+		admin.Close()
+		t.Fatalf("failed to create database: %v", err)
+	}
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.ConnConfig.Database = name
+	db := stdlib.OpenDB(*cfg.ConnConfig.Copy())
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("failed to connect to database %s: %v", name, err)
+	}
+	t.Cleanup(func() {
+		pool.Close()
+		db.Close()
+		if _, err := admin.Exec(ctx, "DROP DATABASE IF EXISTS "+name); err != nil {
+			t.Logf("failed to drop database %s: %v", name, err)
+		}
+		admin.Close()
+	})
+
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+	if err := ApplyMigrations(db); err != nil {
+		t.Fatalf("ApplyMigrations: %v", err)
+	}
+	return db, pool
+}
@@ -1,4 +1,8 @@
-```go
+//go:build ignore
+
+// The projection store is built on BadgerDB, which is not a dependency of this
+// module, so it is left out of the build.
+
 package database
 
 import (
@@ -209,5 +213,3 @@ func deserialize(data []byte, value interface{}) error {
 	decoder := gob.NewDecoder(buf)
 	return decoder.Decode(value)
 }
-### END_OF_FILE_COMPLETED ###
-```
\ No newline at end of file
@@ -5,11 +5,13 @@ package requestconfig
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
-	"math/rand"
+	mathrand "math/rand"
 	"mime"
 	"net/http"
 	"net/url"
@@ -22,6 +24,7 @@ import (
 	"github.com/jocall3/go/internal/apierror"
 	"github.com/jocall3/go/internal/apiform"
 	"github.com/jocall3/go/internal/apiquery"
+	"github.com/jocall3/go/internal/apiroute"
 	"github.com/jocall3/go/internal/param"
 )
 
@@ -78,6 +81,38 @@ func getPlatformProperties() map[string]string {
 	}
 }
 
+// IdempotencyHeader is the request header carrying the idempotency key of a
+// mutating request.
+const IdempotencyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is the response header the server sets to "true"
+// when it answered with the stored result of an earlier request that used the
+// same idempotency key.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// IsIdempotentMethod reports whether requests with the given method are safe
+// to repeat and are therefore sent without an idempotency key.
+func IsIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewIdempotencyKey returns a random (version 4) UUID suitable for use as an
+// idempotency key.
+func NewIdempotencyKey() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
 type RequestOption interface {
 	Apply(*RequestConfig) error
 }
@@ -114,6 +149,15 @@ func NewRequestConfig(ctx context.Context, method string, u string, body interfa
 		reader = bytes.NewBuffer(content)
 		hasSerializationFunc = true
 	}
+	if body, ok := body.(apiform.BodyMarshaler); ok {
+		content, err := body.MarshalMultipartBody()
+		if err != nil {
+			return nil, err
+		}
+		reader = content
+		contentType = content.ContentType()
+		hasSerializationFunc = true
+	}
 	if body, ok := body.(apiquery.Queryer); ok {
 		hasSerializationFunc = true
 		params := body.URLQuery().Encode()
@@ -160,6 +204,18 @@ func NewRequestConfig(ctx context.Context, method string, u string, body interfa
 	for k, v := range getPlatformProperties() {
 		req.Header.Add(k, v)
 	}
+
+	// Generate the idempotency key once per call, before any retries. Every
+	// attempt is cloned from this request, so the server sees the same key for
+	// all of them. Options can still override the key or delete the header.
+	if !IsIdempotentMethod(method) {
+		key, err := NewIdempotencyKey()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(IdempotencyHeader, key)
+	}
+
 	cfg := RequestConfig{
 		MaxRetries: 2,
 		Context:    ctx,
@@ -205,7 +261,10 @@ type HTTPDoer interface {
 // Editing the variables inside RequestConfig directly is unstable api. Prefer
 // composing the RequestOption instead if possible.
 type RequestConfig struct {
-	MaxRetries     int
+	MaxRetries int
+	// RetryPolicy decides which failed attempts are retried and how long to wait
+	// before each retry. If nil, the default policy is used.
+	RetryPolicy    RetryPolicy
 	RequestTimeout time.Duration
 	Context        context.Context
 	Request        *http.Request
@@ -216,8 +275,19 @@ type RequestConfig struct {
 	CustomHTTPDoer HTTPDoer
 	HTTPClient     *http.Client
 	Middlewares    []middleware
+	// CallObservers are notified of every method call, however many attempts
+	// it takes. Middlewares, in contrast, see each attempt.
+	CallObservers  []CallObserver
 	APIKey         string
 	BiometricToken string
+	// CredentialsProvider, if set, supplies the bearer token of every attempt
+	// and a new one when an attempt is rejected with 401 Unauthorized.
+	CredentialsProvider CredentialsProvider
+	// WebhookSecret is the shared secret used to verify webhook signatures.
+	WebhookSecret string
+	// WebhookTolerance is the maximum allowed difference between a webhook's
+	// timestamp and the local clock.
+	WebhookTolerance time.Duration
 	// If ResponseBodyInto not nil, then we will attempt to deserialize into
 	// ResponseBodyInto. If Destination is a []byte, then it will return the body as
 	// is.
@@ -225,9 +295,40 @@ type RequestConfig struct {
 	// ResponseInto copies the \*http.Response of the corresponding request into the
 	// given address
 	ResponseInto **http.Response
-	Body         io.Reader
+	// If IdempotentReplayedInto is not nil, it is set to whether the server
+	// reported that the response was replayed for a repeated idempotency key.
+	IdempotentReplayedInto *bool
+	Body                   io.Reader
+}
+
+// Operation describes the API operation a request is made for. It is available
+// from the context of every attempt through [OperationFromContext].
+type Operation struct {
+	// The service method, relative to the client, such as "Transactions.List",
+	// or empty if the request does not belong to a known operation.
+	Name string
+	// The HTTP method of the request.
+	Method string
+	// The path template of the request, such as "/transactions/{transactionId}".
+	Route string
+	// The number of retries made before the current attempt.
+	Attempt int
+}
+
+type operationContextKey struct{}
+
+// OperationFromContext returns the operation that a request with the context
+// ctx is made for.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationContextKey{}).(Operation)
+	return op, ok
 }
 
+// CallObserver is called when a method call starts, before its first attempt.
+// The context it returns is used for every attempt of the call, and end is
+// called with the final response and error once the call is over.
+type CallObserver = func(ctx context.Context, op Operation) (_ context.Context, end func(res *http.Response, err error))
+
 // middleware is exactly the same type as the Middleware type found in the [option] package,
 // but it is redeclared here for circular dependency issues.
 type middleware = func(*http.Request, middlewareNext) (*http.Response, error)
@@ -242,6 +343,172 @@ func applyMiddleware(middleware middleware, next middlewareNext) middlewareNext
 	}
 }
 
+// RetryAttempt describes an attempt at a request, as seen by a [RetryPolicy].
+type RetryAttempt struct {
+	// The request that was sent.
+	Request *http.Request
+	// The response, or nil if the request failed without one.
+	Response *http.Response
+	// The error returned by the HTTP client, if any.
+	Err error
+	// The number of retries made before this attempt, so zero for the first
+	// attempt.
+	Retry int
+	// The time since the first attempt was sent.
+	Elapsed time.Duration
+	// The delay that preceded this attempt, or zero for the first attempt.
+	PreviousDelay time.Duration
+}
+
+// RetryPolicy decides whether an attempt is retried and how long to wait before
+// the retry. Retry is consulted after every attempt, successful or not, until
+// the number of retries reaches [RequestConfig.MaxRetries]. Requests whose body
+// cannot be replayed are never retried.
+type RetryPolicy interface {
+	Retry(attempt RetryAttempt) (delay time.Duration, retry bool)
+}
+
+// DefaultRetryPolicy is the policy used when none is configured. It retries
+// connection errors and 408, 409, 429 and 5xx responses, honours the
+// x-should-retry header, and waits for the duration given by a Retry-After
+// header under a minute, or else backs off exponentially from 0.5s to 8s with
+// up to 25% jitter.
+var DefaultRetryPolicy RetryPolicy = defaultRetryPolicy{}
+
+type defaultRetryPolicy struct{}
+
+func (defaultRetryPolicy) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	if IsRejected(attempt.Err) || !shouldRetry(attempt.Request, attempt.Response) {
+		return 0, false
+	}
+	return retryDelay(attempt.Response, attempt.Retry), true
+}
+
+// ErrCircuitOpen is matched by the errors returned for requests that a circuit
+// breaker rejected without sending them. Such requests are not retried.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitOpenError is returned for a request that a circuit breaker rejected
+// without sending it.
+type CircuitOpenError struct {
+	// The host the request was addressed to.
+	Host string
+	// The route the request was made for, such as "GET /budgets/{budgetId}".
+	Route string
+	// How long until the circuit lets a trial request through, or zero if it is
+	// already letting as many through as it allows.
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit breaker is open for %s %s", e.Host, e.Route)
+}
+
+// Is reports whether target is [ErrCircuitOpen].
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// ErrRecorderNoMatch is matched by the errors returned for requests that a
+// recorder replaying in strict mode has no recorded response for. Such
+// requests are not retried.
+var ErrRecorderNoMatch = errors.New("no recorded interaction matches the request")
+
+// RecorderMismatchError is returned for a request that a recorder replaying in
+// strict mode has no recorded response for.
+type RecorderMismatchError struct {
+	// The cassette file that was searched.
+	Cassette string
+	Method   string
+	URL      string
+	// The normalized body of the request.
+	Body string
+}
+
+func (e *RecorderMismatchError) Error() string {
+	msg := fmt.Sprintf("no interaction in cassette %s matches %s %s", e.Cassette, e.Method, e.URL)
+	if e.Body != "" {
+		msg += " with body " + e.Body
+	}
+	return msg
+}
+
+// Is reports whether target is [ErrRecorderNoMatch].
+func (e *RecorderMismatchError) Is(target error) bool {
+	return target == ErrRecorderNoMatch
+}
+
+// IsRejected reports whether err was returned by a middleware that refused to
+// send a request, which retrying cannot help.
+func IsRejected(err error) bool {
+	var credentialsErr *CredentialsError
+	return errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrRecorderNoMatch) || errors.As(err, &credentialsErr)
+}
+
+// CredentialsProvider supplies the bearer tokens that requests are
+// authenticated with. Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	// Token returns the token to send with a request.
+	Token(ctx context.Context) (string, error)
+	// Refresh returns a token to replace rejected, which the API refused with
+	// 401 Unauthorized. Concurrent requests may all be refused with the same
+	// token, so a provider should only fetch a new token once for each rejected
+	// one.
+	Refresh(ctx context.Context, rejected string) (string, error)
+}
+
+// CredentialsError is returned for a request whose credentials could not be
+// obtained from its [CredentialsProvider]. Such requests are not retried.
+type CredentialsError struct {
+	Err error
+}
+
+func (e *CredentialsError) Error() string {
+	return "requestconfig: cannot obtain credentials: " + e.Err.Error()
+}
+
+func (e *CredentialsError) Unwrap() error { return e.Err }
+
+// authenticate returns a handler that sets the Authorization header of each
+// request from provider, and sends a request rejected with 401 Unauthorized
+// once more with a refreshed token when its body can be sent again.
+func authenticate(provider CredentialsProvider, next middlewareNext) middlewareNext {
+	return func(req *http.Request) (*http.Response, error) {
+		token, err := provider.Token(req.Context())
+		if err != nil {
+			return nil, &CredentialsError{Err: err}
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		res, err := next(req)
+		if err != nil || res.StatusCode != http.StatusUnauthorized {
+			return res, err
+		}
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return res, nil
+		}
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			if retry.Body, err = req.GetBody(); err != nil {
+				return res, nil
+			}
+		}
+		if res.Body != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		token, err = provider.Refresh(req.Context(), token)
+		if err != nil {
+			if retry.Body != nil {
+				retry.Body.Close()
+			}
+			return nil, &CredentialsError{Err: err}
+		}
+		retry.Header.Set("Authorization", "Bearer "+token)
+		return next(retry)
+	}
+}
+
 func shouldRetry(req *http.Request, res *http.Response) bool {
 	// If there is no way to recover the Body, then we shouldn't retry.
 	if req.Body != nil && req.GetBody == nil {
@@ -269,6 +536,12 @@ func shouldRetry(req *http.Request, res *http.Response) bool {
 		res.StatusCode >= http.StatusInternalServerError
 }
 
+// RetryAfter reports how long the server asked the client to wait before its
+// next request, using the Retry-After-Ms and Retry-After headers of resp.
+func RetryAfter(resp *http.Response) (time.Duration, bool) {
+	return parseRetryAfterHeader(resp)
+}
+
 func parseRetryAfterHeader(resp *http.Response) (time.Duration, bool) {
 	if resp == nil {
 		return 0, false
@@ -373,7 +646,7 @@ func retryDelay(res *http.Response, retryCount int) time.Duration {
 		delay = maxDelay
 	}
 
-	jitter := rand.Int63n(int64(delay / 4))
+	jitter := mathrand.Int63n(int64(delay / 4))
 	delay -= time.Duration(jitter)
 	return delay
 }
@@ -392,6 +665,27 @@ func (cfg *RequestConfig) Execute() (err error) {
 		return err
 	}
 
+	op := Operation{Method: cfg.Request.Method, Route: apiroute.Template(cfg.Request.Method, cfg.Request.URL.Path)}
+	if route, ok := apiroute.Lookup(cfg.Request.Method, cfg.Request.URL.Path); ok {
+		op.Name = route.Operation
+	}
+	var res *http.Response
+	if len(cfg.CallObservers) > 0 {
+		ctx := cfg.Request.Context()
+		ends := make([]func(*http.Response, error), 0, len(cfg.CallObservers))
+		for _, observe := range cfg.CallObservers {
+			var end func(*http.Response, error)
+			ctx, end = observe(ctx, op)
+			ends = append(ends, end)
+		}
+		cfg.Request = cfg.Request.WithContext(ctx)
+		defer func() {
+			for i := len(ends) - 1; i >= 0; i-- {
+				ends[i](res, err)
+			}
+		}()
+	}
+
 	if cfg.Body != nil && cfg.Request.Body == nil {
 		switch body := cfg.Body.(type) {
 		case *bytes.Buffer:
@@ -399,6 +693,16 @@ func (cfg *RequestConfig) Execute() (err error) {
 			cfg.Request.ContentLength = int64(body.Len())
 			cfg.Request.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(b)), nil }
 			cfg.Request.Body, _ = cfg.Request.GetBody()
+		case *apiform.Body:
+			// Multipart bodies are streamed, so their length is not known. They can
+			// only be sent again if every file they hold can be read again.
+			cfg.Request.Body, err = body.Reader()
+			if err != nil {
+				return err
+			}
+			if body.Rewindable() {
+				cfg.Request.GetBody = body.Reader
+			}
 		case *bytes.Reader:
 			cfg.Request.ContentLength = int64(body.Len())
 			cfg.Request.GetBody = func() (io.ReadCloser, error) {
@@ -422,12 +726,21 @@ func (cfg *RequestConfig) Execute() (err error) {
 	for i := len(cfg.Middlewares) - 1; i >= 0; i -= 1 {
 		handler = applyMiddleware(cfg.Middlewares[i], handler)
 	}
+	if cfg.CredentialsProvider != nil {
+		handler = authenticate(cfg.CredentialsProvider, handler)
+	}
 
 	// Don't send the current retry count in the headers if the caller modified the header defaults.
 	shouldSendRetryCount := cfg.Request.Header.Get("X-Stainless-Retry-Count") == "0"
 
-	var res *http.Response
+	policy := cfg.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy
+	}
+
 	var cancel context.CancelFunc
+	var delay time.Duration
+	start := time.Now()
 	for retryCount := 0; retryCount <= cfg.MaxRetries; retryCount += 1 {
 		ctx := cfg.Request.Context()
 		if cfg.RequestTimeout != time.Duration(0) && isBeforeContextDeadline(time.Now().Add(cfg.RequestTimeout), ctx) {
@@ -440,7 +753,8 @@ func (cfg *RequestConfig) Execute() (err error) {
 			}()
 		}
 
-		req := cfg.Request.Clone(ctx)
+		op.Attempt = retryCount
+		req := cfg.Request.Clone(context.WithValue(ctx, operationContextKey{}, op))
 		if shouldSendRetryCount {
 			req.Header.Set("X-Stainless-Retry-Count", strconv.Itoa(retryCount))
 		}
@@ -449,7 +763,23 @@ func (cfg *RequestConfig) Execute() (err error) {
 		if ctx != nil && ctx.Err() != nil {
 			return ctx.Err()
 		}
-		if !shouldRetry(cfg.Request, res) || retryCount >= cfg.MaxRetries {
+		if retryCount >= cfg.MaxRetries {
+			break
+		}
+		// If there is no way to recover the Body, then we shouldn't retry.
+		if cfg.Request.Body != nil && cfg.Request.GetBody == nil {
+			break
+		}
+		var retry bool
+		delay, retry = policy.Retry(RetryAttempt{
+			Request:       req,
+			Response:      res,
+			Err:           err,
+			Retry:         retryCount,
+			Elapsed:       time.Since(start),
+			PreviousDelay: delay,
+		})
+		if !retry {
 			break
 		}
 
@@ -471,7 +801,13 @@ func (cfg *RequestConfig) Execute() (err error) {
 			res.Body.Close()
 		}
 
-		time.Sleep(retryDelay(res, retryCount))
+		timer := time.NewTimer(delay)
+		select {
+		case <-cfg.Request.Context().Done():
+			timer.Stop()
+			return cfg.Request.Context().Err()
+		case <-timer.C:
+		}
 	}
 
 	// Save *http.Response if it is requested to, even if there was an error making the request. This is
@@ -483,31 +819,38 @@ func (cfg *RequestConfig) Execute() (err error) {
 	if responseBodyInto, ok := cfg.ResponseBodyInto.(**http.Response); ok {
 		*responseBodyInto = res
 	}
+	if cfg.IdempotentReplayedInto != nil {
+		*cfg.IdempotentReplayedInto = res != nil && strings.EqualFold(res.Header.Get(IdempotentReplayedHeader), "true")
+	}
 
 	// If there was a connection error in the final request or any other transport error,
 	// return that early without trying to coerce into an APIError.
+	// Errors from middlewares that refused the request are returned as they are.
 	if err != nil {
-		return err
+		if IsRejected(err) {
+			return err
+		}
+		return &apierror.TransportError{Err: err}
 	}
 
 	if res.StatusCode >= 400 {
 		contents, err := io.ReadAll(res.Body)
 		res.Body.Close()
 		if err != nil {
-			return err
+			return &apierror.TransportError{Err: err}
 		}
 
 		// If there is an APIError, re-populate the response body so that debugging
 		// utilities can conveniently dump the response without issue.
 		res.Body = io.NopCloser(bytes.NewBuffer(contents))
 
-		// Load the contents into the error format if it is provided.
-		aerr := apierror.Error{Request: cfg.Request, Response: res, StatusCode: res.StatusCode}
-		err = aerr.UnmarshalJSON(contents)
-		if err != nil {
-			return err
+		// Load the contents into the error format if it is provided. A body that is
+		// not JSON, such as an error page from a proxy, still makes an API error.
+		aerr := apierror.New(cfg.Request, res, contents)
+		if retryAfter, ok := parseRetryAfterHeader(res); ok && retryAfter > 0 {
+			aerr.RetryAfter = retryAfter
 		}
-		return &aerr
+		return aerr
 	}
 
 	_, intoCustomResponseBody := cfg.ResponseBodyInto.(**http.Response)
@@ -525,7 +868,7 @@ func (cfg *RequestConfig) Execute() (err error) {
 	contents, err := io.ReadAll(res.Body)
 	res.Body.Close()
 	if err != nil {
-		return fmt.Errorf("error reading response body: %w", err)
+		return &apierror.TransportError{Err: fmt.Errorf("error reading response body: %w", err)}
 	}
 
 	// If we are not json, return plaintext
@@ -542,7 +885,12 @@ func (cfg *RequestConfig) Execute() (err error) {
 		case *[]byte:
 			*dst = contents
 		default:
-			return fmt.Errorf("expected destination type of 'string' or '[]byte' for responses with content-type '%s' that is not 'application/json'", contentType)
+			return &apierror.DecodeError{
+				StatusCode:  res.StatusCode,
+				ContentType: contentType,
+				Body:        contents,
+				Err:         fmt.Errorf("expected destination type of 'string' or '[]byte' for responses with content-type '%s' that is not 'application/json'", contentType),
+			}
 		}
 		return nil
 	}
@@ -554,7 +902,12 @@ func (cfg *RequestConfig) Execute() (err error) {
 	default:
 		err = json.NewDecoder(bytes.NewReader(contents)).Decode(cfg.ResponseBodyInto)
 		if err != nil {
-			return fmt.Errorf("error parsing response json: %w", err)
+			return &apierror.DecodeError{
+				StatusCode:  res.StatusCode,
+				ContentType: contentType,
+				Body:        contents,
+				Err:         fmt.Errorf("error parsing response json: %w", err),
+			}
 		}
 	}
 
@@ -582,15 +935,22 @@ func (cfg *RequestConfig) Clone(ctx context.Context) *RequestConfig {
 		return nil
 	}
 	new := &RequestConfig{
-		MaxRetries:     cfg.MaxRetries,
-		RequestTimeout: cfg.RequestTimeout,
-		Context:        ctx,
-		Request:        req,
-		BaseURL:        cfg.BaseURL,
-		HTTPClient:     cfg.HTTPClient,
-		Middlewares:    cfg.Middlewares,
-		APIKey:         cfg.APIKey,
-		BiometricToken: cfg.BiometricToken,
+		MaxRetries:          cfg.MaxRetries,
+		RetryPolicy:         cfg.RetryPolicy,
+		RequestTimeout:      cfg.RequestTimeout,
+		Context:             ctx,
+		Request:             req,
+		BaseURL:             cfg.BaseURL,
+		DefaultBaseURL:      cfg.DefaultBaseURL,
+		CustomHTTPDoer:      cfg.CustomHTTPDoer,
+		HTTPClient:          cfg.HTTPClient,
+		Middlewares:         cfg.Middlewares,
+		CallObservers:       cfg.CallObservers,
+		APIKey:              cfg.APIKey,
+		BiometricToken:      cfg.BiometricToken,
+		CredentialsProvider: cfg.CredentialsProvider,
+		WebhookSecret:       cfg.WebhookSecret,
+		WebhookTolerance:    cfg.WebhookTolerance,
 	}
 
 	return new
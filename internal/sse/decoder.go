@@ -0,0 +1,170 @@
+// Package sse decodes text/event-stream response bodies as described by the
+// HTML Living Standard's server-sent events section.
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// maxLineSize bounds the length of a single line in the stream.
+const maxLineSize = 32 << 20
+
+// Event is a single dispatched server-sent event.
+type Event struct {
+	// Type is the value of the last "event" field, or empty if there was none.
+	Type string
+	// ID is the last event ID seen on the stream, which persists across events
+	// until the server changes it.
+	ID string
+	// Data is the concatenation of the event's "data" fields, joined by newlines.
+	Data []byte
+	// Retry is the reconnection time requested by the server, or zero.
+	Retry time.Duration
+}
+
+// Decoder reads events from an event stream. It is not safe for concurrent use,
+// except that [Decoder.Close] may be called while [Decoder.Next] is blocked in
+// order to abort it.
+type Decoder struct {
+	rc     io.ReadCloser
+	scn    *bufio.Scanner
+	evt    Event
+	lastID string
+	retry  time.Duration
+	err    error
+	closed atomic.Bool
+}
+
+// NewDecoder returns a decoder that reads events from rc. Closing the decoder
+// closes rc.
+func NewDecoder(rc io.ReadCloser) *Decoder {
+	scn := bufio.NewScanner(rc)
+	scn.Buffer(nil, maxLineSize)
+	scn.Split(scanLines)
+	return &Decoder{rc: rc, scn: scn}
+}
+
+// Next advances to the next event, returning false when the stream ends or an
+// error occurs. Comments and events without data are skipped. An event that is
+// still pending when the stream ends is dispatched rather than discarded.
+func (d *Decoder) Next() bool {
+	if d.err != nil || d.closed.Load() {
+		return false
+	}
+
+	var (
+		typ     string
+		data    bytes.Buffer
+		hasData bool
+		pending bool
+	)
+	dispatch := func() bool {
+		if !hasData {
+			typ, pending = "", false
+			return false
+		}
+		d.evt = Event{Type: typ, ID: d.lastID, Data: data.Bytes(), Retry: d.retry}
+		return true
+	}
+
+	for d.scn.Scan() {
+		line := d.scn.Bytes()
+		if len(line) == 0 {
+			if dispatch() {
+				return true
+			}
+			continue
+		}
+		if line[0] == ':' {
+			continue
+		}
+		pending = true
+
+		field, value, _ := bytes.Cut(line, []byte(":"))
+		value = bytes.TrimPrefix(value, []byte(" "))
+		switch string(field) {
+		case "event":
+			typ = string(value)
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.Write(value)
+			hasData = true
+		case "id":
+			if bytes.IndexByte(value, 0) < 0 {
+				d.lastID = string(value)
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(string(value), 10, 63); err == nil {
+				d.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	if err := d.scn.Err(); err != nil {
+		if d.closed.Load() {
+			// The read was aborted by Close.
+			return false
+		}
+		d.err = err
+		return false
+	}
+	if pending {
+		return dispatch()
+	}
+	return false
+}
+
+// Event returns the most recent event read by [Decoder.Next].
+func (d *Decoder) Event() Event {
+	return d.evt
+}
+
+// Err returns the error that stopped the decoder, if any. Reaching the end of
+// the stream is not an error.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// Close closes the underlying reader. Subsequent calls to [Decoder.Next] return
+// false.
+func (d *Decoder) Close() error {
+	if d.closed.Swap(true) {
+		return nil
+	}
+	return d.rc.Close()
+}
+
+// scanLines is a [bufio.SplitFunc] that accepts "\r\n", "\n" and a lone "\r" as
+// line terminators.
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		// A trailing "\r" may be the first half of "\r\n".
+		return 0, nil, nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
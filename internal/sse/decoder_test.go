@@ -0,0 +1,101 @@
+package sse
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func decodeAll(t *testing.T, body io.Reader) []Event {
+	t.Helper()
+	dec := NewDecoder(io.NopCloser(body))
+	var events []Event
+	for dec.Next() {
+		events = append(events, dec.Event())
+	}
+	if err := dec.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return events
+}
+
+func TestDecoder(t *testing.T) {
+	stream := ": keep-alive\n" +
+		"event: greeting\r\n" +
+		"id: 1\r\n" +
+		"data: hello\r\n" +
+		"data:world\r\n" +
+		"\r\n" +
+		"retry: 2500\r" +
+		"data: second\r" +
+		"\r" +
+		"id\n" +
+		"event: empty\n" +
+		"\n" +
+		"id: bad\x00id\n" +
+		"data: {\"a\": 1}\n"
+
+	for name, body := range map[string]io.Reader{
+		"whole":    strings.NewReader(stream),
+		"bytewise": iotest.OneByteReader(strings.NewReader(stream)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			events := decodeAll(t, body)
+			if len(events) != 3 {
+				t.Fatalf("expected 3 events, got %d: %+v", len(events), events)
+			}
+			if e := events[0]; e.Type != "greeting" || e.ID != "1" || string(e.Data) != "hello\nworld" || e.Retry != 0 {
+				t.Fatalf("unexpected first event: %+v", e)
+			}
+			if e := events[1]; e.Type != "" || e.ID != "1" || string(e.Data) != "second" || e.Retry != 2500*time.Millisecond {
+				t.Fatalf("unexpected second event: %+v", e)
+			}
+			if e := events[2]; e.ID != "" || string(e.Data) != `{"a": 1}` {
+				t.Fatalf("unexpected trailing event: %+v", e)
+			}
+		})
+	}
+}
+
+func TestDecoderReadError(t *testing.T) {
+	boom := errors.New("connection reset")
+	dec := NewDecoder(io.NopCloser(io.MultiReader(strings.NewReader("data: one\n\n"), iotest.ErrReader(boom))))
+	if !dec.Next() || string(dec.Event().Data) != "one" {
+		t.Fatalf("expected the first event to be decoded")
+	}
+	if dec.Next() {
+		t.Fatalf("expected the decoder to stop")
+	}
+	if !errors.Is(dec.Err(), boom) {
+		t.Fatalf("expected %v, got %v", boom, dec.Err())
+	}
+}
+
+type closeRecorder struct {
+	io.Reader
+	closed int
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestDecoderClose(t *testing.T) {
+	body := &closeRecorder{Reader: strings.NewReader("data: one\n\ndata: two\n\n")}
+	dec := NewDecoder(body)
+	if !dec.Next() {
+		t.Fatalf("expected an event")
+	}
+	dec.Close()
+	dec.Close()
+	if dec.Next() {
+		t.Fatalf("expected no events after Close")
+	}
+	if body.closed != 1 {
+		t.Fatalf("expected the body to be closed once, got %d", body.closed)
+	}
+}
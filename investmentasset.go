@@ -13,6 +13,7 @@ import (
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // InvestmentAssetService contains methods and other services that help with
@@ -36,60 +37,54 @@ func NewInvestmentAssetService(opts ...option.RequestOption) (r *InvestmentAsset
 
 // Searches for available investment assets (stocks, ETFs, mutual funds) and
 // returns their ESG impact scores.
-func (r *InvestmentAssetService) Search(ctx context.Context, query InvestmentAssetSearchParams, opts ...option.RequestOption) (res *InvestmentAssetSearchResponse, err error) {
+func (r *InvestmentAssetService) Search(ctx context.Context, query InvestmentAssetSearchParams, opts ...option.RequestOption) (res *pagination.Page[InvestmentAssetSearchResponseData], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "investments/assets/search"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
-}
-
-type InvestmentAssetSearchResponse struct {
-	Data []InvestmentAssetSearchResponseData `json:"data"`
-	JSON investmentAssetSearchResponseJSON   `json:"-"`
-	PaginatedList
-}
-
-// investmentAssetSearchResponseJSON contains the JSON metadata for the struct
-// [InvestmentAssetSearchResponse]
-type investmentAssetSearchResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *InvestmentAssetSearchResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
 }
 
-func (r investmentAssetSearchResponseJSON) RawJSON() string {
-	return r.raw
+// Searches for available investment assets (stocks, ETFs, mutual funds) and
+// returns their ESG impact scores.
+func (r *InvestmentAssetService) SearchAutoPaging(ctx context.Context, query InvestmentAssetSearchParams, opts ...option.RequestOption) *pagination.PageAutoPager[InvestmentAssetSearchResponseData] {
+	return pagination.NewPageAutoPager(r.Search(ctx, query, opts...))
 }
 
 type InvestmentAssetSearchResponseData struct {
 	// Full name of the investment asset.
-	AssetName interface{} `json:"assetName,required"`
+	AssetName string `json:"assetName,required"`
 	// Symbol of the investment asset.
-	AssetSymbol interface{} `json:"assetSymbol,required"`
+	AssetSymbol string `json:"assetSymbol,required"`
 	// Type of the investment asset.
 	AssetType InvestmentAssetSearchResponseDataAssetType `json:"assetType,required"`
 	// Currency of the asset's price.
-	Currency interface{} `json:"currency,required"`
+	Currency string `json:"currency,required"`
 	// Current market price of the asset.
-	CurrentPrice interface{} `json:"currentPrice,required"`
+	CurrentPrice Decimal `json:"currentPrice,required"`
 	// Overall ESG score (0-10), higher is better.
-	OverallEsgScore interface{} `json:"overallESGScore,required"`
+	OverallEsgScore float64 `json:"overallESGScore,required"`
 	// AI-generated insight summarizing the ESG profile.
-	AIEsgInsight interface{} `json:"aiESGInsight"`
+	AIEsgInsight string `json:"aiESGInsight"`
 	// Environmental component of the ESG score.
-	EnvironmentalScore interface{} `json:"environmentalScore"`
+	EnvironmentalScore float64 `json:"environmentalScore"`
 	// List of any significant ESG-related controversies associated with the asset.
-	EsgControversies []interface{} `json:"esgControversies,nullable"`
+	EsgControversies []string `json:"esgControversies,nullable"`
 	// Provider of the ESG rating (e.g., MSCI, Sustainalytics).
-	EsgRatingProvider interface{} `json:"esgRatingProvider"`
+	EsgRatingProvider string `json:"esgRatingProvider"`
 	// Governance component of the ESG score.
-	GovernanceScore interface{} `json:"governanceScore"`
+	GovernanceScore float64 `json:"governanceScore"`
 	// Social component of the ESG score.
-	SocialScore interface{}                           `json:"socialScore"`
+	SocialScore float64                               `json:"socialScore"`
 	JSON        investmentAssetSearchResponseDataJSON `json:"-"`
 }
 
@@ -140,13 +135,13 @@ func (r InvestmentAssetSearchResponseDataAssetType) IsKnown() bool {
 
 type InvestmentAssetSearchParams struct {
 	// Search query for asset name or symbol.
-	Query param.Field[interface{}] `query:"query,required"`
+	Query param.Field[string] `query:"query,required"`
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Minimum desired ESG score (0-10).
-	MinEsgScore param.Field[interface{}] `query:"minESGScore"`
+	MinEsgScore param.Field[float64] `query:"minESGScore"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [InvestmentAssetSearchParams]'s query parameters as
@@ -26,10 +26,10 @@ func TestInvestmentAssetSearchWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Investments.Assets.Search(context.TODO(), jocall3.InvestmentAssetSearchParams{
-		Query:       jocall3.F[any]("Tesla"),
-		Limit:       jocall3.F[any](map[string]interface{}{}),
-		MinEsgScore: jocall3.F[any](7),
-		Offset:      jocall3.F[any](map[string]interface{}{}),
+		Query:       jocall3.F("Tesla"),
+		Limit:       jocall3.F(int64(0)),
+		MinEsgScore: jocall3.F(7.0),
+		Offset:      jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
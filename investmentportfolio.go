@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // InvestmentPortfolioService contains methods and other services that help with
@@ -45,58 +48,85 @@ func (r *InvestmentPortfolioService) New(ctx context.Context, body InvestmentPor
 
 // Retrieves detailed information for a specific investment portfolio, including
 // holdings, performance, and AI insights.
-func (r *InvestmentPortfolioService) Get(ctx context.Context, portfolioID interface{}, opts ...option.RequestOption) (res *InvestmentPortfolio, err error) {
+func (r *InvestmentPortfolioService) Get(ctx context.Context, portfolioID string, opts ...option.RequestOption) (res *InvestmentPortfolio, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("investments/portfolios/%v", portfolioID)
+	if portfolioID == "" {
+		err = errors.New("missing required portfolioId parameter")
+		return
+	}
+	path := fmt.Sprintf("investments/portfolios/%s", portfolioID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
 
 // Updates high-level details of an investment portfolio, such as name or risk
 // tolerance.
-func (r *InvestmentPortfolioService) Update(ctx context.Context, portfolioID interface{}, body InvestmentPortfolioUpdateParams, opts ...option.RequestOption) (res *InvestmentPortfolio, err error) {
+func (r *InvestmentPortfolioService) Update(ctx context.Context, portfolioID string, body InvestmentPortfolioUpdateParams, opts ...option.RequestOption) (res *InvestmentPortfolio, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("investments/portfolios/%v", portfolioID)
+	if portfolioID == "" {
+		err = errors.New("missing required portfolioId parameter")
+		return
+	}
+	path := fmt.Sprintf("investments/portfolios/%s", portfolioID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
 
 // Retrieves a summary of all investment portfolios linked to the user's account.
-func (r *InvestmentPortfolioService) List(ctx context.Context, query InvestmentPortfolioListParams, opts ...option.RequestOption) (res *InvestmentPortfolioListResponse, err error) {
+func (r *InvestmentPortfolioService) List(ctx context.Context, query InvestmentPortfolioListParams, opts ...option.RequestOption) (res *pagination.Page[InvestmentPortfolio], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "investments/portfolios"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a summary of all investment portfolios linked to the user's account.
+func (r *InvestmentPortfolioService) ListAutoPaging(ctx context.Context, query InvestmentPortfolioListParams, opts ...option.RequestOption) *pagination.PageAutoPager[InvestmentPortfolio] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Triggers an AI-driven rebalancing process for a specific investment portfolio
 // based on a target risk tolerance or strategy.
-func (r *InvestmentPortfolioService) Rebalance(ctx context.Context, portfolioID interface{}, body InvestmentPortfolioRebalanceParams, opts ...option.RequestOption) (res *InvestmentPortfolioRebalanceResponse, err error) {
+func (r *InvestmentPortfolioService) Rebalance(ctx context.Context, portfolioID string, body InvestmentPortfolioRebalanceParams, opts ...option.RequestOption) (res *InvestmentPortfolioRebalanceResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("investments/portfolios/%v/rebalance", portfolioID)
+	if portfolioID == "" {
+		err = errors.New("missing required portfolioId parameter")
+		return
+	}
+	path := fmt.Sprintf("investments/portfolios/%s/rebalance", portfolioID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
 	return
 }
 
 type InvestmentPortfolio struct {
 	// Unique identifier for the investment portfolio.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// ISO 4217 currency code of the portfolio.
-	Currency interface{} `json:"currency,required"`
+	Currency string `json:"currency,required"`
 	// Timestamp when the portfolio data was last updated.
-	LastUpdated interface{} `json:"lastUpdated,required"`
+	LastUpdated time.Time `json:"lastUpdated,required" format:"date-time"`
 	// Name of the portfolio.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// User's stated or AI-assessed risk tolerance for this portfolio.
 	RiskTolerance InvestmentPortfolioRiskTolerance `json:"riskTolerance,required"`
 	// Daily gain or loss on the portfolio.
-	TodayGainLoss interface{} `json:"todayGainLoss,required"`
+	TodayGainLoss Decimal `json:"todayGainLoss,required"`
 	// Current total market value of the portfolio.
-	TotalValue interface{} `json:"totalValue,required"`
+	TotalValue Decimal `json:"totalValue,required"`
 	// General type or strategy of the portfolio.
 	Type InvestmentPortfolioType `json:"type,required"`
 	// Total unrealized gain or loss on the portfolio.
-	UnrealizedGainLoss interface{} `json:"unrealizedGainLoss,required"`
+	UnrealizedGainLoss Decimal `json:"unrealizedGainLoss,required"`
 	// AI-driven insights into portfolio performance and market outlook.
 	AIPerformanceInsights []AIInsight `json:"aiPerformanceInsights,nullable"`
 	// Frequency at which AI-driven rebalancing is set to occur.
@@ -192,21 +222,21 @@ func (r InvestmentPortfolioAIRebalancingFrequency) IsKnown() bool {
 
 type InvestmentPortfolioHolding struct {
 	// Average cost per unit.
-	AverageCost interface{} `json:"averageCost,required"`
+	AverageCost Decimal `json:"averageCost,required"`
 	// Current market price per unit.
-	CurrentPrice interface{} `json:"currentPrice,required"`
+	CurrentPrice Decimal `json:"currentPrice,required"`
 	// Total market value of the holding.
-	MarketValue interface{} `json:"marketValue,required"`
+	MarketValue Decimal `json:"marketValue,required"`
 	// Full name of the investment asset.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// Percentage of the total portfolio value this holding represents.
-	PercentageOfPortfolio interface{} `json:"percentageOfPortfolio,required"`
+	PercentageOfPortfolio float64 `json:"percentageOfPortfolio,required"`
 	// Number of units held.
-	Quantity interface{} `json:"quantity,required"`
+	Quantity float64 `json:"quantity,required"`
 	// Stock ticker or asset symbol.
-	Symbol interface{} `json:"symbol,required"`
+	Symbol string `json:"symbol,required"`
 	// Overall ESG (Environmental, Social, Governance) score of the asset (0-10).
-	EsgScore interface{}                    `json:"esgScore"`
+	EsgScore float64                        `json:"esgScore"`
 	JSON     investmentPortfolioHoldingJSON `json:"-"`
 }
 
@@ -233,44 +263,22 @@ func (r investmentPortfolioHoldingJSON) RawJSON() string {
 	return r.raw
 }
 
-type InvestmentPortfolioListResponse struct {
-	Data []InvestmentPortfolio               `json:"data"`
-	JSON investmentPortfolioListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// investmentPortfolioListResponseJSON contains the JSON metadata for the struct
-// [InvestmentPortfolioListResponse]
-type investmentPortfolioListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *InvestmentPortfolioListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r investmentPortfolioListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type InvestmentPortfolioRebalanceResponse struct {
 	// ID of the portfolio being rebalanced.
-	PortfolioID interface{} `json:"portfolioId,required"`
+	PortfolioID string `json:"portfolioId,required"`
 	// Unique identifier for the rebalancing operation.
-	RebalanceID interface{} `json:"rebalanceId,required"`
+	RebalanceID string `json:"rebalanceId,required"`
 	// Current status of the rebalancing operation.
 	Status InvestmentPortfolioRebalanceResponseStatus `json:"status,required"`
 	// A descriptive message about the current rebalance status.
-	StatusMessage interface{} `json:"statusMessage,required"`
+	StatusMessage string `json:"statusMessage,required"`
 	// Timestamp when the rebalance confirmation expires, if `confirmationRequired` is
 	// true.
-	ConfirmationExpiresAt interface{} `json:"confirmationExpiresAt"`
+	ConfirmationExpiresAt time.Time `json:"confirmationExpiresAt" format:"date-time"`
 	// Indicates if user confirmation is required to proceed with trades.
-	ConfirmationRequired interface{} `json:"confirmationRequired"`
+	ConfirmationRequired bool `json:"confirmationRequired"`
 	// AI-estimated impact of the rebalance on the portfolio.
-	EstimatedImpact interface{} `json:"estimatedImpact"`
+	EstimatedImpact string `json:"estimatedImpact"`
 	// List of proposed trades if `dryRun` was true and status is
 	// `pending_confirmation`.
 	ProposedTrades []InvestmentPortfolioRebalanceResponseProposedTrade `json:"proposedTrades,nullable"`
@@ -321,9 +329,9 @@ func (r InvestmentPortfolioRebalanceResponseStatus) IsKnown() bool {
 
 type InvestmentPortfolioRebalanceResponseProposedTrade struct {
 	Action         InvestmentPortfolioRebalanceResponseProposedTradesAction `json:"action"`
-	EstimatedPrice interface{}                                              `json:"estimatedPrice"`
-	Quantity       interface{}                                              `json:"quantity"`
-	Symbol         interface{}                                              `json:"symbol"`
+	EstimatedPrice Decimal                                                  `json:"estimatedPrice"`
+	Quantity       float64                                                  `json:"quantity"`
+	Symbol         string                                                   `json:"symbol"`
 	JSON           investmentPortfolioRebalanceResponseProposedTradeJSON    `json:"-"`
 }
 
@@ -363,20 +371,20 @@ func (r InvestmentPortfolioRebalanceResponseProposedTradesAction) IsKnown() bool
 
 type InvestmentPortfolioNewParams struct {
 	// ISO 4217 currency code of the portfolio.
-	Currency param.Field[interface{}] `json:"currency,required"`
+	Currency param.Field[string] `json:"currency,required"`
 	// Initial amount to invest into the portfolio.
-	InitialInvestment param.Field[interface{}] `json:"initialInvestment,required"`
+	InitialInvestment param.Field[Decimal] `json:"initialInvestment,required"`
 	// Name for the new investment portfolio.
-	Name param.Field[interface{}] `json:"name,required"`
+	Name param.Field[string] `json:"name,required"`
 	// Desired risk tolerance for this portfolio.
 	RiskTolerance param.Field[InvestmentPortfolioNewParamsRiskTolerance] `json:"riskTolerance,required"`
 	// General type or strategy of the portfolio.
 	Type param.Field[InvestmentPortfolioNewParamsType] `json:"type,required"`
 	// If true, AI will automatically allocate initial investment based on risk
 	// tolerance.
-	AIAutoAllocate param.Field[interface{}] `json:"aiAutoAllocate"`
+	AIAutoAllocate param.Field[bool] `json:"aiAutoAllocate"`
 	// Optional: ID of a linked account to fund the initial investment.
-	LinkedAccountID param.Field[interface{}] `json:"linkedAccountId"`
+	LinkedAccountID param.Field[string] `json:"linkedAccountId"`
 }
 
 func (r InvestmentPortfolioNewParams) MarshalJSON() (data []byte, err error) {
@@ -425,7 +433,7 @@ type InvestmentPortfolioUpdateParams struct {
 	// Updated frequency for AI-driven rebalancing.
 	AIRebalancingFrequency param.Field[InvestmentPortfolioUpdateParamsAIRebalancingFrequency] `json:"aiRebalancingFrequency"`
 	// Updated name of the portfolio.
-	Name param.Field[interface{}] `json:"name"`
+	Name param.Field[string] `json:"name"`
 	// Updated risk tolerance for this portfolio. May trigger rebalancing.
 	RiskTolerance param.Field[InvestmentPortfolioUpdateParamsRiskTolerance] `json:"riskTolerance"`
 }
@@ -473,9 +481,9 @@ func (r InvestmentPortfolioUpdateParamsRiskTolerance) IsKnown() bool {
 
 type InvestmentPortfolioListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [InvestmentPortfolioListParams]'s query parameters as
@@ -492,10 +500,10 @@ type InvestmentPortfolioRebalanceParams struct {
 	TargetRiskTolerance param.Field[InvestmentPortfolioRebalanceParamsTargetRiskTolerance] `json:"targetRiskTolerance,required"`
 	// If true, user confirmation is required before executing actual trades after a
 	// dry run.
-	ConfirmationRequired param.Field[interface{}] `json:"confirmationRequired"`
+	ConfirmationRequired param.Field[bool] `json:"confirmationRequired"`
 	// If true, only simulate the rebalance without executing trades. Returns proposed
 	// trades.
-	DryRun param.Field[interface{}] `json:"dryRun"`
+	DryRun param.Field[bool] `json:"dryRun"`
 }
 
 func (r InvestmentPortfolioRebalanceParams) MarshalJSON() (data []byte, err error) {
@@ -26,13 +26,13 @@ func TestInvestmentPortfolioNewWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Investments.Portfolios.New(context.TODO(), jocall3.InvestmentPortfolioNewParams{
-		Currency:          jocall3.F[any]("USD"),
-		InitialInvestment: jocall3.F[any](10000),
-		Name:              jocall3.F[any]("My First Growth Portfolio"),
+		Currency:          jocall3.F("USD"),
+		InitialInvestment: jocall3.F(jocall3.MustDecimal("10000")),
+		Name:              jocall3.F("My First Growth Portfolio"),
 		RiskTolerance:     jocall3.F(jocall3.InvestmentPortfolioNewParamsRiskToleranceConservative),
 		Type:              jocall3.F(jocall3.InvestmentPortfolioNewParamsTypeDiversified),
-		AIAutoAllocate:    jocall3.F[any](true),
-		LinkedAccountID:   jocall3.F[any]("acc_chase_checking_4567"),
+		AIAutoAllocate:    jocall3.F(true),
+		LinkedAccountID:   jocall3.F("acc_chase_checking_4567"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -82,7 +82,7 @@ func TestInvestmentPortfolioUpdateWithOptionalParams(t *testing.T) {
 		"portfolio_equity_growth",
 		jocall3.InvestmentPortfolioUpdateParams{
 			AIRebalancingFrequency: jocall3.F(jocall3.InvestmentPortfolioUpdateParamsAIRebalancingFrequencyQuarterly),
-			Name:                   jocall3.F[any]("Revised Growth Portfolio"),
+			Name:                   jocall3.F("Revised Growth Portfolio"),
 			RiskTolerance:          jocall3.F(jocall3.InvestmentPortfolioUpdateParamsRiskToleranceConservative),
 		},
 	)
@@ -108,8 +108,8 @@ func TestInvestmentPortfolioListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Investments.Portfolios.List(context.TODO(), jocall3.InvestmentPortfolioListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -137,8 +137,8 @@ func TestInvestmentPortfolioRebalanceWithOptionalParams(t *testing.T) {
 		"portfolio_equity_growth",
 		jocall3.InvestmentPortfolioRebalanceParams{
 			TargetRiskTolerance:  jocall3.F(jocall3.InvestmentPortfolioRebalanceParamsTargetRiskToleranceConservative),
-			ConfirmationRequired: jocall3.F[any](true),
-			DryRun:               jocall3.F[any](true),
+			ConfirmationRequired: jocall3.F(true),
+			DryRun:               jocall3.F(true),
 		},
 	)
 	if err != nil {
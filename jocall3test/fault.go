@@ -0,0 +1,135 @@
+package jocall3test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+)
+
+// Fault changes how a [Server] answers the requests it matches. A fault with
+// neither Status nor Malformed set only adds latency.
+type Fault struct {
+	// The HTTP method to match, or empty to match any method.
+	Method string
+	// The path to match, such as "/budgets/{budgetId}". A segment in braces
+	// matches any single segment. Empty matches any path.
+	Path string
+	// Times is the number of requests the fault applies to before it is removed.
+	// Zero means it applies until [Server.ClearFaults] is called.
+	Times int
+
+	// Latency delays the response. The delay ends early if the client gives up.
+	Latency time.Duration
+	// Status, if not zero, is sent instead of the normal response, with Body, or
+	// with an error object if Body is empty.
+	Status int
+	Header http.Header
+	Body   string
+	// Malformed truncates the normal response body so that it is not valid JSON.
+	Malformed bool
+}
+
+// InjectFault adds f to the faults the server applies. When several faults
+// match a request, the one injected first is used.
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every injected fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// takeFault returns the first fault that matches r, consuming one of its uses.
+// It must be called with s.mu held.
+func (s *Server) takeFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if !f.matches(r) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		copied := *f
+		return &copied
+	}
+	return nil
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+		return false
+	}
+	if f.Path == "" {
+		return true
+	}
+	want := strings.Split(strings.Trim(f.Path, "/"), "/")
+	got := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if strings.HasPrefix(want[i], "{") && strings.HasSuffix(want[i], "}") {
+			continue
+		}
+		if want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *Fault) serve(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if f.Latency > 0 {
+		timer := time.NewTimer(f.Latency)
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			timer.Stop()
+			return
+		}
+	}
+	for k, v := range f.Header {
+		w.Header()[k] = v
+	}
+
+	switch {
+	case f.Status != 0:
+		if f.Body == "" {
+			writeError(w, f.Status, strings.ToLower(strings.ReplaceAll(http.StatusText(f.Status), " ", "_")), fmt.Sprintf("injected fault for %s %s", r.Method, r.URL.Path))
+			return
+		}
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.WriteHeader(f.Status)
+		w.Write([]byte(f.Body))
+	case f.Malformed:
+		rec := httptest.NewRecorder()
+		next.ServeHTTP(rec, r)
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		body := bytes.TrimSpace(rec.Body.Bytes())
+		if len(body) < 2 {
+			body = []byte(`{"`)
+		} else {
+			body = body[:len(body)/2]
+		}
+		w.Header().Del("Content-Length")
+		w.WriteHeader(rec.Code)
+		w.Write(body)
+	default:
+		next.ServeHTTP(w, r)
+	}
+}
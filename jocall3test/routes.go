@@ -0,0 +1,289 @@
+package jocall3test
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	mathrand "math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("jocall3test does not implement %s %s", r.Method, r.URL.Path))
+	})
+
+	s.mux.HandleFunc("GET /accounts/me", s.list(Accounts))
+	s.mux.HandleFunc("GET /accounts/{accountId}/details", s.get(Accounts, "accountId"))
+	s.mux.HandleFunc("GET /accounts/{accountId}/transactions/pending", s.children(Accounts, "accountId", Transactions, func(parent, obj map[string]interface{}) bool {
+		_, posted := obj["postedDate"]
+		return obj["accountId"] == parent["id"] && !posted
+	}))
+
+	s.resource("/budgets", Budgets, "budgetId", func(now time.Time, obj map[string]interface{}) {
+		setDefault(obj, "status", "active")
+		setDefault(obj, "period", "monthly")
+		setDefault(obj, "alertThreshold", json.Number("80"))
+		setDefault(obj, "categories", []interface{}{})
+		setDefault(obj, "spentAmount", json.Number("0"))
+		setDefault(obj, "remainingAmount", obj["totalAmount"])
+	})
+	s.mux.HandleFunc("POST /budgets", s.create(Budgets))
+
+	s.resource("/goals", Goals, "goalId", func(now time.Time, obj map[string]interface{}) {
+		setDefault(obj, "currentAmount", obj["initialContribution"])
+		setDefault(obj, "currentAmount", json.Number("0"))
+		setDefault(obj, "progressPercentage", json.Number("0"))
+		setDefault(obj, "status", "on_track")
+		obj["lastUpdated"] = now.Format(time.RFC3339)
+	})
+	s.mux.HandleFunc("POST /goals", s.create(Goals))
+
+	s.mux.HandleFunc("GET /transactions", s.listTransactions)
+	s.mux.HandleFunc("GET /transactions/{transactionId}", s.get(Transactions, "transactionId"))
+	s.mux.HandleFunc("PUT /transactions/{transactionId}/categorize", s.update(Transactions, "transactionId", func(now time.Time, obj, body map[string]interface{}) interface{} {
+		obj["category"] = body["category"]
+		if notes, ok := body["notes"]; ok {
+			obj["notes"] = notes
+		}
+		return obj
+	}))
+	s.mux.HandleFunc("PUT /transactions/{transactionId}/notes", s.update(Transactions, "transactionId", func(now time.Time, obj, body map[string]interface{}) interface{} {
+		obj["notes"] = body["notes"]
+		return obj
+	}))
+	s.mux.HandleFunc("POST /transactions/{transactionId}/dispute", s.update(Transactions, "transactionId", func(now time.Time, obj, body map[string]interface{}) interface{} {
+		obj["disputeStatus"] = "pending"
+		return map[string]interface{}{
+			"disputeId":   "dispute_" + obj["id"].(string),
+			"status":      "pending",
+			"lastUpdated": now.Format(time.RFC3339),
+			"nextSteps":   "Our team will review the dispute within 5 business days.",
+		}
+	}))
+
+	s.defaults[RecurringTransactions] = func(now time.Time, obj map[string]interface{}) {
+		setDefault(obj, "status", "active")
+		setDefault(obj, "nextDueDate", obj["startDate"])
+	}
+	s.mux.HandleFunc("GET /transactions/recurring", s.list(RecurringTransactions))
+	s.mux.HandleFunc("POST /transactions/recurring", s.create(RecurringTransactions))
+
+	s.resource("/developers/webhooks", Webhooks, "subscriptionId", func(now time.Time, obj map[string]interface{}) {
+		setDefault(obj, "status", "active")
+		setDefault(obj, "failureCount", json.Number("0"))
+		setDefault(obj, "secret", "whsec_"+randomHex(16))
+		obj["createdAt"] = now.Format(time.RFC3339)
+	})
+	s.mux.HandleFunc("POST /developers/webhooks", s.create(Webhooks))
+
+	s.resource("/developers/api-keys", APIKeys, "keyId", func(now time.Time, obj map[string]interface{}) {
+		setDefault(obj, "status", "active")
+		setDefault(obj, "scopes", []interface{}{})
+		obj["prefix"] = "sk_test_" + randomHex(4)
+		obj["createdAt"] = now.Format(time.RFC3339)
+		if days, ok := number(obj["expiresInDays"]); ok {
+			obj["expiresAt"] = now.Add(time.Duration(days) * 24 * time.Hour).Format(time.RFC3339)
+		}
+		delete(obj, "expiresInDays")
+	})
+	s.mux.HandleFunc("POST /developers/api-keys", s.create(APIKeys))
+
+	s.resource("/corporate/cards", Cards, "cardId", func(now time.Time, obj map[string]interface{}) {
+		setDefault(obj, "cardNumberMask", fmt.Sprintf("**** **** **** %04d", mathrand.Intn(10000)))
+		setDefault(obj, "cardType", "virtual")
+		setDefault(obj, "currency", "USD")
+		setDefault(obj, "frozen", false)
+		setDefault(obj, "status", "Active")
+		setDefault(obj, "controls", map[string]interface{}{})
+		obj["createdDate"] = now.Format(time.RFC3339)
+	})
+	s.mux.HandleFunc("POST /corporate/cards/virtual", s.create(Cards))
+	s.mux.HandleFunc("PUT /corporate/cards/{cardId}/controls", s.update(Cards, "cardId", func(now time.Time, obj, body map[string]interface{}) interface{} {
+		controls, _ := obj["controls"].(map[string]interface{})
+		if controls == nil {
+			controls = map[string]interface{}{}
+		}
+		for k, v := range body {
+			controls[k] = v
+		}
+		obj["controls"] = controls
+		return obj
+	}))
+	s.mux.HandleFunc("POST /corporate/cards/{cardId}/freeze", s.update(Cards, "cardId", func(now time.Time, obj, body map[string]interface{}) interface{} {
+		frozen, _ := body["freeze"].(bool)
+		obj["frozen"] = frozen
+		return obj
+	}))
+	s.mux.HandleFunc("GET /corporate/cards/{cardId}/transactions", s.children(Cards, "cardId", Transactions, func(parent, obj map[string]interface{}) bool {
+		return obj["cardId"] == parent["id"]
+	}))
+}
+
+// resource registers the list, get, update and delete routes of a collection
+// rooted at path. Creation routes differ between resources and are registered
+// separately; defaults, if not nil, completes the objects created through them.
+func (s *Server) resource(path string, c Collection, param string, defaults func(now time.Time, obj map[string]interface{})) {
+	if defaults != nil {
+		s.defaults[c] = defaults
+	}
+	s.mux.HandleFunc("GET "+path, s.list(c))
+	s.mux.HandleFunc("GET "+path+"/{"+param+"}", s.get(c, param))
+	s.mux.HandleFunc("PUT "+path+"/{"+param+"}", s.update(c, param, func(now time.Time, obj, body map[string]interface{}) interface{} {
+		for k, v := range body {
+			obj[k] = v
+		}
+		if _, ok := obj["lastUpdated"]; ok {
+			obj["lastUpdated"] = now.Format(time.RFC3339)
+		}
+		return obj
+	}))
+	s.mux.HandleFunc("DELETE "+path+"/{"+param+"}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue(param)
+		s.mu.Lock()
+		removed := s.collection(c).remove(id)
+		s.mu.Unlock()
+		if !removed {
+			notFound(w, c, id)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) list(c Collection) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		s.page(w, r, c, func(obj map[string]interface{}) bool {
+			return matchQuery(query, obj)
+		})
+	}
+}
+
+func (s *Server) listTransactions(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lo, hasLo := number(query.Get("minAmount"))
+	hi, hasHi := number(query.Get("maxAmount"))
+	start, end := query.Get("startDate"), query.Get("endDate")
+	search := strings.ToLower(query.Get("searchQuery"))
+
+	s.page(w, r, Transactions, func(obj map[string]interface{}) bool {
+		if !matchQuery(query, obj, "minAmount", "maxAmount", "startDate", "endDate", "searchQuery") {
+			return false
+		}
+		if amount, ok := number(obj["amount"]); ok {
+			if (hasLo && amount < lo) || (hasHi && amount > hi) {
+				return false
+			}
+		}
+		date, _ := obj["date"].(string)
+		if (start != "" && date < start) || (end != "" && date[:min(len(date), 10)] > end) {
+			return false
+		}
+		if search != "" {
+			var text strings.Builder
+			for _, key := range []string{"description", "notes", "category"} {
+				text.WriteString(fmt.Sprint(obj[key], " "))
+			}
+			if merchant, ok := obj["merchantDetails"].(map[string]interface{}); ok {
+				text.WriteString(fmt.Sprint(merchant["name"]))
+			}
+			if !strings.Contains(strings.ToLower(text.String()), search) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// children lists the objects of c that belong to the parent object named by
+// the path parameter.
+func (s *Server) children(parent Collection, param string, c Collection, belongs func(parent, obj map[string]interface{}) bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue(param)
+		owner, ok := s.Item(parent, id)
+		if !ok {
+			notFound(w, parent, id)
+			return
+		}
+		query := r.URL.Query()
+		s.page(w, r, c, func(obj map[string]interface{}) bool {
+			return belongs(owner, obj) && matchQuery(query, obj)
+		})
+	}
+}
+
+func (s *Server) get(c Collection, param string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue(param)
+		obj, ok := s.Item(c, id)
+		if !ok {
+			notFound(w, c, id)
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+	}
+}
+
+func (s *Server) create(c Collection) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		obj, err := readObject(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", "request body must be a JSON object: "+err.Error())
+			return
+		}
+		delete(obj, "id")
+
+		s.mu.Lock()
+		if defaults := s.defaults[c]; defaults != nil {
+			defaults(s.now(), obj)
+		}
+		s.collection(c).insert(obj)
+		out := clone(obj)
+		s.mu.Unlock()
+		writeJSON(w, http.StatusCreated, out)
+	}
+}
+
+// update applies apply to a stored object and responds with its result.
+func (s *Server) update(c Collection, param string, apply func(now time.Time, obj, body map[string]interface{}) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := readObject(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", "request body must be a JSON object: "+err.Error())
+			return
+		}
+		delete(body, "id")
+
+		id := r.PathValue(param)
+		s.mu.Lock()
+		obj, ok := s.collection(c).items[id]
+		if !ok {
+			s.mu.Unlock()
+			notFound(w, c, id)
+			return
+		}
+		res, err := toObject(apply(s.now(), obj, body))
+		s.mu.Unlock()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "internal_error", err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, res)
+	}
+}
+
+func setDefault(obj map[string]interface{}, key string, value interface{}) {
+	if _, ok := obj[key]; !ok && value != nil {
+		obj[key] = value
+	}
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,424 @@
+// Package jocall3test provides an in-process fake of the jocall3 API, so that
+// code using the SDK can be tested without a network connection or a mock
+// server.
+//
+// The fake keeps its state in memory. It implements the create, get, list,
+// update and delete routes of the resources listed as [Collection] constants,
+// including offset pagination, and answers every other route with a 404 unless
+// a handler is registered for it with [Server.Handle]. Faults such as error
+// statuses, latency and malformed bodies can be injected with
+// [Server.InjectFault].
+//
+//	srv := jocall3test.NewServer()
+//	defer srv.Close()
+//	srv.Seed(jocall3test.Transactions, map[string]interface{}{"accountId": "acc_1", "amount": -42.1})
+//	client := srv.Client()
+package jocall3test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+// Collection names a kind of object held by a [Server].
+type Collection string
+
+const (
+	Accounts              Collection = "accounts"
+	APIKeys               Collection = "api_keys"
+	Budgets               Collection = "budgets"
+	Cards                 Collection = "cards"
+	Goals                 Collection = "goals"
+	RecurringTransactions Collection = "recurring_transactions"
+	Transactions          Collection = "transactions"
+	Webhooks              Collection = "webhooks"
+)
+
+// DefaultPageLimit is the page size used when a list request has no limit.
+const DefaultPageLimit = 20
+
+// Request is a request received by a [Server].
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake jocall3 API listening on a local address. It is safe for
+// concurrent use.
+type Server struct {
+	// URL is the base URL of the server, without a trailing slash.
+	URL string
+
+	srv      *httptest.Server
+	mux      *http.ServeMux
+	custom   *http.ServeMux
+	mu       sync.Mutex
+	now      func() time.Time
+	store    map[Collection]*collection
+	defaults map[Collection]func(now time.Time, obj map[string]interface{})
+	faults   []*Fault
+	requests []Request
+}
+
+// NewServer starts a fake server. It must be closed with [Server.Close].
+func NewServer() *Server {
+	s := &Server{
+		mux:      http.NewServeMux(),
+		custom:   http.NewServeMux(),
+		now:      time.Now,
+		store:    map[Collection]*collection{},
+		defaults: map[Collection]func(now time.Time, obj map[string]interface{}){},
+	}
+	s.routes()
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts the server down, waiting for outstanding requests to finish.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Options returns the request options that point a client at the server.
+func (s *Server) Options() []option.RequestOption {
+	return []option.RequestOption{
+		option.WithBaseURL(s.URL),
+		option.WithHTTPClient(s.srv.Client()),
+		option.WithAPIKey("jocall3test"),
+	}
+}
+
+// Client returns a client for the server. The options are applied after the
+// ones from [Server.Options].
+func (s *Server) Client(opts ...option.RequestOption) *jocall3.Client {
+	return jocall3.NewClient(append(s.Options(), opts...)...)
+}
+
+// SetClock replaces the function used to timestamp created and updated objects.
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// Handle registers handler for pattern, which uses the syntax of
+// [http.ServeMux] such as "GET /users/me". Handlers registered this way take
+// precedence over the built-in routes and may use [http.Request.PathValue].
+func (s *Server) Handle(pattern string, handler http.HandlerFunc) {
+	s.custom.HandleFunc(pattern, handler)
+}
+
+// Seed stores items in the collection c and returns their IDs. Each item must
+// marshal to a JSON object; items without an "id" are assigned one.
+func (s *Server) Seed(c Collection, items ...interface{}) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		obj, err := toObject(item)
+		if err != nil {
+			panic(fmt.Sprintf("jocall3test: cannot seed %s: %v", c, err))
+		}
+		ids = append(ids, s.collection(c).insert(obj))
+	}
+	return ids
+}
+
+// Item returns a copy of the object with the given ID.
+func (s *Server) Item(c Collection, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.collection(c).items[id]
+	if !ok {
+		return nil, false
+	}
+	return clone(obj), true
+}
+
+// Items returns copies of every object in the collection, in insertion order.
+func (s *Server) Items(c Collection) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	col := s.collection(c)
+	out := make([]map[string]interface{}, 0, len(col.ids))
+	for _, id := range col.ids {
+		out = append(out, clone(col.items[id]))
+	}
+	return out
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Reset removes all objects, faults and recorded requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store = map[Collection]*collection{}
+	s.faults = nil
+	s.requests = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	fault := s.takeFault(r)
+	s.mu.Unlock()
+
+	handler := http.Handler(s.mux)
+	if _, pattern := s.custom.Handler(r); pattern != "" {
+		handler = s.custom
+	}
+	if fault != nil {
+		fault.serve(w, r, handler)
+		return
+	}
+	handler.ServeHTTP(w, r)
+}
+
+func (s *Server) collection(c Collection) *collection {
+	col, ok := s.store[c]
+	if !ok {
+		col = &collection{prefix: idPrefixes[c], items: map[string]map[string]interface{}{}}
+		s.store[c] = col
+	}
+	return col
+}
+
+var idPrefixes = map[Collection]string{
+	Accounts:              "acc_",
+	APIKeys:               "key_",
+	Budgets:               "budget_",
+	Cards:                 "card_",
+	Goals:                 "goal_",
+	RecurringTransactions: "rec_",
+	Transactions:          "txn_",
+	Webhooks:              "wh_",
+}
+
+type collection struct {
+	prefix string
+	next   int
+	ids    []string
+	items  map[string]map[string]interface{}
+}
+
+func (c *collection) insert(obj map[string]interface{}) string {
+	id, _ := obj["id"].(string)
+	if id == "" {
+		c.next++
+		id = c.prefix + strconv.Itoa(c.next)
+		for c.items[id] != nil {
+			c.next++
+			id = c.prefix + strconv.Itoa(c.next)
+		}
+		obj["id"] = id
+	}
+	if _, exists := c.items[id]; !exists {
+		c.ids = append(c.ids, id)
+	}
+	c.items[id] = obj
+	return id
+}
+
+func (c *collection) remove(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	for i, existing := range c.ids {
+		if existing == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// page writes the objects of c accepted by keep as a page, honouring the limit
+// and offset query parameters.
+func (s *Server) page(w http.ResponseWriter, r *http.Request, c Collection, keep func(obj map[string]interface{}) bool) {
+	query := r.URL.Query()
+	limit, offset := int64(DefaultPageLimit), int64(0)
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			writeError(w, http.StatusBadRequest, "invalid_parameter", "limit must be a positive integer")
+			return
+		}
+		limit = n
+	}
+	if v := query.Get("offset"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			writeError(w, http.StatusBadRequest, "invalid_parameter", "offset must be a non-negative integer")
+			return
+		}
+		offset = n
+	}
+
+	s.mu.Lock()
+	col := s.collection(c)
+	matched := []map[string]interface{}{}
+	for _, id := range col.ids {
+		if obj := col.items[id]; keep == nil || keep(obj) {
+			matched = append(matched, clone(obj))
+		}
+	}
+	s.mu.Unlock()
+
+	total := int64(len(matched))
+	data := []map[string]interface{}{}
+	if offset < total {
+		data = matched[offset:min(offset+limit, total)]
+	}
+	var nextOffset interface{}
+	if offset+limit < total {
+		nextOffset = offset + limit
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data":       data,
+		"limit":      limit,
+		"offset":     offset,
+		"total":      total,
+		"nextOffset": nextOffset,
+	})
+}
+
+// matchQuery reports whether obj has the value given for every query parameter
+// other than those in skip that names one of its top-level scalar fields.
+func matchQuery(query url.Values, obj map[string]interface{}, skip ...string) bool {
+	for key, values := range query {
+		if key == "limit" || key == "offset" || contains(skip, key) {
+			continue
+		}
+		field, ok := obj[key]
+		if !ok {
+			continue
+		}
+		switch field.(type) {
+		case string, bool, json.Number:
+			if fmt.Sprint(field) != values[0] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func readObject(r *http.Request) (map[string]interface{}, error) {
+	obj := map[string]interface{}{}
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		// Uploaded files are stored by name; their contents are discarded.
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+		for k, v := range r.MultipartForm.Value {
+			obj[k] = v[0]
+		}
+		for k, files := range r.MultipartForm.File {
+			obj[k] = files[0].Filename
+		}
+		return obj, nil
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(bytes.TrimSpace(body)) == 0 {
+		return obj, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func toObject(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	obj := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func clone(obj map[string]interface{}) map[string]interface{} {
+	out, _ := toObject(obj)
+	return out
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{"code": code, "message": message})
+}
+
+func notFound(w http.ResponseWriter, c Collection, id string) {
+	writeError(w, http.StatusNotFound, "not_found", fmt.Sprintf("%s %q was not found", strings.TrimSuffix(string(c), "s"), id))
+}
+
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
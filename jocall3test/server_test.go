@@ -0,0 +1,187 @@
+package jocall3test_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/jocall3test"
+	"github.com/jocall3/go/option"
+)
+
+func TestBudgetLifecycle(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+	ctx := context.Background()
+
+	budget, err := client.Budgets.New(ctx, jocall3.BudgetNewParams{
+		Name:        jocall3.F("Groceries"),
+		TotalAmount: jocall3.F(jocall3.MustDecimal("500.00")),
+		StartDate:   jocall3.F(time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)),
+		EndDate:     jocall3.F(time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)),
+		Period:      jocall3.F(jocall3.BudgetNewParamsPeriodMonthly),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if budget.ID != "budget_1" || budget.Status != jocall3.BudgetStatusActive || budget.RemainingAmount.String() != "500.00" {
+		t.Fatalf("unexpected budget: %+v", budget)
+	}
+
+	updated, err := client.Budgets.Update(ctx, budget.ID, jocall3.BudgetUpdateParams{Name: jocall3.F("Food")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Name != "Food" || !updated.TotalAmount.Equal(budget.TotalAmount) {
+		t.Fatalf("unexpected update: %+v", updated)
+	}
+
+	got, err := client.Budgets.Get(ctx, budget.ID)
+	if err != nil || got.Name != "Food" {
+		t.Fatalf("unexpected get: %+v, %v", got, err)
+	}
+
+	if err := client.Budgets.Delete(ctx, budget.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = client.Budgets.Get(ctx, budget.ID)
+	var apierr *jocall3.Error
+	if !errors.As(err, &apierr) || apierr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404, got %v", err)
+	}
+}
+
+func TestTransactionListing(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	for i, amount := range []string{"-12.50", "-80.00", "2500.00", "-4.25", "-60.10"} {
+		txn := map[string]interface{}{
+			"accountId":   "acc_1",
+			"amount":      jocall3.MustDecimal(amount),
+			"currency":    "USD",
+			"date":        time.Date(2024, 7, i+1, 0, 0, 0, 0, time.UTC).Format("2006-01-02"),
+			"description": "Purchase",
+			"category":    "Dining",
+			"type":        "expense",
+		}
+		if i == 2 {
+			txn["description"], txn["category"], txn["type"] = "Salary", "Income", "income"
+		}
+		if i != 4 {
+			txn["postedDate"] = txn["date"]
+		}
+		srv.Seed(jocall3test.Transactions, txn)
+	}
+	srv.Seed(jocall3test.Accounts, map[string]interface{}{"id": "acc_1", "name": "Checking", "currency": "USD", "currentBalance": 1000})
+	client := srv.Client()
+	ctx := context.Background()
+
+	var ids []string
+	iter := client.Transactions.ListAutoPaging(ctx, jocall3.TransactionListParams{
+		Limit: jocall3.F(int64(2)),
+		Type:  jocall3.F(jocall3.TransactionListParamsTypeExpense),
+	})
+	for iter.Next() {
+		ids = append(ids, iter.Current().ID)
+	}
+	if iter.Err() != nil || strings.Join(ids, ",") != "txn_1,txn_2,txn_4,txn_5" {
+		t.Fatalf("unexpected expenses %v (%v)", ids, iter.Err())
+	}
+	if n := len(srv.Requests()); n != 2 {
+		t.Fatalf("expected 2 page requests, got %d", n)
+	}
+
+	page, err := client.Transactions.List(ctx, jocall3.TransactionListParams{
+		MinAmount: jocall3.F(jocall3.MustDecimal("-70")),
+		MaxAmount: jocall3.F(jocall3.MustDecimal("0")),
+		StartDate: jocall3.F(time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil || page.Total != 2 || page.Data[0].ID != "txn_4" || page.Data[1].ID != "txn_5" {
+		t.Fatalf("unexpected filtered page: %+v, %v", page, err)
+	}
+
+	pending, err := client.Accounts.Transactions.GetPending(ctx, "acc_1", jocall3.AccountTransactionGetPendingParams{})
+	if err != nil || len(pending.Data) != 1 || pending.Data[0].ID != "txn_5" {
+		t.Fatalf("unexpected pending transactions: %+v, %v", pending, err)
+	}
+}
+
+func TestDisputeWithEvidence(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	srv.Seed(jocall3test.Transactions, map[string]interface{}{"accountId": "acc_1", "amount": -42.1})
+	client := srv.Client()
+
+	res, err := client.Transactions.DisputeWithEvidence(context.Background(), "txn_1", jocall3.TransactionDisputeWithEvidenceParams{
+		Details:             jocall3.F("Never arrived"),
+		Reason:              jocall3.F(jocall3.TransactionDisputeParamsReasonProductServiceIssue),
+		SupportingDocuments: jocall3.F([]io.Reader{jocall3.FileParam(strings.NewReader("tracking"), "tracking.txt", "").Value}),
+	})
+	if err != nil || res.DisputeID != "dispute_txn_1" {
+		t.Fatalf("unexpected dispute: %+v, %v", res, err)
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	srv.Seed(jocall3test.Goals, map[string]interface{}{"name": "House", "targetAmount": 50000})
+	client := srv.Client(option.WithMaxRetries(0))
+	ctx := context.Background()
+
+	srv.InjectFault(jocall3test.Fault{Method: http.MethodGet, Path: "/goals/{goalId}", Status: http.StatusServiceUnavailable, Times: 1})
+	_, err := client.Goals.Get(ctx, "goal_1")
+	var apierr *jocall3.Error
+	if !errors.As(err, &apierr) || apierr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503, got %v", err)
+	}
+	if goal, err := client.Goals.Get(ctx, "goal_1"); err != nil || goal.Name != "House" {
+		t.Fatalf("expected the fault to be used up, got %+v, %v", goal, err)
+	}
+
+	srv.InjectFault(jocall3test.Fault{Path: "/goals/goal_1", Malformed: true, Times: 1})
+	if _, err := client.Goals.Get(ctx, "goal_1"); err == nil || errors.As(err, &apierr) {
+		t.Fatalf("expected a decoding error, got %v", err)
+	}
+
+	srv.InjectFault(jocall3test.Fault{Latency: time.Second})
+	start := time.Now()
+	_, err = client.Goals.Get(ctx, "goal_1", option.WithRequestTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > 500*time.Millisecond {
+		t.Fatalf("expected the request to time out quickly, got %v", err)
+	}
+	srv.ClearFaults()
+
+	retrying := srv.Client()
+	srv.InjectFault(jocall3test.Fault{Status: http.StatusTooManyRequests, Header: http.Header{"Retry-After-Ms": []string{"1"}}, Times: 2})
+	if _, err := retrying.Goals.Get(ctx, "goal_1"); err != nil {
+		t.Fatalf("expected the client to retry past the faults, got %v", err)
+	}
+}
+
+func TestCustomHandler(t *testing.T) {
+	srv := jocall3test.NewServer()
+	defer srv.Close()
+	client := srv.Client(option.WithMaxRetries(0))
+
+	_, err := client.Users.Me.Get(context.Background())
+	var apierr *jocall3.Error
+	if !errors.As(err, &apierr) || apierr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected unimplemented routes to 404, got %v", err)
+	}
+
+	srv.Handle("GET /users/me", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"user_1","name":"Alice","email":"alice@example.com"}`))
+	})
+	user, err := client.Users.Me.Get(context.Background())
+	if err != nil || user.ID != "user_1" {
+		t.Fatalf("unexpected user: %+v, %v", user, err)
+	}
+}
@@ -4,9 +4,11 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -35,9 +37,13 @@ func NewLendingApplicationService(opts ...option.RequestOption) (r *LendingAppli
 
 // Retrieves the current status and detailed information for a submitted loan
 // application, including AI underwriting outcomes, approved terms, and next steps.
-func (r *LendingApplicationService) Get(ctx context.Context, applicationID interface{}, opts ...option.RequestOption) (res *LoanApplicationStatus, err error) {
+func (r *LendingApplicationService) Get(ctx context.Context, applicationID string, opts ...option.RequestOption) (res *LoanApplicationStatus, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("lending/applications/%v", applicationID)
+	if applicationID == "" {
+		err = errors.New("missing required applicationId parameter")
+		return
+	}
+	path := fmt.Sprintf("lending/applications/%s", applicationID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
@@ -54,15 +60,15 @@ func (r *LendingApplicationService) Submit(ctx context.Context, body LendingAppl
 
 type LoanApplicationStatus struct {
 	// Timestamp when the application was submitted.
-	ApplicationDate interface{} `json:"applicationDate,required"`
+	ApplicationDate time.Time `json:"applicationDate,required" format:"date-time"`
 	// Unique identifier for the loan application.
-	ApplicationID interface{} `json:"applicationId,required"`
+	ApplicationID string `json:"applicationId,required"`
 	// The amount originally requested in the application.
-	LoanAmountRequested interface{} `json:"loanAmountRequested,required"`
+	LoanAmountRequested Decimal `json:"loanAmountRequested,required"`
 	// The purpose of the loan.
 	LoanPurpose LoanApplicationStatusLoanPurpose `json:"loanPurpose,required"`
 	// Guidance on the next actions for the user.
-	NextSteps interface{} `json:"nextSteps,required"`
+	NextSteps string `json:"nextSteps,required"`
 	// Current status of the loan application.
 	Status               LoanApplicationStatusStatus               `json:"status,required"`
 	AIUnderwritingResult LoanApplicationStatusAIUnderwritingResult `json:"aiUnderwritingResult"`
@@ -136,15 +142,15 @@ func (r LoanApplicationStatusStatus) IsKnown() bool {
 
 type LoanApplicationStatusAIUnderwritingResult struct {
 	// AI's confidence in its underwriting decision (0-1).
-	AIConfidence interface{} `json:"aiConfidence,required"`
+	AIConfidence float64 `json:"aiConfidence,required"`
 	// The AI's underwriting decision.
 	Decision LoanApplicationStatusAIUnderwritingResultDecision `json:"decision,required"`
 	// Reasoning for the AI's decision.
-	Reason interface{} `json:"reason,required"`
+	Reason string `json:"reason,required"`
 	// The maximum amount the AI is willing to approve.
-	MaxApprovedAmount interface{} `json:"maxApprovedAmount"`
+	MaxApprovedAmount Decimal `json:"maxApprovedAmount"`
 	// The interest rate recommended by the AI.
-	RecommendedInterestRate interface{}                                   `json:"recommendedInterestRate"`
+	RecommendedInterestRate float64                                       `json:"recommendedInterestRate"`
 	JSON                    loanApplicationStatusAIUnderwritingResultJSON `json:"-"`
 }
 
@@ -187,13 +193,13 @@ func (r LoanApplicationStatusAIUnderwritingResultDecision) IsKnown() bool {
 
 type LendingApplicationSubmitParams struct {
 	// The desired loan amount.
-	LoanAmount param.Field[interface{}] `json:"loanAmount,required"`
+	LoanAmount param.Field[Decimal] `json:"loanAmount,required"`
 	// The purpose of the loan.
 	LoanPurpose param.Field[LendingApplicationSubmitParamsLoanPurpose] `json:"loanPurpose,required"`
 	// The desired repayment term in months.
-	RepaymentTermMonths param.Field[interface{}] `json:"repaymentTermMonths,required"`
+	RepaymentTermMonths param.Field[int64] `json:"repaymentTermMonths,required"`
 	// Optional notes or details for the application.
-	AdditionalNotes param.Field[interface{}] `json:"additionalNotes"`
+	AdditionalNotes param.Field[string] `json:"additionalNotes"`
 	// Optional: Details of a co-applicant for the loan.
 	CoApplicant param.Field[LendingApplicationSubmitParamsCoApplicant] `json:"coApplicant"`
 }
@@ -224,9 +230,9 @@ func (r LendingApplicationSubmitParamsLoanPurpose) IsKnown() bool {
 
 // Optional: Details of a co-applicant for the loan.
 type LendingApplicationSubmitParamsCoApplicant struct {
-	Email  param.Field[interface{}] `json:"email"`
-	Income param.Field[interface{}] `json:"income"`
-	Name   param.Field[interface{}] `json:"name"`
+	Email  param.Field[string]  `json:"email"`
+	Income param.Field[Decimal] `json:"income"`
+	Name   param.Field[string]  `json:"name"`
 }
 
 func (r LendingApplicationSubmitParamsCoApplicant) MarshalJSON() (data []byte, err error) {
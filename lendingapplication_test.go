@@ -48,14 +48,14 @@ func TestLendingApplicationSubmitWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Lending.Applications.Submit(context.TODO(), jocall3.LendingApplicationSubmitParams{
-		LoanAmount:          jocall3.F[any](10000),
+		LoanAmount:          jocall3.F(jocall3.MustDecimal("10000")),
 		LoanPurpose:         jocall3.F(jocall3.LendingApplicationSubmitParamsLoanPurposeHomeImprovement),
-		RepaymentTermMonths: jocall3.F[any](36),
-		AdditionalNotes:     jocall3.F[any]("Funds needed to replace a broken HVAC system."),
+		RepaymentTermMonths: jocall3.F(int64(36)),
+		AdditionalNotes:     jocall3.F("Funds needed to replace a broken HVAC system."),
 		CoApplicant: jocall3.F(jocall3.LendingApplicationSubmitParamsCoApplicant{
-			Email:  jocall3.F[any]("jane.doe@example.com"),
-			Income: jocall3.F[any](75000),
-			Name:   jocall3.F[any]("Jane Doe"),
+			Email:  jocall3.F("jane.doe@example.com"),
+			Income: jocall3.F(jocall3.MustDecimal("75000")),
+			Name:   jocall3.F("Jane Doe"),
 		}),
 	})
 	if err != nil {
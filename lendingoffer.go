@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // LendingOfferService contains methods and other services that help with
@@ -36,38 +38,54 @@ func NewLendingOfferService(opts ...option.RequestOption) (r *LendingOfferServic
 
 // Retrieves a list of personalized, pre-approved loan offers generated by the AI
 // based on the user's financial profile and credit health.
-func (r *LendingOfferService) ListPreApproved(ctx context.Context, query LendingOfferListPreApprovedParams, opts ...option.RequestOption) (res *LendingOfferListPreApprovedResponse, err error) {
+func (r *LendingOfferService) ListPreApproved(ctx context.Context, query LendingOfferListPreApprovedParams, opts ...option.RequestOption) (res *pagination.Page[LoanOffer], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "lending/offers/pre-approved"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of personalized, pre-approved loan offers generated by the AI
+// based on the user's financial profile and credit health.
+func (r *LendingOfferService) ListPreApprovedAutoPaging(ctx context.Context, query LendingOfferListPreApprovedParams, opts ...option.RequestOption) *pagination.PageAutoPager[LoanOffer] {
+	return pagination.NewPageAutoPager(r.ListPreApproved(ctx, query, opts...))
 }
 
 type LoanOffer struct {
 	// The offered loan amount.
-	Amount interface{} `json:"amount,required"`
+	Amount Decimal `json:"amount,required"`
 	// Date the offer expires.
-	ExpirationDate interface{} `json:"expirationDate,required"`
+	ExpirationDate time.Time `json:"expirationDate,required" format:"date"`
 	// Annual interest rate offered (as a percentage).
-	InterestRate interface{} `json:"interestRate,required"`
+	InterestRate float64 `json:"interestRate,required"`
 	// Indicates if this is a pre-approved offer.
-	IsPreApproved interface{} `json:"isPreApproved,required"`
+	IsPreApproved bool `json:"isPreApproved,required"`
 	// Unique identifier for the loan offer.
-	OfferID interface{} `json:"offerId,required"`
+	OfferID string `json:"offerId,required"`
 	// Type of loan being offered.
 	OfferType LoanOfferOfferType `json:"offerType,required"`
 	// AI's score for how well this offer is personalized to the user (0-1).
-	AIPersonalizationScore interface{} `json:"aiPersonalizationScore"`
+	AIPersonalizationScore float64 `json:"aiPersonalizationScore"`
 	// Estimated monthly payment (if applicable).
-	MonthlyPayment interface{} `json:"monthlyPayment"`
+	MonthlyPayment Decimal `json:"monthlyPayment"`
 	// Any origination fees for the loan.
-	OriginationFee interface{} `json:"originationFee"`
+	OriginationFee Decimal `json:"originationFee"`
 	// Repayment term in months (if applicable).
-	RepaymentTermMonths interface{} `json:"repaymentTermMonths"`
+	RepaymentTermMonths int64 `json:"repaymentTermMonths"`
 	// URL to the full terms and conditions of the loan offer.
-	TermsAndConditionsURL interface{} `json:"termsAndConditionsUrl"`
+	TermsAndConditionsURL string `json:"termsAndConditionsUrl"`
 	// Total amount repayable over the loan term.
-	TotalRepayable interface{}   `json:"totalRepayable"`
+	TotalRepayable Decimal       `json:"totalRepayable"`
 	JSON           loanOfferJSON `json:"-"`
 }
 
@@ -116,33 +134,11 @@ func (r LoanOfferOfferType) IsKnown() bool {
 	return false
 }
 
-type LendingOfferListPreApprovedResponse struct {
-	Data []LoanOffer                             `json:"data"`
-	JSON lendingOfferListPreApprovedResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// lendingOfferListPreApprovedResponseJSON contains the JSON metadata for the
-// struct [LendingOfferListPreApprovedResponse]
-type lendingOfferListPreApprovedResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *LendingOfferListPreApprovedResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r lendingOfferListPreApprovedResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type LendingOfferListPreApprovedParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [LendingOfferListPreApprovedParams]'s query parameters as
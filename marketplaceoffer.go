@@ -4,9 +4,11 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -35,9 +37,13 @@ func NewMarketplaceOfferService(opts ...option.RequestOption) (r *MarketplaceOff
 
 // Redeems a personalized, exclusive offer from the Plato AI marketplace, often
 // resulting in a discount, special rate, or credit to the user's account.
-func (r *MarketplaceOfferService) Redeem(ctx context.Context, offerID interface{}, body MarketplaceOfferRedeemParams, opts ...option.RequestOption) (res *MarketplaceOfferRedeemResponse, err error) {
+func (r *MarketplaceOfferService) Redeem(ctx context.Context, offerID string, body MarketplaceOfferRedeemParams, opts ...option.RequestOption) (res *MarketplaceOfferRedeemResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("marketplace/offers/%v/redeem", offerID)
+	if offerID == "" {
+		err = errors.New("missing required offerId parameter")
+		return
+	}
+	path := fmt.Sprintf("marketplace/offers/%s/redeem", offerID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
 	return
 }
@@ -45,14 +51,14 @@ func (r *MarketplaceOfferService) Redeem(ctx context.Context, offerID interface{
 type MarketplaceOfferRedeemResponse struct {
 	// If applicable, the ID of any associated transaction (e.g., a credit or initial
 	// payment).
-	AssociatedTransactionID interface{} `json:"associatedTransactionId"`
+	AssociatedTransactionID string `json:"associatedTransactionId"`
 	// A descriptive message about the redemption.
-	Message interface{} `json:"message"`
+	Message string `json:"message"`
 	// The ID of the redeemed offer.
-	OfferID        interface{} `json:"offerId"`
-	RedemptionDate interface{} `json:"redemptionDate"`
+	OfferID        string    `json:"offerId"`
+	RedemptionDate time.Time `json:"redemptionDate" format:"date"`
 	// Unique ID for this redemption.
-	RedemptionID interface{} `json:"redemptionId"`
+	RedemptionID string `json:"redemptionId"`
 	// Status of the redemption.
 	Status MarketplaceOfferRedeemResponseStatus `json:"status"`
 	JSON   marketplaceOfferRedeemResponseJSON   `json:"-"`
@@ -98,7 +104,7 @@ func (r MarketplaceOfferRedeemResponseStatus) IsKnown() bool {
 
 type MarketplaceOfferRedeemParams struct {
 	// Optional: The ID of the account to use for any associated payment or credit.
-	PaymentAccountID param.Field[interface{}] `json:"paymentAccountId"`
+	PaymentAccountID param.Field[string] `json:"paymentAccountId"`
 }
 
 func (r MarketplaceOfferRedeemParams) MarshalJSON() (data []byte, err error) {
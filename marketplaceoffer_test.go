@@ -29,7 +29,7 @@ func TestMarketplaceOfferRedeemWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"offer_home_ins_promo_1",
 		jocall3.MarketplaceOfferRedeemParams{
-			PaymentAccountID: jocall3.F[any]("acc_chase_checking_4567"),
+			PaymentAccountID: jocall3.F("acc_chase_checking_4567"),
 		},
 	)
 	if err != nil {
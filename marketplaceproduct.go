@@ -4,6 +4,7 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -14,6 +15,7 @@ import (
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // MarketplaceProductService contains methods and other services that help with
@@ -38,70 +40,69 @@ func NewMarketplaceProductService(opts ...option.RequestOption) (r *MarketplaceP
 // Retrieves a personalized, AI-curated list of products and services from the
 // Plato AI marketplace, tailored to the user's financial profile, goals, and
 // spending patterns. Includes options for filtering and advanced search.
-func (r *MarketplaceProductService) List(ctx context.Context, query MarketplaceProductListParams, opts ...option.RequestOption) (res *MarketplaceProductListResponse, err error) {
+func (r *MarketplaceProductService) List(ctx context.Context, query MarketplaceProductListParams, opts ...option.RequestOption) (res *pagination.Page[MarketplaceProductListResponseData], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "marketplace/products"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a personalized, AI-curated list of products and services from the
+// Plato AI marketplace, tailored to the user's financial profile, goals, and
+// spending patterns. Includes options for filtering and advanced search.
+func (r *MarketplaceProductService) ListAutoPaging(ctx context.Context, query MarketplaceProductListParams, opts ...option.RequestOption) *pagination.PageAutoPager[MarketplaceProductListResponseData] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Uses the Quantum Oracle to simulate the long-term financial impact of purchasing
 // or subscribing to a specific marketplace product, such as a loan, investment, or
 // insurance policy, on the user's overall financial health and goals.
-func (r *MarketplaceProductService) SimulateImpact(ctx context.Context, productID interface{}, body MarketplaceProductSimulateImpactParams, opts ...option.RequestOption) (res *MarketplaceProductSimulateImpactResponse, err error) {
+func (r *MarketplaceProductService) SimulateImpact(ctx context.Context, productID string, body MarketplaceProductSimulateImpactParams, opts ...option.RequestOption) (res *MarketplaceProductSimulateImpactResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("marketplace/products/%v/impact-simulate", productID)
+	if productID == "" {
+		err = errors.New("missing required productId parameter")
+		return
+	}
+	path := fmt.Sprintf("marketplace/products/%s/impact-simulate", productID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
 	return
 }
 
-type MarketplaceProductListResponse struct {
-	Data []MarketplaceProductListResponseData `json:"data"`
-	JSON marketplaceProductListResponseJSON   `json:"-"`
-	PaginatedList
-}
-
-// marketplaceProductListResponseJSON contains the JSON metadata for the struct
-// [MarketplaceProductListResponse]
-type marketplaceProductListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *MarketplaceProductListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r marketplaceProductListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type MarketplaceProductListResponseData struct {
 	// Unique identifier for the marketplace product.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// AI's score for how well this product is personalized to the user (0-1).
-	AIPersonalizationScore interface{} `json:"aiPersonalizationScore,required"`
+	AIPersonalizationScore float64 `json:"aiPersonalizationScore,required"`
 	// Category of the product/service.
 	Category MarketplaceProductListResponseDataCategory `json:"category,required"`
 	// Detailed description of the product/service.
-	Description interface{} `json:"description,required"`
+	Description string `json:"description,required"`
 	// URL to an image representing the product.
-	ImageURL interface{} `json:"imageUrl,required"`
+	ImageURL string `json:"imageUrl,required"`
 	// Name of the product/service.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// Pricing information (can be a range or fixed text).
-	Price interface{} `json:"price,required"`
+	Price string `json:"price,required"`
 	// Provider or vendor of the product/service.
-	Provider interface{} `json:"provider,required"`
+	Provider string `json:"provider,required"`
 	// Average user rating for the product (0-5).
-	Rating interface{} `json:"rating,required"`
+	Rating float64 `json:"rating,required"`
 	// AI-generated explanation for recommending this product.
-	AIRecommendationReason interface{} `json:"aiRecommendationReason"`
+	AIRecommendationReason string `json:"aiRecommendationReason"`
 	// Details of any special offers associated with the product.
 	OfferDetails MarketplaceProductListResponseDataOfferDetails `json:"offerDetails"`
 	// Direct URL to the product on the provider's website.
-	ProductURL interface{}                            `json:"productUrl"`
+	ProductURL string                                 `json:"productUrl"`
 	JSON       marketplaceProductListResponseDataJSON `json:"-"`
 }
 
@@ -158,9 +159,9 @@ func (r MarketplaceProductListResponseDataCategory) IsKnown() bool {
 // Details of any special offers associated with the product.
 type MarketplaceProductListResponseDataOfferDetails struct {
 	// Optional redemption code.
-	Code  interface{}                                        `json:"code"`
+	Code  string                                             `json:"code"`
 	Type  MarketplaceProductListResponseDataOfferDetailsType `json:"type"`
-	Value interface{}                                        `json:"value"`
+	Value string                                             `json:"value"`
 	JSON  marketplaceProductListResponseDataOfferDetailsJSON `json:"-"`
 }
 
@@ -202,11 +203,11 @@ type MarketplaceProductSimulateImpactResponse struct {
 	// Key financial impacts identified by the AI (e.g., on cash flow, debt-to-income).
 	KeyImpacts []MarketplaceProductSimulateImpactResponseKeyImpact `json:"keyImpacts,required"`
 	// A natural language summary of the simulation's results for this product.
-	NarrativeSummary interface{} `json:"narrativeSummary,required"`
+	NarrativeSummary string `json:"narrativeSummary,required"`
 	// The ID of the marketplace product being simulated.
-	ProductID interface{} `json:"productId,required"`
+	ProductID string `json:"productId,required"`
 	// Unique identifier for the simulation performed.
-	SimulationID interface{} `json:"simulationId,required"`
+	SimulationID string `json:"simulationId,required"`
 	// Actionable recommendations or advice related to the product and its impact.
 	AIRecommendations []AIInsight `json:"aiRecommendations,nullable"`
 	// Projected amortization schedule for loan products.
@@ -236,9 +237,9 @@ func (r marketplaceProductSimulateImpactResponseJSON) RawJSON() string {
 }
 
 type MarketplaceProductSimulateImpactResponseKeyImpact struct {
-	Metric   interface{}                                                `json:"metric"`
+	Metric   string                                                     `json:"metric"`
 	Severity MarketplaceProductSimulateImpactResponseKeyImpactsSeverity `json:"severity"`
-	Value    interface{}                                                `json:"value"`
+	Value    Decimal                                                    `json:"value"`
 	JSON     marketplaceProductSimulateImpactResponseKeyImpactJSON      `json:"-"`
 }
 
@@ -277,11 +278,11 @@ func (r MarketplaceProductSimulateImpactResponseKeyImpactsSeverity) IsKnown() bo
 }
 
 type MarketplaceProductSimulateImpactResponseProjectedAmortizationSchedule struct {
-	Interest         interface{}                                                               `json:"interest"`
-	Month            interface{}                                                               `json:"month"`
-	Payment          interface{}                                                               `json:"payment"`
-	Principal        interface{}                                                               `json:"principal"`
-	RemainingBalance interface{}                                                               `json:"remainingBalance"`
+	Interest         Decimal                                                                   `json:"interest"`
+	Month            int64                                                                     `json:"month"`
+	Payment          Decimal                                                                   `json:"payment"`
+	Principal        Decimal                                                                   `json:"principal"`
+	RemainingBalance Decimal                                                                   `json:"remainingBalance"`
 	JSON             marketplaceProductSimulateImpactResponseProjectedAmortizationScheduleJSON `json:"-"`
 }
 
@@ -313,11 +314,11 @@ type MarketplaceProductListParams struct {
 	// Filter products by category (e.g., loans, insurance, credit_cards, investments).
 	Category param.Field[MarketplaceProductListParamsCategory] `query:"category"`
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Minimum user rating for products (0-5).
-	MinRating param.Field[interface{}] `query:"minRating"`
+	MinRating param.Field[float64] `query:"minRating"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [MarketplaceProductListParams]'s query parameters as
@@ -372,7 +373,7 @@ func (r MarketplaceProductListParamsCategory) IsKnown() bool {
 type MarketplaceProductSimulateImpactParams struct {
 	// Dynamic parameters specific to the product type (e.g., loan amount, investment
 	// term).
-	SimulationParameters param.Field[interface{}] `json:"simulationParameters"`
+	SimulationParameters param.Field[map[string]interface{}] `json:"simulationParameters"`
 }
 
 func (r MarketplaceProductSimulateImpactParams) MarshalJSON() (data []byte, err error) {
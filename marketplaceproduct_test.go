@@ -28,9 +28,9 @@ func TestMarketplaceProductListWithOptionalParams(t *testing.T) {
 	_, err := client.Marketplace.Products.List(context.TODO(), jocall3.MarketplaceProductListParams{
 		AIPersonalizationLevel: jocall3.F(jocall3.MarketplaceProductListParamsAIPersonalizationLevelHigh),
 		Category:               jocall3.F(jocall3.MarketplaceProductListParamsCategoryInsurance),
-		Limit:                  jocall3.F[any](map[string]interface{}{}),
-		MinRating:              jocall3.F[any](4),
-		Offset:                 jocall3.F[any](map[string]interface{}{}),
+		Limit:                  jocall3.F(int64(0)),
+		MinRating:              jocall3.F(4.0),
+		Offset:                 jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -57,7 +57,7 @@ func TestMarketplaceProductSimulateImpactWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"prod_home_insurance_quantum",
 		jocall3.MarketplaceProductSimulateImpactParams{
-			SimulationParameters: jocall3.F[any](map[string]interface{}{
+			SimulationParameters: jocall3.F(map[string]interface{}{
 				"loanAmount":          20000,
 				"repaymentTermMonths": 48,
 			}),
@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // NotificationService contains methods and other services that help with
@@ -39,40 +42,60 @@ func NewNotificationService(opts ...option.RequestOption) (r *NotificationServic
 
 // Retrieves a paginated list of personalized notifications and proactive AI alerts
 // for the authenticated user, allowing filtering by status and severity.
-func (r *NotificationService) ListUserNotifications(ctx context.Context, query NotificationListUserNotificationsParams, opts ...option.RequestOption) (res *NotificationListUserNotificationsResponse, err error) {
+func (r *NotificationService) ListUserNotifications(ctx context.Context, query NotificationListUserNotificationsParams, opts ...option.RequestOption) (res *pagination.Page[Notification], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "notifications/me"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a paginated list of personalized notifications and proactive AI alerts
+// for the authenticated user, allowing filtering by status and severity.
+func (r *NotificationService) ListUserNotificationsAutoPaging(ctx context.Context, query NotificationListUserNotificationsParams, opts ...option.RequestOption) *pagination.PageAutoPager[Notification] {
+	return pagination.NewPageAutoPager(r.ListUserNotifications(ctx, query, opts...))
 }
 
 // Marks a specific user notification as read.
-func (r *NotificationService) MarkAsRead(ctx context.Context, notificationID interface{}, opts ...option.RequestOption) (res *Notification, err error) {
+func (r *NotificationService) MarkAsRead(ctx context.Context, notificationID string, opts ...option.RequestOption) (res *Notification, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("notifications/%v/mark-read", notificationID)
+	if notificationID == "" {
+		err = errors.New("missing required notificationId parameter")
+		return
+	}
+	path := fmt.Sprintf("notifications/%s/mark-read", notificationID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, nil, &res, opts...)
 	return
 }
 
 type Notification struct {
 	// Unique identifier for the notification.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Full message content of the notification.
-	Message interface{} `json:"message,required"`
+	Message string `json:"message,required"`
 	// Indicates if the user has read the notification.
-	Read interface{} `json:"read,required"`
+	Read bool `json:"read,required"`
 	// Severity of the notification (AI-assessed).
 	Severity NotificationSeverity `json:"severity,required"`
 	// Timestamp when the notification was generated.
-	Timestamp interface{} `json:"timestamp,required"`
+	Timestamp time.Time `json:"timestamp,required" format:"date-time"`
 	// Concise title for the notification.
-	Title interface{} `json:"title,required"`
+	Title string `json:"title,required"`
 	// Type of notification.
 	Type NotificationType `json:"type,required"`
 	// Optional deep link for the user to take action related to the notification.
-	ActionableLink interface{} `json:"actionableLink"`
+	ActionableLink string `json:"actionableLink"`
 	// If applicable, the ID of the AIInsight that generated this notification.
-	AIInsightID interface{}      `json:"aiInsightId"`
+	AIInsightID string           `json:"aiInsightId"`
 	JSON        notificationJSON `json:"-"`
 }
 
@@ -136,33 +159,11 @@ func (r NotificationType) IsKnown() bool {
 	return false
 }
 
-type NotificationListUserNotificationsResponse struct {
-	Data []Notification                                `json:"data"`
-	JSON notificationListUserNotificationsResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// notificationListUserNotificationsResponseJSON contains the JSON metadata for the
-// struct [NotificationListUserNotificationsResponse]
-type notificationListUserNotificationsResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *NotificationListUserNotificationsResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r notificationListUserNotificationsResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type NotificationListUserNotificationsParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 	// Filter notifications by AI-assigned severity level.
 	Severity param.Field[NotificationListUserNotificationsParamsSeverity] `query:"severity"`
 	// Filter notifications by their read status.
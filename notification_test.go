@@ -26,8 +26,8 @@ func TestNotificationListUserNotificationsWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Notifications.ListUserNotifications(context.TODO(), jocall3.NotificationListUserNotificationsParams{
-		Limit:    jocall3.F[any](map[string]interface{}{}),
-		Offset:   jocall3.F[any](map[string]interface{}{}),
+		Limit:    jocall3.F(int64(0)),
+		Offset:   jocall3.F(int64(0)),
 		Severity: jocall3.F(jocall3.NotificationListUserNotificationsParamsSeverityHigh),
 		Status:   jocall3.F(jocall3.NotificationListUserNotificationsParamsStatusUnread),
 	})
@@ -81,13 +81,13 @@ func (r notificationSettingsJSON) RawJSON() string {
 // Preferences for notification delivery channels.
 type NotificationSettingsChannelPreferences struct {
 	// Receive notifications via email.
-	Email interface{} `json:"email"`
+	Email bool `json:"email"`
 	// Receive notifications within the application.
-	InApp interface{} `json:"inApp"`
+	InApp bool `json:"inApp"`
 	// Receive notifications via push notifications.
-	Push interface{} `json:"push"`
+	Push bool `json:"push"`
 	// Receive notifications via SMS.
-	SMS  interface{}                                `json:"sms"`
+	SMS  bool                                       `json:"sms"`
 	JSON notificationSettingsChannelPreferencesJSON `json:"-"`
 }
 
@@ -113,15 +113,15 @@ func (r notificationSettingsChannelPreferencesJSON) RawJSON() string {
 // Preferences for different types of events.
 type NotificationSettingsEventPreferences struct {
 	// Receive proactive AI-driven financial insights.
-	AIInsights interface{} `json:"aiInsights"`
+	AIInsights bool `json:"aiInsights"`
 	// Receive alerts for budget progress (e.g., nearing limit, over budget).
-	BudgetAlerts interface{} `json:"budgetAlerts"`
+	BudgetAlerts bool `json:"budgetAlerts"`
 	// Receive promotional offers and marketing communications.
-	PromotionalOffers interface{} `json:"promotionalOffers"`
+	PromotionalOffers bool `json:"promotionalOffers"`
 	// Receive critical security alerts (e.g., suspicious login).
-	SecurityAlerts interface{} `json:"securityAlerts"`
+	SecurityAlerts bool `json:"securityAlerts"`
 	// Receive alerts for transactions (e.g., large spend, recurring charges).
-	TransactionAlerts interface{}                              `json:"transactionAlerts"`
+	TransactionAlerts bool                                     `json:"transactionAlerts"`
 	JSON              notificationSettingsEventPreferencesJSON `json:"-"`
 }
 
@@ -148,11 +148,11 @@ func (r notificationSettingsEventPreferencesJSON) RawJSON() string {
 // Settings for notification quiet hours.
 type NotificationSettingsQuietHours struct {
 	// If true, notifications are suppressed during specified quiet hours.
-	Enabled interface{} `json:"enabled"`
+	Enabled bool `json:"enabled"`
 	// End time for quiet hours (HH:MM format).
-	EndTime interface{} `json:"endTime"`
+	EndTime string `json:"endTime"`
 	// Start time for quiet hours (HH:MM format).
-	StartTime interface{}                        `json:"startTime"`
+	StartTime string                             `json:"startTime"`
 	JSON      notificationSettingsQuietHoursJSON `json:"-"`
 }
 
@@ -192,10 +192,10 @@ func (r NotificationSettingUpdateParams) MarshalJSON() (data []byte, err error)
 // Updated preferences for notification delivery channels. Only provided fields are
 // updated.
 type NotificationSettingUpdateParamsChannelPreferences struct {
-	Email param.Field[interface{}] `json:"email"`
-	InApp param.Field[interface{}] `json:"inApp"`
-	Push  param.Field[interface{}] `json:"push"`
-	SMS   param.Field[interface{}] `json:"sms"`
+	Email param.Field[bool] `json:"email"`
+	InApp param.Field[bool] `json:"inApp"`
+	Push  param.Field[bool] `json:"push"`
+	SMS   param.Field[bool] `json:"sms"`
 }
 
 func (r NotificationSettingUpdateParamsChannelPreferences) MarshalJSON() (data []byte, err error) {
@@ -205,11 +205,11 @@ func (r NotificationSettingUpdateParamsChannelPreferences) MarshalJSON() (data [
 // Updated preferences for different types of events. Only provided fields are
 // updated.
 type NotificationSettingUpdateParamsEventPreferences struct {
-	AIInsights        param.Field[interface{}] `json:"aiInsights"`
-	BudgetAlerts      param.Field[interface{}] `json:"budgetAlerts"`
-	PromotionalOffers param.Field[interface{}] `json:"promotionalOffers"`
-	SecurityAlerts    param.Field[interface{}] `json:"securityAlerts"`
-	TransactionAlerts param.Field[interface{}] `json:"transactionAlerts"`
+	AIInsights        param.Field[bool] `json:"aiInsights"`
+	BudgetAlerts      param.Field[bool] `json:"budgetAlerts"`
+	PromotionalOffers param.Field[bool] `json:"promotionalOffers"`
+	SecurityAlerts    param.Field[bool] `json:"securityAlerts"`
+	TransactionAlerts param.Field[bool] `json:"transactionAlerts"`
 }
 
 func (r NotificationSettingUpdateParamsEventPreferences) MarshalJSON() (data []byte, err error) {
@@ -218,9 +218,9 @@ func (r NotificationSettingUpdateParamsEventPreferences) MarshalJSON() (data []b
 
 // Updated settings for notification quiet hours. Only provided fields are updated.
 type NotificationSettingUpdateParamsQuietHours struct {
-	Enabled   param.Field[interface{}] `json:"enabled"`
-	EndTime   param.Field[interface{}] `json:"endTime"`
-	StartTime param.Field[interface{}] `json:"startTime"`
+	Enabled   param.Field[bool]   `json:"enabled"`
+	EndTime   param.Field[string] `json:"endTime"`
+	StartTime param.Field[string] `json:"startTime"`
 }
 
 func (r NotificationSettingUpdateParamsQuietHours) MarshalJSON() (data []byte, err error) {
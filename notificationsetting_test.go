@@ -49,22 +49,22 @@ func TestNotificationSettingUpdateWithOptionalParams(t *testing.T) {
 	)
 	_, err := client.Notifications.Settings.Update(context.TODO(), jocall3.NotificationSettingUpdateParams{
 		ChannelPreferences: jocall3.F(jocall3.NotificationSettingUpdateParamsChannelPreferences{
-			Email: jocall3.F[any](true),
-			InApp: jocall3.F[any](true),
-			Push:  jocall3.F[any](true),
-			SMS:   jocall3.F[any](true),
+			Email: jocall3.F(true),
+			InApp: jocall3.F(true),
+			Push:  jocall3.F(true),
+			SMS:   jocall3.F(true),
 		}),
 		EventPreferences: jocall3.F(jocall3.NotificationSettingUpdateParamsEventPreferences{
-			AIInsights:        jocall3.F[any](true),
-			BudgetAlerts:      jocall3.F[any](true),
-			PromotionalOffers: jocall3.F[any](false),
-			SecurityAlerts:    jocall3.F[any](true),
-			TransactionAlerts: jocall3.F[any](true),
+			AIInsights:        jocall3.F(true),
+			BudgetAlerts:      jocall3.F(true),
+			PromotionalOffers: jocall3.F(false),
+			SecurityAlerts:    jocall3.F(true),
+			TransactionAlerts: jocall3.F(true),
 		}),
 		QuietHours: jocall3.F(jocall3.NotificationSettingUpdateParamsQuietHours{
-			Enabled:   jocall3.F[any](true),
-			EndTime:   jocall3.F[any]("08:00"),
-			StartTime: jocall3.F[any]("22:00"),
+			Enabled:   jocall3.F(true),
+			EndTime:   jocall3.F("08:00"),
+			StartTime: jocall3.F("22:00"),
 		}),
 	})
 	if err != nil {
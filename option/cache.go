@@ -0,0 +1,264 @@
+package option
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores the responses kept by [WithResponseCache]. Implementations must
+// be safe for concurrent use, and must not modify the responses they are given
+// or hand out.
+type Cache interface {
+	// Get returns the response stored under key, if any.
+	Get(key string) (*CachedResponse, bool)
+	// Set stores res under key, replacing any response stored before.
+	Set(key string, res *CachedResponse)
+	// Delete removes the response stored under key, if any.
+	Delete(key string)
+}
+
+// CachedResponse is a response stored in a [Cache].
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// When the response was received or last revalidated.
+	StoredAt time.Time
+}
+
+// WithResponseCache returns a RequestOption that caches the responses to GET
+// requests in cache, so that unchanged resources are not downloaded again.
+//
+// A cached response is returned without a request for as long as its
+// Cache-Control max-age allows. After that, or when the response carries no
+// max-age, the request is sent with If-None-Match and If-Modified-Since
+// headers built from the ETag and Last-Modified of the cached response; a 304
+// Not Modified answer is replaced by the cached response, so that the method
+// returns its usual result. Responses with Cache-Control no-store, and those
+// with neither a validator nor a max-age, are not cached. A successful request
+// with another method removes the cached response for its URL.
+//
+// Responses are cached separately for each Authorization header, so clients
+// acting for different users may share a cache. Requests that already carry
+// conditional headers bypass the cache.
+func WithResponseCache(cache Cache) RequestOption {
+	c := &responseCache{cache: cache, now: time.Now}
+	return WithMiddleware(c.middleware)
+}
+
+type responseCache struct {
+	cache Cache
+	now   func() time.Time
+}
+
+func (c *responseCache) middleware(req *http.Request, next MiddlewareNext) (*http.Response, error) {
+	key := c.key(req)
+	if req.Method != http.MethodGet {
+		res, err := next(req)
+		if err == nil && res.StatusCode < 400 {
+			c.cache.Delete(key)
+		}
+		return res, err
+	}
+	if req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" {
+		return next(req)
+	}
+
+	cached, ok := c.cache.Get(key)
+	if ok {
+		if c.fresh(cached) {
+			return cachedHTTPResponse(req, cached, cached.Header), nil
+		}
+		if etag := cached.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := cached.Header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	res, err := next(req)
+	if err != nil {
+		return res, err
+	}
+
+	if res.StatusCode == http.StatusNotModified && ok {
+		io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+		// The 304 may carry newer caching headers, which replace the stored ones.
+		header := cached.Header.Clone()
+		for k, v := range res.Header {
+			if k != "Content-Length" && k != "Content-Type" {
+				header[k] = v
+			}
+		}
+		revalidated := &CachedResponse{StatusCode: cached.StatusCode, Header: header, Body: cached.Body, StoredAt: c.now()}
+		c.cache.Set(key, revalidated)
+		return cachedHTTPResponse(req, revalidated, header), nil
+	}
+
+	if res.StatusCode != http.StatusOK || !cacheable(res.Header) {
+		return res, nil
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	c.cache.Set(key, &CachedResponse{StatusCode: res.StatusCode, Header: res.Header.Clone(), Body: body, StoredAt: c.now()})
+	return res, nil
+}
+
+// key identifies the resource requested by req, as seen by the holder of its
+// credentials.
+func (c *responseCache) key(req *http.Request) string {
+	key := req.URL.String()
+	if auth := req.Header.Get("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		key += " " + hex.EncodeToString(sum[:8])
+	}
+	return key
+}
+
+// fresh reports whether res may be used without revalidation.
+func (c *responseCache) fresh(res *CachedResponse) bool {
+	maxAge, ok := cacheMaxAge(res.Header)
+	if !ok {
+		return false
+	}
+	if age, err := strconv.Atoi(res.Header.Get("Age")); err == nil && age > 0 {
+		maxAge -= time.Duration(age) * time.Second
+	}
+	return c.now().Sub(res.StoredAt) < maxAge
+}
+
+func cacheable(header http.Header) bool {
+	directives := cacheControl(header)
+	if _, noStore := directives["no-store"]; noStore {
+		return false
+	}
+	if header.Get("ETag") != "" || header.Get("Last-Modified") != "" {
+		return true
+	}
+	maxAge, ok := cacheMaxAge(header)
+	return ok && maxAge > 0
+}
+
+// cacheMaxAge returns the max-age of a response, which is zero if it must be
+// revalidated before every use.
+func cacheMaxAge(header http.Header) (time.Duration, bool) {
+	directives := cacheControl(header)
+	if _, noCache := directives["no-cache"]; noCache {
+		return 0, true
+	}
+	value, ok := directives["max-age"]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+func cacheControl(header http.Header) map[string]string {
+	directives := map[string]string{}
+	for _, line := range header.Values("Cache-Control") {
+		for _, directive := range strings.Split(line, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			directives[strings.ToLower(name)] = strings.Trim(value, `"`)
+		}
+	}
+	return directives
+}
+
+func cachedHTTPResponse(req *http.Request, cached *CachedResponse, header http.Header) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", cached.StatusCode, http.StatusText(cached.StatusCode)),
+		StatusCode:    cached.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+}
+
+// LRUCache is an in-memory [Cache] that holds a bounded number of responses,
+// evicting the least recently used one when it is full.
+type LRUCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type lruCacheEntry struct {
+	key string
+	res *CachedResponse
+}
+
+// NewLRUCache returns a cache that holds at most maxEntries responses. A
+// maxEntries of zero or less means no limit.
+func NewLRUCache(maxEntries int) *LRUCache {
+	return &LRUCache{maxEntries: maxEntries, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+// Get implements [Cache].
+func (c *LRUCache) Get(key string) (*CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruCacheEntry).res, true
+}
+
+// Set implements [Cache].
+func (c *LRUCache) Set(key string, res *CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruCacheEntry).res = res
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruCacheEntry{key: key, res: res})
+	for c.maxEntries > 0 && c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruCacheEntry).key)
+	}
+}
+
+// Delete implements [Cache].
+func (c *LRUCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+// Len returns the number of responses in the cache.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
@@ -0,0 +1,364 @@
+package option
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jocall3/go/internal/apiroute"
+	"github.com/jocall3/go/internal/requestconfig"
+)
+
+// ErrCircuitOpen is matched, using [errors.Is], by the errors returned for
+// requests that a [CircuitBreaker] rejected without sending them.
+var ErrCircuitOpen = requestconfig.ErrCircuitOpen
+
+// CircuitOpenError is returned for a request that a [CircuitBreaker] rejected
+// without sending it. It matches [ErrCircuitOpen].
+type CircuitOpenError = requestconfig.CircuitOpenError
+
+// Default settings for a [CircuitBreaker].
+const (
+	DefaultCircuitBreakerFailureRatio     = 0.5
+	DefaultCircuitBreakerMinRequests      = 10
+	DefaultCircuitBreakerWindow           = 10 * time.Second
+	DefaultCircuitBreakerOpenTimeout      = 30 * time.Second
+	DefaultCircuitBreakerHalfOpenRequests = 1
+)
+
+// CircuitState is the state of a circuit of a [CircuitBreaker].
+type CircuitState int
+
+const (
+	// Requests are sent, and their outcomes are counted.
+	CircuitClosed CircuitState = iota
+	// Requests are rejected with a [*CircuitOpenError] without being sent.
+	CircuitOpen
+	// A limited number of trial requests are sent to find out whether the API
+	// has recovered; the others are rejected.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// CircuitStateChange describes a circuit moving from one state to another.
+type CircuitStateChange struct {
+	// The host the circuit covers.
+	Host string
+	// The route the circuit covers, such as "GET /budgets/{budgetId}".
+	Route string
+	From  CircuitState
+	To    CircuitState
+}
+
+// CircuitBreaker stops sending requests to a route of the API once too many of
+// them fail, so that a degraded backend is not kept busy by requests that are
+// likely to fail anyway. It keeps one circuit per host and route template, so
+// that, say, failures of GET /transactions/{transactionId} do not stop requests
+// to POST /budgets.
+//
+// A circuit opens when, within Window, at least MinRequests requests were sent
+// and the ratio of failures among them reached FailureRatio. While it is open,
+// requests fail fast with a [*CircuitOpenError]. After OpenTimeout it lets
+// HalfOpenRequests trial requests through: if they all succeed the circuit
+// closes, and if any fails it opens again.
+//
+// The zero value uses the defaults above. The exported fields must not be
+// changed once the breaker is in use. A CircuitBreaker is safe for concurrent
+// use and may be shared by several clients.
+type CircuitBreaker struct {
+	// The ratio of failed requests, between 0 and 1, at which a circuit opens.
+	// Zero means [DefaultCircuitBreakerFailureRatio].
+	FailureRatio float64
+	// The number of requests a circuit must have seen within Window before it
+	// may open. Zero means [DefaultCircuitBreakerMinRequests].
+	MinRequests int
+	// The period over which failures are counted. Zero means
+	// [DefaultCircuitBreakerWindow].
+	Window time.Duration
+	// How long a circuit stays open before letting trial requests through. Zero
+	// means [DefaultCircuitBreakerOpenTimeout].
+	OpenTimeout time.Duration
+	// The number of trial requests a half-open circuit sends, and that must all
+	// succeed for it to close. Zero means
+	// [DefaultCircuitBreakerHalfOpenRequests].
+	HalfOpenRequests int
+	// IsFailure reports whether the outcome of a request counts as a failure. If
+	// nil, errors and 5xx responses are failures. Requests whose context was
+	// cancelled are not counted either way.
+	IsFailure func(res *http.Response, err error) bool
+	// OnStateChange, if set, is called whenever a circuit changes state. It is
+	// called synchronously from the request that caused the change, so it should
+	// not block.
+	OnStateChange func(change CircuitStateChange)
+
+	mu       sync.Mutex
+	circuits map[circuitKey]*circuit
+}
+
+// WithCircuitBreaker returns a RequestOption that guards requests with breaker.
+// If breaker is nil, a breaker with the default settings is used.
+//
+// Because the breaker runs as a middleware, every retry of a request goes
+// through it, and requests it rejects are not retried.
+func WithCircuitBreaker(breaker *CircuitBreaker) RequestOption {
+	if breaker == nil {
+		breaker = &CircuitBreaker{}
+	}
+	return WithMiddleware(breaker.middleware)
+}
+
+// State returns the state of the circuit for route on host, where route is a
+// method and a path template such as "GET /budgets/{budgetId}". Circuits that
+// have not seen a request are closed.
+func (b *CircuitBreaker) State(host, route string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[circuitKey{host, route}]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && time.Since(c.openedAt) >= b.openTimeout() {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+type circuitKey struct {
+	host  string
+	route string
+}
+
+const circuitBuckets = 10
+
+type circuit struct {
+	state      CircuitState
+	generation int
+	openedAt   time.Time
+	buckets    [circuitBuckets]circuitBucket
+	probes     int
+	successes  int
+}
+
+type circuitBucket struct {
+	start    time.Time
+	requests int
+	failures int
+}
+
+type circuitOutcome int
+
+const (
+	circuitSuccess circuitOutcome = iota
+	circuitFailure
+	circuitIgnored
+)
+
+func (b *CircuitBreaker) middleware(req *http.Request, next MiddlewareNext) (*http.Response, error) {
+	key := circuitKey{
+		host:  req.URL.Host,
+		route: req.Method + " " + apiroute.Template(req.Method, req.URL.Path),
+	}
+
+	generation, probe, err := b.allow(key)
+	if err != nil {
+		return nil, err
+	}
+	res, err := next(req)
+	b.done(key, generation, probe, b.outcome(req, res, err))
+	return res, err
+}
+
+// allow decides whether a request may be sent on the circuit for key. It
+// returns the generation of the circuit, which changes with every transition,
+// and whether the request is a trial request of a half-open circuit.
+func (b *CircuitBreaker) allow(key circuitKey) (generation int, probe bool, err error) {
+	var changes []CircuitStateChange
+	defer func() { b.notify(changes) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(key)
+	now := time.Now()
+
+	if c.state == CircuitOpen {
+		if wait := b.openTimeout() - now.Sub(c.openedAt); wait > 0 {
+			return 0, false, &CircuitOpenError{Host: key.host, Route: key.route, RetryAfter: wait}
+		}
+		changes = append(changes, b.transition(key, c, CircuitHalfOpen, now))
+	}
+	if c.state == CircuitHalfOpen {
+		if c.probes >= b.halfOpenRequests() {
+			return 0, false, &CircuitOpenError{Host: key.host, Route: key.route}
+		}
+		c.probes++
+		return c.generation, true, nil
+	}
+	return c.generation, false, nil
+}
+
+// done records the outcome of a request allowed by allow.
+func (b *CircuitBreaker) done(key circuitKey, generation int, probe bool, outcome circuitOutcome) {
+	var changes []CircuitStateChange
+	defer func() { b.notify(changes) }()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c := b.circuit(key)
+	now := time.Now()
+
+	// The circuit moved on while the request was in flight, so its outcome says
+	// nothing about the current state.
+	if c.generation != generation {
+		return
+	}
+
+	if probe {
+		c.probes--
+		switch outcome {
+		case circuitFailure:
+			changes = append(changes, b.transition(key, c, CircuitOpen, now))
+		case circuitSuccess:
+			c.successes++
+			if c.successes >= b.halfOpenRequests() {
+				changes = append(changes, b.transition(key, c, CircuitClosed, now))
+			}
+		}
+		return
+	}
+
+	if c.state != CircuitClosed || outcome == circuitIgnored {
+		return
+	}
+	requests, failures := c.record(now, b.window(), outcome == circuitFailure)
+	if outcome == circuitFailure && requests >= b.minRequests() && float64(failures) >= b.failureRatio()*float64(requests) {
+		changes = append(changes, b.transition(key, c, CircuitOpen, now))
+	}
+}
+
+func (b *CircuitBreaker) outcome(req *http.Request, res *http.Response, err error) circuitOutcome {
+	if errors.Is(err, context.Canceled) || errors.Is(req.Context().Err(), context.Canceled) {
+		return circuitIgnored
+	}
+	if b.IsFailure != nil {
+		if b.IsFailure(res, err) {
+			return circuitFailure
+		}
+		return circuitSuccess
+	}
+	if err != nil || res == nil || res.StatusCode >= http.StatusInternalServerError {
+		return circuitFailure
+	}
+	return circuitSuccess
+}
+
+// circuit returns the circuit for key, creating it if needed. It must be called
+// with b.mu held.
+func (b *CircuitBreaker) circuit(key circuitKey) *circuit {
+	if b.circuits == nil {
+		b.circuits = map[circuitKey]*circuit{}
+	}
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	return c
+}
+
+// transition moves c to the state to. It must be called with b.mu held.
+func (b *CircuitBreaker) transition(key circuitKey, c *circuit, to CircuitState, now time.Time) CircuitStateChange {
+	change := CircuitStateChange{Host: key.host, Route: key.route, From: c.state, To: to}
+	c.state = to
+	c.generation++
+	c.probes, c.successes = 0, 0
+	switch to {
+	case CircuitOpen:
+		c.openedAt = now
+	case CircuitClosed:
+		c.buckets = [circuitBuckets]circuitBucket{}
+	}
+	return change
+}
+
+func (b *CircuitBreaker) notify(changes []CircuitStateChange) {
+	if b.OnStateChange == nil {
+		return
+	}
+	for _, change := range changes {
+		b.OnStateChange(change)
+	}
+}
+
+// record counts a request in the bucket covering now and returns the totals
+// over the window.
+func (c *circuit) record(now time.Time, window time.Duration, failed bool) (requests, failures int) {
+	width := window / circuitBuckets
+	if width <= 0 {
+		width = 1
+	}
+	start := now.Truncate(width)
+	bucket := &c.buckets[(start.UnixNano()/int64(width))%circuitBuckets]
+	if !bucket.start.Equal(start) {
+		*bucket = circuitBucket{start: start}
+	}
+	bucket.requests++
+	if failed {
+		bucket.failures++
+	}
+
+	for _, bucket := range c.buckets {
+		if now.Sub(bucket.start) < window {
+			requests += bucket.requests
+			failures += bucket.failures
+		}
+	}
+	return requests, failures
+}
+
+func (b *CircuitBreaker) failureRatio() float64 {
+	if b.FailureRatio <= 0 {
+		return DefaultCircuitBreakerFailureRatio
+	}
+	return b.FailureRatio
+}
+
+func (b *CircuitBreaker) minRequests() int {
+	if b.MinRequests <= 0 {
+		return DefaultCircuitBreakerMinRequests
+	}
+	return b.MinRequests
+}
+
+func (b *CircuitBreaker) window() time.Duration {
+	if b.Window <= 0 {
+		return DefaultCircuitBreakerWindow
+	}
+	return b.Window
+}
+
+func (b *CircuitBreaker) openTimeout() time.Duration {
+	if b.OpenTimeout <= 0 {
+		return DefaultCircuitBreakerOpenTimeout
+	}
+	return b.OpenTimeout
+}
+
+func (b *CircuitBreaker) halfOpenRequests() int {
+	if b.HalfOpenRequests <= 0 {
+		return DefaultCircuitBreakerHalfOpenRequests
+	}
+	return b.HalfOpenRequests
+}
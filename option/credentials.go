@@ -0,0 +1,30 @@
+package option
+
+import (
+	"github.com/jocall3/go/internal/requestconfig"
+)
+
+// CredentialsProvider supplies the bearer tokens that requests are
+// authenticated with. [jocall3.NewLoginCredentials] and
+// [jocall3.NewBiometricCredentials] return providers backed by the API.
+type CredentialsProvider = requestconfig.CredentialsProvider
+
+// CredentialsError is returned for a request whose credentials could not be
+// obtained from its [CredentialsProvider], wrapping the error of the provider.
+// Such requests are not retried.
+type CredentialsError = requestconfig.CredentialsError
+
+// WithCredentialsProvider returns a RequestOption that authenticates every
+// request attempt with a bearer token from provider, in place of any
+// Authorization header set by other options.
+//
+// When an attempt is rejected with 401 Unauthorized, the token is refreshed
+// with [CredentialsProvider.Refresh] and the attempt is sent once more, unless
+// its body cannot be sent again. A nil provider removes one set by an earlier
+// option, for example for a single request.
+func WithCredentialsProvider(provider CredentialsProvider) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.CredentialsProvider = provider
+		return nil
+	})
+}
@@ -0,0 +1,235 @@
+package option
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/jocall3/go/internal/requestconfig"
+)
+
+// DefaultLogMaxBodyBytes is the number of bytes of a body that is logged unless
+// [LogConfig.MaxBodyBytes] says otherwise.
+const DefaultLogMaxBodyBytes = 4096
+
+// LogConfig configures the structured logging set up by [WithLogConfig].
+type LogConfig struct {
+	// The logger records are written to. If nil, [slog.Default] is used.
+	Logger *slog.Logger
+	// The level of request and response records. Responses that failed without
+	// a status, or with a 5xx status, are logged at least at [slog.LevelWarn].
+	Level slog.Level
+	// The headers whose values are replaced by [LogRedacted], matched without
+	// regard to case. If nil, [DefaultRedactedHeaders] is used; an empty,
+	// non-nil slice redacts nothing.
+	RedactHeaders []string
+	// The fields of JSON bodies whose values are replaced by [LogRedacted]. A
+	// path is a list of object keys separated by dots, matched without regard to
+	// case, where "*" matches any single key or array index and "**" matches any
+	// number of them, so that "**.password" matches a password field at any
+	// depth and "data.*.iban" the iban of every item of a list. If nil,
+	// [DefaultRedactedJSONPaths] is used; an empty, non-nil slice redacts
+	// nothing.
+	RedactJSONPaths []string
+	// The number of bytes of each body that is logged, after redaction. Zero
+	// means [DefaultLogMaxBodyBytes]; a negative value leaves bodies out.
+	MaxBodyBytes int
+}
+
+// WithLogger returns a RequestOption that logs every request attempt and its
+// response to logger at level, with the default redaction settings of
+// [LogConfig]. Unlike [WithDebugLog], it is suitable for production use.
+func WithLogger(logger *slog.Logger, level slog.Level) RequestOption {
+	return WithLogConfig(LogConfig{Logger: logger, Level: level})
+}
+
+// WithLogConfig returns a RequestOption that logs every request attempt and its
+// response as configured by config.
+//
+// Each request is logged as a "jocall3 request" record with the method, URL,
+// operation, attempt number, headers and body, and each response as a
+// "jocall3 response" record with the status, duration, headers and body, or
+// the error. Only JSON and text bodies are logged; for others, and for
+// event streams, only the size is recorded when it is known.
+func WithLogConfig(config LogConfig) RequestOption {
+	l := newRequestLogger(config)
+	return WithMiddleware(l.middleware)
+}
+
+type requestLogger struct {
+	*redactor
+	logger       *slog.Logger
+	level        slog.Level
+	maxBodyBytes int
+}
+
+func newRequestLogger(config LogConfig) *requestLogger {
+	l := &requestLogger{
+		redactor:     newRedactor(config.RedactHeaders, config.RedactJSONPaths),
+		logger:       config.Logger,
+		level:        config.Level,
+		maxBodyBytes: config.MaxBodyBytes,
+	}
+	if l.logger == nil {
+		l.logger = slog.Default()
+	}
+	if l.maxBodyBytes == 0 {
+		l.maxBodyBytes = DefaultLogMaxBodyBytes
+	}
+	return l
+}
+
+func (l *requestLogger) middleware(req *http.Request, next MiddlewareNext) (*http.Response, error) {
+	ctx := req.Context()
+	responseLevel := max(l.level, slog.LevelWarn)
+	if !l.logger.Enabled(ctx, l.level) && !l.logger.Enabled(ctx, responseLevel) {
+		return next(req)
+	}
+
+	common := []slog.Attr{slog.String("method", req.Method), slog.String("url", req.URL.Redacted())}
+	if op, ok := requestconfig.OperationFromContext(ctx); ok {
+		if op.Name != "" {
+			common = append(common, slog.String("operation", op.Name))
+		}
+		common = append(common, slog.Int("attempt", op.Attempt))
+	}
+	common = common[:len(common):len(common)]
+
+	if l.logger.Enabled(ctx, l.level) {
+		attrs := append(common, l.headerAttr(req.Header))
+		if body, ok := l.requestBody(req); ok {
+			attrs = append(attrs, body)
+		}
+		l.logger.LogAttrs(ctx, l.level, "jocall3 request", attrs...)
+	}
+
+	start := time.Now()
+	res, err := next(req)
+	attrs := append(common, slog.Duration("duration", time.Since(start)))
+
+	level := l.level
+	switch {
+	case err != nil:
+		level = responseLevel
+		attrs = append(attrs, slog.String("error", err.Error()))
+	case res.StatusCode >= http.StatusInternalServerError:
+		level = responseLevel
+	}
+	if !l.logger.Enabled(ctx, level) {
+		return res, err
+	}
+	if res != nil {
+		attrs = append(attrs, slog.Int("status", res.StatusCode), l.headerAttr(res.Header))
+		if body, ok := l.responseBody(res); ok {
+			attrs = append(attrs, body)
+		}
+	}
+	l.logger.LogAttrs(ctx, level, "jocall3 response", attrs...)
+	return res, err
+}
+
+func (l *requestLogger) headerAttr(header http.Header) slog.Attr {
+	keys := make([]string, 0, len(header))
+	for k := range header {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	attrs := make([]any, 0, len(keys))
+	for _, k := range keys {
+		value := strings.Join(header[k], ", ")
+		if l.redactsHeader(k) {
+			value = LogRedacted
+		}
+		attrs = append(attrs, slog.String(k, value))
+	}
+	return slog.Group("headers", attrs...)
+}
+
+// requestBody reads the body of req, replacing it with a copy so that it can
+// still be sent. Only bodies that can be replayed, and so are already held in
+// memory, are read.
+func (l *requestLogger) requestBody(req *http.Request) (slog.Attr, bool) {
+	if req.Body == nil || req.Body == http.NoBody || l.maxBodyBytes < 0 {
+		return slog.Attr{}, false
+	}
+	if req.GetBody == nil || !loggableContentType(req.Header.Get("Content-Type")) {
+		return l.bodySize(req.ContentLength)
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body = replayBody(data, req.Body)
+	if err != nil {
+		return slog.Attr{}, false
+	}
+	return l.bodyAttr(req.Header.Get("Content-Type"), data), true
+}
+
+// responseBody reads the body of res, replacing it with a copy so that the
+// caller can still read it. Event streams are left alone.
+func (l *requestLogger) responseBody(res *http.Response) (slog.Attr, bool) {
+	if res.Body == nil || res.Body == http.NoBody || l.maxBodyBytes < 0 {
+		return slog.Attr{}, false
+	}
+	contentType := res.Header.Get("Content-Type")
+	if !loggableContentType(contentType) {
+		return l.bodySize(res.ContentLength)
+	}
+	data, err := io.ReadAll(res.Body)
+	res.Body = replayBody(data, res.Body)
+	if err != nil {
+		return slog.Attr{}, false
+	}
+	return l.bodyAttr(contentType, data), true
+}
+
+// replayBody returns a body that yields data and then whatever is left of
+// body, which it closes.
+func replayBody(data []byte, body io.ReadCloser) io.ReadCloser {
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), body), body}
+}
+
+func (l *requestLogger) bodySize(size int64) (slog.Attr, bool) {
+	if size < 0 {
+		return slog.Attr{}, false
+	}
+	return slog.Int64("body_size", size), true
+}
+
+func (l *requestLogger) bodyAttr(contentType string, data []byte) slog.Attr {
+	if isJSONContentType(contentType) && gjson.ValidBytes(data) {
+		data = l.redactJSON(data)
+	}
+	if len(data) > l.maxBodyBytes {
+		// Cut at the start of a character, so that the record stays valid UTF-8.
+		n := l.maxBodyBytes
+		for n > 0 && !utf8.RuneStart(data[n]) {
+			n--
+		}
+		return slog.String("body", string(data[:n])+"…(truncated "+strconv.Itoa(len(data)-n)+" bytes)")
+	}
+	return slog.String("body", string(data))
+}
+
+func loggableContentType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "text/event-stream" {
+		return false
+	}
+	return isJSONContentType(contentType) || strings.HasPrefix(mediaType, "text/") || mediaType == "application/x-www-form-urlencoded"
+}
+
+func isJSONContentType(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,229 @@
+package option
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/jocall3/go/internal"
+	"github.com/jocall3/go/internal/apierror"
+	"github.com/jocall3/go/internal/requestconfig"
+)
+
+// The attributes that WithOpenTelemetry adds to spans and metrics on top of
+// the OpenTelemetry semantic conventions for HTTP clients.
+const (
+	// The service method of the call, such as "Transactions.List".
+	OpenTelemetryOperationKey = attribute.Key("jocall3.operation")
+	// The number of retries a call took.
+	OpenTelemetryRetryCountKey = attribute.Key("jocall3.retry_count")
+)
+
+const openTelemetryInstrumentationName = "github.com/jocall3/go"
+
+// WithOpenTelemetry returns a RequestOption that traces and measures requests
+// with OpenTelemetry. If tracerProvider or meterProvider is nil, the global
+// provider is used.
+//
+// Every method call gets a client span named after the service method, such as
+// "Transactions.List", and every attempt of the call, including retries, gets a
+// child span of the same name. The W3C traceparent header of each attempt
+// carries the attempt's span. The following metrics are recorded:
+//
+//   - jocall3.client.call.duration, the duration of method calls in seconds;
+//   - http.client.request.duration, the duration of each attempt in seconds;
+//   - jocall3.client.retries, the number of retries made.
+//
+// Query strings are left out of the url.full attribute, because they may hold
+// personal data such as search terms.
+func WithOpenTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) RequestOption {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	t := newTelemetry(tracerProvider, meterProvider)
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.CallObservers = append(r.CallObservers, t.observeCall)
+		r.Middlewares = append(r.Middlewares, t.middleware)
+		return nil
+	})
+}
+
+type telemetry struct {
+	tracer          trace.Tracer
+	propagator      propagation.TextMapPropagator
+	callDuration    metric.Float64Histogram
+	attemptDuration metric.Float64Histogram
+	retries         metric.Int64Counter
+}
+
+// telemetryCall collects what the attempts of a call have in common.
+type telemetryCall struct {
+	attempts int
+}
+
+type telemetryCallContextKey struct{}
+
+// The bucket boundaries recommended for http.client.request.duration.
+var telemetryDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10}
+
+func newTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) *telemetry {
+	meter := meterProvider.Meter(openTelemetryInstrumentationName, metric.WithInstrumentationVersion(internal.PackageVersion))
+	t := &telemetry{
+		tracer:     tracerProvider.Tracer(openTelemetryInstrumentationName, trace.WithInstrumentationVersion(internal.PackageVersion)),
+		propagator: propagation.TraceContext{},
+	}
+
+	var errs []error
+	var err error
+	t.callDuration, err = meter.Float64Histogram("jocall3.client.call.duration",
+		metric.WithDescription("Duration of SDK method calls, including retries."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(telemetryDurationBuckets...),
+	)
+	errs = append(errs, err)
+	t.attemptDuration, err = meter.Float64Histogram("http.client.request.duration",
+		metric.WithDescription("Duration of HTTP client requests."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(telemetryDurationBuckets...),
+	)
+	errs = append(errs, err)
+	t.retries, err = meter.Int64Counter("jocall3.client.retries",
+		metric.WithDescription("Number of retried HTTP client requests."),
+		metric.WithUnit("{retry}"),
+	)
+	errs = append(errs, err)
+	if err := errors.Join(errs...); err != nil {
+		otel.Handle(err)
+	}
+	return t
+}
+
+func (t *telemetry) observeCall(ctx context.Context, op requestconfig.Operation) (context.Context, func(*http.Response, error)) {
+	attrs := telemetryOperationAttributes(op)
+	ctx, span := t.tracer.Start(ctx, telemetrySpanName(op), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	call := &telemetryCall{}
+	ctx = context.WithValue(ctx, telemetryCallContextKey{}, call)
+	start := time.Now()
+
+	return ctx, func(res *http.Response, err error) {
+		retries := max(call.attempts-1, 0)
+		attrs = append(attrs, OpenTelemetryRetryCountKey.Int(retries))
+		attrs = append(attrs, telemetryOutcomeAttributes(res, err)...)
+		span.SetAttributes(attrs...)
+		telemetrySetStatus(span, res, err)
+		span.End()
+		t.callDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	}
+}
+
+func (t *telemetry) middleware(req *http.Request, next MiddlewareNext) (*http.Response, error) {
+	op, _ := requestconfig.OperationFromContext(req.Context())
+	if call, ok := req.Context().Value(telemetryCallContextKey{}).(*telemetryCall); ok {
+		call.attempts++
+	}
+
+	attrs := telemetryOperationAttributes(op)
+	if op.Attempt > 0 {
+		attrs = append(attrs, semconv.HTTPRequestResendCount(op.Attempt))
+	}
+	if host, port := req.URL.Hostname(), req.URL.Port(); host != "" {
+		attrs = append(attrs, semconv.ServerAddress(host))
+		if n, err := strconv.Atoi(port); err == nil {
+			attrs = append(attrs, semconv.ServerPort(n))
+		}
+	}
+	spanAttrs := append([]attribute.KeyValue{semconv.URLFull(telemetryURL(req))}, attrs...)
+
+	ctx, span := t.tracer.Start(req.Context(), telemetrySpanName(op), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(spanAttrs...))
+	req = req.WithContext(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	start := time.Now()
+
+	res, err := next(req)
+
+	outcome := telemetryOutcomeAttributes(res, err)
+	span.SetAttributes(outcome...)
+	telemetrySetStatus(span, res, err)
+	span.End()
+	attrs = append(attrs, outcome...)
+	t.attemptDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(attrs...))
+	if op.Attempt > 0 {
+		t.retries.Add(ctx, 1, metric.WithAttributes(telemetryOperationAttributes(op)...))
+	}
+	return res, err
+}
+
+func telemetrySpanName(op requestconfig.Operation) string {
+	if op.Name != "" {
+		return op.Name
+	}
+	return op.Method + " " + op.Route
+}
+
+func telemetryOperationAttributes(op requestconfig.Operation) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(op.Method),
+		semconv.URLTemplate(op.Route),
+	}
+	if op.Name != "" {
+		attrs = append(attrs, OpenTelemetryOperationKey.String(op.Name))
+	}
+	return attrs
+}
+
+func telemetryOutcomeAttributes(res *http.Response, err error) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	status := 0
+	if res != nil {
+		status = res.StatusCode
+	}
+	var apiErr *apierror.Error
+	if errors.As(err, &apiErr) {
+		status = apiErr.StatusCode
+	}
+	if status != 0 {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(status))
+	}
+	switch {
+	case status >= 400:
+		attrs = append(attrs, semconv.ErrorTypeKey.String(strconv.Itoa(status)))
+	case err != nil:
+		// Report the error of the HTTP client rather than the wrapper around it.
+		var transportErr *apierror.TransportError
+		if errors.As(err, &transportErr) {
+			err = transportErr.Err
+		}
+		attrs = append(attrs, semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err)))
+	}
+	return attrs
+}
+
+func telemetrySetStatus(span trace.Span, res *http.Response, err error) {
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case res != nil && res.StatusCode >= 400:
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	}
+}
+
+func telemetryURL(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery, u.ForceQuery, u.User = "", false, nil
+	return u.String()
+}
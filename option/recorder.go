@@ -0,0 +1,355 @@
+package option
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/jocall3/go/internal/requestconfig"
+)
+
+// ErrRecorderNoMatch is matched, using [errors.Is], by the errors returned for
+// requests that a recorder in [RecorderReplayStrict] mode has no recorded
+// response for.
+var ErrRecorderNoMatch = requestconfig.ErrRecorderNoMatch
+
+// RecorderMismatchError is returned for a request that a recorder in
+// [RecorderReplayStrict] mode has no recorded response for. It matches
+// [ErrRecorderNoMatch].
+type RecorderMismatchError = requestconfig.RecorderMismatchError
+
+// RecorderMode says whether a recorder sends requests or answers them from its
+// cassette.
+type RecorderMode int
+
+const (
+	// Every request is sent, and the cassette is replaced by the interactions
+	// recorded.
+	RecorderRecord RecorderMode = iota
+	// Requests are answered from the cassette where possible. The others are
+	// sent, and their interactions added to the cassette.
+	RecorderReplay
+	// Requests are only answered from the cassette, which must exist. Requests it
+	// has no response for fail with a [*RecorderMismatchError].
+	RecorderReplayStrict
+)
+
+// RecorderConfig configures the recorder set up by [WithRecorderConfig].
+type RecorderConfig struct {
+	// The path of the cassette file.
+	Path string
+	Mode RecorderMode
+	// The headers whose values are replaced by [LogRedacted] before they are
+	// written. If nil, [DefaultRedactedHeaders] is used.
+	RedactHeaders []string
+	// The fields of JSON bodies whose values are replaced by [LogRedacted] before
+	// they are written, using the syntax of [LogConfig.RedactJSONPaths]. If nil,
+	// [DefaultRedactedJSONPaths] is used. The same paths apply to the form
+	// fields of multipart bodies, whose names use dots between segments, such
+	// as "additionalDocuments.0". The content of multipart file parts is always
+	// replaced by a placeholder giving its size.
+	RedactJSONPaths []string
+}
+
+// WithRecorder returns a RequestOption that records requests and their
+// responses to the cassette file at path, or replays them from it, depending on
+// mode. Secrets are redacted as described by [RecorderConfig].
+func WithRecorder(path string, mode RecorderMode) RequestOption {
+	return WithRecorderConfig(RecorderConfig{Path: path, Mode: mode})
+}
+
+// WithRecorderConfig returns a RequestOption that records requests and their
+// responses to a cassette file, or replays them from it, as configured by
+// config.
+//
+// A request matches a recorded one if they have the same method, path and
+// query parameters, and the same body once it is redacted, JSON is normalized
+// and multipart boundaries are ignored; the host and headers are not compared.
+// Matching interactions are replayed in the order they were recorded, and the
+// last one is repeated once they have all been used, so that polling a
+// long-running operation replays every recorded state.
+//
+// The cassette is rewritten after every recorded interaction. A recorder is
+// meant for tests, and keeps every response body in memory.
+func WithRecorderConfig(config RecorderConfig) RequestOption {
+	rec := &recorder{
+		config:   config,
+		redactor: newRedactor(config.RedactHeaders, config.RedactJSONPaths),
+	}
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		rec.once.Do(func() { rec.err = rec.load() })
+		if rec.err != nil {
+			return rec.err
+		}
+		r.Middlewares = append(r.Middlewares, rec.middleware)
+		return nil
+	})
+}
+
+type recorder struct {
+	config   RecorderConfig
+	redactor *redactor
+
+	once         sync.Once
+	err          error
+	mu           sync.Mutex
+	interactions []*cassetteInteraction
+}
+
+type cassette struct {
+	Interactions []*cassetteInteraction `json:"interactions"`
+}
+
+type cassetteInteraction struct {
+	Request  cassetteMessage `json:"request"`
+	Response cassetteMessage `json:"response"`
+
+	key  string
+	used bool
+}
+
+// cassetteMessage is a recorded request or response.
+type cassetteMessage struct {
+	Method string      `json:"method,omitempty"`
+	URL    string      `json:"url,omitempty"`
+	Status int         `json:"status,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+	// "base64" if Body is base64 encoded because it is not valid UTF-8.
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+}
+
+func (m *cassetteMessage) setBody(data []byte) {
+	if utf8.Valid(data) {
+		m.Body = string(data)
+		return
+	}
+	m.Body, m.BodyEncoding = base64.StdEncoding.EncodeToString(data), "base64"
+}
+
+func (m *cassetteMessage) body() ([]byte, error) {
+	if m.BodyEncoding == "base64" {
+		return base64.StdEncoding.DecodeString(m.Body)
+	}
+	return []byte(m.Body), nil
+}
+
+func (rec *recorder) load() error {
+	if rec.config.Path == "" {
+		return errors.New("recorder: the cassette path is empty")
+	}
+	if rec.config.Mode == RecorderRecord {
+		return nil
+	}
+	data, err := os.ReadFile(rec.config.Path)
+	if errors.Is(err, fs.ErrNotExist) && rec.config.Mode == RecorderReplay {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+	var c cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return fmt.Errorf("recorder: cannot read cassette %s: %w", rec.config.Path, err)
+	}
+	for _, interaction := range c.Interactions {
+		body, err := interaction.Request.body()
+		if err != nil {
+			return fmt.Errorf("recorder: cannot read cassette %s: %w", rec.config.Path, err)
+		}
+		interaction.key, err = rec.key(interaction.Request.Method, interaction.Request.URL, interaction.Request.Header.Get("Content-Type"), body)
+		if err != nil {
+			return fmt.Errorf("recorder: cannot read cassette %s: %w", rec.config.Path, err)
+		}
+	}
+	rec.interactions = c.Interactions
+	return nil
+}
+
+func (rec *recorder) middleware(req *http.Request, next MiddlewareNext) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	contentType := req.Header.Get("Content-Type")
+	key, err := rec.key(req.Method, req.URL.String(), contentType, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if rec.config.Mode != RecorderRecord {
+		if interaction := rec.match(key); interaction != nil {
+			return interaction.response(req)
+		}
+		if rec.config.Mode == RecorderReplayStrict {
+			mismatch := &RecorderMismatchError{Cassette: rec.config.Path, Method: req.Method, URL: req.URL.String()}
+			if i := strings.IndexByte(key, '\n'); i >= 0 {
+				mismatch.Body = key[i+1:]
+			}
+			return nil, mismatch
+		}
+	}
+
+	res, err := next(req)
+	if err != nil {
+		return res, err
+	}
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	interaction := &cassetteInteraction{
+		Request:  cassetteMessage{Method: req.Method, URL: req.URL.String(), Header: rec.redactHeader(req.Header)},
+		Response: cassetteMessage{Status: res.StatusCode, Header: rec.redactHeader(res.Header)},
+		key:      key,
+		used:     true,
+	}
+	interaction.Request.setBody(rec.redactBody(contentType, body))
+	interaction.Response.setBody(rec.redactBody(res.Header.Get("Content-Type"), resBody))
+	if err := rec.append(interaction); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// match returns the first unused interaction recorded for key, or the last one
+// if they have all been used.
+func (rec *recorder) match(key string) *cassetteInteraction {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	var last *cassetteInteraction
+	for _, interaction := range rec.interactions {
+		if interaction.key != key {
+			continue
+		}
+		if !interaction.used {
+			interaction.used = true
+			return interaction
+		}
+		last = interaction
+	}
+	return last
+}
+
+func (rec *recorder) append(interaction *cassetteInteraction) error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.interactions = append(rec.interactions, interaction)
+
+	data, err := json.MarshalIndent(cassette{Interactions: rec.interactions}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(rec.config.Path), 0o755); err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+	tmp := rec.config.Path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+	if err := os.Rename(tmp, rec.config.Path); err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+	return nil
+}
+
+// key returns the string that requests are matched on: the method, path and
+// sorted query on the first line, and the normalized body after it.
+func (rec *recorder) key(method, rawURL, contentType string, body []byte) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	key := strings.ToUpper(method) + " " + u.EscapedPath()
+	if query := u.Query().Encode(); query != "" {
+		key += "?" + query
+	}
+	if len(body) == 0 {
+		return key, nil
+	}
+	return key + "\n" + string(rec.normalizeBody(contentType, body)), nil
+}
+
+func (rec *recorder) normalizeBody(contentType string, body []byte) []byte {
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	case isJSONContentType(contentType):
+		body = rec.redactBody(contentType, body)
+		var v interface{}
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if dec.Decode(&v) == nil {
+			if normalized, err := json.Marshal(v); err == nil {
+				return normalized
+			}
+		}
+	case strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "":
+		body = rec.redactBody(contentType, body)
+		return bytes.ReplaceAll(body, []byte(params["boundary"]), []byte("boundary"))
+	}
+	return body
+}
+
+func (rec *recorder) redactBody(contentType string, body []byte) []byte {
+	if isJSONContentType(contentType) && json.Valid(body) {
+		return rec.redactor.redactJSON(body)
+	}
+	if mediaType, params, _ := mime.ParseMediaType(contentType); strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		return rec.redactor.redactMultipart(body, params["boundary"])
+	}
+	return body
+}
+
+func (rec *recorder) redactHeader(header http.Header) http.Header {
+	out := header.Clone()
+	for k := range out {
+		if rec.redactor.redactsHeader(k) {
+			out[k] = []string{LogRedacted}
+		}
+	}
+	return out
+}
+
+func (interaction *cassetteInteraction) response(req *http.Request) (*http.Response, error) {
+	body, err := interaction.Response.body()
+	if err != nil {
+		return nil, err
+	}
+	status := interaction.Response.Status
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
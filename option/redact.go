@@ -0,0 +1,211 @@
+package option
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// LogRedacted replaces the values that are redacted from logs and cassettes.
+const LogRedacted = "[REDACTED]"
+
+// DefaultRedactedHeaders lists the headers whose values are redacted unless
+// [LogConfig.RedactHeaders] or [RecorderConfig.RedactHeaders] say otherwise.
+var DefaultRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-API-Key",
+	"1231_API_KEY",
+	"X-Biometric-Token",
+}
+
+// DefaultRedactedJSONPaths lists the JSON body fields that are redacted unless
+// [LogConfig.RedactJSONPaths] or [RecorderConfig.RedactJSONPaths] say
+// otherwise: credentials, tokens, biometric signatures, bank account numbers
+// and KYC document data.
+var DefaultRedactedJSONPaths = []string{
+	"**.password",
+	"**.newPassword",
+	"**.accessToken",
+	"**.refreshToken",
+	"**.token",
+	"**.secret",
+	"**.biometricSignature",
+	"**.biometricToken",
+	"**.documentNumber",
+	"**.documentFrontImage",
+	"**.documentBackImage",
+	"**.additionalDocuments",
+	"**.accountNumber",
+	"**.iban",
+	"**.routingNumber",
+	"**.cardNumber",
+	"**.cvv",
+	"**.ssn",
+	"**.taxId",
+	"**.dateOfBirth",
+}
+
+// redactor hides secrets and personal data in headers and JSON bodies.
+type redactor struct {
+	headers map[string]bool
+	paths   [][]string
+}
+
+// newRedactor returns a redactor for the given headers and JSON paths, using
+// the defaults for either if it is nil.
+func newRedactor(headers, paths []string) *redactor {
+	if headers == nil {
+		headers = DefaultRedactedHeaders
+	}
+	if paths == nil {
+		paths = DefaultRedactedJSONPaths
+	}
+	r := &redactor{headers: map[string]bool{}}
+	for _, h := range headers {
+		r.headers[strings.ToLower(h)] = true
+	}
+	for _, p := range paths {
+		r.paths = append(r.paths, strings.Split(p, "."))
+	}
+	return r
+}
+
+func (r *redactor) redactsHeader(key string) bool {
+	return r.headers[strings.ToLower(key)]
+}
+
+// redactJSON replaces the values at the redacted paths of a valid JSON document.
+func (r *redactor) redactJSON(data []byte) []byte {
+	if len(r.paths) == 0 {
+		return data
+	}
+	var spans [][2]int
+	var walk func(value gjson.Result, path []string)
+	walk = func(value gjson.Result, path []string) {
+		if len(path) > 0 {
+			for _, pattern := range r.paths {
+				if matchRedactedPath(pattern, path) {
+					spans = append(spans, [2]int{value.Index, value.Index + len(value.Raw)})
+					return
+				}
+			}
+		}
+		if !value.IsObject() && !value.IsArray() {
+			return
+		}
+		value.ForEach(func(key, child gjson.Result) bool {
+			segment := key.Str
+			if !value.IsObject() {
+				segment = strconv.Itoa(int(key.Num))
+			}
+			walk(child, append(path[:len(path):len(path)], segment))
+			return true
+		})
+	}
+	walk(gjson.ParseBytes(data), nil)
+	if len(spans) == 0 {
+		return data
+	}
+
+	out := make([]byte, 0, len(data))
+	last := 0
+	for _, span := range spans {
+		out = append(out, data[last:span[0]]...)
+		out = append(out, `"`+LogRedacted+`"`...)
+		last = span[1]
+	}
+	return append(out, data[last:]...)
+}
+
+// redactedFilePattern matches the placeholder redactMultipart leaves in place
+// of a file, so that redacting a body twice gives the same result.
+var redactedFilePattern = regexp.MustCompile(`^\[REDACTED \d+ bytes\]$`)
+
+// redactMultipart rewrites a multipart body with the given boundary. The
+// content of every file part is replaced by a placeholder recording its size,
+// and the form fields whose names match a redacted path, such as
+// "documentNumber" or "additionalDocuments.0", are replaced by [LogRedacted].
+// A body that cannot be parsed is replaced as a whole, as it cannot be checked
+// for secrets.
+func (r *redactor) redactMultipart(data []byte, boundary string) []byte {
+	var out bytes.Buffer
+	writer := multipart.NewWriter(&out)
+	if err := writer.SetBoundary(boundary); err != nil {
+		return redactedSize(len(data))
+	}
+	reader := multipart.NewReader(bytes.NewReader(data), boundary)
+	for {
+		part, err := reader.NextRawPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return redactedSize(len(data))
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return redactedSize(len(data))
+		}
+		switch {
+		case part.FileName() != "":
+			if !redactedFilePattern.Match(content) {
+				content = redactedSize(len(content))
+			}
+		case r.redactsField(strings.Split(part.FormName(), ".")):
+			content = []byte(LogRedacted)
+		}
+		w, err := writer.CreatePart(part.Header)
+		if err != nil {
+			return redactedSize(len(data))
+		}
+		w.Write(content)
+	}
+	if err := writer.Close(); err != nil {
+		return redactedSize(len(data))
+	}
+	return out.Bytes()
+}
+
+func redactedSize(n int) []byte {
+	return []byte(fmt.Sprintf("[REDACTED %d bytes]", n))
+}
+
+// redactsField reports whether a field, or the object or array containing it,
+// is at a redacted path.
+func (r *redactor) redactsField(path []string) bool {
+	for i := 1; i <= len(path); i++ {
+		for _, pattern := range r.paths {
+			if matchRedactedPath(pattern, path[:i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchRedactedPath(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(path); i++ {
+			if matchRedactedPath(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(path) == 0 || (pattern[0] != "*" && !strings.EqualFold(pattern[0], path[0])) {
+		return false
+	}
+	return matchRedactedPath(pattern[1:], path[1:])
+}
@@ -229,6 +229,39 @@ func WithResponseInto(dst **http.Response) RequestOption {
 	})
 }
 
+// WithIdempotencyKey returns a RequestOption that sets the Idempotency-Key header
+// sent with POST, PUT, PATCH and DELETE requests. It has no effect on GET,
+// HEAD, OPTIONS and TRACE requests, which are sent without a key.
+//
+// By default the client generates a random key for every method call and reuses
+// it for each retry of that call, so the server can deduplicate retried
+// requests. Supply your own key when the same operation may be re-issued as a
+// separate call, for example after a process restart. To send no key at all,
+// use WithHeaderDel("Idempotency-Key").
+//
+// Pass this option to a single method call, never to NewClient or a service
+// constructor: every mutating call made with it would share the key, and the
+// server would answer all of them with the stored result of the first.
+func WithIdempotencyKey(key string) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		if requestconfig.IsIdempotentMethod(r.Request.Method) {
+			return nil
+		}
+		r.Request.Header.Set(requestconfig.IdempotencyHeader, key)
+		return nil
+	})
+}
+
+// WithIdempotentReplayedInto returns a RequestOption that reports, through dst,
+// whether the server answered with the stored result of an earlier request that
+// used the same idempotency key rather than performing the operation again.
+func WithIdempotentReplayedInto(dst *bool) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.IdempotentReplayedInto = dst
+		return nil
+	})
+}
+
 // WithRequestBody returns a RequestOption that provides a custom serialized body with the given
 // content type.
 //
@@ -281,3 +314,24 @@ func WithBiometricToken(value string) RequestOption {
 		return r.Apply(WithHeader("authorization", fmt.Sprintf("Bearer %s", r.BiometricToken)))
 	})
 }
+
+// WithWebhookSecret returns a RequestOption that sets the secret used by
+// [jocall3.WebhookService] to verify webhook signatures. This is the secret of
+// the webhook subscription, either supplied when it was created or generated by
+// the API.
+func WithWebhookSecret(value string) RequestOption {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.WebhookSecret = value
+		return nil
+	})
+}
+
+// WithWebhookTolerance returns a RequestOption that sets how far a webhook's
+// timestamp may differ from the local clock before the delivery is rejected. The
+// default is five minutes.
+func WithWebhookTolerance(tolerance time.Duration) RequestOption {
+	return requestconfig.PreRequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.WebhookTolerance = tolerance
+		return nil
+	})
+}
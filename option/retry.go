@@ -0,0 +1,188 @@
+package option
+
+import (
+	"context"
+	"errors"
+	"math"
+	mathrand "math/rand"
+	"net/http"
+	"time"
+
+	"github.com/jocall3/go/internal/requestconfig"
+)
+
+// RetryPolicy decides whether an attempt is retried and how long to wait before
+// the retry. It is consulted after every attempt, including successful ones,
+// until the retries set by [WithMaxRetries] run out. [BackoffRetryPolicy]
+// covers most needs; implement the interface directly for anything else.
+type RetryPolicy = requestconfig.RetryPolicy
+
+// RetryAttempt describes an attempt at a request, as seen by a [RetryPolicy].
+type RetryAttempt = requestconfig.RetryAttempt
+
+// DefaultRetryPolicy is the policy used unless [WithRetryPolicy] says otherwise.
+// It retries connection errors and 408, 409, 429 and 5xx responses, waits for
+// the duration given by a Retry-After header under a minute, and otherwise
+// backs off exponentially from 0.5s to 8s with up to 25% jitter.
+var DefaultRetryPolicy RetryPolicy = requestconfig.DefaultRetryPolicy
+
+// WithRetryPolicy returns a RequestOption that decides which failed attempts
+// are retried, and after how long, with policy. The number of retries is still
+// set by [WithMaxRetries].
+func WithRetryPolicy(policy RetryPolicy) RequestOption {
+	return requestconfig.RequestOptionFunc(func(r *requestconfig.RequestConfig) error {
+		r.RetryPolicy = policy
+		return nil
+	})
+}
+
+// Backoff returns the delay before a retry, given the number of retries made
+// so far and the delay before the previous attempt.
+type Backoff func(retry int, previous time.Duration) time.Duration
+
+// ExponentialBackoff doubles the delay with every retry, starting from base and
+// capped at max, and subtracts up to 25% of it at random. This is the curve of
+// [DefaultRetryPolicy] with a base of 0.5s and a max of 8s.
+func ExponentialBackoff(base, max time.Duration) Backoff {
+	return func(retry int, previous time.Duration) time.Duration {
+		delay := exponential(base, max, retry)
+		if delay >= 4 {
+			delay -= time.Duration(mathrand.Int63n(int64(delay / 4)))
+		}
+		return delay
+	}
+}
+
+// FullJitterBackoff picks a delay uniformly between zero and the exponential
+// delay for the retry, which starts from base and is capped at max. It spreads
+// out clients that fail at the same time better than [ExponentialBackoff].
+func FullJitterBackoff(base, max time.Duration) Backoff {
+	return func(retry int, previous time.Duration) time.Duration {
+		delay := exponential(base, max, retry)
+		if delay <= 0 {
+			return 0
+		}
+		return time.Duration(mathrand.Int63n(int64(delay) + 1))
+	}
+}
+
+// DecorrelatedJitterBackoff picks a delay between base and three times the
+// previous delay, capped at max, so that each delay depends on the one before
+// it rather than on the retry count.
+func DecorrelatedJitterBackoff(base, max time.Duration) Backoff {
+	return func(retry int, previous time.Duration) time.Duration {
+		if previous < base {
+			previous = base
+		}
+		upper := 3 * previous
+		if upper > max || upper < 0 {
+			upper = max
+		}
+		if upper <= base {
+			return upper
+		}
+		return base + time.Duration(mathrand.Int63n(int64(upper-base)+1))
+	}
+}
+
+func exponential(base, max time.Duration, retry int) time.Duration {
+	delay := time.Duration(float64(base) * math.Pow(2, float64(retry)))
+	if delay > max || delay < 0 {
+		delay = max
+	}
+	return delay
+}
+
+// BackoffRetryPolicy is a configurable [RetryPolicy]. Its zero value behaves
+// like [DefaultRetryPolicy].
+type BackoffRetryPolicy struct {
+	// Backoff computes the delay before each retry. If nil,
+	// ExponentialBackoff(500*time.Millisecond, 8*time.Second) is used.
+	Backoff Backoff
+	// RetryableStatuses lists the response status codes that are retried. If
+	// nil, 408, 409, 429 and every 5xx status are retried. An x-should-retry
+	// response header overrides the list either way.
+	RetryableStatuses []int
+	// RetryableError reports whether an error from the HTTP client, such as a
+	// connection reset, is retried. If nil, every such error is retried except a
+	// cancelled or expired context. Requests rejected by a [CircuitBreaker], or
+	// by a recorder replaying in strict mode, are never retried.
+	RetryableError func(err error) bool
+	// Budget bounds the time spent retrying a call. A retry is not made if the
+	// time since the first attempt plus the delay before the retry would exceed
+	// the budget. Zero means no limit.
+	Budget time.Duration
+	// IgnoreRetryAfter disables waiting for the duration given by the
+	// Retry-After and Retry-After-Ms response headers.
+	IgnoreRetryAfter bool
+	// MaxRetryAfter is the longest Retry-After delay that is honoured; longer
+	// delays fall back to Backoff. Zero means one minute.
+	MaxRetryAfter time.Duration
+	// BeforeRetry, if set, is called before waiting for each retry with the
+	// failed attempt and the delay that will follow it.
+	BeforeRetry func(attempt RetryAttempt, delay time.Duration)
+}
+
+// Retry implements [RetryPolicy].
+func (p *BackoffRetryPolicy) Retry(attempt RetryAttempt) (time.Duration, bool) {
+	if !p.retryable(attempt) {
+		return 0, false
+	}
+
+	delay, ok := time.Duration(0), false
+	if !p.IgnoreRetryAfter {
+		maxRetryAfter := p.MaxRetryAfter
+		if maxRetryAfter <= 0 {
+			maxRetryAfter = time.Minute
+		}
+		delay, ok = requestconfig.RetryAfter(attempt.Response)
+		ok = ok && delay >= 0 && delay < maxRetryAfter
+	}
+	if !ok {
+		backoff := p.Backoff
+		if backoff == nil {
+			backoff = ExponentialBackoff(500*time.Millisecond, 8*time.Second)
+		}
+		delay = backoff(attempt.Retry, attempt.PreviousDelay)
+	}
+
+	if p.Budget > 0 && attempt.Elapsed+delay > p.Budget {
+		return 0, false
+	}
+	if p.BeforeRetry != nil {
+		p.BeforeRetry(attempt, delay)
+	}
+	return delay, true
+}
+
+func (p *BackoffRetryPolicy) retryable(attempt RetryAttempt) bool {
+	res := attempt.Response
+	if res == nil {
+		if attempt.Err == nil || requestconfig.IsRejected(attempt.Err) {
+			return false
+		}
+		if p.RetryableError != nil {
+			return p.RetryableError(attempt.Err)
+		}
+		return !errors.Is(attempt.Err, context.Canceled) && !errors.Is(attempt.Err, context.DeadlineExceeded)
+	}
+
+	switch res.Header.Get("x-should-retry") {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if p.RetryableStatuses == nil {
+		return res.StatusCode == http.StatusRequestTimeout ||
+			res.StatusCode == http.StatusConflict ||
+			res.StatusCode == http.StatusTooManyRequests ||
+			res.StatusCode >= http.StatusInternalServerError
+	}
+	for _, status := range p.RetryableStatuses {
+		if res.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
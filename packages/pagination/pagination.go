@@ -0,0 +1,152 @@
+// File generated from our OpenAPI spec by Stainless. See CONTRIBUTING.md for details.
+
+package pagination
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/jocall3/go/internal/apijson"
+	"github.com/jocall3/go/internal/requestconfig"
+)
+
+type Page[T any] struct {
+	Data []T `json:"data"`
+	// The maximum number of items returned in the current page.
+	Limit int64 `json:"limit"`
+	// The number of items skipped before the current page.
+	Offset int64 `json:"offset"`
+	// The total number of items available across all pages.
+	Total int64 `json:"total"`
+	// The offset for the next page of results, if available. Null if no more pages.
+	NextOffset int64    `json:"nextOffset,nullable"`
+	JSON       pageJSON `json:"-"`
+	cfg        *requestconfig.RequestConfig
+	res        *http.Response
+}
+
+// pageJSON contains the JSON metadata for the struct [Page[T]]
+type pageJSON struct {
+	Data        apijson.Field
+	Limit       apijson.Field
+	Offset      apijson.Field
+	Total       apijson.Field
+	NextOffset  apijson.Field
+	raw         string
+	ExtraFields map[string]apijson.Field
+}
+
+func (r *Page[T]) UnmarshalJSON(data []byte) (err error) {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r pageJSON) RawJSON() string {
+	return r.raw
+}
+
+// GetNextPage returns the next page as defined by this pagination style. When
+// there is no next page, this function will return a 'nil' for the page value, but
+// will not return an error
+func (r *Page[T]) GetNextPage() (res *Page[T], err error) {
+	if r.cfg == nil || len(r.Data) == 0 {
+		return nil, nil
+	}
+
+	next := r.Offset + int64(len(r.Data))
+	switch {
+	case !r.JSON.NextOffset.IsNull():
+		next = r.NextOffset
+	case !r.JSON.NextOffset.IsMissing():
+		// An explicit null marks the last page.
+		return nil, nil
+	case !r.JSON.Total.IsNull() && next >= r.Total:
+		return nil, nil
+	}
+
+	cfg := r.cfg.Clone(r.cfg.Context)
+	if cfg == nil {
+		return nil, nil
+	}
+	query := cfg.Request.URL.Query()
+	query.Set("offset", strconv.FormatInt(next, 10))
+	cfg.Request.URL.RawQuery = query.Encode()
+
+	var raw *http.Response
+	cfg.ResponseInto = &raw
+	cfg.ResponseBodyInto = &res
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+func (r *Page[T]) SetPageConfig(cfg *requestconfig.RequestConfig, res *http.Response) {
+	if r == nil {
+		r = &Page[T]{}
+	}
+	r.cfg = cfg
+	r.res = res
+}
+
+type PageAutoPager[T any] struct {
+	page *Page[T]
+	cur  T
+	idx  int
+	run  int
+	err  error
+}
+
+func NewPageAutoPager[T any](page *Page[T], err error) *PageAutoPager[T] {
+	return &PageAutoPager[T]{
+		page: page,
+		err:  err,
+	}
+}
+
+func (r *PageAutoPager[T]) Next() bool {
+	if r.page == nil || len(r.page.Data) == 0 {
+		return false
+	}
+	if r.idx >= len(r.page.Data) {
+		r.idx = 0
+		r.page, r.err = r.page.GetNextPage()
+		if r.err != nil || r.page == nil || len(r.page.Data) == 0 {
+			return false
+		}
+	}
+	r.cur = r.page.Data[r.idx]
+	r.run += 1
+	r.idx += 1
+	return true
+}
+
+func (r *PageAutoPager[T]) Current() T {
+	return r.cur
+}
+
+func (r *PageAutoPager[T]) Err() error {
+	return r.err
+}
+
+func (r *PageAutoPager[T]) Index() int {
+	return r.run
+}
+
+// All returns an iterator over every item across all pages, which can be used
+// with range-over-func as an iter.Seq2[T, error]. Iteration stops after the last
+// page, or after yielding a non-nil error such as a cancelled context.
+func (r *PageAutoPager[T]) All() func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for r.Next() {
+			if !yield(r.Current(), nil) {
+				return
+			}
+		}
+		if err := r.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package ssestream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jocall3/go/internal/sse"
+)
+
+// Stream reads a server-sent event response and decodes the data of each event
+// into a T. Events whose data is empty, as well as keep-alive "ping" events, are
+// skipped, and a "[DONE]" sentinel ends the stream.
+//
+// A Stream must be closed once it is no longer needed, unless it has been read
+// to the end.
+type Stream[T any] struct {
+	dec *sse.Decoder
+	cur T
+	err error
+}
+
+// NewStream returns a stream over the body of res. If err is not nil the stream
+// is empty and [Stream.Err] reports err, which makes it convenient to pass the
+// result of a request straight through.
+func NewStream[T any](res *http.Response, err error) *Stream[T] {
+	if err != nil {
+		return &Stream[T]{err: err}
+	}
+	if res == nil || res.Body == nil {
+		return &Stream[T]{err: errors.New("ssestream: response has no body")}
+	}
+	return &Stream[T]{dec: sse.NewDecoder(res.Body)}
+}
+
+// Next advances the stream, returning false when it ends, fails or is closed.
+func (s *Stream[T]) Next() bool {
+	if s.err != nil || s.dec == nil {
+		return false
+	}
+	for s.dec.Next() {
+		event := s.dec.Event()
+		data := bytes.TrimSpace(event.Data)
+		if event.Type == "ping" || len(data) == 0 {
+			continue
+		}
+		if bytes.Equal(data, []byte("[DONE]")) {
+			s.Close()
+			return false
+		}
+		if event.Type == "error" {
+			s.err = fmt.Errorf("ssestream: received error event: %s", data)
+			s.Close()
+			return false
+		}
+
+		var cur T
+		if err := json.Unmarshal(data, &cur); err != nil {
+			s.err = fmt.Errorf("ssestream: decoding %q event: %w", event.Type, err)
+			s.Close()
+			return false
+		}
+		s.cur = cur
+		return true
+	}
+	s.err = s.dec.Err()
+	s.Close()
+	return false
+}
+
+// Current returns the event most recently decoded by [Stream.Next].
+func (s *Stream[T]) Current() T {
+	return s.cur
+}
+
+// Err returns the error that ended the stream, if any.
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// Close releases the underlying response body. It is safe to call Close more
+// than once.
+func (s *Stream[T]) Close() error {
+	if s.dec == nil {
+		return nil
+	}
+	return s.dec.Close()
+}
+
+// All returns an iterator over the remaining events. Iteration stops after the
+// first error, which is yielded together with the zero value of T, and the
+// stream is closed when the loop exits.
+func (s *Stream[T]) All() func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		defer s.Close()
+		for s.Next() {
+			if !yield(s.Current(), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
@@ -55,25 +56,25 @@ func (r *PaymentFxService) GetRates(ctx context.Context, query PaymentFxGetRates
 
 type PaymentFxConvertResponse struct {
 	// Unique identifier for the currency conversion.
-	ConversionID interface{} `json:"conversionId,required"`
+	ConversionID string `json:"conversionId,required"`
 	// Timestamp when the conversion was completed.
-	ConversionTimestamp interface{} `json:"conversionTimestamp,required"`
+	ConversionTimestamp time.Time `json:"conversionTimestamp,required" format:"date-time"`
 	// The foreign exchange rate applied (target per source currency).
-	FxRateApplied interface{} `json:"fxRateApplied,required"`
+	FxRateApplied Decimal `json:"fxRateApplied,required"`
 	// The amount converted from the source currency.
-	SourceAmount interface{} `json:"sourceAmount,required"`
+	SourceAmount Decimal `json:"sourceAmount,required"`
 	// The source currency code.
-	SourceCurrency interface{} `json:"sourceCurrency,required"`
+	SourceCurrency string `json:"sourceCurrency,required"`
 	// Status of the currency conversion.
 	Status PaymentFxConvertResponseStatus `json:"status,required"`
 	// The amount converted into the target currency.
-	TargetAmount interface{} `json:"targetAmount,required"`
+	TargetAmount Decimal `json:"targetAmount,required"`
 	// Any fees applied to the conversion.
-	FeesApplied interface{} `json:"feesApplied"`
+	FeesApplied Decimal `json:"feesApplied"`
 	// The target currency code.
-	TargetCurrency interface{} `json:"targetCurrency"`
+	TargetCurrency string `json:"targetCurrency"`
 	// The ID of the internal transaction representing this conversion.
-	TransactionID interface{}                  `json:"transactionId"`
+	TransactionID string                       `json:"transactionId"`
 	JSON          paymentFxConvertResponseJSON `json:"-"`
 }
 
@@ -121,11 +122,11 @@ func (r PaymentFxConvertResponseStatus) IsKnown() bool {
 
 type PaymentFxGetRatesResponse struct {
 	// The base currency code.
-	BaseCurrency interface{} `json:"baseCurrency,required"`
+	BaseCurrency string `json:"baseCurrency,required"`
 	// Real-time foreign exchange rates.
 	CurrentRate PaymentFxGetRatesResponseCurrentRate `json:"currentRate,required"`
 	// The target currency code.
-	TargetCurrency       interface{}                                   `json:"targetCurrency,required"`
+	TargetCurrency       string                                        `json:"targetCurrency,required"`
 	HistoricalVolatility PaymentFxGetRatesResponseHistoricalVolatility `json:"historicalVolatility"`
 	// AI-predicted foreign exchange rates for future dates.
 	PredictiveRates []PaymentFxGetRatesResponsePredictiveRate `json:"predictiveRates,nullable"`
@@ -155,13 +156,13 @@ func (r paymentFxGetRatesResponseJSON) RawJSON() string {
 // Real-time foreign exchange rates.
 type PaymentFxGetRatesResponseCurrentRate struct {
 	// Current ask rate (price at which a currency dealer will sell the base currency).
-	Ask interface{} `json:"ask"`
+	Ask Decimal `json:"ask"`
 	// Current bid rate (price at which a currency dealer will buy the base currency).
-	Bid interface{} `json:"bid"`
+	Bid Decimal `json:"bid"`
 	// Mid-market rate (average of bid and ask).
-	Mid interface{} `json:"mid"`
+	Mid Decimal `json:"mid"`
 	// Timestamp of the current rate.
-	Timestamp interface{}                              `json:"timestamp"`
+	Timestamp time.Time                                `json:"timestamp" format:"date-time"`
 	JSON      paymentFxGetRatesResponseCurrentRateJSON `json:"-"`
 }
 
@@ -186,9 +187,9 @@ func (r paymentFxGetRatesResponseCurrentRateJSON) RawJSON() string {
 
 type PaymentFxGetRatesResponseHistoricalVolatility struct {
 	// Historical volatility over the past 30 days.
-	Past30Days interface{} `json:"past30Days"`
+	Past30Days float64 `json:"past30Days"`
 	// Historical volatility over the past 7 days.
-	Past7Days interface{}                                       `json:"past7Days"`
+	Past7Days float64                                           `json:"past7Days"`
 	JSON      paymentFxGetRatesResponseHistoricalVolatilityJSON `json:"-"`
 }
 
@@ -211,15 +212,15 @@ func (r paymentFxGetRatesResponseHistoricalVolatilityJSON) RawJSON() string {
 
 type PaymentFxGetRatesResponsePredictiveRate struct {
 	// AI model's confidence in the prediction (0-1).
-	AIModelConfidence interface{} `json:"aiModelConfidence"`
+	AIModelConfidence float64 `json:"aiModelConfidence"`
 	// Lower bound of the AI's confidence interval for the predicted rate.
-	ConfidenceIntervalLower interface{} `json:"confidenceIntervalLower"`
+	ConfidenceIntervalLower Decimal `json:"confidenceIntervalLower"`
 	// Upper bound of the AI's confidence interval for the predicted rate.
-	ConfidenceIntervalUpper interface{} `json:"confidenceIntervalUpper"`
+	ConfidenceIntervalUpper Decimal `json:"confidenceIntervalUpper"`
 	// Date for the predicted rate.
-	Date interface{} `json:"date"`
+	Date time.Time `json:"date" format:"date"`
 	// AI-predicted mid-market rate.
-	PredictedMidRate interface{}                                 `json:"predictedMidRate"`
+	PredictedMidRate Decimal                                     `json:"predictedMidRate"`
 	JSON             paymentFxGetRatesResponsePredictiveRateJSON `json:"-"`
 }
 
@@ -245,18 +246,18 @@ func (r paymentFxGetRatesResponsePredictiveRateJSON) RawJSON() string {
 
 type PaymentFxConvertParams struct {
 	// The ID of the account from which funds will be converted.
-	SourceAccountID param.Field[interface{}] `json:"sourceAccountId,required"`
+	SourceAccountID param.Field[string] `json:"sourceAccountId,required"`
 	// The amount to convert from the source currency.
-	SourceAmount param.Field[interface{}] `json:"sourceAmount,required"`
+	SourceAmount param.Field[Decimal] `json:"sourceAmount,required"`
 	// The ISO 4217 currency code of the source funds.
-	SourceCurrency param.Field[interface{}] `json:"sourceCurrency,required"`
+	SourceCurrency param.Field[string] `json:"sourceCurrency,required"`
 	// The ISO 4217 currency code for the target currency.
-	TargetCurrency param.Field[interface{}] `json:"targetCurrency,required"`
+	TargetCurrency param.Field[string] `json:"targetCurrency,required"`
 	// If true, attempts to lock the quoted FX rate for a short period.
-	FxRateLock param.Field[interface{}] `json:"fxRateLock"`
+	FxRateLock param.Field[bool] `json:"fxRateLock"`
 	// Optional: The ID of the account to deposit the converted funds. If null, funds
 	// are held in a wallet/balance.
-	TargetAccountID param.Field[interface{}] `json:"targetAccountId"`
+	TargetAccountID param.Field[string] `json:"targetAccountId"`
 }
 
 func (r PaymentFxConvertParams) MarshalJSON() (data []byte, err error) {
@@ -265,11 +266,11 @@ func (r PaymentFxConvertParams) MarshalJSON() (data []byte, err error) {
 
 type PaymentFxGetRatesParams struct {
 	// The base currency code (e.g., USD).
-	BaseCurrency param.Field[interface{}] `query:"baseCurrency,required"`
+	BaseCurrency param.Field[string] `query:"baseCurrency,required"`
 	// The target currency code (e.g., EUR).
-	TargetCurrency param.Field[interface{}] `query:"targetCurrency,required"`
+	TargetCurrency param.Field[string] `query:"targetCurrency,required"`
 	// Number of days into the future to provide an AI-driven prediction.
-	ForecastDays param.Field[interface{}] `query:"forecastDays"`
+	ForecastDays param.Field[int64] `query:"forecastDays"`
 }
 
 // URLQuery serializes [PaymentFxGetRatesParams]'s query parameters as
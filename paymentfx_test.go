@@ -26,12 +26,12 @@ func TestPaymentFxConvertWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Payments.Fx.Convert(context.TODO(), jocall3.PaymentFxConvertParams{
-		SourceAccountID: jocall3.F[any]("acc_chase_checking_4567"),
-		SourceAmount:    jocall3.F[any](1000),
-		SourceCurrency:  jocall3.F[any]("USD"),
-		TargetCurrency:  jocall3.F[any]("EUR"),
-		FxRateLock:      jocall3.F[any](true),
-		TargetAccountID: jocall3.F[any]("acc_euro_savings_9876"),
+		SourceAccountID: jocall3.F("acc_chase_checking_4567"),
+		SourceAmount:    jocall3.F(jocall3.MustDecimal("1000")),
+		SourceCurrency:  jocall3.F("USD"),
+		TargetCurrency:  jocall3.F("EUR"),
+		FxRateLock:      jocall3.F(true),
+		TargetAccountID: jocall3.F("acc_euro_savings_9876"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -55,9 +55,9 @@ func TestPaymentFxGetRatesWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Payments.Fx.GetRates(context.TODO(), jocall3.PaymentFxGetRatesParams{
-		BaseCurrency:   jocall3.F[any]("USD"),
-		TargetCurrency: jocall3.F[any]("EUR"),
-		ForecastDays:   jocall3.F[any](7),
+		BaseCurrency:   jocall3.F("USD"),
+		TargetCurrency: jocall3.F("EUR"),
+		ForecastDays:   jocall3.F(int64(7)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -4,9 +4,11 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -45,37 +47,41 @@ func (r *PaymentInternationalService) Initiate(ctx context.Context, body Payment
 
 // Retrieves the current processing status and details of an initiated
 // international payment.
-func (r *PaymentInternationalService) GetStatus(ctx context.Context, paymentID interface{}, opts ...option.RequestOption) (res *InternationalPaymentStatus, err error) {
+func (r *PaymentInternationalService) GetStatus(ctx context.Context, paymentID string, opts ...option.RequestOption) (res *InternationalPaymentStatus, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("payments/international/%v/status", paymentID)
+	if paymentID == "" {
+		err = errors.New("missing required paymentId parameter")
+		return
+	}
+	path := fmt.Sprintf("payments/international/%s/status", paymentID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
 
 type InternationalPaymentStatus struct {
 	// The foreign exchange rate applied (target per source currency).
-	FxRateApplied interface{} `json:"fxRateApplied,required"`
+	FxRateApplied Decimal `json:"fxRateApplied,required"`
 	// Unique identifier for the international payment.
-	PaymentID interface{} `json:"paymentId,required"`
+	PaymentID string `json:"paymentId,required"`
 	// The amount sent in the source currency.
-	SourceAmount interface{} `json:"sourceAmount,required"`
+	SourceAmount Decimal `json:"sourceAmount,required"`
 	// The source currency code.
-	SourceCurrency interface{} `json:"sourceCurrency,required"`
+	SourceCurrency string `json:"sourceCurrency,required"`
 	// Current processing status of the payment.
 	Status InternationalPaymentStatusStatus `json:"status,required"`
 	// The amount received by the beneficiary in the target currency.
-	TargetAmount interface{} `json:"targetAmount,required"`
+	TargetAmount Decimal `json:"targetAmount,required"`
 	// The target currency code.
-	TargetCurrency interface{} `json:"targetCurrency,required"`
+	TargetCurrency string `json:"targetCurrency,required"`
 	// Estimated time when the payment will be completed.
-	EstimatedCompletionTime interface{} `json:"estimatedCompletionTime"`
+	EstimatedCompletionTime time.Time `json:"estimatedCompletionTime" format:"date-time"`
 	// Total fees applied to the payment.
-	FeesApplied interface{} `json:"feesApplied"`
+	FeesApplied Decimal `json:"feesApplied"`
 	// An optional message providing more context on the status (e.g., reason for
 	// hold).
-	Message interface{} `json:"message"`
+	Message string `json:"message"`
 	// URL to track the payment's progress.
-	TrackingURL interface{}                    `json:"trackingUrl"`
+	TrackingURL string                         `json:"trackingUrl"`
 	JSON        internationalPaymentStatusJSON `json:"-"`
 }
 
@@ -126,23 +132,23 @@ func (r InternationalPaymentStatusStatus) IsKnown() bool {
 
 type PaymentInternationalInitiateParams struct {
 	// The amount to send in the source currency.
-	Amount param.Field[interface{}] `json:"amount,required"`
+	Amount param.Field[Decimal] `json:"amount,required"`
 	// Details of the payment beneficiary.
 	Beneficiary param.Field[PaymentInternationalInitiateParamsBeneficiary] `json:"beneficiary,required"`
 	// Purpose of the payment.
-	Purpose param.Field[interface{}] `json:"purpose,required"`
+	Purpose param.Field[string] `json:"purpose,required"`
 	// The ID of the user's source account for the payment.
-	SourceAccountID param.Field[interface{}] `json:"sourceAccountId,required"`
+	SourceAccountID param.Field[string] `json:"sourceAccountId,required"`
 	// The ISO 4217 currency code of the source funds.
-	SourceCurrency param.Field[interface{}] `json:"sourceCurrency,required"`
+	SourceCurrency param.Field[string] `json:"sourceCurrency,required"`
 	// The ISO 4217 currency code for the beneficiary's currency.
-	TargetCurrency param.Field[interface{}] `json:"targetCurrency,required"`
+	TargetCurrency param.Field[string] `json:"targetCurrency,required"`
 	// If true, attempts to lock the quoted FX rate for a short period.
-	FxRateLock param.Field[interface{}] `json:"fxRateLock"`
+	FxRateLock param.Field[bool] `json:"fxRateLock"`
 	// Indicates whether to use AI-optimized FX rates or standard market rates.
 	FxRateProvider param.Field[PaymentInternationalInitiateParamsFxRateProvider] `json:"fxRateProvider"`
 	// Optional: Your internal reference for this payment.
-	Reference param.Field[interface{}] `json:"reference"`
+	Reference param.Field[string] `json:"reference"`
 }
 
 func (r PaymentInternationalInitiateParams) MarshalJSON() (data []byte, err error) {
@@ -152,19 +158,19 @@ func (r PaymentInternationalInitiateParams) MarshalJSON() (data []byte, err erro
 // Details of the payment beneficiary.
 type PaymentInternationalInitiateParamsBeneficiary struct {
 	// Full address of the beneficiary.
-	Address param.Field[interface{}] `json:"address,required"`
+	Address param.Field[string] `json:"address,required"`
 	// Name of the beneficiary's bank.
-	BankName param.Field[interface{}] `json:"bankName,required"`
+	BankName param.Field[string] `json:"bankName,required"`
 	// Full name of the beneficiary.
-	Name param.Field[interface{}] `json:"name,required"`
+	Name param.Field[string] `json:"name,required"`
 	// Account number (if IBAN/SWIFT not applicable).
-	AccountNumber param.Field[interface{}] `json:"accountNumber"`
+	AccountNumber param.Field[string] `json:"accountNumber"`
 	// IBAN for Eurozone transfers.
-	Iban param.Field[interface{}] `json:"iban"`
+	Iban param.Field[string] `json:"iban"`
 	// Routing number (if applicable, e.g., for US transfers).
-	RoutingNumber param.Field[interface{}] `json:"routingNumber"`
+	RoutingNumber param.Field[string] `json:"routingNumber"`
 	// SWIFT/BIC code for international transfers.
-	SwiftBic param.Field[interface{}] `json:"swiftBic"`
+	SwiftBic param.Field[string] `json:"swiftBic"`
 }
 
 func (r PaymentInternationalInitiateParamsBeneficiary) MarshalJSON() (data []byte, err error) {
@@ -26,23 +26,23 @@ func TestPaymentInternationalInitiateWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Payments.International.Initiate(context.TODO(), jocall3.PaymentInternationalInitiateParams{
-		Amount: jocall3.F[any](5000),
+		Amount: jocall3.F(jocall3.MustDecimal("5000")),
 		Beneficiary: jocall3.F(jocall3.PaymentInternationalInitiateParamsBeneficiary{
-			Address:       jocall3.F[any]("Hauptstrasse 1, 10115 Berlin, Germany"),
-			BankName:      jocall3.F[any]("Deutsche Bank"),
-			Name:          jocall3.F[any]("Maria Schmidt"),
-			AccountNumber: jocall3.F[any](map[string]interface{}{}),
-			Iban:          jocall3.F[any]("DE89370400440532013000"),
-			RoutingNumber: jocall3.F[any](map[string]interface{}{}),
-			SwiftBic:      jocall3.F[any]("DEUTDEFF"),
+			Address:       jocall3.F("Hauptstrasse 1, 10115 Berlin, Germany"),
+			BankName:      jocall3.F("Deutsche Bank"),
+			Name:          jocall3.F("Maria Schmidt"),
+			AccountNumber: jocall3.F("string"),
+			Iban:          jocall3.F("DE89370400440532013000"),
+			RoutingNumber: jocall3.F("string"),
+			SwiftBic:      jocall3.F("DEUTDEFF"),
 		}),
-		Purpose:         jocall3.F[any]("Vendor payment for Q2 services."),
-		SourceAccountID: jocall3.F[any]("acc_chase_checking_4567"),
-		SourceCurrency:  jocall3.F[any]("USD"),
-		TargetCurrency:  jocall3.F[any]("EUR"),
-		FxRateLock:      jocall3.F[any](true),
+		Purpose:         jocall3.F("Vendor payment for Q2 services."),
+		SourceAccountID: jocall3.F("acc_chase_checking_4567"),
+		SourceCurrency:  jocall3.F("USD"),
+		TargetCurrency:  jocall3.F("EUR"),
+		FxRateLock:      jocall3.F(true),
 		FxRateProvider:  jocall3.F(jocall3.PaymentInternationalInitiateParamsFxRateProviderProprietaryAI),
-		Reference:       jocall3.F[any](map[string]interface{}{}),
+		Reference:       jocall3.F("string"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -0,0 +1,174 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Aggregate is the state of an event-sourced entity, such as an account,
+// that an AggregateLoader rebuilds from its events and snapshots.
+type Aggregate interface {
+	// Apply updates the state with the next event of the aggregate.
+	Apply(event Event) error
+
+	// MarshalSnapshot encodes the state for a snapshot.
+	MarshalSnapshot() ([]byte, error)
+
+	// UnmarshalSnapshot replaces the state with one MarshalSnapshot encoded
+	// in the current snapshot schema version.
+	UnmarshalSnapshot(data []byte) error
+}
+
+// SnapshotOptions configures an AggregateLoader. The zero value uses the
+// defaults below.
+type SnapshotOptions struct {
+	// Every is the number of events replayed on top of the latest snapshot
+	// after which loading an aggregate takes a new snapshot. Defaults to 100.
+	Every int
+
+	// SchemaVersion is the version of the aggregate's snapshot format. Bump
+	// it whenever MarshalSnapshot's output changes incompatibly: snapshots of
+	// any other version are ignored, so the next load replays the aggregate's
+	// full history and replaces them.
+	SchemaVersion int
+
+	// OnError, if set, is called with the snapshot errors Load recovered from
+	// by replaying the events instead, such as an unreadable snapshot or a
+	// failure to save a new one. Such errors never fail a load, as the events
+	// alone determine the state.
+	OnError func(aggregateID string, err error)
+}
+
+func (o SnapshotOptions) withDefaults() SnapshotOptions {
+	if o.Every <= 0 {
+		o.Every = 100
+	}
+	return o
+}
+
+// AggregateStore is the part of a Store that an AggregateLoader reads. Every
+// Store implements it, and so does the PostgreSQL event store in
+// internal/database.
+type AggregateStore interface {
+	// LoadByAggregate returns the events of the aggregate with a version
+	// greater than after, in version order.
+	LoadByAggregate(ctx context.Context, aggregateID string, after Sequence) ([]Event, error)
+}
+
+// AggregateLoader rebuilds aggregates from an AggregateStore, using a
+// SnapshotStore to avoid replaying their full history. Loading an aggregate
+// restores its latest snapshot and applies only the events after it; once
+// SnapshotOptions.Every events have been applied on top of a snapshot, the
+// load saves a new one. It is safe for concurrent use if its stores are.
+type AggregateLoader struct {
+	store     AggregateStore
+	snapshots SnapshotStore
+	create    func() Aggregate
+	opts      SnapshotOptions
+}
+
+// NewAggregateLoader creates a loader for the aggregates create returns, each
+// in its initial state before any event.
+func NewAggregateLoader(store AggregateStore, snapshots SnapshotStore, create func() Aggregate, opts SnapshotOptions) *AggregateLoader {
+	if store == nil || snapshots == nil || create == nil {
+		panic("aggregate loader requires a store, a snapshot store and an aggregate constructor")
+	}
+	return &AggregateLoader{
+		store:     store,
+		snapshots: snapshots,
+		create:    create,
+		opts:      opts.withDefaults(),
+	}
+}
+
+// Load returns the current state of the aggregate and its version, or
+// ErrNotFound if the aggregate has no events.
+func (l *AggregateLoader) Load(ctx context.Context, aggregateID string) (Aggregate, int, error) {
+	agg, version, stale := l.restore(ctx, aggregateID)
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	snapshotVersion := version
+
+	events, err := l.store.LoadByAggregate(ctx, aggregateID, Sequence(version))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load events of aggregate %s: %w", aggregateID, err)
+	}
+	for _, event := range events {
+		// Versions have no gaps, so anything else means the store and the
+		// snapshot disagree and the state cannot be trusted.
+		if got := event.Header().Version; got != version+1 {
+			return nil, 0, fmt.Errorf("aggregate %s: expected event version %d, got %d", aggregateID, version+1, got)
+		}
+		if err := agg.Apply(event); err != nil {
+			return nil, 0, fmt.Errorf("failed to apply event version %d of aggregate %s: %w", version+1, aggregateID, err)
+		}
+		version++
+	}
+	if version == 0 {
+		return nil, 0, ErrNotFound
+	}
+
+	if stale || version-snapshotVersion >= l.opts.Every {
+		if err := l.Snapshot(ctx, aggregateID, agg, version); err != nil {
+			l.report(aggregateID, err)
+		}
+	}
+	return agg, version, nil
+}
+
+// restore returns a new aggregate with the latest usable snapshot of the
+// aggregate applied, and the version it is at. Without a usable snapshot it
+// returns the aggregate in its initial state at version 0; stale reports
+// whether that is because the snapshot's schema version is out of date.
+func (l *AggregateLoader) restore(ctx context.Context, aggregateID string) (agg Aggregate, version int, stale bool) {
+	snapshot, err := l.snapshots.LoadSnapshot(ctx, aggregateID)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) && ctx.Err() == nil {
+			l.report(aggregateID, err)
+		}
+		return l.create(), 0, false
+	}
+	if snapshot.SchemaVersion != l.opts.SchemaVersion {
+		return l.create(), 0, true
+	}
+	agg = l.create()
+	if err := agg.UnmarshalSnapshot(snapshot.State); err != nil {
+		// The aggregate may be half restored, so start again from scratch.
+		l.report(aggregateID, fmt.Errorf("failed to restore snapshot version %d: %w", snapshot.Version, err))
+		return l.create(), 0, true
+	}
+	return agg, snapshot.Version, false
+}
+
+// Snapshot saves a snapshot of the aggregate at the given version, which must
+// be the version of the last event applied to it.
+func (l *AggregateLoader) Snapshot(ctx context.Context, aggregateID string, agg Aggregate, version int) error {
+	state, err := agg.MarshalSnapshot()
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot of aggregate %s: %w", aggregateID, err)
+	}
+	err = l.snapshots.SaveSnapshot(ctx, Snapshot{
+		AggregateID:   aggregateID,
+		Version:       version,
+		SchemaVersion: l.opts.SchemaVersion,
+		TakenAt:       time.Now().UTC(),
+		State:         state,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save snapshot of aggregate %s: %w", aggregateID, err)
+	}
+	return nil
+}
+
+func (l *AggregateLoader) report(aggregateID string, err error) {
+	if l.opts.OnError != nil {
+		l.opts.OnError(aggregateID, err)
+	}
+}
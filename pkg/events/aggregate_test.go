@@ -0,0 +1,168 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// balance is an Aggregate summing the credits of an account. It counts the
+// events applied to it, to tell how much of the history a load replayed.
+type balance struct {
+	Total int64 `json:"total"`
+
+	applied      int
+	unmarshalErr error
+}
+
+func (b *balance) Apply(event Event) error {
+	b.Total += event.(*AccountCredited).Amount
+	b.applied++
+	return nil
+}
+
+func (b *balance) MarshalSnapshot() ([]byte, error) { return json.Marshal(b) }
+
+func (b *balance) UnmarshalSnapshot(data []byte) error {
+	if b.unmarshalErr != nil {
+		return b.unmarshalErr
+	}
+	return json.Unmarshal(data, b)
+}
+
+// loaderTest is an account with events in a MemoryStore, and a loader for it.
+type loaderTest struct {
+	t         *testing.T
+	store     *MemoryStore
+	snapshots *MemorySnapshotStore
+	id        uuid.UUID
+	version   int
+}
+
+func newLoaderTest(t *testing.T, events int) *loaderTest {
+	lt := &loaderTest{t: t, store: NewMemoryStore(), snapshots: NewMemorySnapshotStore(), id: uuid.New()}
+	lt.append(events)
+	return lt
+}
+
+// append appends n credits of 10 to the account.
+func (lt *loaderTest) append(n int) {
+	for i := 0; i < n; i++ {
+		lt.version++
+		mustAppend(lt.t, lt.store, NewAccountCredited(lt.id, uuid.New(), lt.version, 10, int64(10*lt.version), "deposit"))
+	}
+}
+
+// load loads the account with a new loader and checks its state, returning
+// the number of events the load replayed.
+func (lt *loaderTest) load(opts SnapshotOptions, create func() Aggregate) int {
+	lt.t.Helper()
+	if create == nil {
+		create = func() Aggregate { return &balance{} }
+	}
+	agg, version, err := NewAggregateLoader(lt.store, lt.snapshots, create, opts).Load(context.Background(), lt.id.String())
+	if err != nil {
+		lt.t.Fatalf("Load: %v", err)
+	}
+	b := agg.(*balance)
+	if version != lt.version || b.Total != int64(10*lt.version) {
+		lt.t.Fatalf("loaded version %d with total %d, want version %d with total %d", version, b.Total, lt.version, 10*lt.version)
+	}
+	return b.applied
+}
+
+func (lt *loaderTest) snapshot() *Snapshot {
+	lt.t.Helper()
+	snapshot, err := lt.snapshots.LoadSnapshot(context.Background(), lt.id.String())
+	if err != nil {
+		lt.t.Fatalf("LoadSnapshot: %v", err)
+	}
+	return snapshot
+}
+
+func TestAggregateLoaderSnapshotsEveryN(t *testing.T) {
+	lt := newLoaderTest(t, 12)
+	opts := SnapshotOptions{Every: 5, SchemaVersion: 1}
+
+	if _, _, err := NewAggregateLoader(lt.store, lt.snapshots, func() Aggregate { return &balance{} }, opts).Load(context.Background(), uuid.NewString()); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an aggregate without events, got %v", err)
+	}
+
+	if applied := lt.load(opts, nil); applied != 12 {
+		t.Fatalf("expected the first load to replay 12 events, got %d", applied)
+	}
+	if v := lt.snapshot().Version; v != 12 {
+		t.Fatalf("expected a snapshot at version 12, got %d", v)
+	}
+	if applied := lt.load(opts, nil); applied != 0 {
+		t.Fatalf("expected a load from the snapshot to replay nothing, got %d", applied)
+	}
+
+	// Fewer than Every events on top of the snapshot do not take a new one.
+	lt.append(4)
+	if applied := lt.load(opts, nil); applied != 4 {
+		t.Fatalf("expected 4 events to be replayed, got %d", applied)
+	}
+	if v := lt.snapshot().Version; v != 12 {
+		t.Fatalf("expected the snapshot to stay at version 12, got %d", v)
+	}
+	lt.append(1)
+	if applied := lt.load(opts, nil); applied != 5 {
+		t.Fatalf("expected 5 events to be replayed, got %d", applied)
+	}
+	if v := lt.snapshot().Version; v != 17 {
+		t.Fatalf("expected a new snapshot at version 17, got %d", v)
+	}
+}
+
+func TestAggregateLoaderStaleSchemaReplays(t *testing.T) {
+	lt := newLoaderTest(t, 12)
+	lt.load(SnapshotOptions{Every: 5, SchemaVersion: 1}, nil)
+
+	// A new snapshot format ignores the old snapshot, and replaces it even
+	// though the aggregate has not moved.
+	opts := SnapshotOptions{Every: 5, SchemaVersion: 2}
+	if applied := lt.load(opts, nil); applied != 12 {
+		t.Fatalf("expected a stale snapshot to force a full replay, got %d events replayed", applied)
+	}
+	if s := lt.snapshot(); s.Version != 12 || s.SchemaVersion != 2 {
+		t.Fatalf("expected the snapshot to be replaced in schema version 2, got version %d schema %d", s.Version, s.SchemaVersion)
+	}
+	if applied := lt.load(opts, nil); applied != 0 {
+		t.Fatalf("expected the new snapshot to be used, got %d events replayed", applied)
+	}
+}
+
+func TestAggregateLoaderUnreadableSnapshotReplays(t *testing.T) {
+	lt := newLoaderTest(t, 12)
+	opts := SnapshotOptions{Every: 5, SchemaVersion: 1}
+	lt.load(opts, nil)
+
+	var reported []error
+	opts.OnError = func(aggregateID string, err error) {
+		if aggregateID != lt.id.String() {
+			t.Errorf("error reported for aggregate %s", aggregateID)
+		}
+		reported = append(reported, err)
+	}
+	broken := errors.New("bad snapshot")
+	create := func() Aggregate { return &balance{unmarshalErr: broken} }
+	if applied := lt.load(opts, create); applied != 12 {
+		t.Fatalf("expected a snapshot that fails to unmarshal to force a full replay, got %d events replayed", applied)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], broken) {
+		t.Fatalf("expected the unmarshal error to be reported, got %v", reported)
+	}
+
+	// The snapshot is replaced as soon as the aggregate moves on.
+	lt.append(1)
+	if applied := lt.load(opts, create); applied != 13 {
+		t.Fatalf("expected a full replay, got %d events replayed", applied)
+	}
+	if v := lt.snapshot().Version; v != 13 {
+		t.Fatalf("expected a new snapshot at version 13, got %d", v)
+	}
+}
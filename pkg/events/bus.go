@@ -0,0 +1,528 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Bus is an in-process event bus built on a Store. It implements Publisher by
+// appending events to the store, and delivers them to Subscribers through
+// subscriptions that first catch up from the store and then switch to live
+// delivery.
+//
+// Each subscriber is its own consumer group, named by its SubscriberID. The
+// group's offset, the Sequence of the last event it handled, is persisted in
+// an OffsetStore, so a subscription resumes where the group left off after a
+// restart. Delivery is in sequence order and at-least-once: events handled
+// after the last saved offset are delivered again after a crash, so handlers
+// must be idempotent, as the Subscriber contract requires.
+//
+// A subscription never skips or repeats an event when it switches from
+// catching up to live delivery. It registers for live events before its last
+// read of the store, and discards live events it has already read; a live
+// event that does not directly follow the last one it handled sends it back
+// to the store instead. The store must therefore make events visible in
+// sequence order, as FileStore does.
+//
+// Memory is bounded per subscription. Catching up reads BusOptions.BatchSize
+// events at a time, and only when the subscriber has handled the previous
+// batch. Live events wait in a buffer of BusOptions.BufferSize events; if a
+// slow subscriber lets it fill, the bus stops delivering to it live rather
+// than block publishers, and the subscription catches up from the store
+// again. Events appended to the store directly, rather than through the bus,
+// are found by polling every BusOptions.PollInterval.
+type Bus struct {
+	store   Store
+	offsets OffsetStore
+	opts    BusOptions
+
+	mu     sync.Mutex
+	live   map[*liveFeed]struct{}
+	subs   map[SubscriberID]*Subscription
+	closed bool
+}
+
+// BusOptions configures a Bus. The zero value uses the defaults below.
+type BusOptions struct {
+	// BatchSize is the number of events a catching-up subscription loads from
+	// the store at a time. Defaults to 256.
+	BatchSize uint64
+
+	// BufferSize is the number of live events buffered for each subscription.
+	// Defaults to 1024.
+	BufferSize int
+
+	// PollInterval is how often a live subscription checks the store for
+	// events appended without going through the bus. Defaults to 1s.
+	PollInterval time.Duration
+
+	// CommitEvery is the number of handled events after which a subscription
+	// saves its offset. Offsets are also saved whenever a subscription has no
+	// more events to handle, and when it stops. Defaults to 100.
+	CommitEvery int
+
+	// RetryMin and RetryMax bound the exponential backoff between attempts to
+	// handle an event that failed. They default to 100ms and 30s.
+	RetryMin time.Duration
+	RetryMax time.Duration
+
+	// MaxAttempts is the number of times an event is handled before the
+	// subscription gives up and stops with the error. Zero retries until the
+	// subscription is stopped.
+	MaxAttempts int
+
+	// DeadLetter, if set, is called with an event whose handler returned a
+	// permanent error (see Permanent). If it returns nil the event counts as
+	// handled and delivery carries on; otherwise the subscription stops. If
+	// DeadLetter is not set, a permanent error stops the subscription.
+	DeadLetter func(ctx context.Context, group SubscriberID, event SequencedEvent, err error) error
+}
+
+func (o BusOptions) withDefaults() BusOptions {
+	if o.BatchSize == 0 {
+		o.BatchSize = 256
+	}
+	if o.BufferSize <= 0 {
+		o.BufferSize = 1024
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = time.Second
+	}
+	if o.CommitEvery <= 0 {
+		o.CommitEvery = 100
+	}
+	if o.RetryMin <= 0 {
+		o.RetryMin = 100 * time.Millisecond
+	}
+	if o.RetryMax < o.RetryMin {
+		o.RetryMax = 30 * time.Second
+		if o.RetryMax < o.RetryMin {
+			o.RetryMax = o.RetryMin
+		}
+	}
+	return o
+}
+
+// ErrAlreadySubscribed indicates that a subscriber's consumer group already
+// has an active subscription on the bus. Two subscriptions of one group would
+// race each other's offsets.
+var ErrAlreadySubscribed = errors.New("events: subscriber already subscribed")
+
+// PermanentError marks a handler error that retrying cannot fix, such as an
+// event that fails validation. See Permanent.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string { return "permanent: " + e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// Permanent wraps err so that the bus does not retry the event that caused it
+// and hands the event to BusOptions.DeadLetter instead.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// A compile-time check to ensure Bus implements the Store and Publisher
+// interfaces.
+var (
+	_ Store     = (*Bus)(nil)
+	_ Publisher = (*Bus)(nil)
+)
+
+// NewBus creates a Bus that appends to and reads from store, and persists the
+// offsets of its consumer groups in offsets.
+func NewBus(store Store, offsets OffsetStore, opts BusOptions) *Bus {
+	if store == nil || offsets == nil {
+		panic("events: a bus requires a store and an offset store")
+	}
+	return &Bus{
+		store:   store,
+		offsets: offsets,
+		opts:    opts.withDefaults(),
+		live:    make(map[*liveFeed]struct{}),
+		subs:    make(map[SubscriberID]*Subscription),
+	}
+}
+
+// Append appends event to the store and delivers it to live subscriptions.
+func (b *Bus) Append(ctx context.Context, event Event) (Sequence, error) {
+	b.mu.Lock()
+	closed := b.closed
+	b.mu.Unlock()
+	if closed {
+		return 0, ErrClosed
+	}
+	seq, err := b.store.Append(ctx, event)
+	if err != nil {
+		return 0, err
+	}
+	b.fanOut(SequencedEvent{Sequence: seq, Event: event})
+	return seq, nil
+}
+
+// Publish appends event to the store and delivers it to live subscriptions.
+// Once it returns nil the event is durable in the store, and every
+// subscription will receive it, live or by catching up.
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	_, err := b.Append(ctx, event)
+	return err
+}
+
+// Load reads from the underlying store.
+func (b *Bus) Load(ctx context.Context, after Sequence, limit uint64) ([]Event, error) {
+	return b.store.Load(ctx, after, limit)
+}
+
+// LoadByAggregate reads from the underlying store.
+func (b *Bus) LoadByAggregate(ctx context.Context, aggregateID string, after Sequence) ([]Event, error) {
+	return b.store.LoadByAggregate(ctx, aggregateID, after)
+}
+
+// Subscribe starts delivering events to sub, after the stored offset of its
+// consumer group, until ctx is done, the subscription is closed, or the bus
+// shuts down. Events are handled one at a time, in sequence order, on a
+// goroutine owned by the subscription.
+func (b *Bus) Subscribe(ctx context.Context, sub Subscriber) (*Subscription, error) {
+	group := sub.SubscriberID()
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Subscription{
+		bus:    b,
+		sub:    sub,
+		group:  group,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		cancel()
+		return nil, ErrClosed
+	}
+	if _, ok := b.subs[group]; ok {
+		b.mu.Unlock()
+		cancel()
+		return nil, fmt.Errorf("%w: %s", ErrAlreadySubscribed, group)
+	}
+	b.subs[group] = s
+	b.mu.Unlock()
+
+	offset, err := b.offsets.LoadOffset(ctx, group)
+	if err != nil {
+		cancel()
+		b.mu.Lock()
+		delete(b.subs, group)
+		b.mu.Unlock()
+		close(s.done)
+		return nil, fmt.Errorf("failed to load offset of %s: %w", group, err)
+	}
+	s.committed = offset
+	s.position.Store(uint64(offset))
+	go s.run(ctx)
+	return s, nil
+}
+
+// Shutdown stops every subscription, saving their offsets, and waits for them
+// to finish or for ctx to be done. Appends and subscriptions fail with
+// ErrClosed afterwards. The underlying store is not closed.
+func (b *Bus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	subs := make([]*Subscription, 0, len(b.subs))
+	for _, s := range b.subs {
+		subs = append(subs, s)
+	}
+	b.mu.Unlock()
+
+	for _, s := range subs {
+		s.cancel()
+	}
+	for _, s := range subs {
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// liveFeed is the buffer of live events of one subscription. The bus closes
+// ch when it stops feeding it, which it does when the buffer overflows.
+type liveFeed struct {
+	ch chan SequencedEvent
+}
+
+func (b *Bus) fanOut(e SequencedEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for feed := range b.live {
+		select {
+		case feed.ch <- e:
+		default:
+			// The subscriber is too slow for live delivery; it will catch
+			// up from the store once it has drained the buffer.
+			delete(b.live, feed)
+			close(feed.ch)
+		}
+	}
+}
+
+func (b *Bus) attach() *liveFeed {
+	feed := &liveFeed{ch: make(chan SequencedEvent, b.opts.BufferSize)}
+	b.mu.Lock()
+	b.live[feed] = struct{}{}
+	b.mu.Unlock()
+	return feed
+}
+
+func (b *Bus) detach(feed *liveFeed) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.live[feed]; ok {
+		delete(b.live, feed)
+		close(feed.ch)
+	}
+}
+
+// loadFrom reads up to limit events after the given sequence, with their
+// sequence numbers.
+func (b *Bus) loadFrom(ctx context.Context, after Sequence, limit uint64) ([]SequencedEvent, error) {
+	if l, ok := b.store.(SequencedLoader); ok {
+		return l.LoadSequenced(ctx, after, limit)
+	}
+	loaded, err := b.store.Load(ctx, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SequencedEvent, len(loaded))
+	for i, e := range loaded {
+		out[i] = SequencedEvent{Sequence: after + Sequence(i) + 1, Event: e}
+	}
+	return out, nil
+}
+
+// Subscription is the delivery of events to one Subscriber. It is created by
+// Bus.Subscribe.
+type Subscription struct {
+	bus    *Bus
+	sub    Subscriber
+	group  SubscriberID
+	cancel context.CancelFunc
+	done   chan struct{}
+	err    error
+
+	// position is the Sequence of the last event handled.
+	position atomic.Uint64
+	isLive   atomic.Bool
+
+	// Owned by the subscription's goroutine.
+	committed Sequence
+	pending   int
+}
+
+// Position returns the Sequence of the last event the subscriber handled.
+func (s *Subscription) Position() Sequence {
+	return Sequence(s.position.Load())
+}
+
+// Live reports whether the subscription is receiving events live rather than
+// catching up from the store.
+func (s *Subscription) Live() bool {
+	return s.isLive.Load()
+}
+
+// Done returns a channel that is closed once the subscription has stopped.
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that stopped the subscription, or nil if it was
+// stopped by Close, by its context or by the bus shutting down. It must only
+// be called after Done is closed.
+func (s *Subscription) Err() error {
+	return s.err
+}
+
+// Close stops the subscription, saves its offset and waits for it to finish.
+func (s *Subscription) Close() error {
+	s.cancel()
+	<-s.done
+	return s.err
+}
+
+func (s *Subscription) run(ctx context.Context) {
+	defer close(s.done)
+	defer func() {
+		s.bus.mu.Lock()
+		delete(s.bus.subs, s.group)
+		s.bus.mu.Unlock()
+	}()
+
+	err := s.deliver(ctx)
+	if ctx.Err() != nil && errors.Is(err, ctx.Err()) {
+		err = nil // Stopped, not failed.
+	}
+	// Save the offset even though ctx is done, so that a clean stop does not
+	// redeliver the events handled since the last commit.
+	if commitErr := s.commit(context.WithoutCancel(ctx)); err == nil {
+		err = commitErr
+	}
+	s.err = err
+}
+
+// deliver alternates between catching up from the store and live delivery
+// until ctx is done or handling fails.
+func (s *Subscription) deliver(ctx context.Context) error {
+	for {
+		if err := s.catchUp(ctx); err != nil {
+			return err
+		}
+		// Register for live events before the final read of the store, so
+		// an event appended in between is in one or the other, or both.
+		feed := s.bus.attach()
+		err := s.catchUp(ctx)
+		if err == nil {
+			s.isLive.Store(true)
+			err = s.followLive(ctx, feed)
+			s.isLive.Store(false)
+		}
+		s.bus.detach(feed)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// catchUp handles events from the store until it has none left after the
+// subscription's position.
+func (s *Subscription) catchUp(ctx context.Context) error {
+	for {
+		batch, err := s.bus.loadFrom(ctx, s.Position(), s.bus.opts.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to load events for %s: %w", s.group, err)
+		}
+		if len(batch) == 0 {
+			return s.commit(ctx)
+		}
+		for _, e := range batch {
+			if e.Sequence <= s.Position() {
+				continue
+			}
+			if err := s.handle(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// followLive handles live events until the feed is closed or delivers an
+// event that does not directly follow the last one handled, or polling finds
+// events in the store that were not published through the bus. It returns
+// nil when the subscription should catch up again.
+func (s *Subscription) followLive(ctx context.Context, feed *liveFeed) error {
+	poll := time.NewTicker(s.bus.opts.PollInterval)
+	defer poll.Stop()
+	for {
+		select {
+		case e, ok := <-feed.ch:
+			if !ok {
+				return nil
+			}
+			if e.Sequence <= s.Position() {
+				continue // Already read while catching up.
+			}
+			if e.Sequence != s.Position()+1 {
+				return nil
+			}
+			if err := s.handle(ctx, e); err != nil {
+				return err
+			}
+			if len(feed.ch) == 0 {
+				if err := s.commit(ctx); err != nil {
+					return err
+				}
+			}
+		case <-poll.C:
+			next, err := s.bus.loadFrom(ctx, s.Position(), 1)
+			if err != nil {
+				return fmt.Errorf("failed to load events for %s: %w", s.group, err)
+			}
+			if len(next) > 0 && len(feed.ch) == 0 {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// handle delivers one event, retrying failures with exponential backoff, and
+// advances the position once it is handled.
+func (s *Subscription) handle(ctx context.Context, e SequencedEvent) error {
+	backoff := s.bus.opts.RetryMin
+	for attempt := 1; ; attempt++ {
+		err := s.sub.HandleEvent(ctx, e.Event)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var permanent *PermanentError
+		if errors.As(err, &permanent) {
+			if s.bus.opts.DeadLetter == nil {
+				return fmt.Errorf("%s failed to handle event %d: %w", s.group, e.Sequence, err)
+			}
+			if dlErr := s.bus.opts.DeadLetter(ctx, s.group, e, err); dlErr != nil {
+				return fmt.Errorf("failed to dead-letter event %d for %s: %w", e.Sequence, s.group, dlErr)
+			}
+			break
+		}
+		if limit := s.bus.opts.MaxAttempts; limit > 0 && attempt >= limit {
+			return fmt.Errorf("%s failed to handle event %d after %d attempts: %w", s.group, e.Sequence, attempt, err)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		if backoff *= 2; backoff > s.bus.opts.RetryMax {
+			backoff = s.bus.opts.RetryMax
+		}
+	}
+
+	s.position.Store(uint64(e.Sequence))
+	if s.pending++; s.pending >= s.bus.opts.CommitEvery {
+		return s.commit(ctx)
+	}
+	return nil
+}
+
+// commit saves the position as the group's offset if it has moved.
+func (s *Subscription) commit(ctx context.Context) error {
+	pos := s.Position()
+	if pos == s.committed {
+		return nil
+	}
+	if err := s.bus.offsets.SaveOffset(ctx, s.group, pos); err != nil {
+		return fmt.Errorf("failed to save offset of %s: %w", s.group, err)
+	}
+	s.committed = pos
+	s.pending = 0
+	return nil
+}
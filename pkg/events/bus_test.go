@@ -0,0 +1,252 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recordingSubscriber records the events it handles, after calling block, if
+// set, with each of them.
+type recordingSubscriber struct {
+	id    SubscriberID
+	block func(Event)
+
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recordingSubscriber) SubscriberID() SubscriberID { return r.id }
+
+func (r *recordingSubscriber) HandleEvent(ctx context.Context, event Event) error {
+	if r.block != nil {
+		r.block(event)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *recordingSubscriber) handled() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// checkDelivered checks that sub handled exactly the events of the store
+// after the given sequence, once each and in sequence order.
+func checkDelivered(t *testing.T, store Store, after Sequence, sub *recordingSubscriber) {
+	t.Helper()
+	want, err := store.Load(context.Background(), after, 0)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	got := sub.handled()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d events to be delivered, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].Header().EventID != want[i].Header().EventID {
+			t.Fatalf("event %d delivered out of order or twice: got %s, want %s",
+				i, got[i].Header().EventID, want[i].Header().EventID)
+		}
+	}
+}
+
+// switchingStore is a MemoryStore that calls onEmpty whenever a read finds no
+// new events, before returning them. A subscription that switches to live
+// delivery makes such a read last, so events appended by onEmpty land in the
+// window between its catching up and its going live.
+type switchingStore struct {
+	*MemoryStore
+	onEmpty func()
+}
+
+func (s *switchingStore) LoadSequenced(ctx context.Context, after Sequence, limit uint64) ([]SequencedEvent, error) {
+	loaded, err := s.MemoryStore.LoadSequenced(ctx, after, limit)
+	if err == nil && len(loaded) == 0 && s.onEmpty != nil {
+		s.onEmpty()
+	}
+	return loaded, err
+}
+
+func TestBusConcurrentAppendsDuringSwitchToLive(t *testing.T) {
+	ctx := context.Background()
+	store := &switchingStore{MemoryStore: NewMemoryStore()}
+	for i := 0; i < 500; i++ {
+		mustAppend(t, store, credited(uuid.New(), 1))
+	}
+	// Polling is effectively off, so an event missed in the switch is never
+	// delivered.
+	bus := NewBus(store, NewMemoryOffsetStore(), BusOptions{BatchSize: 16, BufferSize: 8, PollInterval: time.Hour})
+	defer bus.Shutdown(ctx)
+
+	const switches = 50
+	var appendedOnSwitch atomic.Int32
+	store.onEmpty = func() {
+		if appendedOnSwitch.Add(1) <= switches {
+			// The bus may be shutting down once the test is over.
+			if _, err := bus.Append(ctx, credited(uuid.New(), 1)); err != nil && !errors.Is(err, ErrClosed) {
+				t.Errorf("Append: %v", err)
+			}
+		}
+	}
+
+	sub := &recordingSubscriber{id: "projector"}
+	s, err := bus.Subscribe(ctx, sub)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	// Publish from several goroutines while the subscription is still
+	// catching up, so that it switches to live delivery in the middle of
+	// them.
+	const writers, perWriter = 8, 100
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				if _, err := bus.Append(ctx, credited(uuid.New(), 1)); err != nil {
+					t.Errorf("Append: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Once live with every stored event handled, the subscription stays so.
+	waitFor(t, "every event to be handled live", func() bool {
+		stored, err := store.Load(ctx, 0, 0)
+		return err == nil && s.Live() && s.Position() == Sequence(len(stored))
+	})
+	if n := appendedOnSwitch.Load(); n < 2 {
+		t.Fatalf("expected events to be appended during several switches to live delivery, got %d", n)
+	}
+	checkDelivered(t, store, 0, sub)
+}
+
+func TestBusLiveOverflowFallsBackToCatchUp(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	bus := NewBus(store, NewMemoryOffsetStore(), BusOptions{BufferSize: 2, PollInterval: time.Hour})
+	defer bus.Shutdown(ctx)
+
+	// The subscriber blocks on the first event until released, so the live
+	// events published meanwhile overflow its buffer.
+	started, release := make(chan struct{}), make(chan struct{})
+	var once sync.Once
+	sub := &recordingSubscriber{id: "slow", block: func(Event) {
+		once.Do(func() {
+			close(started)
+			<-release
+		})
+	}}
+	s, err := bus.Subscribe(ctx, sub)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	waitFor(t, "the subscription to go live", s.Live)
+
+	mustAppend(t, bus, credited(uuid.New(), 1))
+	<-started
+	for i := 0; i < 10; i++ {
+		mustAppend(t, bus, credited(uuid.New(), 1))
+	}
+	bus.mu.Lock()
+	attached := len(bus.live)
+	bus.mu.Unlock()
+	if attached != 0 {
+		t.Fatalf("expected the overflowing feed to be detached, %d still attached", attached)
+	}
+	close(release)
+
+	// Polling is effectively off, so only catching up from the store can
+	// deliver the events that did not fit in the buffer.
+	waitFor(t, "every event to be handled", func() bool { return s.Position() == 11 })
+	waitFor(t, "the subscription to go live again", s.Live)
+	checkDelivered(t, store, 0, sub)
+
+	mustAppend(t, bus, credited(uuid.New(), 1))
+	waitFor(t, "the next live event to be handled", func() bool { return s.Position() == 12 })
+	checkDelivered(t, store, 0, sub)
+}
+
+func TestBusResumesFromSavedOffset(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	offsets, err := NewFileOffsetStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// CommitEvery is large, so the offset is only saved when the
+	// subscription runs out of events or stops.
+	opts := BusOptions{CommitEvery: 1000, PollInterval: 5 * time.Millisecond}
+
+	bus := NewBus(store, offsets, opts)
+	first := &recordingSubscriber{id: "settlement-projector"}
+	s, err := bus.Subscribe(ctx, first)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	if _, err := bus.Subscribe(ctx, &recordingSubscriber{id: first.id}); !errors.Is(err, ErrAlreadySubscribed) {
+		t.Fatalf("expected ErrAlreadySubscribed for a second subscription of the group, got %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := bus.Publish(ctx, credited(uuid.New(), 1)); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	waitFor(t, "the events to be handled", func() bool { return s.Position() == 5 })
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if offset, err := offsets.LoadOffset(ctx, first.id); err != nil || offset != 5 {
+		t.Fatalf("expected offset 5 to be saved on Close, got %d (%v)", offset, err)
+	}
+
+	// Events appended while the group is not subscribed are delivered when
+	// it resumes, and those it handled before are not.
+	for i := 0; i < 3; i++ {
+		mustAppend(t, bus, credited(uuid.New(), 1))
+	}
+	if err := bus.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	if err := bus.Publish(ctx, credited(uuid.New(), 1)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed after Shutdown, got %v", err)
+	}
+
+	reopened, err := NewFileOffsetStore(offsets.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus = NewBus(store, reopened, opts)
+	defer bus.Shutdown(ctx)
+	second := &recordingSubscriber{id: first.id}
+	s, err = bus.Subscribe(ctx, second)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	waitFor(t, "the new events to be handled", func() bool { return s.Position() == 8 })
+	checkDelivered(t, store, 5, second)
+}
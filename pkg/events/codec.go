@@ -0,0 +1,91 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Codec converts events to and from the bytes a durable store persists.
+// Because Event is an interface, a store cannot rebuild an event from its
+// bytes alone; the codec supplies the concrete type for each event type name.
+type Codec interface {
+	// Encode returns the type name of the event and its serialized payload.
+	Encode(event Event) (eventType string, data []byte, err error)
+
+	// Decode rebuilds an event of the named type from a payload produced by
+	// Encode. It returns an error wrapping ErrUnknownEventType if the type is
+	// not known to the codec.
+	Decode(eventType string, data []byte) (Event, error)
+}
+
+// ErrUnknownEventType indicates that a stored event has a type name for which
+// no concrete event type has been registered. Replaying such an event would
+// silently drop a state change, so it is always reported rather than skipped.
+var ErrUnknownEventType = errors.New("events: unknown event type")
+
+// JSONCodec is a Codec that stores events as JSON. Each payload is decoded
+// into a new value of the struct type registered for its event type name.
+// It is safe for concurrent use.
+type JSONCodec struct {
+	mu    sync.RWMutex
+	types map[string]reflect.Type
+}
+
+// NewJSONCodec creates a JSONCodec that knows the types of the given
+// prototype events. See Register.
+func NewJSONCodec(prototypes ...Event) *JSONCodec {
+	c := &JSONCodec{types: make(map[string]reflect.Type)}
+	for _, p := range prototypes {
+		c.Register(p)
+	}
+	return c
+}
+
+// Register records the concrete type of prototype under the name returned by
+// its EventType method, such as:
+//
+//	codec.Register(&PaymentInitiated{})
+//
+// The prototype must be a non-nil pointer to a struct. It panics otherwise, as
+// registration happens at startup and a bad prototype is a programming error.
+func (c *JSONCodec) Register(prototype Event) {
+	t := reflect.TypeOf(prototype)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct || reflect.ValueOf(prototype).IsNil() {
+		panic(fmt.Sprintf("events: prototype %T must be a non-nil pointer to a struct", prototype))
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.types[prototype.EventType()] = t.Elem()
+}
+
+// Encode returns the type name of event and its JSON encoding.
+func (c *JSONCodec) Encode(event Event) (string, []byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return "", nil, fmt.Errorf("events: encoding %s: %w", event.EventType(), err)
+	}
+	return event.EventType(), data, nil
+}
+
+// Decode returns a new event of the type registered for eventType, decoded
+// from data.
+func (c *JSONCodec) Decode(eventType string, data []byte) (Event, error) {
+	c.mu.RLock()
+	t, ok := c.types[eventType]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+	}
+	event := reflect.New(t).Interface().(Event)
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, fmt.Errorf("events: decoding %s: %w", eventType, err)
+	}
+	return event, nil
+}
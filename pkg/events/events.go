@@ -0,0 +1,57 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// This file defines the types shared by every event of the system. The
+// concrete events of each domain embed EventHeader and implement Event.
+
+// Event is the interface that all system events must implement.
+// It provides a standard way to access common event metadata.
+type Event interface {
+	// Header returns the common event header.
+	Header() *EventHeader
+	// EventType returns a string identifier for the event type.
+	EventType() string
+}
+
+// EventHeader contains common metadata for all events. It is intended to be
+// embedded in specific event structs.
+type EventHeader struct {
+	EventID     uuid.UUID `json:"eventId"`
+	EventType   string    `json:"eventType"`
+	AggregateID uuid.UUID `json:"aggregateId"` // The ID of the entity this event pertains to, e.g., PaymentID.
+	Version     int       `json:"version"`     // The version of the aggregate after this event is applied.
+	Timestamp   time.Time `json:"timestamp"`   // The UTC timestamp when the event was created.
+}
+
+// Header returns the event header itself.
+func (h *EventHeader) Header() *EventHeader {
+	return h
+}
+
+// EventType is the name of an event type, such as "AccountCredited" or
+// "governance.proposal.created". It is the value returned by Event.EventType.
+type EventType string
+
+// AggregateType names a kind of aggregate, such as an account.
+type AggregateType string
+
+// newEventHeader creates the header of a new event of the given type, applied
+// to aggregateID at the given aggregate version.
+func newEventHeader(eventType EventType, aggregateID uuid.UUID, version int) EventHeader {
+	return EventHeader{
+		EventID:     uuid.New(),
+		EventType:   string(eventType),
+		AggregateID: aggregateID,
+		Version:     version,
+		Timestamp:   time.Now().UTC(),
+	}
+}
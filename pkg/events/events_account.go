@@ -1,4 +1,3 @@
-```go
 // Copyright (c) 2024. The Bridge Project. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
@@ -6,8 +5,6 @@
 package events
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 )
 
@@ -35,23 +32,21 @@ const (
 // AccountCreated is the event recorded when a new financial account is successfully opened.
 // It represents the initial state of an account in the system.
 type AccountCreated struct {
-	BaseEvent
+	EventHeader
 	OwnerID     uuid.UUID `json:"owner_id"`
 	Currency    string    `json:"currency"`     // e.g., "USD", "BTC". Should be a validated type in a real system.
 	AccountType string    `json:"account_type"` // e.g., "customer", "internal", "settlement"
 }
 
+// EventType returns the constant type for AccountCreated.
+func (e AccountCreated) EventType() string {
+	return string(AccountCreatedType)
+}
+
 // NewAccountCreated creates a new AccountCreated event.
 func NewAccountCreated(aggregateID, ownerID uuid.UUID, currency, accountType string) *AccountCreated {
 	return &AccountCreated{
-		BaseEvent: BaseEvent{
-			EventID:       uuid.New(),
-			EventType:     AccountCreatedType,
-			AggregateID:   aggregateID,
-			AggregateType: AccountAggregate,
-			Version:       1, // The first event for this aggregate
-			Timestamp:     time.Now().UTC(),
-		},
+		EventHeader: newEventHeader(AccountCreatedType, aggregateID, 1), // The first event for this aggregate
 		OwnerID:     ownerID,
 		Currency:    currency,
 		AccountType: accountType,
@@ -63,24 +58,22 @@ func NewAccountCreated(aggregateID, ownerID uuid.UUID, currency, accountType str
 // AccountCredited is the event recorded when funds are added to an account.
 // This is an immutable record of a credit operation.
 type AccountCredited struct {
-	BaseEvent
+	EventHeader
 	Amount        int64     `json:"amount"`         // Amount in the smallest unit of the currency (e.g., cents)
 	TransactionID uuid.UUID `json:"transaction_id"` // The transaction that caused this credit
 	Reason        string    `json:"reason"`         // e.g., "deposit", "transfer_in"
 	NewBalance    int64     `json:"new_balance"`    // The balance of the account *after* this credit
 }
 
+// EventType returns the constant type for AccountCredited.
+func (e AccountCredited) EventType() string {
+	return string(AccountCreditedType)
+}
+
 // NewAccountCredited creates a new AccountCredited event.
 func NewAccountCredited(aggregateID, transactionID uuid.UUID, version int, amount, newBalance int64, reason string) *AccountCredited {
 	return &AccountCredited{
-		BaseEvent: BaseEvent{
-			EventID:       uuid.New(),
-			EventType:     AccountCreditedType,
-			AggregateID:   aggregateID,
-			AggregateType: AccountAggregate,
-			Version:       version,
-			Timestamp:     time.Now().UTC(),
-		},
+		EventHeader:   newEventHeader(AccountCreditedType, aggregateID, version),
 		Amount:        amount,
 		TransactionID: transactionID,
 		Reason:        reason,
@@ -93,24 +86,22 @@ func NewAccountCredited(aggregateID, transactionID uuid.UUID, version int, amoun
 // AccountDebited is the event recorded when funds are removed from an account.
 // This is an immutable record of a debit operation.
 type AccountDebited struct {
-	BaseEvent
+	EventHeader
 	Amount        int64     `json:"amount"`         // Amount in the smallest unit of the currency (e.g., cents)
 	TransactionID uuid.UUID `json:"transaction_id"` // The transaction that caused this debit
 	Reason        string    `json:"reason"`         // e.g., "withdrawal", "transfer_out", "fee"
 	NewBalance    int64     `json:"new_balance"`    // The balance of the account *after* this debit
 }
 
+// EventType returns the constant type for AccountDebited.
+func (e AccountDebited) EventType() string {
+	return string(AccountDebitedType)
+}
+
 // NewAccountDebited creates a new AccountDebited event.
 func NewAccountDebited(aggregateID, transactionID uuid.UUID, version int, amount, newBalance int64, reason string) *AccountDebited {
 	return &AccountDebited{
-		BaseEvent: BaseEvent{
-			EventID:       uuid.New(),
-			EventType:     AccountDebitedType,
-			AggregateID:   aggregateID,
-			AggregateType: AccountAggregate,
-			Version:       version,
-			Timestamp:     time.Now().UTC(),
-		},
+		EventHeader:   newEventHeader(AccountDebitedType, aggregateID, version),
 		Amount:        amount,
 		TransactionID: transactionID,
 		Reason:        reason,
@@ -123,24 +114,22 @@ func NewAccountDebited(aggregateID, transactionID uuid.UUID, version int, amount
 // AccountFrozen is the event recorded when an account's ability to transact is suspended.
 // This is a critical event for risk and compliance management.
 type AccountFrozen struct {
-	BaseEvent
+	EventHeader
 	Reason   string    `json:"reason"`    // A structured reason for the freeze, e.g., "compliance_review", "fraud_suspicion"
 	FrozenBy uuid.UUID `json:"frozen_by"` // ID of the user or system component that initiated the freeze
 }
 
+// EventType returns the constant type for AccountFrozen.
+func (e AccountFrozen) EventType() string {
+	return string(AccountFrozenType)
+}
+
 // NewAccountFrozen creates a new AccountFrozen event.
 func NewAccountFrozen(aggregateID, frozenBy uuid.UUID, version int, reason string) *AccountFrozen {
 	return &AccountFrozen{
-		BaseEvent: BaseEvent{
-			EventID:       uuid.New(),
-			EventType:     AccountFrozenType,
-			AggregateID:   aggregateID,
-			AggregateType: AccountAggregate,
-			Version:       version,
-			Timestamp:     time.Now().UTC(),
-		},
-		Reason:   reason,
-		FrozenBy: frozenBy,
+		EventHeader: newEventHeader(AccountFrozenType, aggregateID, version),
+		Reason:      reason,
+		FrozenBy:    frozenBy,
 	}
 }
 
@@ -148,24 +137,22 @@ func NewAccountFrozen(aggregateID, frozenBy uuid.UUID, version int, reason strin
 
 // AccountUnfrozen is the event recorded when a previously frozen account is made active again.
 type AccountUnfrozen struct {
-	BaseEvent
+	EventHeader
 	Reason     string    `json:"reason"`      // A structured reason for the unfreeze, e.g., "compliance_review_cleared"
 	UnfrozenBy uuid.UUID `json:"unfrozen_by"` // ID of the user or system component that initiated the unfreeze
 }
 
+// EventType returns the constant type for AccountUnfrozen.
+func (e AccountUnfrozen) EventType() string {
+	return string(AccountUnfrozenType)
+}
+
 // NewAccountUnfrozen creates a new AccountUnfrozen event.
 func NewAccountUnfrozen(aggregateID, unfrozenBy uuid.UUID, version int, reason string) *AccountUnfrozen {
 	return &AccountUnfrozen{
-		BaseEvent: BaseEvent{
-			EventID:       uuid.New(),
-			EventType:     AccountUnfrozenType,
-			AggregateID:   aggregateID,
-			AggregateType: AccountAggregate,
-			Version:       version,
-			Timestamp:     time.Now().UTC(),
-		},
-		Reason:     reason,
-		UnfrozenBy: unfrozenBy,
+		EventHeader: newEventHeader(AccountUnfrozenType, aggregateID, version),
+		Reason:      reason,
+		UnfrozenBy:  unfrozenBy,
 	}
 }
 
@@ -174,27 +161,23 @@ func NewAccountUnfrozen(aggregateID, unfrozenBy uuid.UUID, version int, reason s
 // AccountClosed is the event recorded when an account is permanently closed.
 // This is a terminal state for an account aggregate.
 type AccountClosed struct {
-	BaseEvent
+	EventHeader
 	Reason       string    `json:"reason"`        // A structured reason for the closure, e.g., "customer_request", "dormancy"
 	ClosedBy     uuid.UUID `json:"closed_by"`     // ID of the user or system component that initiated the closure
 	FinalBalance int64     `json:"final_balance"` // The balance at the time of closure (should typically be zero)
 }
 
+// EventType returns the constant type for AccountClosed.
+func (e AccountClosed) EventType() string {
+	return string(AccountClosedType)
+}
+
 // NewAccountClosed creates a new AccountClosed event.
 func NewAccountClosed(aggregateID, closedBy uuid.UUID, version int, finalBalance int64, reason string) *AccountClosed {
 	return &AccountClosed{
-		BaseEvent: BaseEvent{
-			EventID:       uuid.New(),
-			EventType:     AccountClosedType,
-			AggregateID:   aggregateID,
-			AggregateType: AccountAggregate,
-			Version:       version,
-			Timestamp:     time.Now().UTC(),
-		},
+		EventHeader:  newEventHeader(AccountClosedType, aggregateID, version),
 		Reason:       reason,
 		ClosedBy:     closedBy,
 		FinalBalance: finalBalance,
 	}
 }
-
-```
\ No newline at end of file
@@ -1,4 +1,3 @@
-```go
 // Copyright (c) 2024. All rights reserved.
 // This file is part of the Go-based financial infrastructure project.
 //
@@ -15,33 +14,6 @@ import (
 	"github.com/google/uuid"
 )
 
-// =================================================================================
-// NOTE: Base Event Types
-//
-// The types `EventType` and `EventHeader` are fundamental to the event system.
-// In a complete project structure, they would be defined in a central file like
-// `pkg/events/events.go` to be used across all event categories.
-// They are defined here to make this file self-contained and compilable.
-// =================================================================================
-
-// EventType is a string alias for the type of an event, providing a clear,
-// human-readable identifier for different event categories.
-type EventType string
-
-// EventHeader contains common fields for all system events.
-// It is embedded in specific event structs to ensure consistency in event
-// metadata, which is crucial for event sourcing, logging, and auditing.
-type EventHeader struct {
-	// EventID is a unique identifier for this specific event instance.
-	EventID uuid.UUID `json:"eventId"`
-	// Timestamp is the UTC time at which the event was created.
-	Timestamp time.Time `json:"timestamp"`
-	// EventType indicates the specific type of event.
-	EventType EventType `json:"eventType"`
-	// Version is the schema version of the event payload.
-	Version int `json:"version"`
-}
-
 // =================================================================================
 // Governance Event Type Constants
 // =================================================================================
@@ -104,12 +76,17 @@ type VoteTally struct {
 // This provides an auditable record of all policy modifications.
 type PolicyUpdated struct {
 	EventHeader
-	PolicyID           string    `json:"policyId"`          // A unique identifier for the policy being updated.
-	PreviousVersion    string    `json:"previousVersion"`   // The version/hash of the policy before the update.
-	NewVersion         string    `json:"newVersion"`        // The version/hash of the new policy.
-	ChangeDescription  string    `json:"changeDescription"` // A human-readable description of the change.
-	EffectiveTimestamp time.Time `json:"effectiveTimestamp"`// When the new policy takes effect.
-	AuthorizedBy       string    `json:"authorizedBy"`      // The authority for the change (e.g., ProposalID, AdminID).
+	PolicyID           string    `json:"policyId"`           // A unique identifier for the policy being updated.
+	PreviousVersion    string    `json:"previousVersion"`    // The version/hash of the policy before the update.
+	NewVersion         string    `json:"newVersion"`         // The version/hash of the new policy.
+	ChangeDescription  string    `json:"changeDescription"`  // A human-readable description of the change.
+	EffectiveTimestamp time.Time `json:"effectiveTimestamp"` // When the new policy takes effect.
+	AuthorizedBy       string    `json:"authorizedBy"`       // The authority for the change (e.g., ProposalID, AdminID).
+}
+
+// EventType returns the constant type for PolicyUpdated.
+func (e PolicyUpdated) EventType() string {
+	return string(PolicyUpdatedEventType)
 }
 
 // ProposalCreated event is published when a new governance proposal is submitted.
@@ -124,6 +101,11 @@ type ProposalCreated struct {
 	ProposedChanges   []ProposedChange `json:"proposedChanges"`
 }
 
+// EventType returns the constant type for ProposalCreated.
+func (e ProposalCreated) EventType() string {
+	return string(ProposalCreatedEventType)
+}
+
 // ProposalVotedOn event is published when a vote is cast on a proposal.
 type ProposalVotedOn struct {
 	EventHeader
@@ -133,6 +115,11 @@ type ProposalVotedOn struct {
 	VotingPower string     `json:"votingPower"` // The weight of the vote, as a string to handle large numbers.
 }
 
+// EventType returns the constant type for ProposalVotedOn.
+func (e ProposalVotedOn) EventType() string {
+	return string(ProposalVotedOnEventType)
+}
+
 // ProposalEnacted event is published when a proposal passes and its changes are applied.
 // This is a critical event marking the successful completion of a governance cycle.
 type ProposalEnacted struct {
@@ -143,6 +130,11 @@ type ProposalEnacted struct {
 	AppliedChanges     []ProposedChange `json:"appliedChanges"` // A record of the exact changes that were executed.
 }
 
+// EventType returns the constant type for ProposalEnacted.
+func (e ProposalEnacted) EventType() string {
+	return string(ProposalEnactedEventType)
+}
+
 // ProposalRejected event is published when a proposal fails to pass.
 type ProposalRejected struct {
 	EventHeader
@@ -152,6 +144,11 @@ type ProposalRejected struct {
 	Reason             string    `json:"reason"` // e.g., "Failed to meet quorum", "Majority voted against".
 }
 
+// EventType returns the constant type for ProposalRejected.
+func (e ProposalRejected) EventType() string {
+	return string(ProposalRejectedEventType)
+}
+
 // ParameterChanged event is published for each individual system parameter modification.
 // This provides a granular audit trail and is often triggered by a ProposalEnacted event.
 type ParameterChanged struct {
@@ -162,27 +159,25 @@ type ParameterChanged struct {
 	ChangeContext string `json:"changeContext"` // Reference to the source of the change (e.g., "proposal:a1b2c3d4...").
 }
 
+// EventType returns the constant type for ParameterChanged.
+func (e ParameterChanged) EventType() string {
+	return string(ParameterChangedEventType)
+}
+
 // =================================================================================
 // Event Constructor Functions
 //
 // These functions ensure that events are created with consistent and valid
 // metadata, such as a unique event ID, timestamp, and correct event type.
+// Proposal events belong to the proposal's aggregate, keyed by its ProposalID,
+// and callers pass the proposal's next version. A policy update or parameter
+// change is recorded as an aggregate of its own, at version 1.
 // =================================================================================
 
-// newEventHeader creates a standard header for a new event.
-func newEventHeader(eventType EventType) EventHeader {
-	return EventHeader{
-		EventID:   uuid.New(),
-		Timestamp: time.Now().UTC(),
-		EventType: eventType,
-		Version:   1,
-	}
-}
-
 // NewPolicyUpdated creates a new PolicyUpdated event.
 func NewPolicyUpdated(policyID, prevVersion, newVersion, desc, authorizedBy string, effectiveAt time.Time) *PolicyUpdated {
 	return &PolicyUpdated{
-		EventHeader:        newEventHeader(PolicyUpdatedEventType),
+		EventHeader:        newEventHeader(PolicyUpdatedEventType, uuid.New(), 1),
 		PolicyID:           policyID,
 		PreviousVersion:    prevVersion,
 		NewVersion:         newVersion,
@@ -194,9 +189,10 @@ func NewPolicyUpdated(policyID, prevVersion, newVersion, desc, authorizedBy stri
 
 // NewProposalCreated creates a new ProposalCreated event.
 func NewProposalCreated(proposer, title, desc string, start, end time.Time, changes []ProposedChange) *ProposalCreated {
+	proposalID := uuid.New()
 	return &ProposalCreated{
-		EventHeader:       newEventHeader(ProposalCreatedEventType),
-		ProposalID:        uuid.New(),
+		EventHeader:       newEventHeader(ProposalCreatedEventType, proposalID, 1),
+		ProposalID:        proposalID,
 		Proposer:          proposer,
 		Title:             title,
 		Description:       desc,
@@ -207,9 +203,9 @@ func NewProposalCreated(proposer, title, desc string, start, end time.Time, chan
 }
 
 // NewProposalVotedOn creates a new ProposalVotedOn event.
-func NewProposalVotedOn(proposalID uuid.UUID, voter string, option VoteOption, votingPower string) *ProposalVotedOn {
+func NewProposalVotedOn(proposalID uuid.UUID, version int, voter string, option VoteOption, votingPower string) *ProposalVotedOn {
 	return &ProposalVotedOn{
-		EventHeader: newEventHeader(ProposalVotedOnEventType),
+		EventHeader: newEventHeader(ProposalVotedOnEventType, proposalID, version),
 		ProposalID:  proposalID,
 		Voter:       voter,
 		Option:      option,
@@ -218,9 +214,9 @@ func NewProposalVotedOn(proposalID uuid.UUID, voter string, option VoteOption, v
 }
 
 // NewProposalEnacted creates a new ProposalEnacted event.
-func NewProposalEnacted(proposalID uuid.UUID, outcome VoteTally, appliedChanges []ProposedChange) *ProposalEnacted {
+func NewProposalEnacted(proposalID uuid.UUID, version int, outcome VoteTally, appliedChanges []ProposedChange) *ProposalEnacted {
 	return &ProposalEnacted{
-		EventHeader:        newEventHeader(ProposalEnactedEventType),
+		EventHeader:        newEventHeader(ProposalEnactedEventType, proposalID, version),
 		ProposalID:         proposalID,
 		EnactmentTimestamp: time.Now().UTC(),
 		Outcome:            outcome,
@@ -229,9 +225,9 @@ func NewProposalEnacted(proposalID uuid.UUID, outcome VoteTally, appliedChanges
 }
 
 // NewProposalRejected creates a new ProposalRejected event.
-func NewProposalRejected(proposalID uuid.UUID, outcome VoteTally, reason string) *ProposalRejected {
+func NewProposalRejected(proposalID uuid.UUID, version int, outcome VoteTally, reason string) *ProposalRejected {
 	return &ProposalRejected{
-		EventHeader:        newEventHeader(ProposalRejectedEventType),
+		EventHeader:        newEventHeader(ProposalRejectedEventType, proposalID, version),
 		ProposalID:         proposalID,
 		RejectionTimestamp: time.Now().UTC(),
 		Outcome:            outcome,
@@ -242,13 +238,10 @@ func NewProposalRejected(proposalID uuid.UUID, outcome VoteTally, reason string)
 // NewParameterChanged creates a new ParameterChanged event.
 func NewParameterChanged(name, prevValue, newValue, context string) *ParameterChanged {
 	return &ParameterChanged{
-		EventHeader:   newEventHeader(ParameterChangedEventType),
+		EventHeader:   newEventHeader(ParameterChangedEventType, uuid.New(), 1),
 		ParameterName: name,
 		PreviousValue: prevValue,
 		NewValue:      newValue,
 		ChangeContext: context,
 	}
 }
-
-
-```
\ No newline at end of file
@@ -1,9 +1,6 @@
-```go
 package events
 
 import (
-	"time"
-
 	"github.com/google/uuid"
 )
 
@@ -14,30 +11,6 @@ import (
 // critical for a financial system. Each event is a granular, self-contained
 // record of a specific business occurrence.
 
-// Event is the interface that all system events must implement.
-// It provides a standard way to access common event metadata.
-type Event interface {
-	// Header returns the common event header.
-	Header() *EventHeader
-	// EventType returns a string identifier for the event type.
-	EventType() string
-}
-
-// EventHeader contains common metadata for all events. It is intended to be
-// embedded in specific event structs.
-type EventHeader struct {
-	EventID     uuid.UUID `json:"eventId"`
-	EventType   string    `json:"eventType"`
-	AggregateID uuid.UUID `json:"aggregateId"` // The ID of the entity this event pertains to, e.g., PaymentID.
-	Version     int       `json:"version"`     // The version of the aggregate after this event is applied.
-	Timestamp   time.Time `json:"timestamp"`   // The UTC timestamp when the event was created.
-}
-
-// Header returns the event header itself.
-func (h *EventHeader) Header() *EventHeader {
-	return h
-}
-
 // Amount represents a monetary value in its smallest unit (e.g., cents).
 // This avoids floating-point arithmetic errors, a critical invariant for financial safety.
 type Amount struct {
@@ -226,5 +199,3 @@ type PaymentFailed struct {
 func (e PaymentFailed) EventType() string {
 	return PaymentFailedEvent
 }
-### END_OF_FILE_COMPLETED ###
-```
\ No newline at end of file
@@ -0,0 +1,831 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FileStore is a durable, embedded implementation of the Store interface. It
+// keeps the global event log as a series of append-only segment files in a
+// directory, which makes it a real store for local deployments and tests that
+// do not have PostgreSQL available.
+//
+// The log is made of framed records:
+//
+//	length  uint32  length of the body
+//	crc     uint32  CRC-32C (Castagnoli) of the body
+//	body:
+//	  sequence     uint64
+//	  version      uint32  aggregate version after the event
+//	  aggregateID  [16]byte
+//	  typeLength   uint16
+//	  type         [typeLength]byte
+//	  payload      the bytes produced by the Codec
+//
+// All integers are big-endian. A segment is named after the first sequence
+// number it holds, and a new segment is started once the active one reaches
+// FileStoreOptions.SegmentSize.
+//
+// Appends are serialized by a single writer goroutine that batches concurrent
+// appends into one write and one fsync (group commit). Append returns only
+// once its record is on stable storage, and an event becomes visible to Load
+// and LoadByAggregate at the same moment, so readers never observe an event
+// that a crash could take back.
+//
+// Sequences are dense: the nth event appended has Sequence n. Versions are
+// checked optimistically: an event must carry exactly the version after the
+// latest one stored for its aggregate, otherwise Append fails with ErrConflict.
+//
+// On open, every segment is scanned and its records verified. A record cut
+// short by the end of the last segment, which is what a crash mid-write leaves
+// behind, is truncated away. Any other bad record, such as one failing its
+// checksum, is reported as ErrCorrupt wherever it is, because dropping it and
+// the events after it would silently rewrite history. A record that was
+// written but never acknowledged before a crash may survive recovery if it is
+// intact; callers needing exactly-once appends should make their events
+// idempotent.
+//
+// Indexes are kept in memory and rebuilt on open: a sparse index per segment
+// maps sequence numbers to file offsets for Load, and a per-aggregate index
+// locates every event of an aggregate for LoadByAggregate.
+//
+// A directory must be opened by at most one FileStore at a time. FileStore is
+// safe for concurrent use.
+type FileStore struct {
+	dir   string
+	codec Codec
+	opts  FileStoreOptions
+
+	// appends carries requests to the writer goroutine. It is unbuffered, so a
+	// request is either taken by the writer or not sent at all.
+	appends chan *appendRequest
+	closing chan struct{}
+	stopped chan struct{}
+	once    sync.Once
+
+	// Owned by the writer goroutine.
+	nextSeq     Sequence
+	versions    map[string]int
+	writeOffset int64
+	failed      error
+
+	// mu guards the segment list and the indexes, which the writer updates
+	// only after the records they describe are synced.
+	mu          sync.RWMutex
+	segments    []*segment
+	byAggregate map[string][]recordRef
+
+	// files is held for reading while segment files are read, so that Close
+	// does not close them underneath a reader.
+	files  sync.RWMutex
+	closed bool
+}
+
+// FileStoreOptions configures a FileStore. Only Codec is required; the other
+// fields have defaults suited to a single-node deployment.
+type FileStoreOptions struct {
+	// Codec encodes events into record payloads and decodes them on load.
+	Codec Codec
+
+	// SegmentSize is the size in bytes after which a new segment file is
+	// started. Defaults to 64 MiB.
+	SegmentSize int64
+
+	// IndexInterval is the number of bytes of records between entries in the
+	// sparse sequence index. Smaller values make Load seek faster at the cost
+	// of memory. Defaults to 4 KiB.
+	IndexInterval int64
+
+	// MaxBatch is the most appends committed with one fsync. Defaults to 256.
+	MaxBatch int
+
+	// MaxLoad is the most events Load returns in one call, whatever limit the
+	// caller asks for. Defaults to 1000.
+	MaxLoad uint64
+}
+
+func (o FileStoreOptions) withDefaults() FileStoreOptions {
+	if o.SegmentSize <= 0 {
+		o.SegmentSize = 64 << 20
+	}
+	if o.IndexInterval <= 0 {
+		o.IndexInterval = 4 << 10
+	}
+	if o.MaxBatch <= 0 {
+		o.MaxBatch = 256
+	}
+	if o.MaxLoad == 0 {
+		o.MaxLoad = 1000
+	}
+	return o
+}
+
+// Errors specific to FileStore.
+var (
+	// ErrClosed indicates that the store has been closed.
+	ErrClosed = errors.New("events: store closed")
+
+	// ErrCorrupt indicates that the log failed verification other than by
+	// ending in a torn write. The store refuses to open rather than replay an
+	// incomplete history.
+	ErrCorrupt = errors.New("events: corrupt log")
+)
+
+const (
+	segmentExt      = ".log"
+	frameHeaderSize = 8
+	bodyFixedSize   = 8 + 4 + 16 + 2
+	// maxRecordSize bounds the body of a record. A length above it in a
+	// frame header can only come from a torn or corrupt write.
+	maxRecordSize = 16 << 20
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// errTornRecord is returned by readRecord for a record cut short by the end of
+// the file, which is what a crash in the middle of a write leaves behind.
+var errTornRecord = errors.New("truncated record")
+
+// Compile-time checks to ensure FileStore implements the Store,
+// SequencedLoader and RawScanner interfaces.
+var (
+	_ Store           = (*FileStore)(nil)
+	_ SequencedLoader = (*FileStore)(nil)
+	_ RawScanner      = (*FileStore)(nil)
+)
+
+// segment is one file of the log.
+type segment struct {
+	path string
+	file *os.File
+	// base is the sequence number of the first record in the segment.
+	base Sequence
+	// size is the number of bytes of synced, visible records.
+	size int64
+	// index is the sparse sequence index, in ascending order.
+	index []indexEntry
+}
+
+type indexEntry struct {
+	seq    Sequence
+	offset int64
+}
+
+// recordRef locates one record in the log.
+type recordRef struct {
+	seg    *segment
+	offset int64
+	size   int64
+}
+
+// record is a decoded log record whose payload has not yet been decoded by
+// the codec.
+type record struct {
+	seq         Sequence
+	version     int
+	aggregateID uuid.UUID
+	eventType   string
+	data        []byte
+}
+
+type appendRequest struct {
+	aggregateID uuid.UUID
+	version     int
+	eventType   string
+	data        []byte
+	done        chan appendResult
+}
+
+type appendResult struct {
+	seq Sequence
+	err error
+}
+
+// OpenFileStore opens the log in dir, creating the directory and an empty log
+// if needed. It verifies every record, truncates a torn tail left by a crash,
+// and rebuilds the indexes before returning. The store must be closed with
+// Close to release its files.
+func OpenFileStore(dir string, opts FileStoreOptions) (*FileStore, error) {
+	if opts.Codec == nil {
+		return nil, errors.New("events: a file store requires a codec")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create event log directory %s: %w", dir, err)
+	}
+	s := &FileStore{
+		dir:         dir,
+		codec:       opts.Codec,
+		opts:        opts.withDefaults(),
+		appends:     make(chan *appendRequest),
+		closing:     make(chan struct{}),
+		stopped:     make(chan struct{}),
+		nextSeq:     1,
+		versions:    make(map[string]int),
+		byAggregate: make(map[string][]recordRef),
+	}
+
+	paths, err := s.segmentPaths()
+	if err != nil {
+		return nil, err
+	}
+	for i, path := range paths {
+		if err := s.recover(path, i == len(paths)-1); err != nil {
+			s.closeFiles()
+			return nil, err
+		}
+	}
+	if len(s.segments) == 0 {
+		if err := s.roll(); err != nil {
+			return nil, err
+		}
+	}
+	s.writeOffset = s.active().size
+
+	go s.write()
+	return s, nil
+}
+
+// segmentPaths returns the segment files of the log, oldest first.
+func (s *FileStore) segmentPaths() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event log directory %s: %w", s.dir, err)
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), segmentExt) {
+			paths = append(paths, filepath.Join(s.dir, e.Name()))
+		}
+	}
+	// Names are zero-padded sequence numbers, so they sort in log order.
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// recover opens the segment at path, verifies its records and adds them to
+// the indexes. A record cut short at the end of the last segment is a torn
+// write, and the segment is truncated before it; any other bad record is
+// ErrCorrupt.
+func (s *FileStore) recover(path string, last bool) error {
+	base, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(path), segmentExt), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: unexpected segment file %s", ErrCorrupt, path)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to open segment %s: %w", path, err)
+	}
+	seg := &segment{path: path, file: f, base: Sequence(base)}
+	s.segments = append(s.segments, seg)
+
+	r := bufio.NewReaderSize(f, 64<<10)
+	for {
+		rec, n, err := readRecord(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err == nil && rec.seq != s.nextSeq {
+			err = fmt.Errorf("sequence %d where %d was expected", rec.seq, s.nextSeq)
+		}
+		if err == nil && seg.size == 0 && rec.seq != seg.base {
+			err = fmt.Errorf("first sequence %d does not match the segment name", rec.seq)
+		}
+		if err != nil {
+			if !last || !errors.Is(err, errTornRecord) {
+				return fmt.Errorf("%w: %s at offset %d: %v", ErrCorrupt, path, seg.size, err)
+			}
+			// A torn tail: the record was never acknowledged, and nothing
+			// follows it.
+			if err := f.Truncate(seg.size); err != nil {
+				return fmt.Errorf("failed to truncate torn tail of %s: %w", path, err)
+			}
+			if err := f.Sync(); err != nil {
+				return fmt.Errorf("failed to sync %s: %w", path, err)
+			}
+			return nil
+		}
+		s.index(seg, rec.seq, rec.aggregateID, rec.version, seg.size, n)
+		s.versions[rec.aggregateID.String()] = rec.version
+		s.nextSeq++
+	}
+}
+
+// Append durably adds event to the log and returns its sequence number. The
+// event's version must be one more than the latest version stored for its
+// aggregate; otherwise Append returns an error wrapping ErrConflict and the
+// event is not stored.
+//
+// If ctx is done before the writer has taken the event, Append returns the
+// context's error and the event is not stored. Once taken, Append waits for
+// the outcome, so a returned sequence always means the event is durable.
+func (s *FileStore) Append(ctx context.Context, event Event) (Sequence, error) {
+	h := event.Header()
+	if h.Version < 1 {
+		return 0, fmt.Errorf("events: event %s has version %d; aggregate versions start at 1", h.EventID, h.Version)
+	}
+	eventType, data, err := s.codec.Encode(event)
+	if err != nil {
+		return 0, err
+	}
+	if len(eventType) > 1<<16-1 || bodyFixedSize+len(eventType)+len(data) > maxRecordSize {
+		return 0, fmt.Errorf("events: event %s is too large to store", h.EventID)
+	}
+
+	req := &appendRequest{
+		aggregateID: h.AggregateID,
+		version:     h.Version,
+		eventType:   eventType,
+		data:        data,
+		done:        make(chan appendResult, 1),
+	}
+	select {
+	case s.appends <- req:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-s.closing:
+		return 0, ErrClosed
+	}
+	res := <-req.done
+	return res.seq, res.err
+}
+
+// write is the writer goroutine. It takes the first waiting append, then
+// every other append that queued up behind it, and commits them together.
+func (s *FileStore) write() {
+	defer close(s.stopped)
+	batch := make([]*appendRequest, 0, s.opts.MaxBatch)
+	for {
+		select {
+		case req := <-s.appends:
+			batch = append(batch[:0], req)
+		case <-s.closing:
+			return
+		}
+	drain:
+		for len(batch) < s.opts.MaxBatch {
+			select {
+			case req := <-s.appends:
+				batch = append(batch, req)
+			default:
+				break drain
+			}
+		}
+		s.commit(batch)
+	}
+}
+
+// pendingRecord is a record written by the current batch but not yet synced.
+type pendingRecord struct {
+	req    *appendRequest
+	seq    Sequence
+	seg    *segment
+	offset int64
+	size   int64
+}
+
+// commit writes the accepted appends of batch, syncs them and publishes them
+// to the indexes. A write or sync failure leaves the tail of the log in an
+// unknown state, so the store then fails every later append; reopening it
+// recovers the log.
+func (s *FileStore) commit(batch []*appendRequest) {
+	if s.failed != nil {
+		for _, req := range batch {
+			req.done <- appendResult{err: s.failed}
+		}
+		return
+	}
+
+	var (
+		pending []pendingRecord
+		buf     []byte
+	)
+	fail := func(err error) {
+		s.failed = fmt.Errorf("events: file store failed and must be reopened: %w", err)
+		for _, p := range pending {
+			p.req.done <- appendResult{err: s.failed}
+		}
+	}
+	for i, req := range batch {
+		key := req.aggregateID.String()
+		if current := s.versions[key]; req.version != current+1 {
+			req.done <- appendResult{err: fmt.Errorf("%w: aggregate %s is at version %d, event has version %d",
+				ErrConflict, key, current, req.version)}
+			continue
+		}
+		frame := encodeRecord(s.nextSeq, req)
+		if used := s.writeOffset + int64(len(buf)); used > 0 && used+int64(len(frame)) > s.opts.SegmentSize {
+			if err := s.flush(buf); err != nil {
+				fail(err)
+				s.failRest(batch[i:])
+				return
+			}
+			buf = buf[:0]
+			if err := s.roll(); err != nil {
+				fail(err)
+				s.failRest(batch[i:])
+				return
+			}
+		}
+		pending = append(pending, pendingRecord{
+			req:    req,
+			seq:    s.nextSeq,
+			seg:    s.active(),
+			offset: s.writeOffset + int64(len(buf)),
+			size:   int64(len(frame)),
+		})
+		buf = append(buf, frame...)
+		s.versions[key] = req.version
+		s.nextSeq++
+	}
+	if len(pending) == 0 {
+		return
+	}
+	if err := s.flush(buf); err != nil {
+		fail(err)
+		return
+	}
+
+	s.mu.Lock()
+	for _, p := range pending {
+		s.index(p.seg, p.seq, p.req.aggregateID, p.req.version, p.offset, p.size)
+	}
+	s.mu.Unlock()
+	for _, p := range pending {
+		p.req.done <- appendResult{seq: p.seq}
+	}
+}
+
+func (s *FileStore) failRest(batch []*appendRequest) {
+	for _, req := range batch {
+		req.done <- appendResult{err: s.failed}
+	}
+}
+
+// flush writes buf to the end of the active segment and syncs it.
+func (s *FileStore) flush(buf []byte) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	seg := s.active()
+	if _, err := seg.file.Write(buf); err != nil {
+		return fmt.Errorf("failed to write to segment %s: %w", seg.path, err)
+	}
+	s.writeOffset += int64(len(buf))
+	if err := seg.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync segment %s: %w", seg.path, err)
+	}
+	return nil
+}
+
+// roll starts a new, empty active segment beginning at the next sequence.
+func (s *FileStore) roll() error {
+	path := filepath.Join(s.dir, fmt.Sprintf("%020d%s", s.nextSeq, segmentExt))
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0640)
+	if err != nil {
+		return fmt.Errorf("failed to create segment %s: %w", path, err)
+	}
+	// Sync the directory so the new segment's name survives a crash.
+	if d, err := os.Open(s.dir); err == nil {
+		err = d.Sync()
+		d.Close()
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("failed to sync event log directory %s: %w", s.dir, err)
+		}
+	}
+	s.mu.Lock()
+	s.segments = append(s.segments, &segment{path: path, file: f, base: s.nextSeq})
+	s.mu.Unlock()
+	s.writeOffset = 0
+	return nil
+}
+
+func (s *FileStore) active() *segment {
+	return s.segments[len(s.segments)-1]
+}
+
+// index makes a synced record visible. The caller must hold mu for writing,
+// or be opening the store.
+func (s *FileStore) index(seg *segment, seq Sequence, aggregateID uuid.UUID, version int, offset, size int64) {
+	if n := len(seg.index); n == 0 || offset-seg.index[n-1].offset >= s.opts.IndexInterval {
+		seg.index = append(seg.index, indexEntry{seq: seq, offset: offset})
+	}
+	seg.size = offset + size
+	key := aggregateID.String()
+	s.byAggregate[key] = append(s.byAggregate[key], recordRef{seg: seg, offset: offset, size: size})
+}
+
+// Load returns up to limit events from the global log with sequence numbers
+// greater than after, in sequence order. A limit of zero, or one above
+// FileStoreOptions.MaxLoad, is treated as MaxLoad.
+func (s *FileStore) Load(ctx context.Context, after Sequence, limit uint64) ([]Event, error) {
+	loaded, err := s.LoadSequenced(ctx, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Event, len(loaded))
+	for i, e := range loaded {
+		out[i] = e.Event
+	}
+	return out, nil
+}
+
+// LoadSequenced is like Load, but also returns the sequence number of each
+// event.
+func (s *FileStore) LoadSequenced(ctx context.Context, after Sequence, limit uint64) ([]SequencedEvent, error) {
+	if limit == 0 || limit > s.opts.MaxLoad {
+		limit = s.opts.MaxLoad
+	}
+	var out []SequencedEvent
+	err := s.scan(ctx, after, func(rec record) error {
+		event, err := s.decode(rec)
+		if err != nil {
+			return err
+		}
+		out = append(out, SequencedEvent{Sequence: rec.seq, Event: event})
+		if uint64(len(out)) >= limit {
+			return errStopScan
+		}
+		return nil
+	})
+	if err != nil && err != errStopScan {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScanRaw calls fn with every visible event after the given sequence, in
+// sequence order, without decoding it. It stops at the first error fn
+// returns. The store cannot be closed until ScanRaw returns.
+func (s *FileStore) ScanRaw(ctx context.Context, after Sequence, fn func(RawEvent) error) error {
+	return s.scan(ctx, after, func(rec record) error {
+		return fn(RawEvent{Sequence: rec.seq, Type: rec.eventType, Data: rec.data})
+	})
+}
+
+// errStopScan stops a scan early without error.
+var errStopScan = errors.New("stop scan")
+
+// scan calls fn with every visible record after the given sequence, in order.
+func (s *FileStore) scan(ctx context.Context, after Sequence, fn func(record) error) error {
+	s.files.RLock()
+	defer s.files.RUnlock()
+	if s.closed {
+		return ErrClosed
+	}
+
+	// Take a consistent view of the visible log. Records are only ever
+	// appended, so the view stays valid while the writer carries on.
+	type view struct {
+		seg   *segment
+		size  int64
+		index []indexEntry
+	}
+	s.mu.RLock()
+	first := sort.Search(len(s.segments), func(i int) bool { return s.segments[i].base > after+1 }) - 1
+	if first < 0 {
+		first = 0
+	}
+	views := make([]view, 0, len(s.segments)-first)
+	for _, seg := range s.segments[first:] {
+		views = append(views, view{seg: seg, size: seg.size, index: seg.index})
+	}
+	s.mu.RUnlock()
+
+	for _, v := range views {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// Seek to the last indexed record at or before the first one wanted.
+		i := sort.Search(len(v.index), func(i int) bool { return v.index[i].seq > after+1 }) - 1
+		var offset int64
+		if i >= 0 {
+			offset = v.index[i].offset
+		}
+		r := bufio.NewReader(io.NewSectionReader(v.seg.file, offset, v.size-offset))
+		for {
+			rec, _, err := readRecord(r)
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("%w: %s: %v", ErrCorrupt, v.seg.path, err)
+			}
+			if rec.seq <= after {
+				continue
+			}
+			if err := fn(rec); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// FileLog reads the log of a FileStore in a directory without opening it as a
+// store. Unlike OpenFileStore it never modifies the log, so it is safe to use
+// on the log of a running store.
+type FileLog struct {
+	Dir string
+}
+
+// A compile-time check to ensure FileLog implements the RawScanner interface.
+var _ RawScanner = FileLog{}
+
+// ScanRaw calls fn with every event after the given sequence, in sequence
+// order, without decoding it. An incomplete record at the end of the last
+// segment is taken to be a write in progress and ends the scan; corruption
+// elsewhere is reported as ErrCorrupt.
+func (l FileLog) ScanRaw(ctx context.Context, after Sequence, fn func(RawEvent) error) error {
+	paths, err := (&FileStore{dir: l.Dir}).segmentPaths()
+	if err != nil {
+		return err
+	}
+	for i, path := range paths {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		last := i == len(paths)-1
+		err := func() error {
+			f, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("failed to open segment %s: %w", path, err)
+			}
+			defer f.Close()
+			r := bufio.NewReaderSize(f, 64<<10)
+			for {
+				rec, _, err := readRecord(r)
+				if err == io.EOF || (last && errors.Is(err, errTornRecord)) {
+					return nil
+				}
+				if err != nil {
+					return fmt.Errorf("%w: %s: %v", ErrCorrupt, path, err)
+				}
+				if rec.seq <= after {
+					continue
+				}
+				if err := fn(RawEvent{Sequence: rec.seq, Type: rec.eventType, Data: rec.data}); err != nil {
+					return err
+				}
+			}
+		}()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadByAggregate returns the events of the aggregate with versions greater
+// than after, in version order. An aggregate with no events yields no events
+// and no error.
+func (s *FileStore) LoadByAggregate(ctx context.Context, aggregateID string, after Sequence) ([]Event, error) {
+	s.files.RLock()
+	defer s.files.RUnlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+
+	// Versions start at 1 and have no gaps, so version v is at index v-1.
+	s.mu.RLock()
+	all := s.byAggregate[aggregateID]
+	var refs []recordRef
+	if after < Sequence(len(all)) {
+		refs = append(refs, all[after:]...)
+	}
+	s.mu.RUnlock()
+
+	out := make([]Event, 0, len(refs))
+	var buf []byte
+	for _, ref := range refs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if int64(cap(buf)) < ref.size {
+			buf = make([]byte, ref.size)
+		}
+		buf = buf[:ref.size]
+		if _, err := ref.seg.file.ReadAt(buf, ref.offset); err != nil {
+			return nil, fmt.Errorf("failed to read segment %s: %w", ref.seg.path, err)
+		}
+		rec, _, err := readRecord(bytes.NewReader(buf))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s at offset %d: %v", ErrCorrupt, ref.seg.path, ref.offset, err)
+		}
+		event, err := s.decode(rec)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, event)
+	}
+	return out, nil
+}
+
+func (s *FileStore) decode(rec record) (Event, error) {
+	event, err := s.codec.Decode(rec.eventType, rec.data)
+	if err != nil {
+		return nil, fmt.Errorf("events: decoding event at sequence %d: %w", rec.seq, err)
+	}
+	return event, nil
+}
+
+// Close stops accepting appends, waits for the commit in progress, and closes
+// the segment files. Appends waiting to be taken fail with ErrClosed.
+func (s *FileStore) Close() error {
+	s.once.Do(func() { close(s.closing) })
+	<-s.stopped
+
+	s.files.Lock()
+	defer s.files.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.closeFiles()
+}
+
+func (s *FileStore) closeFiles() error {
+	var first error
+	for _, seg := range s.segments {
+		if err := seg.file.Close(); err != nil && first == nil {
+			first = fmt.Errorf("failed to close segment %s: %w", seg.path, err)
+		}
+	}
+	return first
+}
+
+// encodeRecord returns the framed record for an append at sequence seq.
+func encodeRecord(seq Sequence, req *appendRequest) []byte {
+	bodySize := bodyFixedSize + len(req.eventType) + len(req.data)
+	frame := make([]byte, frameHeaderSize+bodySize)
+	body := frame[frameHeaderSize:]
+	binary.BigEndian.PutUint64(body[0:], uint64(seq))
+	binary.BigEndian.PutUint32(body[8:], uint32(req.version))
+	copy(body[12:28], req.aggregateID[:])
+	binary.BigEndian.PutUint16(body[28:], uint16(len(req.eventType)))
+	n := copy(body[bodyFixedSize:], req.eventType)
+	copy(body[bodyFixedSize+n:], req.data)
+
+	binary.BigEndian.PutUint32(frame[0:], uint32(bodySize))
+	binary.BigEndian.PutUint32(frame[4:], crc32.Checksum(body, crcTable))
+	return frame
+}
+
+// readRecord reads and verifies the next record from r, returning it and its
+// framed size. It returns io.EOF only at a clean end between records, and an
+// error wrapping errTornRecord for a record cut short by the end of r; any
+// other bad record is a different error.
+func readRecord(r io.Reader) (record, int64, error) {
+	var header [frameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return record{}, 0, fmt.Errorf("%w header", errTornRecord)
+		}
+		return record{}, 0, err
+	}
+	size := binary.BigEndian.Uint32(header[0:])
+	if size < bodyFixedSize || size > maxRecordSize {
+		return record{}, 0, fmt.Errorf("invalid record length %d", size)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return record{}, 0, fmt.Errorf("%w body", errTornRecord)
+		}
+		return record{}, 0, err
+	}
+	if crc32.Checksum(body, crcTable) != binary.BigEndian.Uint32(header[4:]) {
+		return record{}, 0, errors.New("checksum mismatch")
+	}
+
+	rec := record{
+		seq:     Sequence(binary.BigEndian.Uint64(body[0:])),
+		version: int(binary.BigEndian.Uint32(body[8:])),
+	}
+	copy(rec.aggregateID[:], body[12:28])
+	typeLen := int(binary.BigEndian.Uint16(body[28:]))
+	if bodyFixedSize+typeLen > len(body) {
+		return record{}, 0, fmt.Errorf("invalid type length %d", typeLen)
+	}
+	rec.eventType = string(body[bodyFixedSize : bodyFixedSize+typeLen])
+	rec.data = body[bodyFixedSize+typeLen:]
+	return rec, frameHeaderSize + int64(size), nil
+}
@@ -0,0 +1,316 @@
+package events
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func openTestFileStore(t *testing.T, dir string, opts FileStoreOptions) *FileStore {
+	t.Helper()
+	if opts.Codec == nil {
+		opts.Codec = NewJSONCodec(&AccountCredited{})
+	}
+	s, err := OpenFileStore(dir, opts)
+	if err != nil {
+		t.Fatalf("OpenFileStore: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+func credited(aggregateID uuid.UUID, version int) *AccountCredited {
+	return NewAccountCredited(aggregateID, uuid.New(), version, 100, int64(100*version), "deposit")
+}
+
+func mustAppend(t *testing.T, s Store, event Event) Sequence {
+	t.Helper()
+	seq, err := s.Append(context.Background(), event)
+	if err != nil {
+		t.Fatalf("Append version %d: %v", event.Header().Version, err)
+	}
+	return seq
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestFileStoreAppendAndReopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a, b := uuid.New(), uuid.New()
+
+	s := openTestFileStore(t, dir, FileStoreOptions{})
+	appended := []Event{credited(a, 1), credited(b, 1), credited(a, 2)}
+	for i, e := range appended {
+		if seq := mustAppend(t, s, e); seq != Sequence(i+1) {
+			t.Fatalf("event %d got sequence %d", i, seq)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := s.Append(ctx, credited(a, 3)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed after Close, got %v", err)
+	}
+
+	s = openTestFileStore(t, dir, FileStoreOptions{})
+	loaded, err := s.Load(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(loaded) != len(appended) {
+		t.Fatalf("expected %d events after reopening, got %d", len(appended), len(loaded))
+	}
+	for i, e := range loaded {
+		got, want := e.(*AccountCredited), appended[i].(*AccountCredited)
+		if got.EventID != want.EventID || got.AggregateID != want.AggregateID || got.Version != want.Version || got.NewBalance != want.NewBalance {
+			t.Fatalf("event %d: got %+v, want %+v", i, got, want)
+		}
+	}
+	if seq := mustAppend(t, s, credited(a, 3)); seq != 4 {
+		t.Fatalf("expected the reopened store to continue at sequence 4, got %d", seq)
+	}
+}
+
+func TestFileStoreTruncatesTornTail(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a := uuid.New()
+
+	s := openTestFileStore(t, dir, FileStoreOptions{})
+	for v := 1; v <= 3; v++ {
+		mustAppend(t, s, credited(a, v))
+	}
+	s.Close()
+
+	// Cut the last record short, as a crash in the middle of a write would.
+	paths := segmentFiles(t, dir)
+	last := paths[len(paths)-1]
+	info, err := os.Stat(last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(last, info.Size()-5); err != nil {
+		t.Fatal(err)
+	}
+
+	// A reader of the log takes the torn record for a write in progress.
+	var scanned int
+	if err := (FileLog{Dir: dir}).ScanRaw(ctx, 0, func(RawEvent) error { scanned++; return nil }); err != nil || scanned != 2 {
+		t.Fatalf("expected FileLog to read the 2 intact events, got %d (%v)", scanned, err)
+	}
+
+	s = openTestFileStore(t, dir, FileStoreOptions{})
+	loaded, err := s.Load(ctx, 0, 0)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("expected the torn record to be dropped, got %d events", len(loaded))
+	}
+	// The torn version is free again, and the log carries on after the
+	// truncated tail.
+	if seq := mustAppend(t, s, credited(a, 3)); seq != 3 {
+		t.Fatalf("expected sequence 3 after truncation, got %d", seq)
+	}
+	s.Close()
+
+	s = openTestFileStore(t, dir, FileStoreOptions{})
+	if loaded, err = s.Load(ctx, 0, 0); err != nil || len(loaded) != 3 {
+		t.Fatalf("expected 3 events after reopening, got %d (%v)", len(loaded), err)
+	}
+}
+
+func TestFileStoreCorruptSealedSegment(t *testing.T) {
+	dir := t.TempDir()
+	a := uuid.New()
+
+	s := openTestFileStore(t, dir, FileStoreOptions{SegmentSize: 512})
+	for v := 1; v <= 10; v++ {
+		mustAppend(t, s, credited(a, v))
+	}
+	s.Close()
+
+	paths := segmentFiles(t, dir)
+	if len(paths) < 2 {
+		t.Fatalf("expected several segments, got %d", len(paths))
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[frameHeaderSize+bodyFixedSize+10] ^= 0xff
+	if err := os.WriteFile(paths[0], data, 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenFileStore(dir, FileStoreOptions{Codec: NewJSONCodec(&AccountCredited{})}); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("expected ErrCorrupt for a damaged sealed segment, got %v", err)
+	}
+	// The damaged segment must be left as it was, not truncated.
+	if info, err := os.Stat(paths[0]); err != nil || info.Size() != int64(len(data)) {
+		t.Fatalf("expected the sealed segment to be left intact, got %v (%v)", info.Size(), err)
+	}
+}
+
+func TestFileStoreCorruptLastSegment(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a := uuid.New()
+
+	s := openTestFileStore(t, dir, FileStoreOptions{})
+	for v := 1; v <= 3; v++ {
+		mustAppend(t, s, credited(a, v))
+	}
+	s.Close()
+
+	// Damage the second of three records, as bit rot would: the records after
+	// it were acknowledged and must not be dropped.
+	paths := segmentFiles(t, dir)
+	if len(paths) != 1 {
+		t.Fatalf("expected a single segment, got %d", len(paths))
+	}
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	second := frameHeaderSize + int(binary.BigEndian.Uint32(data))
+	data[second+frameHeaderSize+bodyFixedSize+10] ^= 0xff
+	if err := os.WriteFile(paths[0], data, 0o640); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := OpenFileStore(dir, FileStoreOptions{Codec: NewJSONCodec(&AccountCredited{})}); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("expected ErrCorrupt for a damaged record in the last segment, got %v", err)
+	}
+	if info, err := os.Stat(paths[0]); err != nil || info.Size() != int64(len(data)) {
+		t.Fatalf("expected the segment to be left intact, got %v (%v)", info.Size(), err)
+	}
+	if err := (FileLog{Dir: dir}).ScanRaw(ctx, 0, func(RawEvent) error { return nil }); !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("expected FileLog to report ErrCorrupt, got %v", err)
+	}
+}
+
+func TestFileStoreConflict(t *testing.T) {
+	ctx := context.Background()
+	a := uuid.New()
+	s := openTestFileStore(t, t.TempDir(), FileStoreOptions{})
+
+	mustAppend(t, s, credited(a, 1))
+	for _, v := range []int{1, 3} {
+		if _, err := s.Append(ctx, credited(a, v)); !errors.Is(err, ErrConflict) {
+			t.Fatalf("expected ErrConflict appending version %d, got %v", v, err)
+		}
+	}
+	if _, err := s.Append(ctx, credited(a, 0)); err == nil || errors.Is(err, ErrConflict) {
+		t.Fatalf("expected version 0 to be rejected, got %v", err)
+	}
+	// A conflict stores nothing, and does not consume a sequence number.
+	if seq := mustAppend(t, s, credited(a, 2)); seq != 2 {
+		t.Fatalf("expected sequence 2, got %d", seq)
+	}
+}
+
+func TestFileStoreLoadAcrossSegments(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a := uuid.New()
+	opts := FileStoreOptions{SegmentSize: 1024, IndexInterval: 256, MaxLoad: 8}
+
+	s := openTestFileStore(t, dir, opts)
+	for v := 1; v <= 20; v++ {
+		mustAppend(t, s, credited(a, v))
+	}
+	if n := len(segmentFiles(t, dir)); n < 3 {
+		t.Fatalf("expected the log to span several segments, got %d", n)
+	}
+
+	check := func(s *FileStore) {
+		t.Helper()
+		loaded, err := s.LoadSequenced(ctx, 5, 7)
+		if err != nil {
+			t.Fatalf("LoadSequenced: %v", err)
+		}
+		if len(loaded) != 7 {
+			t.Fatalf("expected 7 events, got %d", len(loaded))
+		}
+		for i, e := range loaded {
+			if want := Sequence(6 + i); e.Sequence != want || e.Event.Header().Version != int(want) {
+				t.Fatalf("event %d: sequence %d version %d, want %d", i, e.Sequence, e.Event.Header().Version, want)
+			}
+		}
+		// A limit of 0, or one above MaxLoad, is capped at MaxLoad.
+		for _, limit := range []uint64{0, 100} {
+			if loaded, err := s.Load(ctx, 0, limit); err != nil || len(loaded) != 8 {
+				t.Fatalf("Load with limit %d: got %d events (%v), want 8", limit, len(loaded), err)
+			}
+		}
+		if loaded, err := s.Load(ctx, 18, 0); err != nil || len(loaded) != 2 || loaded[1].Header().Version != 20 {
+			t.Fatalf("expected the last 2 events, got %d (%v)", len(loaded), err)
+		}
+		if loaded, err := s.Load(ctx, 20, 0); err != nil || len(loaded) != 0 {
+			t.Fatalf("expected no events after the end of the log, got %d (%v)", len(loaded), err)
+		}
+	}
+	check(s)
+	s.Close()
+	check(openTestFileStore(t, dir, opts))
+}
+
+func TestFileStoreLoadByAggregate(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	a, b := uuid.New(), uuid.New()
+	opts := FileStoreOptions{SegmentSize: 1024}
+
+	s := openTestFileStore(t, dir, opts)
+	for v := 1; v <= 4; v++ {
+		mustAppend(t, s, credited(a, v))
+		mustAppend(t, s, credited(b, v))
+	}
+
+	check := func(s *FileStore) {
+		t.Helper()
+		for _, tt := range []struct {
+			id    uuid.UUID
+			after Sequence
+			want  []int
+		}{
+			{a, 0, []int{1, 2, 3, 4}},
+			{b, 2, []int{3, 4}},
+			{a, 4, nil},
+			{uuid.New(), 0, nil},
+		} {
+			loaded, err := s.LoadByAggregate(ctx, tt.id.String(), tt.after)
+			if err != nil {
+				t.Fatalf("LoadByAggregate: %v", err)
+			}
+			if len(loaded) != len(tt.want) {
+				t.Fatalf("LoadByAggregate after %d: got %d events, want %d", tt.after, len(loaded), len(tt.want))
+			}
+			for i, e := range loaded {
+				if e.Header().AggregateID != tt.id || e.Header().Version != tt.want[i] {
+					t.Fatalf("event %d: aggregate %s version %d, want %s version %d",
+						i, e.Header().AggregateID, e.Header().Version, tt.id, tt.want[i])
+				}
+			}
+		}
+	}
+	check(s)
+	s.Close()
+	check(openTestFileStore(t, dir, opts))
+}
@@ -1,4 +1,3 @@
-```go
 // Copyright (c) 2024. The Bridge Project. All rights reserved.
 // Use of this source code is governed by a MIT-style
 // license that can be found in the LICENSE file.
@@ -14,130 +13,106 @@ import (
 // MemoryStore is an in-memory implementation of the Store interface.
 // It is designed for fast and isolated unit/integration tests, embodying the
 // principle of making the system 'fast to builders'. It is safe for concurrent use.
+//
+// Like FileStore, it assigns dense sequence numbers starting at 1 and checks
+// aggregate versions optimistically.
 type MemoryStore struct {
 	mu     sync.RWMutex
-	events map[ID]Event // Primary store: event ID -> event
+	events []Event // The global log: the event with Sequence n is at index n-1.
 
-	// Indexes for efficient lookups
-	byStream    map[string][]Event // stream ID -> sorted list of events by version
-	byAggregate map[string][]Event // aggregate key -> sorted list of events by version
+	// byAggregate indexes the log by aggregate ID. Versions start at 1 and have
+	// no gaps, so version v of an aggregate is at index v-1.
+	byAggregate map[string][]Event
 }
 
 // NewMemoryStore creates and initializes a new MemoryStore.
 func NewMemoryStore() *MemoryStore {
 	return &MemoryStore{
-		events:      make(map[ID]Event),
-		byStream:    make(map[string][]Event),
 		byAggregate: make(map[string][]Event),
 	}
 }
 
-// A compile-time check to ensure MemoryStore implements the Store interface.
-var _ Store = (*MemoryStore)(nil)
+// Compile-time checks to ensure MemoryStore implements the Store and
+// SequencedLoader interfaces.
+var (
+	_ Store           = (*MemoryStore)(nil)
+	_ SequencedLoader = (*MemoryStore)(nil)
+)
+
+// Append adds a new event to the store and returns its sequence number.
+// The event's version must be exactly one greater than the latest version
+// stored for its aggregate, which prevents concurrent writers from silently
+// overwriting each other's state transitions; otherwise Append returns an
+// error wrapping ErrConflict.
+func (s *MemoryStore) Append(ctx context.Context, event Event) (Sequence, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	h := event.Header()
+	key := h.AggregateID.String()
 
-// Append adds a new event to the store.
-// It enforces stream version consistency to prevent race conditions and ensure ordering,
-// which is critical for deterministic state transitions.
-// If an event with the same ID already exists, it returns ErrDuplicateEvent.
-// If the event's stream version is not exactly one greater than the latest
-// version in the stream, it returns ErrVersionConflict.
-func (s *MemoryStore) Append(ctx context.Context, event Event) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Check for duplicate event ID
-	if _, exists := s.events[event.ID]; exists {
-		return fmt.Errorf("%w: id %s", ErrDuplicateEvent, event.ID)
+	stream := s.byAggregate[key]
+	if h.Version != len(stream)+1 {
+		return 0, fmt.Errorf("%w: aggregate %s is at version %d, event %s has version %d",
+			ErrConflict, key, len(stream), h.EventID, h.Version)
 	}
+	s.events = append(s.events, event)
+	s.byAggregate[key] = append(stream, event)
+	return Sequence(len(s.events)), nil
+}
 
-	// Check for stream version conflict
-	streamEvents := s.byStream[event.StreamID]
-	currentVersion := uint64(0)
-	if len(streamEvents) > 0 {
-		currentVersion = streamEvents[len(streamEvents)-1].StreamVersion
+// Load returns up to limit events after the given sequence, in sequence
+// order. A limit of 0 returns every remaining event.
+func (s *MemoryStore) Load(ctx context.Context, after Sequence, limit uint64) ([]Event, error) {
+	seqd, err := s.LoadSequenced(ctx, after, limit)
+	if err != nil {
+		return nil, err
 	}
-
-	if event.StreamVersion != currentVersion+1 {
-		return fmt.Errorf("%w: expected version %d, got %d for stream %s",
-			ErrVersionConflict, currentVersion+1, event.StreamVersion, event.StreamID)
+	out := make([]Event, len(seqd))
+	for i, e := range seqd {
+		out[i] = e.Event
 	}
-
-	// Add to primary store
-	s.events[event.ID] = event
-
-	// Add to stream index
-	s.byStream[event.StreamID] = append(s.byStream[event.StreamID], event)
-
-	// Add to aggregate index
-	aggregateKey := s.getAggregateKey(event.AggregateType, event.AggregateID)
-	s.byAggregate[aggregateKey] = append(s.byAggregate[aggregateKey], event)
-
-	return nil
+	return out, nil
 }
 
-// Get retrieves a single event by its unique ID.
-// If no event is found, it returns ErrEventNotFound.
-func (s *MemoryStore) Get(ctx context.Context, id ID) (Event, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-
-	event, ok := s.events[id]
-	if !ok {
-		return Event{}, fmt.Errorf("%w: id %s", ErrEventNotFound, id)
+// LoadSequenced is like Load, but also returns the Sequence of each event.
+func (s *MemoryStore) LoadSequenced(ctx context.Context, after Sequence, limit uint64) ([]SequencedEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	return event, nil
-}
-
-// GetByStream retrieves all events for a given stream ID starting after a specific version.
-// The returned events are ordered by their stream version.
-// If fromVersion is 0, it retrieves all events for the stream.
-// If the stream does not exist, it returns a nil slice and no error.
-func (s *MemoryStore) GetByStream(ctx context.Context, streamID string, fromVersion uint64) ([]Event, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	streamEvents, ok := s.byStream[streamID]
-	if !ok {
+	if after >= Sequence(len(s.events)) {
 		return nil, nil
 	}
-
-	// A linear scan is simple and sufficient for a test implementation.
-	// For very long event streams in a production store, a more performant
-	// lookup (e.g., binary search or a database query) would be used.
-	var results []Event
-	for _, event := range streamEvents {
-		if event.StreamVersion > fromVersion {
-			results = append(results, event)
-		}
+	end := Sequence(len(s.events))
+	if limit > 0 && uint64(end-after) > limit {
+		end = after + Sequence(limit)
 	}
-
-	return results, nil
+	out := make([]SequencedEvent, 0, end-after)
+	for seq := after + 1; seq <= end; seq++ {
+		out = append(out, SequencedEvent{Sequence: seq, Event: s.events[seq-1]})
+	}
+	return out, nil
 }
 
-// GetByAggregate retrieves all events for a given aggregate type and ID.
-// The returned events are ordered by their stream version.
-// If the aggregate does not exist, it returns a nil slice and no error.
-// It returns a copy of the event slice to prevent mutation of the store's internal state.
-func (s *MemoryStore) GetByAggregate(ctx context.Context, aggregateType string, aggregateID string) ([]Event, error) {
+// LoadByAggregate returns the events of the aggregate after the given
+// version, in version order. An unknown aggregate has no events.
+func (s *MemoryStore) LoadByAggregate(ctx context.Context, aggregateID string, after Sequence) ([]Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	aggregateKey := s.getAggregateKey(aggregateType, aggregateID)
-	aggregateEvents, ok := s.byAggregate[aggregateKey]
-	if !ok {
+	stream := s.byAggregate[aggregateID]
+	if after >= Sequence(len(stream)) {
 		return nil, nil
 	}
-
-	// Create a copy to prevent external modification of the internal slice
-	result := make([]Event, len(aggregateEvents))
-	copy(result, aggregateEvents)
-
-	return result, nil
+	// Return a copy to prevent external modification of the internal slice.
+	return append([]Event(nil), stream[after:]...), nil
 }
-
-// getAggregateKey generates a consistent key for the aggregate index map.
-func (s *MemoryStore) getAggregateKey(aggregateType, aggregateID string) string {
-	return fmt.Sprintf("%s:%s", aggregateType, aggregateID)
-}
-
-```
\ No newline at end of file
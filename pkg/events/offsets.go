@@ -0,0 +1,140 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OffsetStore persists the position of each consumer group in the global
+// log: the Sequence of the last event the group has fully processed. A
+// subscription resumes after its stored offset, so an offset must only be
+// saved once every event up to it has been handled.
+type OffsetStore interface {
+	// LoadOffset returns the stored offset of the group, or 0 if it has none,
+	// which starts the group from the beginning of the log.
+	LoadOffset(ctx context.Context, group SubscriberID) (Sequence, error)
+
+	// SaveOffset durably stores the offset of the group.
+	SaveOffset(ctx context.Context, group SubscriberID, offset Sequence) error
+}
+
+// MemoryOffsetStore is an in-memory OffsetStore for tests. It is safe for
+// concurrent use.
+type MemoryOffsetStore struct {
+	mu      sync.RWMutex
+	offsets map[SubscriberID]Sequence
+}
+
+// NewMemoryOffsetStore creates an empty MemoryOffsetStore.
+func NewMemoryOffsetStore() *MemoryOffsetStore {
+	return &MemoryOffsetStore{offsets: make(map[SubscriberID]Sequence)}
+}
+
+// LoadOffset returns the offset of the group, or 0 if it has none.
+func (m *MemoryOffsetStore) LoadOffset(ctx context.Context, group SubscriberID) (Sequence, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.offsets[group], nil
+}
+
+// SaveOffset stores the offset of the group.
+func (m *MemoryOffsetStore) SaveOffset(ctx context.Context, group SubscriberID, offset Sequence) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.offsets[group] = offset
+	return nil
+}
+
+// FileOffsetStore is an OffsetStore that keeps each group's offset in its own
+// file in a directory. Writes use the write, sync and rename pattern, so an
+// offset file always holds either the old or the new offset, never a partial
+// write. It is safe for concurrent use.
+type FileOffsetStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileOffsetStore creates a FileOffsetStore in dir, creating the directory
+// if needed.
+func NewFileOffsetStore(dir string) (*FileOffsetStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("offset directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create offset directory %s: %w", dir, err)
+	}
+	return &FileOffsetStore{dir: dir}, nil
+}
+
+// path returns the offset file of the group. Group names are escaped so any
+// SubscriberID maps to a single file inside the directory.
+func (f *FileOffsetStore) path(group SubscriberID) string {
+	return filepath.Join(f.dir, url.PathEscape(string(group))+".offset")
+}
+
+// LoadOffset reads the offset of the group. A missing file means the group
+// has no offset. A malformed file is an error rather than a fresh start, as
+// guessing would either skip or replay events.
+func (f *FileOffsetStore) LoadOffset(ctx context.Context, group SubscriberID) (Sequence, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := f.path(group)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read offset file %s: %w", path, err)
+	}
+	offset, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("offset file %s is corrupted: %w", path, err)
+	}
+	return Sequence(offset), nil
+}
+
+// SaveOffset atomically replaces the offset file of the group.
+func (f *FileOffsetStore) SaveOffset(ctx context.Context, group SubscriberID, offset Sequence) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return writeFileAtomic(f.dir, f.path(group), []byte(strconv.FormatUint(uint64(offset), 10)))
+}
+
+// writeFileAtomic replaces the file at path, in directory dir, with data. It
+// writes a temporary file in the same directory, syncs it and renames it over
+// path, so the file always holds either its old or its new content, never a
+// partial write.
+func writeFileAtomic(dir, path string, data []byte) error {
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file for %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write temporary file %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync temporary file %s: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file %s: %w", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to rename %s to %s: %w", tmp.Name(), path, err)
+	}
+	return nil
+}
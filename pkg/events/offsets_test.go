@@ -0,0 +1,72 @@
+package events
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileOffsetStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	offsets, err := NewFileOffsetStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileOffsetStore: %v", err)
+	}
+
+	if offset, err := offsets.LoadOffset(ctx, "risk-engine"); err != nil || offset != 0 {
+		t.Fatalf("expected a group without a file to start at 0, got %d (%v)", offset, err)
+	}
+	groups := map[SubscriberID]Sequence{"risk-engine": 42, "../escape/attempt": 7}
+	for group, offset := range groups {
+		if err := offsets.SaveOffset(ctx, group, offset); err != nil {
+			t.Fatalf("SaveOffset %s: %v", group, err)
+		}
+	}
+	if err := offsets.SaveOffset(ctx, "risk-engine", 43); err != nil {
+		t.Fatalf("SaveOffset: %v", err)
+	}
+	groups["risk-engine"] = 43
+
+	reopened, err := NewFileOffsetStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileOffsetStore: %v", err)
+	}
+	for group, want := range groups {
+		if got, err := reopened.LoadOffset(ctx, group); err != nil || got != want {
+			t.Fatalf("LoadOffset %s: got %d (%v), want %d", group, got, err, want)
+		}
+	}
+
+	// Every group has one file inside the directory, and no temporary files
+	// are left behind.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(groups) {
+		t.Fatalf("expected %d offset files, got %d", len(groups), len(entries))
+	}
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".offset") {
+			t.Fatalf("unexpected file %s in the offset directory", e.Name())
+		}
+	}
+}
+
+func TestFileOffsetStoreCorruptFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	offsets, err := NewFileOffsetStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileOffsetStore: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "risk-engine.offset"), []byte("12x"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := offsets.LoadOffset(ctx, "risk-engine"); err == nil || !strings.Contains(err.Error(), "corrupted") {
+		t.Fatalf("expected a corrupted offset file to be an error, got %v", err)
+	}
+}
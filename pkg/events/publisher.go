@@ -1,4 +1,3 @@
-```go
 // Copyright (c) 2024. The Bridge Project. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
@@ -15,15 +14,15 @@ import (
 // without direct dependencies.
 //
 // The design of the Publisher is critical for achieving the system's goals:
-// - **Speed:** Implementations can be optimized for low-latency, in-memory
-//   dispatch for co-located services, or high-throughput message brokers for
-//   distributed systems.
-// - **Stability:** A robust implementation (e.g., backed by a persistent queue)
-//   ensures that events are not lost during service outages, supporting
-//   at-least-once delivery semantics crucial for financial transactions.
-// - **Auditability:** Every event published can be logged and stored, creating
-//   an immutable, replayable history of system state changes. This is a
-//   cornerstone for regulatory compliance and incident analysis.
+//   - **Speed:** Implementations can be optimized for low-latency, in-memory
+//     dispatch for co-located services, or high-throughput message brokers for
+//     distributed systems.
+//   - **Stability:** A robust implementation (e.g., backed by a persistent queue)
+//     ensures that events are not lost during service outages, supporting
+//     at-least-once delivery semantics crucial for financial transactions.
+//   - **Auditability:** Every event published can be logged and stored, creating
+//     an immutable, replayable history of system state changes. This is a
+//     cornerstone for regulatory compliance and incident analysis.
 //
 // Implementations might range from a simple in-memory fan-out dispatcher to
 // a sophisticated client for a distributed messaging system like Kafka, NATS,
@@ -54,5 +53,3 @@ type Publisher interface {
 	// preventing the application from hanging indefinitely.
 	Shutdown(ctx context.Context) error
 }
-
-```
\ No newline at end of file
@@ -0,0 +1,329 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// Registry records the schema of every event type: its name, its current
+// schema version and the Go struct that holds it. It is a Codec that stores
+// each event in a versioned envelope:
+//
+//	{"type": "payment.initiated", "schemaVersion": 2, "payload": {...}}
+//
+// When an event struct changes incompatibly, its schema version is bumped and
+// an Upcaster is registered to migrate payloads of the previous version. On
+// load, the payload of an old event is passed through the chain of upcasters,
+// from its stored version to the current one, before it is decoded into the
+// current struct. Replaying the log therefore always yields current events,
+// however old they are.
+//
+// Payloads stored without an envelope, such as those written by JSONCodec,
+// are read as schema version 1.
+//
+// Registration happens at startup; a Registry is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	schemas map[string]*schema
+}
+
+// schema is the registered schema of one event type.
+type schema struct {
+	version int
+	typ     reflect.Type
+	// upcasters[v] migrates a payload from version v to v+1.
+	upcasters map[int]Upcaster
+}
+
+// Upcaster migrates the payload of an event from one schema version to the
+// next, for example by renaming a field or filling a new one with a default.
+// The payload is a decoded JSON object whose numbers are json.Number, so
+// integer amounts keep their precision. An upcaster may modify the map it is
+// given and return it.
+type Upcaster func(payload map[string]interface{}) (map[string]interface{}, error)
+
+// envelope is the stored form of an event.
+type envelope struct {
+	Type          string          `json:"type"`
+	SchemaVersion int             `json:"schemaVersion"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+// Errors returned when a stored event cannot be brought up to date.
+var (
+	// ErrNoUpcaster indicates that an event is stored at a schema version for
+	// which no upcaster to the next version is registered.
+	ErrNoUpcaster = errors.New("events: no upcaster")
+
+	// ErrFutureSchema indicates that an event is stored at a schema version
+	// newer than the registered one, typically because it was written by a
+	// newer release. Decoding it into the older struct would lose data.
+	ErrFutureSchema = errors.New("events: schema version newer than registered")
+)
+
+// A compile-time check to ensure Registry implements the Codec interface.
+var _ Codec = (*Registry)(nil)
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{schemas: make(map[string]*schema)}
+}
+
+// Register records the current schema version of the event type of
+// prototype, which must be a non-nil pointer to the event struct:
+//
+//	registry.Register(&PaymentInitiated{}, 1)
+//
+// Versions start at 1. It panics on a bad prototype, a version below 1 or a
+// type registered twice, as these are programming errors.
+func (r *Registry) Register(prototype Event, version int) {
+	t := reflect.TypeOf(prototype)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct || reflect.ValueOf(prototype).IsNil() {
+		panic(fmt.Sprintf("events: prototype %T must be a non-nil pointer to a struct", prototype))
+	}
+	if version < 1 {
+		panic(fmt.Sprintf("events: schema version of %s must be at least 1, got %d", prototype.EventType(), version))
+	}
+	name := prototype.EventType()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.schemas[name]
+	if ok && s.typ != nil {
+		panic(fmt.Sprintf("events: event type %s is already registered", name))
+	}
+	if !ok {
+		s = &schema{upcasters: make(map[int]Upcaster)}
+		r.schemas[name] = s
+	}
+	s.version = version
+	s.typ = t.Elem()
+}
+
+// RegisterUpcaster records the upcaster that migrates payloads of eventType
+// from schema version from to version from+1. It panics if one is already
+// registered for that step.
+func (r *Registry) RegisterUpcaster(eventType string, from int, up Upcaster) {
+	if from < 1 || up == nil {
+		panic(fmt.Sprintf("events: invalid upcaster for %s from version %d", eventType, from))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.schemas[eventType]
+	if !ok {
+		s = &schema{upcasters: make(map[int]Upcaster)}
+		r.schemas[eventType] = s
+	}
+	if _, dup := s.upcasters[from]; dup {
+		panic(fmt.Sprintf("events: upcaster for %s from version %d is already registered", eventType, from))
+	}
+	s.upcasters[from] = up
+}
+
+// Version returns the current schema version of eventType, and whether the
+// type is registered.
+func (r *Registry) Version(eventType string) (int, bool) {
+	s, ok := r.lookup(eventType)
+	if !ok {
+		return 0, false
+	}
+	return s.version, true
+}
+
+// Validate checks that every registered event type has an upcaster for each
+// step from version 1 to its current version, and that no upcaster belongs to
+// an unregistered type. Calling it at startup turns a forgotten migration
+// into a failed deploy rather than a failed replay.
+func (r *Registry) Validate() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.schemas))
+	for name := range r.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	for _, name := range names {
+		s := r.schemas[name]
+		if s.typ == nil {
+			errs = append(errs, fmt.Errorf("%w: upcasters registered for %s, but not the event type", ErrUnknownEventType, name))
+			continue
+		}
+		for v := 1; v < s.version; v++ {
+			if _, ok := s.upcasters[v]; !ok {
+				errs = append(errs, fmt.Errorf("%w: %s from version %d to %d", ErrNoUpcaster, name, v, v+1))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Registry) lookup(eventType string) (*schema, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.schemas[eventType]
+	if !ok || s.typ == nil {
+		return nil, false
+	}
+	return s, true
+}
+
+// Encode returns the type name of event and its versioned envelope. The event
+// type must be registered, so that nothing is ever stored without a version.
+func (r *Registry) Encode(event Event) (string, []byte, error) {
+	name := event.EventType()
+	s, ok := r.lookup(name)
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %q", ErrUnknownEventType, name)
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return "", nil, fmt.Errorf("events: encoding %s: %w", name, err)
+	}
+	data, err := json.Marshal(envelope{Type: name, SchemaVersion: s.version, Payload: payload})
+	if err != nil {
+		return "", nil, fmt.Errorf("events: encoding %s: %w", name, err)
+	}
+	return name, data, nil
+}
+
+// Decode reads a stored event, upcasting its payload to the current schema
+// version of its type before decoding it.
+func (r *Registry) Decode(eventType string, data []byte) (Event, error) {
+	return r.decode(eventType, data, false)
+}
+
+func (r *Registry) decode(eventType string, data []byte, strict bool) (Event, error) {
+	s, ok := r.lookup(eventType)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+	}
+	version, payload, err := r.Upcast(eventType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	event := reflect.New(s.typ).Interface().(Event)
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(event); err != nil {
+		return nil, fmt.Errorf("events: decoding %s at schema version %d: %w", eventType, version, err)
+	}
+	return event, nil
+}
+
+// Upcast returns the payload of a stored event migrated to the current schema
+// version of its type, along with the version it was stored at. A payload that
+// is already current is returned as stored.
+func (r *Registry) Upcast(eventType string, data []byte) (storedVersion int, payload []byte, err error) {
+	s, ok := r.lookup(eventType)
+	if !ok {
+		return 0, nil, fmt.Errorf("%w: %q", ErrUnknownEventType, eventType)
+	}
+	storedVersion, payload = 1, data
+	var env envelope
+	if json.Unmarshal(data, &env) == nil && env.SchemaVersion > 0 && len(env.Payload) > 0 {
+		storedVersion, payload = env.SchemaVersion, env.Payload
+	}
+	if storedVersion > s.version {
+		return storedVersion, nil, fmt.Errorf("%w: %s is stored at version %d, registered at %d",
+			ErrFutureSchema, eventType, storedVersion, s.version)
+	}
+	if storedVersion == s.version {
+		return storedVersion, payload, nil
+	}
+
+	var fields map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	if err := dec.Decode(&fields); err != nil {
+		return storedVersion, nil, fmt.Errorf("events: decoding %s at schema version %d: %w", eventType, storedVersion, err)
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for v := storedVersion; v < s.version; v++ {
+		up, ok := s.upcasters[v]
+		if !ok {
+			return storedVersion, nil, fmt.Errorf("%w: %s from version %d to %d", ErrNoUpcaster, eventType, v, v+1)
+		}
+		if fields, err = up(fields); err != nil {
+			return storedVersion, nil, fmt.Errorf("events: upcasting %s from version %d to %d: %w", eventType, v, v+1, err)
+		}
+	}
+	if payload, err = json.Marshal(fields); err != nil {
+		return storedVersion, nil, fmt.Errorf("events: encoding upcast %s: %w", eventType, err)
+	}
+	return storedVersion, payload, nil
+}
+
+// VerifyReport is the result of Registry.Verify.
+type VerifyReport struct {
+	// Checked is the number of stored events examined.
+	Checked int
+	// Versions counts the events examined by type name and stored schema
+	// version.
+	Versions map[string]map[int]int
+	// Failures lists every event that could not be brought up to date.
+	Failures []VerifyFailure
+}
+
+// VerifyFailure is a stored event that failed verification.
+type VerifyFailure struct {
+	Sequence Sequence
+	Type     string
+	// Version is the schema version the event is stored at, if known.
+	Version int
+	Err     error
+}
+
+// OK reports whether every event examined passed verification.
+func (r *VerifyReport) OK() bool {
+	return len(r.Failures) == 0
+}
+
+// Verify reads every event in src after the given sequence and checks that
+// it can be upcast to the current schema version of its type and decoded
+// into the current struct. Decoding is strict: a payload with a field the
+// current struct does not have fails, as that field would be silently lost on
+// replay, which usually means an upcaster is missing a rename.
+//
+// Verify examines the whole log rather than stopping at the first failure. It
+// returns an error only if src cannot be read; failing events are listed in
+// the report.
+func (r *Registry) Verify(ctx context.Context, src RawScanner, after Sequence) (*VerifyReport, error) {
+	report := &VerifyReport{Versions: make(map[string]map[int]int)}
+	err := src.ScanRaw(ctx, after, func(e RawEvent) error {
+		report.Checked++
+		version, _, err := r.Upcast(e.Type, e.Data)
+		if err == nil {
+			_, err = r.decode(e.Type, e.Data, true)
+		}
+		if version > 0 {
+			if report.Versions[e.Type] == nil {
+				report.Versions[e.Type] = make(map[int]int)
+			}
+			report.Versions[e.Type][version]++
+		}
+		if err != nil {
+			report.Failures = append(report.Failures, VerifyFailure{Sequence: e.Sequence, Type: e.Type, Version: version, Err: err})
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+	return report, nil
+}
@@ -0,0 +1,228 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// rawLog is a RawScanner over events held in memory.
+type rawLog []RawEvent
+
+func (l rawLog) ScanRaw(ctx context.Context, after Sequence, fn func(RawEvent) error) error {
+	for _, e := range l {
+		if e.Sequence <= after {
+			continue
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func enveloped(t *testing.T, eventType string, version int, payload string) []byte {
+	t.Helper()
+	data, err := json.Marshal(envelope{Type: eventType, SchemaVersion: version, Payload: json.RawMessage(payload)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// creditedRegistry registers AccountCredited at schema version 3, where
+// version 1 named the amount "credit" and version 2 named it "creditAmount".
+func creditedRegistry() (*Registry, map[int]int) {
+	calls := make(map[int]int)
+	rename := func(from, to string) Upcaster {
+		return func(p map[string]interface{}) (map[string]interface{}, error) {
+			p[to] = p[from]
+			delete(p, from)
+			return p, nil
+		}
+	}
+	r := NewRegistry()
+	r.Register(&AccountCredited{}, 3)
+	for v, up := range map[int]Upcaster{1: rename("credit", "creditAmount"), 2: rename("creditAmount", "amount")} {
+		r.RegisterUpcaster(string(AccountCreditedType), v, func(p map[string]interface{}) (map[string]interface{}, error) {
+			calls[v]++
+			return up(p)
+		})
+	}
+	return r, calls
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	r := DefaultRegistry()
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	for _, event := range []Event{
+		&PaymentInitiated{EventHeader: newEventHeader(PaymentInitiatedEvent, uuid.New(), 1), Reference: "INV-1"},
+		NewAccountCredited(uuid.New(), uuid.New(), 2, 500, 1500, "deposit"),
+		NewProposalCreated("alice", "Lower fees", "", time.Now().UTC(), time.Now().UTC(), []ProposedChange{{Target: "system.fees", Parameter: "taker_fee_bps", NewValue: "5"}}),
+		NewLimitBreached("risk-engine", LimitBreachedPayload{TraceID: uuid.New(), LimitType: "ExposureLimit", LimitValue: "100", BreachedValue: "120"}),
+	} {
+		name, data, err := r.Encode(event)
+		if err != nil {
+			t.Fatalf("Encode %s: %v", event.EventType(), err)
+		}
+		decoded, err := r.Decode(name, data)
+		if err != nil {
+			t.Fatalf("Decode %s: %v", name, err)
+		}
+		if decoded.EventType() != event.EventType() || decoded.Header().EventID != event.Header().EventID {
+			t.Fatalf("%s did not round-trip: got %s %s", name, decoded.EventType(), decoded.Header().EventID)
+		}
+	}
+}
+
+func TestRegistryUpcastChain(t *testing.T) {
+	r, calls := creditedRegistry()
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	eventType := string(AccountCreditedType)
+
+	// The amount is above 2^53, so it only survives if the upcasters keep
+	// numbers exact.
+	v1 := enveloped(t, eventType, 1, `{"eventType":"AccountCredited","version":4,"credit":9007199254740993,"reason":"deposit"}`)
+	stored, payload, err := r.Upcast(eventType, v1)
+	if err != nil {
+		t.Fatalf("Upcast: %v", err)
+	}
+	if stored != 1 || calls[1] != 1 || calls[2] != 1 {
+		t.Fatalf("expected version 1 to pass through both upcasters, got stored version %d and calls %v", stored, calls)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil || string(fields["amount"]) != "9007199254740993" || fields["credit"] != nil {
+		t.Fatalf("unexpected upcast payload %s (%v)", payload, err)
+	}
+	event, err := r.Decode(eventType, v1)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got := event.(*AccountCredited); got.Amount != 9007199254740993 || got.Version != 4 || got.Reason != "deposit" {
+		t.Fatalf("unexpected decoded event %+v", got)
+	}
+
+	// A payload stored at version 2 only needs the last step, and a current
+	// one none.
+	for k := range calls {
+		delete(calls, k)
+	}
+	if event, err = r.Decode(eventType, enveloped(t, eventType, 2, `{"creditAmount":7}`)); err != nil || event.(*AccountCredited).Amount != 7 {
+		t.Fatalf("Decode version 2: %+v (%v)", event, err)
+	}
+	if event, err = r.Decode(eventType, enveloped(t, eventType, 3, `{"amount":8}`)); err != nil || event.(*AccountCredited).Amount != 8 {
+		t.Fatalf("Decode version 3: %+v (%v)", event, err)
+	}
+	if calls[1] != 0 || calls[2] != 1 {
+		t.Fatalf("unexpected upcaster calls %v", calls)
+	}
+}
+
+func TestRegistryLegacyPayloads(t *testing.T) {
+	// Payloads written by JSONCodec have no envelope and are read as
+	// version 1.
+	legacy := NewAccountCredited(uuid.New(), uuid.New(), 1, 250, 250, "deposit")
+	eventType, data, err := NewJSONCodec(legacy).Encode(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := NewRegistry()
+	current.Register(&AccountCredited{}, 1)
+	event, err := current.Decode(eventType, data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if got := event.(*AccountCredited); got.EventID != legacy.EventID || got.Amount != 250 {
+		t.Fatalf("unexpected decoded event %+v", got)
+	}
+
+	r, calls := creditedRegistry()
+	old := []byte(`{"eventType":"AccountCredited","version":1,"credit":40}`)
+	if stored, _, err := r.Upcast(eventType, old); err != nil || stored != 1 {
+		t.Fatalf("expected an unenveloped payload to be read as version 1, got %d (%v)", stored, err)
+	}
+	if event, err = r.Decode(eventType, old); err != nil || event.(*AccountCredited).Amount != 40 {
+		t.Fatalf("Decode: %+v (%v)", event, err)
+	}
+	if calls[1] != 2 || calls[2] != 2 {
+		t.Fatalf("expected the legacy payload to be upcast from version 1, got calls %v", calls)
+	}
+}
+
+func TestRegistryUpcastErrors(t *testing.T) {
+	eventType := string(AccountCreditedType)
+	r := NewRegistry()
+	r.Register(&AccountCredited{}, 3)
+	r.RegisterUpcaster(eventType, 2, func(p map[string]interface{}) (map[string]interface{}, error) { return p, nil })
+
+	if err := r.Validate(); !errors.Is(err, ErrNoUpcaster) {
+		t.Fatalf("expected Validate to report the missing upcaster, got %v", err)
+	}
+	if _, err := r.Decode(eventType, enveloped(t, eventType, 1, `{}`)); !errors.Is(err, ErrNoUpcaster) {
+		t.Fatalf("expected ErrNoUpcaster, got %v", err)
+	}
+	if _, err := r.Decode(eventType, enveloped(t, eventType, 2, `{"amount":1}`)); err != nil {
+		t.Fatalf("expected version 2 to upcast, got %v", err)
+	}
+	if stored, _, err := r.Upcast(eventType, enveloped(t, eventType, 4, `{}`)); !errors.Is(err, ErrFutureSchema) || stored != 4 {
+		t.Fatalf("expected ErrFutureSchema for version 4, got %d (%v)", stored, err)
+	}
+	if _, err := r.Decode("AccountRenamed", []byte(`{}`)); !errors.Is(err, ErrUnknownEventType) {
+		t.Fatalf("expected ErrUnknownEventType, got %v", err)
+	}
+	if _, _, err := r.Encode(NewAccountDebited(uuid.New(), uuid.New(), 1, 1, 0, "fee")); !errors.Is(err, ErrUnknownEventType) {
+		t.Fatalf("expected encoding an unregistered type to fail, got %v", err)
+	}
+}
+
+func TestRegistryVerify(t *testing.T) {
+	r, _ := creditedRegistry()
+	eventType := string(AccountCreditedType)
+	log := rawLog{
+		{Sequence: 1, Type: eventType, Data: enveloped(t, eventType, 1, `{"credit":1}`)},
+		{Sequence: 2, Type: eventType, Data: enveloped(t, eventType, 3, `{"amount":2}`)},
+		// Decode ignores the unknown field, but strict verification does
+		// not, as replaying the event would lose it.
+		{Sequence: 3, Type: eventType, Data: enveloped(t, eventType, 3, `{"amount":3,"fee":1}`)},
+		{Sequence: 4, Type: eventType, Data: enveloped(t, eventType, 5, `{"amount":4}`)},
+		{Sequence: 5, Type: "AccountRenamed", Data: []byte(`{}`)},
+		{Sequence: 6, Type: eventType, Data: []byte(`{"credit":6}`)},
+	}
+	if _, err := r.Decode(eventType, log[2].Data); err != nil {
+		t.Fatalf("expected a lenient decode to succeed, got %v", err)
+	}
+
+	report, err := r.Verify(context.Background(), log, 0)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if report.OK() || report.Checked != 6 || len(report.Failures) != 3 {
+		t.Fatalf("unexpected report: checked %d, failures %+v", report.Checked, report.Failures)
+	}
+	for i, want := range []struct {
+		seq     Sequence
+		version int
+		err     error
+	}{{3, 3, nil}, {4, 5, ErrFutureSchema}, {5, 0, ErrUnknownEventType}} {
+		f := report.Failures[i]
+		if f.Sequence != want.seq || f.Version != want.version || (want.err != nil && !errors.Is(f.Err, want.err)) {
+			t.Fatalf("failure %d: got %+v, want sequence %d version %d error %v", i, f, want.seq, want.version, want.err)
+		}
+	}
+	if got := report.Versions[eventType]; got[1] != 2 || got[3] != 2 || got[5] != 1 {
+		t.Fatalf("unexpected version counts %v", report.Versions)
+	}
+
+	if report, err = r.Verify(context.Background(), log, 5); err != nil || !report.OK() || report.Checked != 1 {
+		t.Fatalf("expected only the last event to be checked and pass, got %+v (%v)", report, err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+// This file is the single record of the schema version of every event type
+// and of the upcasters between versions. To change an event struct in a way
+// that old payloads no longer decode into, bump its version below and
+// register an upcaster from the previous version, for example:
+//
+//	r.Register(&PaymentInitiated{}, 2)
+//	r.RegisterUpcaster(PaymentInitiatedEvent, 1, func(p map[string]interface{}) (map[string]interface{}, error) {
+//		p["endToEndReference"] = p["reference"]
+//		delete(p, "reference")
+//		return p, nil
+//	})
+//
+// Then run the verify-events tool against a copy of production data before
+// deploying.
+
+// DefaultRegistry returns a Registry with the current schema of every event
+// type defined in this package: the payment, account, governance and risk
+// events. A new event type must be registered here before it is stored.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	// Payment lifecycle.
+	r.Register(&PaymentInitiated{}, 1)
+	r.Register(&PaymentValidationSucceeded{}, 1)
+	r.Register(&PaymentValidationFailed{}, 1)
+	r.Register(&FundsReservationInitiated{}, 1)
+	r.Register(&FundsReserved{}, 1)
+	r.Register(&FundsReservationFailed{}, 1)
+	r.Register(&CreditTransferInitiated{}, 1)
+	r.Register(&CreditTransferSucceeded{}, 1)
+	r.Register(&CreditTransferFailed{}, 1)
+	r.Register(&FundsReservationReleaseInitiated{}, 1)
+	r.Register(&FundsReservationReleased{}, 1)
+	r.Register(&PaymentSettled{}, 1)
+	r.Register(&PaymentFailed{}, 1)
+
+	// Accounts.
+	r.Register(&AccountCreated{}, 1)
+	r.Register(&AccountCredited{}, 1)
+	r.Register(&AccountDebited{}, 1)
+	r.Register(&AccountFrozen{}, 1)
+	r.Register(&AccountUnfrozen{}, 1)
+	r.Register(&AccountClosed{}, 1)
+
+	// Governance.
+	r.Register(&PolicyUpdated{}, 1)
+	r.Register(&ProposalCreated{}, 1)
+	r.Register(&ProposalVotedOn{}, 1)
+	r.Register(&ProposalEnacted{}, 1)
+	r.Register(&ProposalRejected{}, 1)
+	r.Register(&ParameterChanged{}, 1)
+
+	// Risk.
+	r.Register(&RiskCheckFailed{}, 1)
+	r.Register(&LimitBreached{}, 1)
+
+	return r
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Snapshot is the state of an aggregate as of one of its versions. A
+// snapshot is only a cache of what replaying the aggregate's events up to
+// Version produces; the events remain the source of truth.
+type Snapshot struct {
+	AggregateID string `json:"aggregateId"`
+	// Version is the version of the last event reflected in State.
+	Version int `json:"version"`
+	// SchemaVersion is the version of the format State is encoded in. A
+	// snapshot whose SchemaVersion differs from the current one is stale and
+	// is ignored.
+	SchemaVersion int       `json:"schemaVersion"`
+	TakenAt       time.Time `json:"takenAt"`
+	State         []byte    `json:"state"`
+}
+
+// SnapshotStore persists the latest snapshot of each aggregate.
+type SnapshotStore interface {
+	// LoadSnapshot returns the latest snapshot of the aggregate, or
+	// ErrNotFound if it has none.
+	LoadSnapshot(ctx context.Context, aggregateID string) (*Snapshot, error)
+
+	// SaveSnapshot stores the snapshot, replacing any earlier snapshot of the
+	// same aggregate. A snapshot is never replaced by one of the same schema
+	// version at the same or an older Version, so that a slow writer cannot
+	// undo a newer snapshot; such a save does nothing and returns nil. A
+	// snapshot of another schema version is always replaced.
+	SaveSnapshot(ctx context.Context, snapshot Snapshot) error
+}
+
+// MemorySnapshotStore is an in-memory SnapshotStore for tests. It is safe for
+// concurrent use.
+type MemorySnapshotStore struct {
+	mu        sync.RWMutex
+	snapshots map[string]Snapshot
+}
+
+// NewMemorySnapshotStore creates an empty MemorySnapshotStore.
+func NewMemorySnapshotStore() *MemorySnapshotStore {
+	return &MemorySnapshotStore{snapshots: make(map[string]Snapshot)}
+}
+
+// LoadSnapshot returns a copy of the aggregate's snapshot, or ErrNotFound.
+func (m *MemorySnapshotStore) LoadSnapshot(ctx context.Context, aggregateID string) (*Snapshot, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	snapshot, ok := m.snapshots[aggregateID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	snapshot.State = append([]byte(nil), snapshot.State...)
+	return &snapshot, nil
+}
+
+// SaveSnapshot stores a copy of the snapshot, unless it is not newer than the
+// stored one.
+func (m *MemorySnapshotStore) SaveSnapshot(ctx context.Context, snapshot Snapshot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.snapshots[snapshot.AggregateID]; ok && supersedes(&current, snapshot) {
+		return nil
+	}
+	snapshot.State = append([]byte(nil), snapshot.State...)
+	m.snapshots[snapshot.AggregateID] = snapshot
+	return nil
+}
+
+// FileSnapshotStore is a SnapshotStore that keeps each aggregate's snapshot
+// as JSON in its own file in a directory. Like FileOffsetStore, it writes
+// with writeFileAtomic, so a snapshot file always holds a complete snapshot.
+// It is safe for concurrent use.
+type FileSnapshotStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileSnapshotStore creates a FileSnapshotStore in dir, creating the
+// directory if needed.
+func NewFileSnapshotStore(dir string) (*FileSnapshotStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("snapshot directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory %s: %w", dir, err)
+	}
+	return &FileSnapshotStore{dir: dir}, nil
+}
+
+// path returns the snapshot file of the aggregate. Aggregate IDs are escaped
+// so any ID maps to a single file inside the directory.
+func (f *FileSnapshotStore) path(aggregateID string) string {
+	return filepath.Join(f.dir, url.PathEscape(aggregateID)+".snapshot")
+}
+
+// supersedes reports whether current makes saving snapshot pointless: it is
+// in the same schema version and at least as recent.
+func supersedes(current *Snapshot, snapshot Snapshot) bool {
+	return current.SchemaVersion == snapshot.SchemaVersion && current.Version >= snapshot.Version
+}
+
+// LoadSnapshot reads the aggregate's snapshot file. A missing file is
+// ErrNotFound.
+func (f *FileSnapshotStore) LoadSnapshot(ctx context.Context, aggregateID string) (*Snapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.load(aggregateID)
+}
+
+func (f *FileSnapshotStore) load(aggregateID string) (*Snapshot, error) {
+	path := f.path(aggregateID)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read snapshot file %s: %w", path, err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("snapshot file %s is corrupted: %w", path, err)
+	}
+	if snapshot.AggregateID != aggregateID {
+		return nil, fmt.Errorf("snapshot file %s belongs to aggregate %q", path, snapshot.AggregateID)
+	}
+	return &snapshot, nil
+}
+
+// SaveSnapshot atomically replaces the aggregate's snapshot file, unless the
+// snapshot is not newer than the stored one. An unreadable snapshot file is
+// replaced.
+func (f *FileSnapshotStore) SaveSnapshot(ctx context.Context, snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot of aggregate %s: %w", snapshot.AggregateID, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if current, err := f.load(snapshot.AggregateID); err == nil && supersedes(current, snapshot) {
+		return nil
+	}
+	return writeFileAtomic(f.dir, f.path(snapshot.AggregateID), data)
+}
+
+// A compile-time check to ensure the snapshot stores implement the
+// SnapshotStore interface.
+var (
+	_ SnapshotStore = (*MemorySnapshotStore)(nil)
+	_ SnapshotStore = (*FileSnapshotStore)(nil)
+)
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotStoresKeepNewestSnapshot(t *testing.T) {
+	files, err := NewFileSnapshotStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileSnapshotStore: %v", err)
+	}
+	for name, store := range map[string]SnapshotStore{"memory": NewMemorySnapshotStore(), "file": files} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			save := func(version, schema int, state string) {
+				t.Helper()
+				err := store.SaveSnapshot(ctx, Snapshot{AggregateID: "acc-1", Version: version, SchemaVersion: schema, State: []byte(state)})
+				if err != nil {
+					t.Fatalf("SaveSnapshot: %v", err)
+				}
+			}
+			check := func(version, schema int, state string) {
+				t.Helper()
+				s, err := store.LoadSnapshot(ctx, "acc-1")
+				if err != nil {
+					t.Fatalf("LoadSnapshot: %v", err)
+				}
+				if s.Version != version || s.SchemaVersion != schema || string(s.State) != state {
+					t.Fatalf("got snapshot at version %d schema %d with %q, want version %d schema %d with %q",
+						s.Version, s.SchemaVersion, s.State, version, schema, state)
+				}
+			}
+
+			save(10, 1, "ten")
+			save(5, 1, "five")
+			check(10, 1, "ten")
+			save(10, 1, "ten again")
+			check(10, 1, "ten")
+			save(11, 1, "eleven")
+			check(11, 1, "eleven")
+			// A snapshot in another format is unusable, so it is replaced
+			// whatever its version.
+			save(11, 2, "eleven v2")
+			check(11, 2, "eleven v2")
+			save(3, 1, "three")
+			check(3, 1, "three")
+		})
+	}
+}
+
+func TestFileSnapshotStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := NewFileSnapshotStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileSnapshotStore: %v", err)
+	}
+	if _, err := store.LoadSnapshot(ctx, "acc-1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound without a snapshot, got %v", err)
+	}
+
+	want := Snapshot{
+		AggregateID:   "../tenant/acc-1",
+		Version:       42,
+		SchemaVersion: 3,
+		TakenAt:       time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		State:         []byte{0, 1, 2, 0xff},
+	}
+	if err := store.SaveSnapshot(ctx, want); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	reopened, err := NewFileSnapshotStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileSnapshotStore: %v", err)
+	}
+	got, err := reopened.LoadSnapshot(ctx, want.AggregateID)
+	if err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+	if got.AggregateID != want.AggregateID || got.Version != want.Version || got.SchemaVersion != want.SchemaVersion ||
+		!got.TakenAt.Equal(want.TakenAt) || string(got.State) != string(want.State) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	// The snapshot is one file inside the directory, with no temporary file
+	// left behind.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Name(), ".snapshot") {
+		t.Fatalf("unexpected files in the snapshot directory: %v", entries)
+	}
+
+	if err := os.WriteFile(reopened.path(want.AggregateID), []byte("{"), 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reopened.LoadSnapshot(ctx, want.AggregateID); err == nil || !strings.Contains(err.Error(), "corrupted") {
+		t.Fatalf("expected a corrupted snapshot file to be an error, got %v", err)
+	}
+	// A corrupted file does not block saving a new snapshot.
+	if err := reopened.SaveSnapshot(ctx, want); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+	if _, err := reopened.LoadSnapshot(ctx, want.AggregateID); err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+}
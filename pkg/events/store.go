@@ -1,4 +1,3 @@
-```go
 // Copyright (c) 2024. The Bridge Project Authors. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
@@ -60,6 +59,41 @@ type Store interface {
 	LoadByAggregate(ctx context.Context, aggregateID string, after Sequence) ([]Event, error)
 }
 
+// SequencedEvent is an event together with the Sequence it was assigned in the
+// global log.
+type SequencedEvent struct {
+	Sequence Sequence
+	Event    Event
+}
+
+// SequencedLoader is implemented by stores that can report the Sequence of
+// each event they load. Consumers that track their position in the log, such
+// as subscriptions, need it whenever a store's sequence numbers may have gaps.
+// For a store that does not implement it, the events returned by Load are
+// assumed to have consecutive sequence numbers following 'after'.
+type SequencedLoader interface {
+	// LoadSequenced is like Store.Load, but also returns the Sequence of each
+	// event.
+	LoadSequenced(ctx context.Context, after Sequence, limit uint64) ([]SequencedEvent, error)
+}
+
+// RawEvent is a stored event before it is decoded: its type name and the
+// bytes its store's Codec produced.
+type RawEvent struct {
+	Sequence Sequence
+	Type     string
+	Data     []byte
+}
+
+// RawScanner is implemented by stores that can read their events without
+// decoding them, which tools such as Registry.Verify use to examine events
+// that may no longer decode.
+type RawScanner interface {
+	// ScanRaw calls fn with every event after the given sequence, in sequence
+	// order, stopping at the first error fn returns.
+	ScanRaw(ctx context.Context, after Sequence, fn func(RawEvent) error) error
+}
+
 // Pre-defined errors for store operations.
 // These allow consumers of the Store interface to handle specific failure modes
 // in a standardized way, regardless of the underlying implementation. This promotes
@@ -76,6 +110,3 @@ var (
 	// specific event sequence) does not exist in the store.
 	ErrNotFound = errors.New("events: not found")
 )
-
-
-```
\ No newline at end of file
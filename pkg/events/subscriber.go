@@ -1,4 +1,3 @@
-```go
 // Copyright (c) 2024. The Bridge Project. All rights reserved.
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
@@ -52,6 +51,3 @@ type SubscriberID string
 func (s SubscriberID) String() string {
 	return string(s)
 }
-
-
-```
\ No newline at end of file
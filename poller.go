@@ -0,0 +1,266 @@
+package jocall3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jocall3/go/internal/requestconfig"
+	"github.com/jocall3/go/option"
+)
+
+// Default backoff settings for a [Poller].
+const (
+	DefaultPollerInitialInterval = time.Second
+	DefaultPollerMaxInterval     = 30 * time.Second
+	DefaultPollerMultiplier      = 2.0
+)
+
+// PollerFetchFunc fetches the current state of a long-running operation.
+type PollerFetchFunc[T any] func(ctx context.Context, opts ...option.RequestOption) (*T, error)
+
+// PollerDoneFunc reports whether res, together with the response it was read
+// from, describes a terminal state. A non-nil error means the operation
+// finished unsuccessfully and stops polling.
+type PollerDoneFunc[T any] func(res *T, raw *http.Response) (done bool, err error)
+
+// OperationFailedError is returned by [Poller.PollUntilDone] when an operation
+// reaches a terminal state that indicates failure. The last fetched state is
+// returned alongside it.
+type OperationFailedError struct {
+	// The terminal status reported by the API, such as `failed`.
+	Status string
+	// The explanation provided by the API, if any.
+	Message string
+}
+
+func (e *OperationFailedError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("operation %s: %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("operation %s", e.Status)
+}
+
+// Poller repeatedly fetches a long-running operation until it reaches a
+// terminal state. The interval between fetches grows exponentially from
+// InitialInterval up to MaxInterval, except that a Retry-After or
+// Retry-After-Ms header on a response sets the next interval verbatim.
+//
+// Pollers for the operations in this SDK are created by the service methods
+// that end in `Poller`, such as [AIAdService.GetStatusPoller]; [NewPoller] can
+// wrap any other endpoint. The exported fields may be changed before polling
+// starts. A Poller is not safe for concurrent use.
+type Poller[T any] struct {
+	// The delay before the second fetch. Zero means
+	// [DefaultPollerInitialInterval].
+	InitialInterval time.Duration
+	// The longest delay between two fetches, unless the server asks for more with
+	// Retry-After. Zero means [DefaultPollerMaxInterval].
+	MaxInterval time.Duration
+	// The factor the delay grows by after each fetch. Values below 1 mean
+	// [DefaultPollerMultiplier].
+	Multiplier float64
+	// OnProgress, if set, is called with every state fetched, including the
+	// terminal one.
+	OnProgress func(res *T)
+
+	fetch    PollerFetchFunc[T]
+	isDone   PollerDoneFunc[T]
+	opts     []option.RequestOption
+	result   *T
+	done     bool
+	err      error
+	attempts int
+	interval time.Duration
+}
+
+// NewPoller returns a poller that calls fetch with opts until isDone reports a
+// terminal state.
+func NewPoller[T any](fetch PollerFetchFunc[T], isDone PollerDoneFunc[T], opts ...option.RequestOption) *Poller[T] {
+	return &Poller[T]{fetch: fetch, isDone: isDone, opts: opts}
+}
+
+// Poll fetches the operation once and reports whether it has finished. Once it
+// has, further calls return the terminal state without making a request.
+func (p *Poller[T]) Poll(ctx context.Context) (res *T, done bool, err error) {
+	res, _, done, err = p.poll(ctx)
+	return
+}
+
+func (p *Poller[T]) poll(ctx context.Context) (res *T, raw *http.Response, done bool, err error) {
+	if p.done {
+		return p.result, nil, true, p.err
+	}
+	opts := append([]option.RequestOption{option.WithResponseInto(&raw)}, p.opts...)
+	res, err = p.fetch(ctx, opts...)
+	p.attempts++
+	if err != nil {
+		return nil, raw, false, err
+	}
+	p.result = res
+	done, err = p.isDone(res, raw)
+	if done || err != nil {
+		p.done, p.err = true, err
+	}
+	if p.OnProgress != nil {
+		p.OnProgress(res)
+	}
+	return res, raw, p.done, err
+}
+
+// PollUntilDone polls until the operation reaches a terminal state, a request
+// fails or ctx is done. It returns the last state fetched, together with an
+// [*OperationFailedError] if the operation finished unsuccessfully.
+func (p *Poller[T]) PollUntilDone(ctx context.Context) (*T, error) {
+	for {
+		res, raw, done, err := p.poll(ctx)
+		if done || err != nil {
+			return p.result, err
+		}
+
+		timer := time.NewTimer(p.nextInterval(raw))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Done reports whether the operation has reached a terminal state.
+func (p *Poller[T]) Done() bool {
+	return p.done
+}
+
+// Result returns the most recently fetched state, or nil if nothing has been
+// fetched yet.
+func (p *Poller[T]) Result() *T {
+	return p.result
+}
+
+// Attempts returns the number of fetches made so far.
+func (p *Poller[T]) Attempts() int {
+	return p.attempts
+}
+
+func (p *Poller[T]) nextInterval(raw *http.Response) time.Duration {
+	initial, max, multiplier := p.InitialInterval, p.MaxInterval, p.Multiplier
+	if initial <= 0 {
+		initial = DefaultPollerInitialInterval
+	}
+	if max <= 0 {
+		max = DefaultPollerMaxInterval
+	}
+	if multiplier < 1 {
+		multiplier = DefaultPollerMultiplier
+	}
+
+	if p.interval == 0 {
+		p.interval = initial
+	} else {
+		p.interval = time.Duration(float64(p.interval) * multiplier)
+	}
+	if p.interval > max {
+		p.interval = max
+	}
+	if retryAfter, ok := requestconfig.RetryAfter(raw); ok && retryAfter >= 0 {
+		return retryAfter
+	}
+	return p.interval
+}
+
+// GetStatusPoller returns a poller for [AIAdService.GetStatus] that finishes when
+// the video is `done` or has failed with `error`.
+func (r *AIAdService) GetStatusPoller(operationID string, opts ...option.RequestOption) *Poller[VideoOperationStatus] {
+	return NewPoller(func(ctx context.Context, opts ...option.RequestOption) (*VideoOperationStatus, error) {
+		return r.GetStatus(ctx, operationID, opts...)
+	}, func(res *VideoOperationStatus, raw *http.Response) (bool, error) {
+		switch res.Status {
+		case VideoOperationStatusStatusDone:
+			return true, nil
+		case VideoOperationStatusStatusError:
+			msg := res.ErrorMessage
+			if msg == "" {
+				msg = res.Message
+			}
+			return true, &OperationFailedError{Status: string(res.Status), Message: msg}
+		}
+		return false, nil
+	}, opts...)
+}
+
+// GetPoller returns a poller for [LendingApplicationService.Get] that finishes
+// once underwriting has produced a decision, that is when the application is no
+// longer `submitted` or `underwriting`. Declined and cancelled applications are
+// terminal states, not errors.
+func (r *LendingApplicationService) GetPoller(applicationID string, opts ...option.RequestOption) *Poller[LoanApplicationStatus] {
+	return NewPoller(func(ctx context.Context, opts ...option.RequestOption) (*LoanApplicationStatus, error) {
+		return r.Get(ctx, applicationID, opts...)
+	}, func(res *LoanApplicationStatus, raw *http.Response) (bool, error) {
+		switch res.Status {
+		case LoanApplicationStatusStatusSubmitted, LoanApplicationStatusStatusUnderwriting:
+			return false, nil
+		}
+		return true, nil
+	}, opts...)
+}
+
+// GetReportPoller returns a poller for [CorporateComplianceAuditService.GetReport]
+// that finishes when the audit is `completed` or has `failed`.
+func (r *CorporateComplianceAuditService) GetReportPoller(auditID string, opts ...option.RequestOption) *Poller[CorporateComplianceAuditGetReportResponse] {
+	return NewPoller(func(ctx context.Context, opts ...option.RequestOption) (*CorporateComplianceAuditGetReportResponse, error) {
+		return r.GetReport(ctx, auditID, opts...)
+	}, func(res *CorporateComplianceAuditGetReportResponse, raw *http.Response) (bool, error) {
+		switch res.Status {
+		case CorporateComplianceAuditGetReportResponseStatusCompleted:
+			return true, nil
+		case CorporateComplianceAuditGetReportResponseStatusFailed:
+			return true, &OperationFailedError{Status: string(res.Status)}
+		}
+		return false, nil
+	}, opts...)
+}
+
+// GetStatusPoller returns a poller for [PaymentInternationalService.GetStatus]
+// that finishes when the payment is `completed`, `cancelled` or has `failed`.
+// Payments `held_for_review` keep being polled.
+func (r *PaymentInternationalService) GetStatusPoller(paymentID string, opts ...option.RequestOption) *Poller[InternationalPaymentStatus] {
+	return NewPoller(func(ctx context.Context, opts ...option.RequestOption) (*InternationalPaymentStatus, error) {
+		return r.GetStatus(ctx, paymentID, opts...)
+	}, func(res *InternationalPaymentStatus, raw *http.Response) (bool, error) {
+		switch res.Status {
+		case InternationalPaymentStatusStatusCompleted, InternationalPaymentStatusStatusCancelled:
+			return true, nil
+		case InternationalPaymentStatusStatusFailed:
+			return true, &OperationFailedError{Status: string(res.Status), Message: res.Message}
+		}
+		return false, nil
+	}, opts...)
+}
+
+// GetPoller returns a poller for [AIOracleSimulationService.Get]. While a
+// simulation runs the API answers with `202 Accepted` and a `processing` status;
+// the poller finishes once the full results are returned or the status is
+// `failed`.
+func (r *AIOracleSimulationService) GetPoller(simulationID string, opts ...option.RequestOption) *Poller[AIOracleSimulationGetResponse] {
+	return NewPoller(func(ctx context.Context, opts ...option.RequestOption) (*AIOracleSimulationGetResponse, error) {
+		return r.Get(ctx, simulationID, opts...)
+	}, func(res *AIOracleSimulationGetResponse, raw *http.Response) (bool, error) {
+		var status AIOracleSimulationListResponseDataStatus
+		if field, ok := res.JSON.ExtraFields["status"]; ok {
+			json.Unmarshal([]byte(field.Raw()), &status)
+		}
+		switch {
+		case status == AIOracleSimulationListResponseDataStatusFailed:
+			return true, &OperationFailedError{Status: string(status)}
+		case status == AIOracleSimulationListResponseDataStatusProcessing:
+			return false, nil
+		case raw != nil && raw.StatusCode == http.StatusAccepted:
+			return false, nil
+		}
+		return true, nil
+	}, opts...)
+}
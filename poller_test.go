@@ -0,0 +1,131 @@
+package jocall3_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+type scriptedResponse struct {
+	status int
+	header http.Header
+	body   string
+}
+
+func scriptedClient(t *testing.T, path string, script []scriptedResponse) *jocall3.Client {
+	calls := 0
+	return jocall3.NewClient(
+		option.WithHTTPClient(&http.Client{
+			Transport: &closureTransport{
+				fn: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path != path {
+						t.Fatalf("unexpected request: %s", req.URL)
+					}
+					if calls >= len(script) {
+						t.Fatalf("unexpected extra request %d", calls+1)
+					}
+					step := script[calls]
+					calls++
+					header := http.Header{"Content-Type": []string{"application/json"}}
+					for k, v := range step.header {
+						header[k] = v
+					}
+					return &http.Response{
+						StatusCode: step.status,
+						Header:     header,
+						Body:       io.NopCloser(strings.NewReader(step.body)),
+					}, nil
+				},
+			},
+		}),
+	)
+}
+
+func TestPollerUntilDone(t *testing.T) {
+	client := scriptedClient(t, "/ai/ads/operations/op_1", []scriptedResponse{
+		{status: 200, body: `{"operationId":"op_1","status":"queued","progressPercentage":0,"message":"Queued"}`},
+		{status: 200, header: http.Header{"Retry-After-Ms": []string{"1"}}, body: `{"operationId":"op_1","status":"generating","progressPercentage":40,"message":"Generating"}`},
+		{status: 200, body: `{"operationId":"op_1","status":"done","progressPercentage":100,"message":"Done","videoUri":"https://cdn.example.com/v.mp4"}`},
+	})
+
+	poller := client.AI.Ads.GetStatusPoller("op_1")
+	poller.InitialInterval = time.Millisecond
+	var progress []float64
+	poller.OnProgress = func(res *jocall3.VideoOperationStatus) {
+		progress = append(progress, res.ProgressPercentage)
+	}
+	res, err := poller.PollUntilDone(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.VideoUri != "https://cdn.example.com/v.mp4" || !poller.Done() || poller.Attempts() != 3 {
+		t.Fatalf("unexpected result %+v after %d attempts", res, poller.Attempts())
+	}
+	if len(progress) != 3 || progress[1] != 40 || progress[2] != 100 {
+		t.Fatalf("unexpected progress callbacks: %v", progress)
+	}
+	if again, done, err := poller.Poll(context.Background()); again != res || !done || err != nil {
+		t.Fatalf("expected a finished poller to return its result without a request")
+	}
+}
+
+func TestPollerFailure(t *testing.T) {
+	client := scriptedClient(t, "/payments/international/pay_1/status", []scriptedResponse{
+		{status: 200, body: `{"paymentId":"pay_1","status":"held_for_review","sourceAmount":10,"sourceCurrency":"USD","targetAmount":9,"targetCurrency":"EUR","fxRateApplied":0.9}`},
+		{status: 200, body: `{"paymentId":"pay_1","status":"failed","message":"Beneficiary bank rejected the transfer","sourceAmount":10,"sourceCurrency":"USD","targetAmount":9,"targetCurrency":"EUR","fxRateApplied":0.9}`},
+	})
+
+	poller := client.Payments.International.GetStatusPoller("pay_1")
+	poller.InitialInterval = time.Millisecond
+	res, err := poller.PollUntilDone(context.Background())
+	var failed *jocall3.OperationFailedError
+	if !errors.As(err, &failed) || failed.Status != "failed" || failed.Message != "Beneficiary bank rejected the transfer" {
+		t.Fatalf("expected an OperationFailedError, got %v", err)
+	}
+	if res == nil || res.Status != jocall3.InternationalPaymentStatusStatusFailed {
+		t.Fatalf("expected the failed state to be returned, got %+v", res)
+	}
+}
+
+func TestPollerAcceptedUntilComplete(t *testing.T) {
+	client := scriptedClient(t, "/ai/oracle/simulations/sim_1", []scriptedResponse{
+		{status: 202, body: `{"simulationId":"sim_1","status":"processing"}`},
+		{status: 202, body: `{"simulationId":"sim_1"}`},
+		{status: 200, body: `{"simulationId":"sim_1","narrativeSummary":"You can retire at 60."}`},
+	})
+
+	poller := client.AI.Oracle.Simulations.GetPoller("sim_1")
+	poller.InitialInterval = time.Millisecond
+	res, err := poller.PollUntilDone(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.NarrativeSummary != "You can retire at 60." || poller.Attempts() != 3 {
+		t.Fatalf("unexpected result %+v after %d attempts", res, poller.Attempts())
+	}
+}
+
+func TestPollerContextCancelled(t *testing.T) {
+	client := scriptedClient(t, "/lending/applications/app_1", []scriptedResponse{
+		{status: 200, body: `{"applicationId":"app_1","status":"underwriting"}`},
+	})
+
+	poller := client.Lending.Applications.GetPoller("app_1")
+	poller.InitialInterval = time.Hour
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	res, err := poller.PollUntilDone(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error, got %v", err)
+	}
+	if res == nil || res.Status != jocall3.LoanApplicationStatusStatusUnderwriting || poller.Done() {
+		t.Fatalf("expected the in-progress state, got %+v", res)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -55,19 +56,19 @@ func (r *SustainabilityService) GetCarbonFootprint(ctx context.Context, opts ...
 
 type SustainabilityPurchaseCarbonOffsetsResponse struct {
 	// The amount of carbon dioxide equivalent offset by this purchase.
-	AmountOffsetKgCo2e interface{} `json:"amountOffsetKgCO2e,required"`
+	AmountOffsetKgCo2e float64 `json:"amountOffsetKgCO2e,required"`
 	// Timestamp of the purchase.
-	PurchaseDate interface{} `json:"purchaseDate,required"`
+	PurchaseDate time.Time `json:"purchaseDate,required" format:"date-time"`
 	// Unique identifier for the carbon offset purchase.
-	PurchaseID interface{} `json:"purchaseId,required"`
+	PurchaseID string `json:"purchaseId,required"`
 	// Total cost of the carbon offset purchase in USD.
-	TotalCostUsd interface{} `json:"totalCostUSD,required"`
+	TotalCostUsd Decimal `json:"totalCostUSD,required"`
 	// URL to the official carbon offset certificate.
-	CertificateURL interface{} `json:"certificateUrl"`
+	CertificateURL string `json:"certificateUrl"`
 	// The carbon offset project supported.
-	ProjectSupported interface{} `json:"projectSupported"`
+	ProjectSupported string `json:"projectSupported"`
 	// The ID of the internal financial transaction for this purchase.
-	TransactionID interface{}                                     `json:"transactionId"`
+	TransactionID string                                          `json:"transactionId"`
 	JSON          sustainabilityPurchaseCarbonOffsetsResponseJSON `json:"-"`
 }
 
@@ -99,11 +100,11 @@ type SustainabilityGetCarbonFootprintResponse struct {
 	// Breakdown of carbon footprint by spending categories.
 	BreakdownByCategory []SustainabilityGetCarbonFootprintResponseBreakdownByCategory `json:"breakdownByCategory,required"`
 	// The period covered by the report.
-	Period interface{} `json:"period,required"`
+	Period string `json:"period,required"`
 	// Unique identifier for the carbon footprint report.
-	ReportID interface{} `json:"reportId,required"`
+	ReportID string `json:"reportId,required"`
 	// Total estimated carbon footprint in kilograms of CO2 equivalent.
-	TotalCarbonFootprintKgCo2e interface{} `json:"totalCarbonFootprintKgCO2e,required"`
+	TotalCarbonFootprintKgCo2e float64 `json:"totalCarbonFootprintKgCO2e,required"`
 	// Recommendations for purchasing carbon offsets.
 	OffsetRecommendations []SustainabilityGetCarbonFootprintResponseOffsetRecommendation `json:"offsetRecommendations,nullable"`
 	JSON                  sustainabilityGetCarbonFootprintResponseJSON                   `json:"-"`
@@ -131,9 +132,9 @@ func (r sustainabilityGetCarbonFootprintResponseJSON) RawJSON() string {
 }
 
 type SustainabilityGetCarbonFootprintResponseBreakdownByCategory struct {
-	CarbonFootprintKgCo2e interface{}                                                     `json:"carbonFootprintKgCO2e"`
-	Category              interface{}                                                     `json:"category"`
-	Percentage            interface{}                                                     `json:"percentage"`
+	CarbonFootprintKgCo2e float64                                                         `json:"carbonFootprintKgCO2e"`
+	Category              string                                                          `json:"category"`
+	Percentage            float64                                                         `json:"percentage"`
 	JSON                  sustainabilityGetCarbonFootprintResponseBreakdownByCategoryJSON `json:"-"`
 }
 
@@ -157,10 +158,10 @@ func (r sustainabilityGetCarbonFootprintResponseBreakdownByCategoryJSON) RawJSON
 }
 
 type SustainabilityGetCarbonFootprintResponseOffsetRecommendation struct {
-	CostPerTonUsd      interface{}                                                      `json:"costPerTonUSD"`
-	OffsetAmountKgCo2e interface{}                                                      `json:"offsetAmountKgCO2e"`
-	Project            interface{}                                                      `json:"project"`
-	TotalCostUsd       interface{}                                                      `json:"totalCostUSD"`
+	CostPerTonUsd      Decimal                                                          `json:"costPerTonUSD"`
+	OffsetAmountKgCo2e float64                                                          `json:"offsetAmountKgCO2e"`
+	Project            string                                                           `json:"project"`
+	TotalCostUsd       Decimal                                                          `json:"totalCostUSD"`
 	JSON               sustainabilityGetCarbonFootprintResponseOffsetRecommendationJSON `json:"-"`
 }
 
@@ -186,11 +187,11 @@ func (r sustainabilityGetCarbonFootprintResponseOffsetRecommendationJSON) RawJSO
 
 type SustainabilityPurchaseCarbonOffsetsParams struct {
 	// The amount of carbon dioxide equivalent to offset in kilograms.
-	AmountKgCo2e param.Field[interface{}] `json:"amountKgCO2e,required"`
+	AmountKgCo2e param.Field[float64] `json:"amountKgCO2e,required"`
 	// Optional: The specific carbon offset project to support.
-	OffsetProject param.Field[interface{}] `json:"offsetProject,required"`
+	OffsetProject param.Field[string] `json:"offsetProject,required"`
 	// The ID of the user's account to use for payment.
-	PaymentAccountID param.Field[interface{}] `json:"paymentAccountId,required"`
+	PaymentAccountID param.Field[string] `json:"paymentAccountId,required"`
 }
 
 func (r SustainabilityPurchaseCarbonOffsetsParams) MarshalJSON() (data []byte, err error) {
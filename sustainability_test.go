@@ -26,9 +26,9 @@ func TestSustainabilityPurchaseCarbonOffsets(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Sustainability.PurchaseCarbonOffsets(context.TODO(), jocall3.SustainabilityPurchaseCarbonOffsetsParams{
-		AmountKgCo2e:     jocall3.F[any](500),
-		OffsetProject:    jocall3.F[any]("Verified Carbon Standard Project X"),
-		PaymentAccountID: jocall3.F[any]("acc_chase_checking_4567"),
+		AmountKgCo2e:     jocall3.F(500.0),
+		OffsetProject:    jocall3.F("Verified Carbon Standard Project X"),
+		PaymentAccountID: jocall3.F("acc_chase_checking_4567"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
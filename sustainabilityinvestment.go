@@ -45,15 +45,15 @@ type SustainabilityInvestmentAnalyzeImpactResponse struct {
 	// AI-driven recommendations to improve the portfolio's ESG impact.
 	AIRecommendations []AIInsight `json:"aiRecommendations,required"`
 	// Average ESG score of a relevant market benchmark for comparison.
-	BenchmarkEsgScore interface{} `json:"benchmarkESGScore,required"`
+	BenchmarkEsgScore float64 `json:"benchmarkESGScore,required"`
 	// Breakdown of the portfolio's ESG score by individual factors.
 	BreakdownByEsgFactors SustainabilityInvestmentAnalyzeImpactResponseBreakdownByEsgFactors `json:"breakdownByESGFactors,required"`
 	// Lowest holdings in the portfolio by ESG score.
 	LowestEsgHoldings []SustainabilityInvestmentAnalyzeImpactResponseLowestEsgHolding `json:"lowestESGHoldings,required"`
 	// Overall ESG score of the entire portfolio (0-10).
-	OverallEsgScore interface{} `json:"overallESGScore,required"`
+	OverallEsgScore float64 `json:"overallESGScore,required"`
 	// ID of the investment portfolio analyzed.
-	PortfolioID interface{} `json:"portfolioId,required"`
+	PortfolioID string `json:"portfolioId,required"`
 	// Top holdings in the portfolio by ESG score.
 	TopEsgHoldings []SustainabilityInvestmentAnalyzeImpactResponseTopEsgHolding `json:"topESGHoldings,required"`
 	JSON           sustainabilityInvestmentAnalyzeImpactResponseJSON            `json:"-"`
@@ -83,9 +83,9 @@ func (r sustainabilityInvestmentAnalyzeImpactResponseJSON) RawJSON() string {
 
 // Breakdown of the portfolio's ESG score by individual factors.
 type SustainabilityInvestmentAnalyzeImpactResponseBreakdownByEsgFactors struct {
-	EnvironmentalScore interface{}                                                            `json:"environmentalScore"`
-	GovernanceScore    interface{}                                                            `json:"governanceScore"`
-	SocialScore        interface{}                                                            `json:"socialScore"`
+	EnvironmentalScore float64                                                                `json:"environmentalScore"`
+	GovernanceScore    float64                                                                `json:"governanceScore"`
+	SocialScore        float64                                                                `json:"socialScore"`
 	JSON               sustainabilityInvestmentAnalyzeImpactResponseBreakdownByEsgFactorsJSON `json:"-"`
 }
 
@@ -109,9 +109,9 @@ func (r sustainabilityInvestmentAnalyzeImpactResponseBreakdownByEsgFactorsJSON)
 }
 
 type SustainabilityInvestmentAnalyzeImpactResponseLowestEsgHolding struct {
-	AssetName   interface{}                                                       `json:"assetName"`
-	AssetSymbol interface{}                                                       `json:"assetSymbol"`
-	EsgScore    interface{}                                                       `json:"esgScore"`
+	AssetName   string                                                            `json:"assetName"`
+	AssetSymbol string                                                            `json:"assetSymbol"`
+	EsgScore    float64                                                           `json:"esgScore"`
 	JSON        sustainabilityInvestmentAnalyzeImpactResponseLowestEsgHoldingJSON `json:"-"`
 }
 
@@ -135,9 +135,9 @@ func (r sustainabilityInvestmentAnalyzeImpactResponseLowestEsgHoldingJSON) RawJS
 }
 
 type SustainabilityInvestmentAnalyzeImpactResponseTopEsgHolding struct {
-	AssetName   interface{}                                                    `json:"assetName"`
-	AssetSymbol interface{}                                                    `json:"assetSymbol"`
-	EsgScore    interface{}                                                    `json:"esgScore"`
+	AssetName   string                                                         `json:"assetName"`
+	AssetSymbol string                                                         `json:"assetSymbol"`
+	EsgScore    float64                                                        `json:"esgScore"`
 	JSON        sustainabilityInvestmentAnalyzeImpactResponseTopEsgHoldingJSON `json:"-"`
 }
 
@@ -4,16 +4,21 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
+	"github.com/jocall3/go/internal/apiform"
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // TransactionService contains methods and other services that help with
@@ -42,9 +47,13 @@ func NewTransactionService(opts ...option.RequestOption) (r *TransactionService)
 // Retrieves granular information for a single transaction by its unique ID,
 // including AI categorization confidence, merchant details, and associated carbon
 // footprint.
-func (r *TransactionService) Get(ctx context.Context, transactionID interface{}, opts ...option.RequestOption) (res *Transaction, err error) {
+func (r *TransactionService) Get(ctx context.Context, transactionID string, opts ...option.RequestOption) (res *Transaction, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("transactions/%v", transactionID)
+	if transactionID == "" {
+		err = errors.New("missing required transactionId parameter")
+		return
+	}
+	path := fmt.Sprintf("transactions/%s", transactionID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, nil, &res, opts...)
 	return
 }
@@ -52,35 +61,80 @@ func (r *TransactionService) Get(ctx context.Context, transactionID interface{},
 // Retrieves a paginated list of the user's transactions, with extensive options
 // for filtering by type, category, date range, amount, and intelligent AI-driven
 // sorting and search capabilities.
-func (r *TransactionService) List(ctx context.Context, query TransactionListParams, opts ...option.RequestOption) (res *PaginatedTransactions, err error) {
+func (r *TransactionService) List(ctx context.Context, query TransactionListParams, opts ...option.RequestOption) (res *pagination.Page[Transaction], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "transactions"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a paginated list of the user's transactions, with extensive options
+// for filtering by type, category, date range, amount, and intelligent AI-driven
+// sorting and search capabilities.
+func (r *TransactionService) ListAutoPaging(ctx context.Context, query TransactionListParams, opts ...option.RequestOption) *pagination.PageAutoPager[Transaction] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Allows the user to override or refine the AI's categorization for a transaction,
 // improving future AI accuracy and personal financial reporting.
-func (r *TransactionService) Categorize(ctx context.Context, transactionID interface{}, body TransactionCategorizeParams, opts ...option.RequestOption) (res *Transaction, err error) {
+func (r *TransactionService) Categorize(ctx context.Context, transactionID string, body TransactionCategorizeParams, opts ...option.RequestOption) (res *Transaction, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("transactions/%v/categorize", transactionID)
+	if transactionID == "" {
+		err = errors.New("missing required transactionId parameter")
+		return
+	}
+	path := fmt.Sprintf("transactions/%s/categorize", transactionID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
 
 // Begins the process of disputing a specific transaction, providing details and
 // supporting documentation for review by our compliance team and AI.
-func (r *TransactionService) Dispute(ctx context.Context, transactionID interface{}, body TransactionDisputeParams, opts ...option.RequestOption) (res *TransactionDisputeResponse, err error) {
+func (r *TransactionService) Dispute(ctx context.Context, transactionID string, body TransactionDisputeParams, opts ...option.RequestOption) (res *TransactionDisputeResponse, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("transactions/%v/dispute", transactionID)
+	if transactionID == "" {
+		err = errors.New("missing required transactionId parameter")
+		return
+	}
+	path := fmt.Sprintf("transactions/%s/dispute", transactionID)
+	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
+	return
+}
+
+// Disputes a transaction like [TransactionService.Dispute], but uploads the
+// supporting documents as a multipart/form-data request instead of linking to
+// them. Files are streamed rather than held in memory; the request is only
+// retried if every file can be read again, see [FileParam] and
+// [FileOpenerParam].
+func (r *TransactionService) DisputeWithEvidence(ctx context.Context, transactionID string, body TransactionDisputeWithEvidenceParams, opts ...option.RequestOption) (res *TransactionDisputeResponse, err error) {
+	opts = slices.Concat(r.Options, opts)
+	if transactionID == "" {
+		err = errors.New("missing required transactionId parameter")
+		return
+	}
+	path := fmt.Sprintf("transactions/%s/dispute", transactionID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPost, path, body, &res, opts...)
 	return
 }
 
 // Allows the user to add or update personal notes for a specific transaction.
-func (r *TransactionService) UpdateNotes(ctx context.Context, transactionID interface{}, body TransactionUpdateNotesParams, opts ...option.RequestOption) (res *Transaction, err error) {
+func (r *TransactionService) UpdateNotes(ctx context.Context, transactionID string, body TransactionUpdateNotesParams, opts ...option.RequestOption) (res *Transaction, err error) {
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("transactions/%v/notes", transactionID)
+	if transactionID == "" {
+		err = errors.New("missing required transactionId parameter")
+		return
+	}
+	path := fmt.Sprintf("transactions/%s/notes", transactionID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodPut, path, body, &res, opts...)
 	return
 }
@@ -109,26 +163,26 @@ func (r paginatedTransactionsJSON) RawJSON() string {
 
 type Transaction struct {
 	// Unique identifier for the transaction.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// ID of the account from which the transaction occurred.
-	AccountID interface{} `json:"accountId,required"`
+	AccountID string `json:"accountId,required"`
 	// Amount of the transaction.
-	Amount interface{} `json:"amount,required"`
+	Amount Decimal `json:"amount,required"`
 	// AI-assigned or user-defined category of the transaction (e.g., 'Groceries',
 	// 'Utilities').
-	Category interface{} `json:"category,required"`
+	Category string `json:"category,required"`
 	// ISO 4217 currency code of the transaction.
-	Currency interface{} `json:"currency,required"`
+	Currency string `json:"currency,required"`
 	// Date the transaction occurred (local date).
-	Date interface{} `json:"date,required"`
+	Date time.Time `json:"date,required" format:"date"`
 	// Detailed description of the transaction.
-	Description interface{} `json:"description,required"`
+	Description string `json:"description,required"`
 	// Type of the transaction.
 	Type TransactionType `json:"type,required"`
 	// AI confidence score for the assigned category (0-1).
-	AICategoryConfidence interface{} `json:"aiCategoryConfidence"`
+	AICategoryConfidence float64 `json:"aiCategoryConfidence"`
 	// Estimated carbon footprint in kg CO2e for this transaction, derived by AI.
-	CarbonFootprint interface{} `json:"carbonFootprint"`
+	CarbonFootprint float64 `json:"carbonFootprint"`
 	// Current dispute status of the transaction.
 	DisputeStatus TransactionDisputeStatus `json:"disputeStatus"`
 	// Geographic location details for a transaction.
@@ -136,15 +190,15 @@ type Transaction struct {
 	// Detailed information about a merchant associated with a transaction.
 	MerchantDetails TransactionMerchantDetails `json:"merchantDetails"`
 	// Personal notes added by the user to the transaction.
-	Notes interface{} `json:"notes"`
+	Notes string `json:"notes"`
 	// Channel through which the payment was made.
 	PaymentChannel TransactionPaymentChannel `json:"paymentChannel,nullable"`
 	// Date the transaction was posted to the account (local date).
-	PostedDate interface{} `json:"postedDate"`
+	PostedDate time.Time `json:"postedDate" format:"date"`
 	// URL to a digital receipt for the transaction.
-	ReceiptURL interface{} `json:"receiptUrl"`
+	ReceiptURL string `json:"receiptUrl"`
 	// User-defined tags for the transaction.
-	Tags []interface{}   `json:"tags,nullable"`
+	Tags []string        `json:"tags,nullable"`
 	JSON transactionJSON `json:"-"`
 }
 
@@ -222,15 +276,15 @@ func (r TransactionDisputeStatus) IsKnown() bool {
 // Geographic location details for a transaction.
 type TransactionLocation struct {
 	// City where the transaction occurred.
-	City interface{} `json:"city"`
+	City string `json:"city"`
 	// Latitude coordinate of the transaction.
-	Latitude interface{} `json:"latitude"`
+	Latitude float64 `json:"latitude"`
 	// Longitude coordinate of the transaction.
-	Longitude interface{} `json:"longitude"`
+	Longitude float64 `json:"longitude"`
 	// State where the transaction occurred.
-	State interface{} `json:"state"`
+	State string `json:"state"`
 	// Zip code where the transaction occurred.
-	Zip  interface{}             `json:"zip"`
+	Zip  string                  `json:"zip"`
 	JSON transactionLocationJSON `json:"-"`
 }
 
@@ -258,13 +312,13 @@ func (r transactionLocationJSON) RawJSON() string {
 type TransactionMerchantDetails struct {
 	Address Address `json:"address"`
 	// URL to the merchant's logo.
-	LogoURL interface{} `json:"logoUrl"`
+	LogoURL string `json:"logoUrl"`
 	// Official name of the merchant.
-	Name interface{} `json:"name"`
+	Name string `json:"name"`
 	// Merchant's phone number.
-	Phone interface{} `json:"phone"`
+	Phone string `json:"phone"`
 	// Merchant's website URL.
-	Website interface{}                    `json:"website"`
+	Website string                         `json:"website"`
 	JSON    transactionMerchantDetailsJSON `json:"-"`
 }
 
@@ -311,11 +365,11 @@ func (r TransactionPaymentChannel) IsKnown() bool {
 
 type TransactionDisputeResponse struct {
 	// Unique identifier for the dispute case.
-	DisputeID interface{} `json:"disputeId,required"`
+	DisputeID string `json:"disputeId,required"`
 	// Timestamp when the dispute status was last updated.
-	LastUpdated interface{} `json:"lastUpdated,required"`
+	LastUpdated time.Time `json:"lastUpdated,required" format:"date-time"`
 	// Guidance on what to expect next in the dispute process.
-	NextSteps interface{} `json:"nextSteps,required"`
+	NextSteps string `json:"nextSteps,required"`
 	// Current status of the dispute.
 	Status TransactionDisputeResponseStatus `json:"status,required"`
 	JSON   transactionDisputeResponseJSON   `json:"-"`
@@ -361,21 +415,21 @@ func (r TransactionDisputeResponseStatus) IsKnown() bool {
 
 type TransactionListParams struct {
 	// Filter transactions by their AI-assigned or user-defined category.
-	Category param.Field[interface{}] `query:"category"`
+	Category param.Field[string] `query:"category"`
 	// Retrieve transactions up to this date (inclusive).
-	EndDate param.Field[interface{}] `query:"endDate"`
+	EndDate param.Field[time.Time] `query:"endDate" format:"date"`
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Filter for transactions with an amount less than or equal to this value.
-	MaxAmount param.Field[interface{}] `query:"maxAmount"`
+	MaxAmount param.Field[Decimal] `query:"maxAmount"`
 	// Filter for transactions with an amount greater than or equal to this value.
-	MinAmount param.Field[interface{}] `query:"minAmount"`
+	MinAmount param.Field[Decimal] `query:"minAmount"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 	// Free-text search across transaction descriptions, merchants, and notes.
-	SearchQuery param.Field[interface{}] `query:"searchQuery"`
+	SearchQuery param.Field[string] `query:"searchQuery"`
 	// Retrieve transactions from this date (inclusive).
-	StartDate param.Field[interface{}] `query:"startDate"`
+	StartDate param.Field[time.Time] `query:"startDate" format:"date"`
 	// Filter transactions by type (e.g., income, expense, transfer).
 	Type param.Field[TransactionListParamsType] `query:"type"`
 }
@@ -410,12 +464,12 @@ func (r TransactionListParamsType) IsKnown() bool {
 
 type TransactionCategorizeParams struct {
 	// The new category for the transaction. Can be hierarchical.
-	Category param.Field[interface{}] `json:"category,required"`
+	Category param.Field[string] `json:"category,required"`
 	// If true, the AI will learn from this correction and try to apply it to similar
 	// future transactions.
-	ApplyToFuture param.Field[interface{}] `json:"applyToFuture"`
+	ApplyToFuture param.Field[bool] `json:"applyToFuture"`
 	// Optional notes to add to the transaction.
-	Notes param.Field[interface{}] `json:"notes"`
+	Notes param.Field[string] `json:"notes"`
 }
 
 func (r TransactionCategorizeParams) MarshalJSON() (data []byte, err error) {
@@ -424,11 +478,11 @@ func (r TransactionCategorizeParams) MarshalJSON() (data []byte, err error) {
 
 type TransactionDisputeParams struct {
 	// Detailed explanation of the dispute.
-	Details param.Field[interface{}] `json:"details,required"`
+	Details param.Field[string] `json:"details,required"`
 	// The primary reason for disputing the transaction.
 	Reason param.Field[TransactionDisputeParamsReason] `json:"reason,required"`
 	// URLs to supporting documents (e.g., receipts, communication).
-	SupportingDocuments param.Field[[]interface{}] `json:"supportingDocuments"`
+	SupportingDocuments param.Field[[]string] `json:"supportingDocuments"`
 }
 
 func (r TransactionDisputeParams) MarshalJSON() (data []byte, err error) {
@@ -454,9 +508,22 @@ func (r TransactionDisputeParamsReason) IsKnown() bool {
 	return false
 }
 
+type TransactionDisputeWithEvidenceParams struct {
+	// Detailed explanation of the dispute.
+	Details param.Field[string] `json:"details,required"`
+	// The primary reason for disputing the transaction.
+	Reason param.Field[TransactionDisputeParamsReason] `json:"reason,required"`
+	// Supporting documents (e.g., receipts, communication).
+	SupportingDocuments param.Field[[]io.Reader] `json:"supportingDocuments" format:"binary"`
+}
+
+func (r TransactionDisputeWithEvidenceParams) MarshalMultipartBody() (*apiform.Body, error) {
+	return apiform.NewRootBody(r)
+}
+
 type TransactionUpdateNotesParams struct {
 	// The personal notes to add or update for the transaction.
-	Notes param.Field[interface{}] `json:"notes,required"`
+	Notes param.Field[string] `json:"notes,required"`
 }
 
 func (r TransactionUpdateNotesParams) MarshalJSON() (data []byte, err error) {
@@ -3,10 +3,13 @@
 package jocall3_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -48,14 +51,14 @@ func TestTransactionListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Transactions.List(context.TODO(), jocall3.TransactionListParams{
-		Category:    jocall3.F[any]("Groceries"),
-		EndDate:     jocall3.F[any]("2024-12-31"),
-		Limit:       jocall3.F[any](map[string]interface{}{}),
-		MaxAmount:   jocall3.F[any](100),
-		MinAmount:   jocall3.F[any](20),
-		Offset:      jocall3.F[any](map[string]interface{}{}),
-		SearchQuery: jocall3.F[any]("Starbucks"),
-		StartDate:   jocall3.F[any]("2024-01-01"),
+		Category:    jocall3.F("Groceries"),
+		EndDate:     jocall3.F(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)),
+		Limit:       jocall3.F(int64(0)),
+		MaxAmount:   jocall3.F(jocall3.MustDecimal("100")),
+		MinAmount:   jocall3.F(jocall3.MustDecimal("20")),
+		Offset:      jocall3.F(int64(0)),
+		SearchQuery: jocall3.F("Starbucks"),
+		StartDate:   jocall3.F(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
 		Type:        jocall3.F(jocall3.TransactionListParamsTypeExpense),
 	})
 	if err != nil {
@@ -83,9 +86,9 @@ func TestTransactionCategorizeWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"txn_quantum-2024-07-21-A7B8C9",
 		jocall3.TransactionCategorizeParams{
-			Category:      jocall3.F[any]("Home > Groceries"),
-			ApplyToFuture: jocall3.F[any](true),
-			Notes:         jocall3.F[any]("Bulk purchase for party"),
+			Category:      jocall3.F("Home > Groceries"),
+			ApplyToFuture: jocall3.F(true),
+			Notes:         jocall3.F("Bulk purchase for party"),
 		},
 	)
 	if err != nil {
@@ -113,9 +116,39 @@ func TestTransactionDisputeWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"txn_quantum-2024-07-21-A7B8C9",
 		jocall3.TransactionDisputeParams{
-			Details:             jocall3.F[any]("I did not authorize this purchase. My card may have been compromised and I was traveling internationally on this date."),
+			Details:             jocall3.F("I did not authorize this purchase. My card may have been compromised and I was traveling internationally on this date."),
 			Reason:              jocall3.F(jocall3.TransactionDisputeParamsReasonUnauthorized),
-			SupportingDocuments: jocall3.F([]interface{}{"https://demobank.com/uploads/flight_ticket.png"}),
+			SupportingDocuments: jocall3.F([]string{"https://demobank.com/uploads/flight_ticket.png"}),
+		},
+	)
+	if err != nil {
+		var apierr *jocall3.Error
+		if errors.As(err, &apierr) {
+			t.Log(string(apierr.DumpRequest(true)))
+		}
+		t.Fatalf("err should be nil: %s", err.Error())
+	}
+}
+
+func TestTransactionDisputeWithEvidenceWithOptionalParams(t *testing.T) {
+	t.Skip("Prism tests are disabled")
+	baseURL := "http://localhost:4010"
+	if envURL, ok := os.LookupEnv("TEST_API_BASE_URL"); ok {
+		baseURL = envURL
+	}
+	if !testutil.CheckTestServer(t, baseURL) {
+		return
+	}
+	client := jocall3.NewClient(
+		option.WithBaseURL(baseURL),
+	)
+	_, err := client.Transactions.DisputeWithEvidence(
+		context.TODO(),
+		"txn_quantum-2024-07-21-A7B8C9",
+		jocall3.TransactionDisputeWithEvidenceParams{
+			Details:             jocall3.F("I did not authorize this purchase. My card may have been compromised and I was traveling internationally on this date."),
+			Reason:              jocall3.F(jocall3.TransactionDisputeParamsReasonUnauthorized),
+			SupportingDocuments: jocall3.F([]io.Reader{io.Reader(bytes.NewBuffer([]byte("some file contents")))}),
 		},
 	)
 	if err != nil {
@@ -143,7 +176,7 @@ func TestTransactionUpdateNotes(t *testing.T) {
 		context.TODO(),
 		"txn_quantum-2024-07-21-A7B8C9",
 		jocall3.TransactionUpdateNotesParams{
-			Notes: jocall3.F[any]("This was a special coffee for a client meeting."),
+			Notes: jocall3.F("This was a special coffee for a client meeting."),
 		},
 	)
 	if err != nil {
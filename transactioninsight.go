@@ -6,6 +6,7 @@ import (
 	"context"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/requestconfig"
@@ -43,22 +44,22 @@ func (r *TransactionInsightService) GetSpendingTrends(ctx context.Context, opts
 // An AI-generated insight, alert, or recommendation.
 type AIInsight struct {
 	// Unique identifier for the AI insight.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Category of the insight (e.g., spending, saving, investing).
 	Category AIInsightCategory `json:"category,required"`
 	// Detailed explanation of the insight.
-	Description interface{} `json:"description,required"`
+	Description string `json:"description,required"`
 	// AI-assessed severity or importance of the insight.
 	Severity AIInsightSeverity `json:"severity,required"`
 	// Timestamp when the insight was generated.
-	Timestamp interface{} `json:"timestamp,required"`
+	Timestamp time.Time `json:"timestamp,required" format:"date-time"`
 	// A concise title for the insight.
-	Title interface{} `json:"title,required"`
+	Title string `json:"title,required"`
 	// Optional: A concrete action the user can take based on the insight.
-	ActionableRecommendation interface{} `json:"actionableRecommendation"`
+	ActionableRecommendation string `json:"actionableRecommendation"`
 	// Optional: A programmatic trigger or deep link to initiate the recommended
 	// action.
-	ActionTrigger interface{}   `json:"actionTrigger"`
+	ActionTrigger string        `json:"actionTrigger"`
 	JSON          aiInsightJSON `json:"-"`
 }
 
@@ -130,13 +131,13 @@ type TransactionInsightGetSpendingTrendsResponse struct {
 	// AI-driven insights and recommendations related to spending.
 	AIInsights []AIInsight `json:"aiInsights,required"`
 	// AI-projected total spending for the next month.
-	ForecastNextMonth interface{} `json:"forecastNextMonth,required"`
+	ForecastNextMonth Decimal `json:"forecastNextMonth,required"`
 	// Overall trend of spending (increasing, decreasing, stable).
 	OverallTrend TransactionInsightGetSpendingTrendsResponseOverallTrend `json:"overallTrend,required"`
 	// Percentage change in spending over the period.
-	PercentageChange interface{} `json:"percentageChange,required"`
+	PercentageChange float64 `json:"percentageChange,required"`
 	// The period over which the spending trend is analyzed.
-	Period interface{} `json:"period,required"`
+	Period string `json:"period,required"`
 	// Categories with the most significant changes in spending.
 	TopCategoriesByChange []TransactionInsightGetSpendingTrendsResponseTopCategoriesByChange `json:"topCategoriesByChange,required"`
 	JSON                  transactionInsightGetSpendingTrendsResponseJSON                    `json:"-"`
@@ -181,9 +182,9 @@ func (r TransactionInsightGetSpendingTrendsResponseOverallTrend) IsKnown() bool
 }
 
 type TransactionInsightGetSpendingTrendsResponseTopCategoriesByChange struct {
-	AbsoluteChange   interface{}                                                          `json:"absoluteChange"`
-	Category         interface{}                                                          `json:"category"`
-	PercentageChange interface{}                                                          `json:"percentageChange"`
+	AbsoluteChange   Decimal                                                              `json:"absoluteChange"`
+	Category         string                                                               `json:"category"`
+	PercentageChange float64                                                              `json:"percentageChange"`
 	JSON             transactionInsightGetSpendingTrendsResponseTopCategoriesByChangeJSON `json:"-"`
 }
 
@@ -7,12 +7,14 @@ import (
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // TransactionRecurringService contains methods and other services that help with
@@ -44,37 +46,53 @@ func (r *TransactionRecurringService) New(ctx context.Context, body TransactionR
 
 // Retrieves a list of all detected or user-defined recurring transactions, useful
 // for budget tracking and subscription management.
-func (r *TransactionRecurringService) List(ctx context.Context, query TransactionRecurringListParams, opts ...option.RequestOption) (res *TransactionRecurringListResponse, err error) {
+func (r *TransactionRecurringService) List(ctx context.Context, query TransactionRecurringListParams, opts ...option.RequestOption) (res *pagination.Page[RecurringTransaction], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "transactions/recurring"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of all detected or user-defined recurring transactions, useful
+// for budget tracking and subscription management.
+func (r *TransactionRecurringService) ListAutoPaging(ctx context.Context, query TransactionRecurringListParams, opts ...option.RequestOption) *pagination.PageAutoPager[RecurringTransaction] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Details of a detected or user-defined recurring transaction.
 type RecurringTransaction struct {
 	// Unique identifier for the recurring transaction.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Amount of the recurring transaction.
-	Amount interface{} `json:"amount,required"`
+	Amount Decimal `json:"amount,required"`
 	// Category of the recurring transaction.
-	Category interface{} `json:"category,required"`
+	Category string `json:"category,required"`
 	// ISO 4217 currency code.
-	Currency interface{} `json:"currency,required"`
+	Currency string `json:"currency,required"`
 	// Description of the recurring transaction.
-	Description interface{} `json:"description,required"`
+	Description string `json:"description,required"`
 	// Frequency of the recurring transaction.
 	Frequency RecurringTransactionFrequency `json:"frequency,required"`
 	// Current status of the recurring transaction.
 	Status RecurringTransactionStatus `json:"status,required"`
 	// AI confidence score that this is a recurring transaction (0-1).
-	AIConfidenceScore interface{} `json:"aiConfidenceScore"`
+	AIConfidenceScore float64 `json:"aiConfidenceScore"`
 	// Date of the last payment for this recurring transaction.
-	LastPaidDate interface{} `json:"lastPaidDate"`
+	LastPaidDate time.Time `json:"lastPaidDate" format:"date"`
 	// ID of the account typically used for this recurring transaction.
-	LinkedAccountID interface{} `json:"linkedAccountId"`
+	LinkedAccountID string `json:"linkedAccountId"`
 	// Next scheduled due date for the transaction.
-	NextDueDate interface{}              `json:"nextDueDate"`
+	NextDueDate time.Time                `json:"nextDueDate" format:"date"`
 	JSON        recurringTransactionJSON `json:"-"`
 }
 
@@ -143,43 +161,21 @@ func (r RecurringTransactionStatus) IsKnown() bool {
 	return false
 }
 
-type TransactionRecurringListResponse struct {
-	Data []RecurringTransaction               `json:"data"`
-	JSON transactionRecurringListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// transactionRecurringListResponseJSON contains the JSON metadata for the struct
-// [TransactionRecurringListResponse]
-type transactionRecurringListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *TransactionRecurringListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r transactionRecurringListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type TransactionRecurringNewParams struct {
 	// Amount of the recurring transaction.
-	Amount param.Field[interface{}] `json:"amount,required"`
+	Amount param.Field[Decimal] `json:"amount,required"`
 	// Category of the recurring transaction.
-	Category param.Field[interface{}] `json:"category,required"`
+	Category param.Field[string] `json:"category,required"`
 	// ISO 4217 currency code.
-	Currency param.Field[interface{}] `json:"currency,required"`
+	Currency param.Field[string] `json:"currency,required"`
 	// Description of the recurring transaction.
-	Description param.Field[interface{}] `json:"description,required"`
+	Description param.Field[string] `json:"description,required"`
 	// Frequency of the recurring transaction.
 	Frequency param.Field[TransactionRecurringNewParamsFrequency] `json:"frequency,required"`
 	// ID of the account to associate with this recurring transaction.
-	LinkedAccountID param.Field[interface{}] `json:"linkedAccountId,required"`
+	LinkedAccountID param.Field[string] `json:"linkedAccountId,required"`
 	// The date when this recurring transaction is expected to start.
-	StartDate param.Field[interface{}] `json:"startDate,required"`
+	StartDate param.Field[time.Time] `json:"startDate,required" format:"date"`
 }
 
 func (r TransactionRecurringNewParams) MarshalJSON() (data []byte, err error) {
@@ -209,9 +205,9 @@ func (r TransactionRecurringNewParamsFrequency) IsKnown() bool {
 
 type TransactionRecurringListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [TransactionRecurringListParams]'s query parameters as
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -26,13 +27,13 @@ func TestTransactionRecurringNew(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Transactions.Recurring.New(context.TODO(), jocall3.TransactionRecurringNewParams{
-		Amount:          jocall3.F[any](55.5),
-		Category:        jocall3.F[any]("Health & Fitness"),
-		Currency:        jocall3.F[any]("USD"),
-		Description:     jocall3.F[any]("New Gym Membership"),
+		Amount:          jocall3.F(jocall3.MustDecimal("55.5")),
+		Category:        jocall3.F("Health & Fitness"),
+		Currency:        jocall3.F("USD"),
+		Description:     jocall3.F("New Gym Membership"),
 		Frequency:       jocall3.F(jocall3.TransactionRecurringNewParamsFrequencyMonthly),
-		LinkedAccountID: jocall3.F[any]("acc_chase_checking_4567"),
-		StartDate:       jocall3.F[any]("2024-09-01"),
+		LinkedAccountID: jocall3.F("acc_chase_checking_4567"),
+		StartDate:       jocall3.F(time.Date(2024, 9, 1, 0, 0, 0, 0, time.UTC)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -56,8 +57,8 @@ func TestTransactionRecurringListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Transactions.Recurring.List(context.TODO(), jocall3.TransactionRecurringListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -25,9 +25,9 @@ func TestUsage(t *testing.T) {
 	)
 	t.Skip("Prism tests are disabled")
 	user, err := client.Users.Register(context.TODO(), jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 	})
 	if err != nil {
 		t.Error(err)
@@ -6,6 +6,7 @@ import (
 	"context"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -55,11 +56,11 @@ func (r *UserService) Register(ctx context.Context, body UserRegisterParams, opt
 }
 
 type Address struct {
-	City    interface{} `json:"city"`
-	Country interface{} `json:"country"`
-	State   interface{} `json:"state"`
-	Street  interface{} `json:"street"`
-	Zip     interface{} `json:"zip"`
+	City    string      `json:"city"`
+	Country string      `json:"country"`
+	State   string      `json:"state"`
+	Street  string      `json:"street"`
+	Zip     string      `json:"zip"`
 	JSON    addressJSON `json:"-"`
 }
 
@@ -83,11 +84,11 @@ func (r addressJSON) RawJSON() string {
 }
 
 type AddressParam struct {
-	City    param.Field[interface{}] `json:"city"`
-	Country param.Field[interface{}] `json:"country"`
-	State   param.Field[interface{}] `json:"state"`
-	Street  param.Field[interface{}] `json:"street"`
-	Zip     param.Field[interface{}] `json:"zip"`
+	City    param.Field[string] `json:"city"`
+	Country param.Field[string] `json:"country"`
+	State   param.Field[string] `json:"state"`
+	Street  param.Field[string] `json:"street"`
+	Zip     param.Field[string] `json:"zip"`
 }
 
 func (r AddressParam) MarshalJSON() (data []byte, err error) {
@@ -96,26 +97,26 @@ func (r AddressParam) MarshalJSON() (data []byte, err error) {
 
 type User struct {
 	// Unique identifier for the user.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Primary email address of the user.
-	Email interface{} `json:"email,required"`
+	Email string `json:"email,required"`
 	// Indicates if the user's identity has been verified (e.g., via KYC).
-	IdentityVerified interface{} `json:"identityVerified,required"`
+	IdentityVerified bool `json:"identityVerified,required"`
 	// Full name of the user.
-	Name    interface{} `json:"name,required"`
-	Address Address     `json:"address"`
+	Name    string  `json:"name,required"`
+	Address Address `json:"address"`
 	// AI-identified financial persona for tailored advice.
-	AIPersona interface{} `json:"aiPersona"`
+	AIPersona string `json:"aiPersona"`
 	// Date of birth of the user (YYYY-MM-DD).
-	DateOfBirth interface{} `json:"dateOfBirth"`
+	DateOfBirth time.Time `json:"dateOfBirth" format:"date"`
 	// Current gamification level.
-	GamificationLevel interface{} `json:"gamificationLevel"`
+	GamificationLevel int64 `json:"gamificationLevel"`
 	// Current balance of loyalty points.
-	LoyaltyPoints interface{} `json:"loyaltyPoints"`
+	LoyaltyPoints int64 `json:"loyaltyPoints"`
 	// Current loyalty program tier.
-	LoyaltyTier interface{} `json:"loyaltyTier"`
+	LoyaltyTier string `json:"loyaltyTier"`
 	// Primary phone number of the user.
-	Phone interface{} `json:"phone"`
+	Phone string `json:"phone"`
 	// User's personalized preferences for the platform.
 	Preferences UserPreferences `json:"preferences"`
 	// Security-related status for the user account.
@@ -153,13 +154,13 @@ func (r userJSON) RawJSON() string {
 // Security-related status for the user account.
 type UserSecurityStatus struct {
 	// Indicates if biometric authentication is enrolled.
-	BiometricsEnrolled interface{} `json:"biometricsEnrolled"`
+	BiometricsEnrolled bool `json:"biometricsEnrolled"`
 	// Timestamp of the last successful login.
-	LastLogin interface{} `json:"lastLogin"`
+	LastLogin time.Time `json:"lastLogin" format:"date-time"`
 	// IP address of the last successful login.
-	LastLoginIP interface{} `json:"lastLoginIp"`
+	LastLoginIP string `json:"lastLoginIp"`
 	// Indicates if two-factor authentication (2FA) is enabled.
-	TwoFactorEnabled interface{}            `json:"twoFactorEnabled"`
+	TwoFactorEnabled bool                   `json:"twoFactorEnabled"`
 	JSON             userSecurityStatusJSON `json:"-"`
 }
 
@@ -184,13 +185,13 @@ func (r userSecurityStatusJSON) RawJSON() string {
 
 type UserLoginResponse struct {
 	// JWT access token to authenticate subsequent API requests.
-	AccessToken interface{} `json:"accessToken,required"`
+	AccessToken string `json:"accessToken,required"`
 	// Lifetime of the access token in seconds.
-	ExpiresIn interface{} `json:"expiresIn,required"`
+	ExpiresIn int64 `json:"expiresIn,required"`
 	// Token used to obtain new access tokens without re-authenticating.
-	RefreshToken interface{} `json:"refreshToken,required"`
+	RefreshToken string `json:"refreshToken,required"`
 	// Type of the access token.
-	TokenType interface{}           `json:"tokenType,required"`
+	TokenType string                `json:"tokenType,required"`
 	JSON      userLoginResponseJSON `json:"-"`
 }
 
@@ -215,11 +216,11 @@ func (r userLoginResponseJSON) RawJSON() string {
 
 type UserLoginParams struct {
 	// User's email address.
-	Email param.Field[interface{}] `json:"email,required"`
+	Email param.Field[string] `json:"email,required"`
 	// User's password.
-	Password param.Field[interface{}] `json:"password,required"`
+	Password param.Field[string] `json:"password,required"`
 	// Optional: Multi-factor authentication code, if required.
-	MfaCode param.Field[interface{}] `json:"mfaCode"`
+	MfaCode param.Field[string] `json:"mfaCode"`
 }
 
 func (r UserLoginParams) MarshalJSON() (data []byte, err error) {
@@ -228,16 +229,16 @@ func (r UserLoginParams) MarshalJSON() (data []byte, err error) {
 
 type UserRegisterParams struct {
 	// Email address for registration and login.
-	Email param.Field[interface{}] `json:"email,required"`
+	Email param.Field[string] `json:"email,required"`
 	// Full name of the user.
-	Name param.Field[interface{}] `json:"name,required"`
+	Name param.Field[string] `json:"name,required"`
 	// User's chosen password.
-	Password param.Field[interface{}]  `json:"password,required"`
+	Password param.Field[string]       `json:"password,required"`
 	Address  param.Field[AddressParam] `json:"address"`
 	// Optional date of birth (YYYY-MM-DD).
-	DateOfBirth param.Field[interface{}] `json:"dateOfBirth"`
+	DateOfBirth param.Field[time.Time] `json:"dateOfBirth" format:"date"`
 	// Optional phone number for MFA or recovery.
-	Phone param.Field[interface{}] `json:"phone"`
+	Phone param.Field[string] `json:"phone"`
 }
 
 func (r UserRegisterParams) MarshalJSON() (data []byte, err error) {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jocall3/go"
 	"github.com/jocall3/go/internal/testutil"
@@ -26,9 +27,9 @@ func TestUserLoginWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Users.Login(context.TODO(), jocall3.UserLoginParams{
-		Email:    jocall3.F[any]("quantum.visionary@demobank.com"),
-		Password: jocall3.F[any]("YourSecurePassword123"),
-		MfaCode:  jocall3.F[any]("123456"),
+		Email:    jocall3.F("quantum.visionary@demobank.com"),
+		Password: jocall3.F("YourSecurePassword123"),
+		MfaCode:  jocall3.F("123456"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -52,18 +53,18 @@ func TestUserRegisterWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Users.Register(context.TODO(), jocall3.UserRegisterParams{
-		Email:    jocall3.F[any]("alice.w@example.com"),
-		Name:     jocall3.F[any]("Alice Wonderland"),
-		Password: jocall3.F[any]("SecureP@ssw0rd2024!"),
+		Email:    jocall3.F("alice.w@example.com"),
+		Name:     jocall3.F("Alice Wonderland"),
+		Password: jocall3.F("SecureP@ssw0rd2024!"),
 		Address: jocall3.F(jocall3.AddressParam{
-			City:    jocall3.F[any]("Anytown"),
-			Country: jocall3.F[any]("USA"),
-			State:   jocall3.F[any]("CA"),
-			Street:  jocall3.F[any]("123 Main St"),
-			Zip:     jocall3.F[any]("90210"),
+			City:    jocall3.F("Anytown"),
+			Country: jocall3.F("USA"),
+			State:   jocall3.F("CA"),
+			Street:  jocall3.F("123 Main St"),
+			Zip:     jocall3.F("90210"),
 		}),
-		DateOfBirth: jocall3.F[any]("1990-05-10"),
-		Phone:       jocall3.F[any]("+1-555-987-6543"),
+		DateOfBirth: jocall3.F(time.Date(1990, 5, 10, 0, 0, 0, 0, time.UTC)),
+		Phone:       jocall3.F("+1-555-987-6543"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
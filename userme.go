@@ -60,9 +60,9 @@ func (r *UserMeService) Update(ctx context.Context, body UserMeUpdateParams, opt
 type UserMeUpdateParams struct {
 	Address param.Field[AddressParam] `json:"address"`
 	// Updated full name of the user.
-	Name param.Field[interface{}] `json:"name"`
+	Name param.Field[string] `json:"name"`
 	// Updated primary phone number of the user.
-	Phone param.Field[interface{}] `json:"phone"`
+	Phone param.Field[string] `json:"phone"`
 	// User's personalized preferences for the platform.
 	Preferences param.Field[UserPreferencesParam] `json:"preferences"`
 }
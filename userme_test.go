@@ -49,25 +49,25 @@ func TestUserMeUpdateWithOptionalParams(t *testing.T) {
 	)
 	_, err := client.Users.Me.Update(context.TODO(), jocall3.UserMeUpdateParams{
 		Address: jocall3.F(jocall3.AddressParam{
-			City:    jocall3.F[any]("Anytown"),
-			Country: jocall3.F[any]("USA"),
-			State:   jocall3.F[any]("CA"),
-			Street:  jocall3.F[any]("123 Main St"),
-			Zip:     jocall3.F[any]("90210"),
+			City:    jocall3.F("Anytown"),
+			Country: jocall3.F("USA"),
+			State:   jocall3.F("CA"),
+			Street:  jocall3.F("123 Main St"),
+			Zip:     jocall3.F("90210"),
 		}),
-		Name:  jocall3.F[any]("Quantum Visionary Pro"),
-		Phone: jocall3.F[any]("+1-555-999-0000"),
+		Name:  jocall3.F("Quantum Visionary Pro"),
+		Phone: jocall3.F("+1-555-999-0000"),
 		Preferences: jocall3.F(jocall3.UserPreferencesParam{
 			AIInteractionMode:  jocall3.F(jocall3.UserPreferencesAIInteractionModeBalanced),
-			DataSharingConsent: jocall3.F[any](true),
+			DataSharingConsent: jocall3.F("true"),
 			NotificationChannels: jocall3.F(jocall3.UserPreferencesNotificationChannelsParam{
-				Email: jocall3.F[any](true),
-				InApp: jocall3.F[any](true),
-				Push:  jocall3.F[any](true),
-				SMS:   jocall3.F[any](false),
+				Email: jocall3.F(true),
+				InApp: jocall3.F(true),
+				Push:  jocall3.F(true),
+				SMS:   jocall3.F(false),
 			}),
-			PreferredLanguage:   jocall3.F[any]("en-US"),
-			Theme:               jocall3.F[any]("Dark-Quantum"),
+			PreferredLanguage:   jocall3.F("en-US"),
+			Theme:               jocall3.F("Dark-Quantum"),
 			TransactionGrouping: jocall3.F(jocall3.UserPreferencesTransactionGroupingCategory),
 		}),
 	})
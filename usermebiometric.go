@@ -6,6 +6,7 @@ import (
 	"context"
 	"net/http"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/param"
@@ -73,11 +74,11 @@ func (r *UserMeBiometricService) Verify(ctx context.Context, body UserMeBiometri
 // Current biometric enrollment status for a user.
 type BiometricStatus struct {
 	// Overall status indicating if any biometrics are enrolled.
-	BiometricsEnrolled interface{} `json:"biometricsEnrolled,required"`
+	BiometricsEnrolled bool `json:"biometricsEnrolled,required"`
 	// List of specific biometric types and devices enrolled.
 	EnrolledBiometrics []BiometricStatusEnrolledBiometric `json:"enrolledBiometrics,required"`
 	// Timestamp of the last successful biometric authentication.
-	LastUsed interface{}         `json:"lastUsed"`
+	LastUsed time.Time           `json:"lastUsed" format:"date-time"`
 	JSON     biometricStatusJSON `json:"-"`
 }
 
@@ -99,8 +100,8 @@ func (r biometricStatusJSON) RawJSON() string {
 }
 
 type BiometricStatusEnrolledBiometric struct {
-	DeviceID       interface{}                           `json:"deviceId"`
-	EnrollmentDate interface{}                           `json:"enrollmentDate"`
+	DeviceID       string                                `json:"deviceId"`
+	EnrollmentDate time.Time                             `json:"enrollmentDate" format:"date"`
 	Type           BiometricStatusEnrolledBiometricsType `json:"type"`
 	JSON           biometricStatusEnrolledBiometricJSON  `json:"-"`
 }
@@ -141,7 +142,7 @@ func (r BiometricStatusEnrolledBiometricsType) IsKnown() bool {
 
 type UserMeBiometricVerifyResponse struct {
 	// A descriptive message for the verification result.
-	Message interface{} `json:"message"`
+	Message string `json:"message"`
 	// Status of the biometric verification.
 	VerificationStatus UserMeBiometricVerifyResponseVerificationStatus `json:"verificationStatus"`
 	JSON               userMeBiometricVerifyResponseJSON               `json:"-"`
@@ -182,13 +183,13 @@ func (r UserMeBiometricVerifyResponseVerificationStatus) IsKnown() bool {
 
 type UserMeBiometricEnrollParams struct {
 	// Base64 encoded representation of the biometric template or proof.
-	BiometricSignature param.Field[interface{}] `json:"biometricSignature,required"`
+	BiometricSignature param.Field[string] `json:"biometricSignature,required"`
 	// The type of biometric data being enrolled.
 	BiometricType param.Field[UserMeBiometricEnrollParamsBiometricType] `json:"biometricType,required"`
 	// The ID of the device on which the biometric is being enrolled.
-	DeviceID param.Field[interface{}] `json:"deviceId,required"`
+	DeviceID param.Field[string] `json:"deviceId,required"`
 	// Optional: A friendly name for the device, if not already linked.
-	DeviceName param.Field[interface{}] `json:"deviceName"`
+	DeviceName param.Field[string] `json:"deviceName"`
 }
 
 func (r UserMeBiometricEnrollParams) MarshalJSON() (data []byte, err error) {
@@ -214,11 +215,11 @@ func (r UserMeBiometricEnrollParamsBiometricType) IsKnown() bool {
 
 type UserMeBiometricVerifyParams struct {
 	// Base64 encoded representation of the one-time biometric proof for verification.
-	BiometricSignature param.Field[interface{}] `json:"biometricSignature,required"`
+	BiometricSignature param.Field[string] `json:"biometricSignature,required"`
 	// The type of biometric data being verified.
 	BiometricType param.Field[UserMeBiometricVerifyParamsBiometricType] `json:"biometricType,required"`
 	// The ID of the device initiating the biometric verification.
-	DeviceID param.Field[interface{}] `json:"deviceId,required"`
+	DeviceID param.Field[string] `json:"deviceId,required"`
 }
 
 func (r UserMeBiometricVerifyParams) MarshalJSON() (data []byte, err error) {
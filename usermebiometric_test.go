@@ -48,10 +48,10 @@ func TestUserMeBiometricEnrollWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Users.Me.Biometrics.Enroll(context.TODO(), jocall3.UserMeBiometricEnrollParams{
-		BiometricSignature: jocall3.F[any]("base64encoded_facial_template_for_enrollment"),
+		BiometricSignature: jocall3.F("base64encoded_facial_template_for_enrollment"),
 		BiometricType:      jocall3.F(jocall3.UserMeBiometricEnrollParamsBiometricTypeFacialRecognition),
-		DeviceID:           jocall3.F[any]("dev_mobile_ios_aabbcc"),
-		DeviceName:         jocall3.F[any]("My Primary iPhone"),
+		DeviceID:           jocall3.F("dev_mobile_ios_aabbcc"),
+		DeviceName:         jocall3.F("My Primary iPhone"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -97,9 +97,9 @@ func TestUserMeBiometricVerify(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Users.Me.Biometrics.Verify(context.TODO(), jocall3.UserMeBiometricVerifyParams{
-		BiometricSignature: jocall3.F[any]("base64encoded_one_time_fingerprint_proof"),
+		BiometricSignature: jocall3.F("base64encoded_one_time_fingerprint_proof"),
 		BiometricType:      jocall3.F(jocall3.UserMeBiometricVerifyParamsBiometricTypeFingerprint),
-		DeviceID:           jocall3.F[any]("dev_mobile_android_ddeeff"),
+		DeviceID:           jocall3.F("dev_mobile_android_ddeeff"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
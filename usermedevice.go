@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // UserMeDeviceService contains methods and other services that help with
@@ -38,20 +41,41 @@ func NewUserMeDeviceService(opts ...option.RequestOption) (r *UserMeDeviceServic
 // Retrieves a list of all devices linked to the user's account, including mobile
 // phones, tablets, and desktops, indicating their last active status and security
 // posture.
-func (r *UserMeDeviceService) List(ctx context.Context, query UserMeDeviceListParams, opts ...option.RequestOption) (res *UserMeDeviceListResponse, err error) {
+func (r *UserMeDeviceService) List(ctx context.Context, query UserMeDeviceListParams, opts ...option.RequestOption) (res *pagination.Page[Device], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "users/me/devices"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of all devices linked to the user's account, including mobile
+// phones, tablets, and desktops, indicating their last active status and security
+// posture.
+func (r *UserMeDeviceService) ListAutoPaging(ctx context.Context, query UserMeDeviceListParams, opts ...option.RequestOption) *pagination.PageAutoPager[Device] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Removes a specific device from the user's linked devices, revoking its access
 // and requiring re-registration for future use. Useful for lost or compromised
 // devices.
-func (r *UserMeDeviceService) Deregister(ctx context.Context, deviceID interface{}, opts ...option.RequestOption) (err error) {
+func (r *UserMeDeviceService) Deregister(ctx context.Context, deviceID string, opts ...option.RequestOption) (err error) {
 	opts = slices.Concat(r.Options, opts)
 	opts = append([]option.RequestOption{option.WithHeader("Accept", "*/*")}, opts...)
-	path := fmt.Sprintf("users/me/devices/%v", deviceID)
+	if deviceID == "" {
+		err = errors.New("missing required deviceId parameter")
+		return
+	}
+	path := fmt.Sprintf("users/me/devices/%s", deviceID)
 	err = requestconfig.ExecuteNewRequest(ctx, http.MethodDelete, path, nil, nil, opts...)
 	return
 }
@@ -69,24 +93,24 @@ func (r *UserMeDeviceService) Register(ctx context.Context, body UserMeDeviceReg
 // Information about a connected device.
 type Device struct {
 	// Unique identifier for the device.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Last known IP address of the device.
-	IPAddress interface{} `json:"ipAddress,required"`
+	IPAddress string `json:"ipAddress,required"`
 	// Timestamp of the last activity from this device.
-	LastActive interface{} `json:"lastActive,required"`
+	LastActive time.Time `json:"lastActive,required" format:"date-time"`
 	// Model of the device.
-	Model interface{} `json:"model,required"`
+	Model string `json:"model,required"`
 	// Operating system of the device.
-	Os interface{} `json:"os,required"`
+	Os string `json:"os,required"`
 	// Security trust level of the device.
 	TrustLevel DeviceTrustLevel `json:"trustLevel,required"`
 	// Type of the device.
 	Type DeviceType `json:"type,required"`
 	// User-assigned name for the device.
-	DeviceName interface{} `json:"deviceName"`
+	DeviceName string `json:"deviceName"`
 	// Push notification token for the device.
-	PushToken interface{} `json:"pushToken"`
-	JSON      deviceJSON  `json:"-"`
+	PushToken string     `json:"pushToken"`
+	JSON      deviceJSON `json:"-"`
 }
 
 // deviceJSON contains the JSON metadata for the struct [Device]
@@ -150,13 +174,13 @@ func (r DeviceType) IsKnown() bool {
 
 type PaginatedList struct {
 	// The maximum number of items returned in the current page.
-	Limit interface{} `json:"limit,required"`
+	Limit int64 `json:"limit,required"`
 	// The number of items skipped before the current page.
-	Offset interface{} `json:"offset,required"`
+	Offset int64 `json:"offset,required"`
 	// The total number of items available across all pages.
-	Total interface{} `json:"total,required"`
+	Total int64 `json:"total,required"`
 	// The offset for the next page of results, if available. Null if no more pages.
-	NextOffset interface{}       `json:"nextOffset"`
+	NextOffset int64             `json:"nextOffset"`
 	JSON       paginatedListJSON `json:"-"`
 }
 
@@ -178,33 +202,11 @@ func (r paginatedListJSON) RawJSON() string {
 	return r.raw
 }
 
-type UserMeDeviceListResponse struct {
-	Data []Device                     `json:"data"`
-	JSON userMeDeviceListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// userMeDeviceListResponseJSON contains the JSON metadata for the struct
-// [UserMeDeviceListResponse]
-type userMeDeviceListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *UserMeDeviceListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r userMeDeviceListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type UserMeDeviceListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [UserMeDeviceListParams]'s query parameters as `url.Values`.
@@ -219,16 +221,16 @@ type UserMeDeviceRegisterParams struct {
 	// Type of the device being registered.
 	DeviceType param.Field[UserMeDeviceRegisterParamsDeviceType] `json:"deviceType,required"`
 	// Model of the device.
-	Model param.Field[interface{}] `json:"model,required"`
+	Model param.Field[string] `json:"model,required"`
 	// Operating system of the device.
-	Os param.Field[interface{}] `json:"os,required"`
+	Os param.Field[string] `json:"os,required"`
 	// Optional: Base64 encoded biometric signature for initial enrollment (e.g., for
 	// Passkey registration).
-	BiometricSignature param.Field[interface{}] `json:"biometricSignature"`
+	BiometricSignature param.Field[string] `json:"biometricSignature"`
 	// Optional: A friendly name for the device.
-	DeviceName param.Field[interface{}] `json:"deviceName"`
+	DeviceName param.Field[string] `json:"deviceName"`
 	// Optional: Push notification token for the device.
-	PushToken param.Field[interface{}] `json:"pushToken"`
+	PushToken param.Field[string] `json:"pushToken"`
 }
 
 func (r UserMeDeviceRegisterParams) MarshalJSON() (data []byte, err error) {
@@ -26,8 +26,8 @@ func TestUserMeDeviceListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Users.Me.Devices.List(context.TODO(), jocall3.UserMeDeviceListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -74,11 +74,11 @@ func TestUserMeDeviceRegisterWithOptionalParams(t *testing.T) {
 	)
 	_, err := client.Users.Me.Devices.Register(context.TODO(), jocall3.UserMeDeviceRegisterParams{
 		DeviceType:         jocall3.F(jocall3.UserMeDeviceRegisterParamsDeviceTypeMobile),
-		Model:              jocall3.F[any]("Samsung Galaxy S24 Ultra"),
-		Os:                 jocall3.F[any]("Android 14"),
-		BiometricSignature: jocall3.F[any]("base64encoded_android_biometric_proof"),
-		DeviceName:         jocall3.F[any]("Alex's Work Phone"),
-		PushToken:          jocall3.F[any]("ExponentPushToken[yzzzyzzzyzzzyzzz]"),
+		Model:              jocall3.F("Samsung Galaxy S24 Ultra"),
+		Os:                 jocall3.F("Android 14"),
+		BiometricSignature: jocall3.F("base64encoded_android_biometric_proof"),
+		DeviceName:         jocall3.F("Alex's Work Phone"),
+		PushToken:          jocall3.F("ExponentPushToken[yzzzyzzzyzzzyzzz]"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
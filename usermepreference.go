@@ -59,13 +59,13 @@ type UserPreferences struct {
 	AIInteractionMode UserPreferencesAIInteractionMode `json:"aiInteractionMode"`
 	// Consent status for sharing anonymized data for AI improvement and personalized
 	// offers.
-	DataSharingConsent interface{} `json:"dataSharingConsent"`
+	DataSharingConsent bool `json:"dataSharingConsent"`
 	// Preferred channels for receiving notifications.
 	NotificationChannels UserPreferencesNotificationChannels `json:"notificationChannels"`
 	// Preferred language for the user interface.
-	PreferredLanguage interface{} `json:"preferredLanguage"`
+	PreferredLanguage string `json:"preferredLanguage"`
 	// Preferred UI theme (e.g., Light-Default, Dark-Quantum).
-	Theme interface{} `json:"theme"`
+	Theme string `json:"theme"`
 	// Default grouping preference for transaction lists.
 	TransactionGrouping UserPreferencesTransactionGrouping `json:"transactionGrouping"`
 	JSON                userPreferencesJSON                `json:"-"`
@@ -111,10 +111,10 @@ func (r UserPreferencesAIInteractionMode) IsKnown() bool {
 
 // Preferred channels for receiving notifications.
 type UserPreferencesNotificationChannels struct {
-	Email interface{}                             `json:"email"`
-	InApp interface{}                             `json:"inApp"`
-	Push  interface{}                             `json:"push"`
-	SMS   interface{}                             `json:"sms"`
+	Email bool                                    `json:"email"`
+	InApp bool                                    `json:"inApp"`
+	Push  bool                                    `json:"push"`
+	SMS   bool                                    `json:"sms"`
 	JSON  userPreferencesNotificationChannelsJSON `json:"-"`
 }
 
@@ -162,13 +162,13 @@ type UserPreferencesParam struct {
 	AIInteractionMode param.Field[UserPreferencesAIInteractionMode] `json:"aiInteractionMode"`
 	// Consent status for sharing anonymized data for AI improvement and personalized
 	// offers.
-	DataSharingConsent param.Field[interface{}] `json:"dataSharingConsent"`
+	DataSharingConsent param.Field[string] `json:"dataSharingConsent"`
 	// Preferred channels for receiving notifications.
 	NotificationChannels param.Field[UserPreferencesNotificationChannelsParam] `json:"notificationChannels"`
 	// Preferred language for the user interface.
-	PreferredLanguage param.Field[interface{}] `json:"preferredLanguage"`
+	PreferredLanguage param.Field[string] `json:"preferredLanguage"`
 	// Preferred UI theme (e.g., Light-Default, Dark-Quantum).
-	Theme param.Field[interface{}] `json:"theme"`
+	Theme param.Field[string] `json:"theme"`
 	// Default grouping preference for transaction lists.
 	TransactionGrouping param.Field[UserPreferencesTransactionGrouping] `json:"transactionGrouping"`
 }
@@ -179,10 +179,10 @@ func (r UserPreferencesParam) MarshalJSON() (data []byte, err error) {
 
 // Preferred channels for receiving notifications.
 type UserPreferencesNotificationChannelsParam struct {
-	Email param.Field[interface{}] `json:"email"`
-	InApp param.Field[interface{}] `json:"inApp"`
-	Push  param.Field[interface{}] `json:"push"`
-	SMS   param.Field[interface{}] `json:"sms"`
+	Email param.Field[bool] `json:"email"`
+	InApp param.Field[bool] `json:"inApp"`
+	Push  param.Field[bool] `json:"push"`
+	SMS   param.Field[bool] `json:"sms"`
 }
 
 func (r UserPreferencesNotificationChannelsParam) MarshalJSON() (data []byte, err error) {
@@ -50,15 +50,15 @@ func TestUserMePreferenceUpdateWithOptionalParams(t *testing.T) {
 	_, err := client.Users.Me.Preferences.Update(context.TODO(), jocall3.UserMePreferenceUpdateParams{
 		UserPreferences: jocall3.UserPreferencesParam{
 			AIInteractionMode:  jocall3.F(jocall3.UserPreferencesAIInteractionModeProactive),
-			DataSharingConsent: jocall3.F[any](true),
+			DataSharingConsent: jocall3.F("true"),
 			NotificationChannels: jocall3.F(jocall3.UserPreferencesNotificationChannelsParam{
-				Email: jocall3.F[any](true),
-				InApp: jocall3.F[any](true),
-				Push:  jocall3.F[any](true),
-				SMS:   jocall3.F[any](false),
+				Email: jocall3.F(true),
+				InApp: jocall3.F(true),
+				Push:  jocall3.F(true),
+				SMS:   jocall3.F(false),
 			}),
-			PreferredLanguage:   jocall3.F[any]("en-US"),
-			Theme:               jocall3.F[any]("Dark-Quantum"),
+			PreferredLanguage:   jocall3.F("en-US"),
+			Theme:               jocall3.F("Dark-Quantum"),
 			TransactionGrouping: jocall3.F(jocall3.UserPreferencesTransactionGroupingCategory),
 		},
 	})
@@ -26,9 +26,9 @@ func TestUserPasswordResetConfirm(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Users.PasswordReset.Confirm(context.TODO(), jocall3.UserPasswordResetConfirmParams{
-		Identifier:       jocall3.F[any]("reset.user@example.com"),
-		NewPassword:      jocall3.F[any]("MyNewStrongPassword@789"),
-		VerificationCode: jocall3.F[any]("654321"),
+		Identifier:       jocall3.F("reset.user@example.com"),
+		NewPassword:      jocall3.F("MyNewStrongPassword@789"),
+		VerificationCode: jocall3.F("654321"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -52,7 +52,7 @@ func TestUserPasswordResetInitiate(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Users.PasswordReset.Initiate(context.TODO(), jocall3.UserPasswordResetInitiateParams{
-		Identifier: jocall3.F[any]("reset.user@example.com"),
+		Identifier: jocall3.F("reset.user@example.com"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -13,6 +13,7 @@ import (
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // Web3Service contains methods and other services that help with interacting with
@@ -41,60 +42,55 @@ func NewWeb3Service(opts ...option.RequestOption) (r *Web3Service) {
 // Fetches a comprehensive list of Non-Fungible Tokens (NFTs) owned by the user
 // across all connected wallets and supported blockchain networks, including
 // metadata and market values.
-func (r *Web3Service) GetNFTs(ctx context.Context, query Web3GetNFTsParams, opts ...option.RequestOption) (res *Web3GetNFTsResponse, err error) {
+func (r *Web3Service) GetNFTs(ctx context.Context, query Web3GetNFTsParams, opts ...option.RequestOption) (res *pagination.Page[Web3GetNFTsResponseData], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "web3/nfts"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
-}
-
-type Web3GetNFTsResponse struct {
-	Data []Web3GetNFTsResponseData `json:"data"`
-	JSON web3GetNFTsResponseJSON   `json:"-"`
-	PaginatedList
-}
-
-// web3GetNFTsResponseJSON contains the JSON metadata for the struct
-// [Web3GetNFTsResponse]
-type web3GetNFTsResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
 }
 
-func (r *Web3GetNFTsResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r web3GetNFTsResponseJSON) RawJSON() string {
-	return r.raw
+// Fetches a comprehensive list of Non-Fungible Tokens (NFTs) owned by the user
+// across all connected wallets and supported blockchain networks, including
+// metadata and market values.
+func (r *Web3Service) GetNFTsAutoPaging(ctx context.Context, query Web3GetNFTsParams, opts ...option.RequestOption) *pagination.PageAutoPager[Web3GetNFTsResponseData] {
+	return pagination.NewPageAutoPager(r.GetNFTs(ctx, query, opts...))
 }
 
 type Web3GetNFTsResponseData struct {
 	// Unique identifier for the NFT within the system.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// Blockchain network on which the NFT exists.
-	BlockchainNetwork interface{} `json:"blockchainNetwork,required"`
+	BlockchainNetwork string `json:"blockchainNetwork,required"`
 	// Name of the NFT collection.
-	CollectionName interface{} `json:"collectionName,required"`
+	CollectionName string `json:"collectionName,required"`
 	// Blockchain contract address of the NFT collection.
-	ContractAddress interface{} `json:"contractAddress,required"`
+	ContractAddress string `json:"contractAddress,required"`
 	// URL to the NFT's image.
-	ImageURL interface{} `json:"imageUrl,required"`
+	ImageURL string `json:"imageUrl,required"`
 	// Name of the specific NFT.
-	Name interface{} `json:"name,required"`
+	Name string `json:"name,required"`
 	// Blockchain address of the current owner.
-	OwnerAddress interface{} `json:"ownerAddress,required"`
+	OwnerAddress string `json:"ownerAddress,required"`
 	// Unique ID of the token within its contract.
-	TokenID interface{} `json:"tokenId,required"`
+	TokenID string `json:"tokenId,required"`
 	// Key-value attributes of the NFT (e.g., rarity traits).
 	Attributes []Web3GetNFTsResponseDataAttribute `json:"attributes,nullable"`
 	// Description of the NFT.
-	Description interface{} `json:"description"`
+	Description string `json:"description"`
 	// AI-estimated current market value in USD.
-	EstimatedValueUsd interface{} `json:"estimatedValueUSD"`
+	EstimatedValueUsd Decimal `json:"estimatedValueUSD"`
 	// Last known sale price in USD.
-	LastSalePriceUsd interface{}                 `json:"lastSalePriceUSD"`
+	LastSalePriceUsd Decimal                     `json:"lastSalePriceUSD"`
 	JSON             web3GetNFTsResponseDataJSON `json:"-"`
 }
 
@@ -126,8 +122,8 @@ func (r web3GetNFTsResponseDataJSON) RawJSON() string {
 }
 
 type Web3GetNFTsResponseDataAttribute struct {
-	TraitType interface{}                          `json:"trait_type"`
-	Value     interface{}                          `json:"value"`
+	TraitType string                               `json:"trait_type"`
+	Value     string                               `json:"value"`
 	JSON      web3GetNFTsResponseDataAttributeJSON `json:"-"`
 }
 
@@ -150,9 +146,9 @@ func (r web3GetNFTsResponseDataAttributeJSON) RawJSON() string {
 
 type Web3GetNFTsParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [Web3GetNFTsParams]'s query parameters as `url.Values`.
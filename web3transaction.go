@@ -46,11 +46,11 @@ type Web3TransactionInitiateTransferResponse struct {
 	// Current status of the transfer.
 	Status Web3TransactionInitiateTransferResponseStatus `json:"status,required"`
 	// Unique identifier for this cryptocurrency transfer operation.
-	TransferID interface{} `json:"transferId,required"`
+	TransferID string `json:"transferId,required"`
 	// The blockchain transaction hash, if available and confirmed.
-	BlockchainTxnHash interface{} `json:"blockchainTxnHash"`
+	BlockchainTxnHash string `json:"blockchainTxnHash"`
 	// A descriptive message about the transfer status.
-	Message interface{}                                 `json:"message"`
+	Message string                                      `json:"message"`
 	JSON    web3TransactionInitiateTransferResponseJSON `json:"-"`
 }
 
@@ -94,19 +94,19 @@ func (r Web3TransactionInitiateTransferResponseStatus) IsKnown() bool {
 
 type Web3TransactionInitiateTransferParams struct {
 	// The amount of cryptocurrency to transfer.
-	Amount param.Field[interface{}] `json:"amount,required"`
+	Amount param.Field[Decimal] `json:"amount,required"`
 	// Symbol of the crypto asset to transfer (e.g., ETH, USDC).
-	AssetSymbol param.Field[interface{}] `json:"assetSymbol,required"`
+	AssetSymbol param.Field[string] `json:"assetSymbol,required"`
 	// The blockchain network for the transfer.
-	BlockchainNetwork param.Field[interface{}] `json:"blockchainNetwork,required"`
+	BlockchainNetwork param.Field[string] `json:"blockchainNetwork,required"`
 	// The recipient's blockchain address.
-	RecipientAddress param.Field[interface{}] `json:"recipientAddress,required"`
+	RecipientAddress param.Field[string] `json:"recipientAddress,required"`
 	// ID of the connected wallet from which to send funds.
-	SourceWalletID param.Field[interface{}] `json:"sourceWalletId,required"`
+	SourceWalletID param.Field[string] `json:"sourceWalletId,required"`
 	// Optional: Gas price in Gwei for Ethereum-based transactions.
-	GasPriceGwei param.Field[interface{}] `json:"gasPriceGwei"`
+	GasPriceGwei param.Field[Decimal] `json:"gasPriceGwei"`
 	// Optional: A short memo or note for the transaction.
-	Memo param.Field[interface{}] `json:"memo"`
+	Memo param.Field[string] `json:"memo"`
 }
 
 func (r Web3TransactionInitiateTransferParams) MarshalJSON() (data []byte, err error) {
@@ -26,13 +26,13 @@ func TestWeb3TransactionInitiateTransferWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Web3.Transactions.InitiateTransfer(context.TODO(), jocall3.Web3TransactionInitiateTransferParams{
-		Amount:            jocall3.F[any](0.1),
-		AssetSymbol:       jocall3.F[any]("ETH"),
-		BlockchainNetwork: jocall3.F[any]("Ethereum"),
-		RecipientAddress:  jocall3.F[any]("0xdef4567890abcdef1234567890abcdef1234567890"),
-		SourceWalletID:    jocall3.F[any]("wallet_conn_eth_0xabc123"),
-		GasPriceGwei:      jocall3.F[any](50),
-		Memo:              jocall3.F[any]("Payment for services"),
+		Amount:            jocall3.F(jocall3.MustDecimal("0.1")),
+		AssetSymbol:       jocall3.F("ETH"),
+		BlockchainNetwork: jocall3.F("Ethereum"),
+		RecipientAddress:  jocall3.F("0xdef4567890abcdef1234567890abcdef1234567890"),
+		SourceWalletID:    jocall3.F("wallet_conn_eth_0xabc123"),
+		GasPriceGwei:      jocall3.F(jocall3.MustDecimal("50")),
+		Memo:              jocall3.F("Payment for services"),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -4,16 +4,19 @@ package jocall3
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"slices"
+	"time"
 
 	"github.com/jocall3/go/internal/apijson"
 	"github.com/jocall3/go/internal/apiquery"
 	"github.com/jocall3/go/internal/param"
 	"github.com/jocall3/go/internal/requestconfig"
 	"github.com/jocall3/go/option"
+	"github.com/jocall3/go/packages/pagination"
 )
 
 // Web3WalletService contains methods and other services that help with interacting
@@ -38,11 +41,28 @@ func NewWeb3WalletService(opts ...option.RequestOption) (r *Web3WalletService) {
 // Retrieves a list of all securely linked cryptocurrency wallets (e.g., MetaMask,
 // Ledger integration), showing their addresses, associated networks, and
 // verification status.
-func (r *Web3WalletService) List(ctx context.Context, query Web3WalletListParams, opts ...option.RequestOption) (res *Web3WalletListResponse, err error) {
+func (r *Web3WalletService) List(ctx context.Context, query Web3WalletListParams, opts ...option.RequestOption) (res *pagination.Page[CryptoWalletConnection], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
 	path := "web3/wallets"
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves a list of all securely linked cryptocurrency wallets (e.g., MetaMask,
+// Ledger integration), showing their addresses, associated networks, and
+// verification status.
+func (r *Web3WalletService) ListAutoPaging(ctx context.Context, query Web3WalletListParams, opts ...option.RequestOption) *pagination.PageAutoPager[CryptoWalletConnection] {
+	return pagination.NewPageAutoPager(r.List(ctx, query, opts...))
 }
 
 // Initiates the process to securely connect a new cryptocurrency wallet to the
@@ -57,32 +77,52 @@ func (r *Web3WalletService) Connect(ctx context.Context, body Web3WalletConnectP
 
 // Retrieves the current balances of all recognized crypto assets within a specific
 // connected wallet.
-func (r *Web3WalletService) GetBalances(ctx context.Context, walletID interface{}, query Web3WalletGetBalancesParams, opts ...option.RequestOption) (res *Web3WalletGetBalancesResponse, err error) {
+func (r *Web3WalletService) GetBalances(ctx context.Context, walletID string, query Web3WalletGetBalancesParams, opts ...option.RequestOption) (res *pagination.Page[Web3WalletGetBalancesResponseData], err error) {
+	var raw *http.Response
 	opts = slices.Concat(r.Options, opts)
-	path := fmt.Sprintf("web3/wallets/%v/balances", walletID)
-	err = requestconfig.ExecuteNewRequest(ctx, http.MethodGet, path, query, &res, opts...)
-	return
+	opts = append([]option.RequestOption{option.WithResponseInto(&raw)}, opts...)
+	if walletID == "" {
+		err = errors.New("missing required walletId parameter")
+		return
+	}
+	path := fmt.Sprintf("web3/wallets/%s/balances", walletID)
+	cfg, err := requestconfig.NewRequestConfig(ctx, http.MethodGet, path, query, &res, opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = cfg.Execute()
+	if err != nil {
+		return nil, err
+	}
+	res.SetPageConfig(cfg, raw)
+	return res, nil
+}
+
+// Retrieves the current balances of all recognized crypto assets within a specific
+// connected wallet.
+func (r *Web3WalletService) GetBalancesAutoPaging(ctx context.Context, walletID string, query Web3WalletGetBalancesParams, opts ...option.RequestOption) *pagination.PageAutoPager[Web3WalletGetBalancesResponseData] {
+	return pagination.NewPageAutoPager(r.GetBalances(ctx, walletID, query, opts...))
 }
 
 type CryptoWalletConnection struct {
 	// Unique identifier for this wallet connection.
-	ID interface{} `json:"id,required"`
+	ID string `json:"id,required"`
 	// The blockchain network this wallet is primarily connected to (e.g., Ethereum,
 	// Solana, Polygon).
-	BlockchainNetwork interface{} `json:"blockchainNetwork,required"`
+	BlockchainNetwork string `json:"blockchainNetwork,required"`
 	// Timestamp when the wallet's data was last synchronized.
-	LastSynced interface{} `json:"lastSynced,required"`
+	LastSynced time.Time `json:"lastSynced,required" format:"date-time"`
 	// Indicates if read access (balances, NFTs) is granted.
-	ReadAccessGranted interface{} `json:"readAccessGranted,required"`
+	ReadAccessGranted bool `json:"readAccessGranted,required"`
 	// Current status of the wallet connection.
 	Status CryptoWalletConnectionStatus `json:"status,required"`
 	// Public address of the connected cryptocurrency wallet.
-	WalletAddress interface{} `json:"walletAddress,required"`
+	WalletAddress string `json:"walletAddress,required"`
 	// Name of the wallet provider (e.g., MetaMask, Ledger, Phantom).
-	WalletProvider interface{} `json:"walletProvider,required"`
+	WalletProvider string `json:"walletProvider,required"`
 	// Indicates if write access (transactions) is granted. Requires higher
 	// permission/security.
-	WriteAccessGranted interface{}                `json:"writeAccessGranted,required"`
+	WriteAccessGranted bool                       `json:"writeAccessGranted,required"`
 	JSON               cryptoWalletConnectionJSON `json:"-"`
 }
 
@@ -127,64 +167,20 @@ func (r CryptoWalletConnectionStatus) IsKnown() bool {
 	return false
 }
 
-type Web3WalletListResponse struct {
-	Data []CryptoWalletConnection   `json:"data"`
-	JSON web3WalletListResponseJSON `json:"-"`
-	PaginatedList
-}
-
-// web3WalletListResponseJSON contains the JSON metadata for the struct
-// [Web3WalletListResponse]
-type web3WalletListResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *Web3WalletListResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r web3WalletListResponseJSON) RawJSON() string {
-	return r.raw
-}
-
-type Web3WalletGetBalancesResponse struct {
-	Data []Web3WalletGetBalancesResponseData `json:"data"`
-	JSON web3WalletGetBalancesResponseJSON   `json:"-"`
-	PaginatedList
-}
-
-// web3WalletGetBalancesResponseJSON contains the JSON metadata for the struct
-// [Web3WalletGetBalancesResponse]
-type web3WalletGetBalancesResponseJSON struct {
-	Data        apijson.Field
-	raw         string
-	ExtraFields map[string]apijson.Field
-}
-
-func (r *Web3WalletGetBalancesResponse) UnmarshalJSON(data []byte) (err error) {
-	return apijson.UnmarshalRoot(data, r)
-}
-
-func (r web3WalletGetBalancesResponseJSON) RawJSON() string {
-	return r.raw
-}
-
 type Web3WalletGetBalancesResponseData struct {
 	// Full name of the crypto asset.
-	AssetName interface{} `json:"assetName,required"`
+	AssetName string `json:"assetName,required"`
 	// Symbol of the crypto asset (e.g., ETH, BTC, USDC).
-	AssetSymbol interface{} `json:"assetSymbol,required"`
+	AssetSymbol string `json:"assetSymbol,required"`
 	// Current balance of the asset in the wallet.
-	Balance interface{} `json:"balance,required"`
+	Balance Decimal `json:"balance,required"`
 	// Current USD value of the asset balance.
-	UsdValue interface{} `json:"usdValue,required"`
+	UsdValue Decimal `json:"usdValue,required"`
 	// The contract address for ERC-20 tokens or similar.
-	ContractAddress interface{} `json:"contractAddress"`
+	ContractAddress string `json:"contractAddress"`
 	// The blockchain network the asset resides on (if different from wallet's
 	// primary).
-	Network interface{}                           `json:"network"`
+	Network string                                `json:"network"`
 	JSON    web3WalletGetBalancesResponseDataJSON `json:"-"`
 }
 
@@ -211,9 +207,9 @@ func (r web3WalletGetBalancesResponseDataJSON) RawJSON() string {
 
 type Web3WalletListParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [Web3WalletListParams]'s query parameters as `url.Values`.
@@ -226,16 +222,16 @@ func (r Web3WalletListParams) URLQuery() (v url.Values) {
 
 type Web3WalletConnectParams struct {
 	// The blockchain network for this wallet (e.g., Ethereum, Solana).
-	BlockchainNetwork param.Field[interface{}] `json:"blockchainNetwork,required"`
+	BlockchainNetwork param.Field[string] `json:"blockchainNetwork,required"`
 	// A message cryptographically signed by the wallet owner to prove
 	// ownership/intent.
-	SignedMessage param.Field[interface{}] `json:"signedMessage,required"`
+	SignedMessage param.Field[string] `json:"signedMessage,required"`
 	// The public address of the cryptocurrency wallet.
-	WalletAddress param.Field[interface{}] `json:"walletAddress,required"`
+	WalletAddress param.Field[string] `json:"walletAddress,required"`
 	// The name of the wallet provider (e.g., MetaMask, Phantom).
-	WalletProvider param.Field[interface{}] `json:"walletProvider,required"`
+	WalletProvider param.Field[string] `json:"walletProvider,required"`
 	// If true, requests write access to initiate transactions from this wallet.
-	RequestWriteAccess param.Field[interface{}] `json:"requestWriteAccess"`
+	RequestWriteAccess param.Field[bool] `json:"requestWriteAccess"`
 }
 
 func (r Web3WalletConnectParams) MarshalJSON() (data []byte, err error) {
@@ -244,9 +240,9 @@ func (r Web3WalletConnectParams) MarshalJSON() (data []byte, err error) {
 
 type Web3WalletGetBalancesParams struct {
 	// Maximum number of items to return in a single page.
-	Limit param.Field[interface{}] `query:"limit"`
+	Limit param.Field[int64] `query:"limit"`
 	// Number of items to skip before starting to collect the result set.
-	Offset param.Field[interface{}] `query:"offset"`
+	Offset param.Field[int64] `query:"offset"`
 }
 
 // URLQuery serializes [Web3WalletGetBalancesParams]'s query parameters as
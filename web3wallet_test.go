@@ -26,8 +26,8 @@ func TestWeb3WalletListWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Web3.Wallets.List(context.TODO(), jocall3.Web3WalletListParams{
-		Limit:  jocall3.F[any](map[string]interface{}{}),
-		Offset: jocall3.F[any](map[string]interface{}{}),
+		Limit:  jocall3.F(int64(0)),
+		Offset: jocall3.F(int64(0)),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -51,11 +51,11 @@ func TestWeb3WalletConnectWithOptionalParams(t *testing.T) {
 		option.WithBaseURL(baseURL),
 	)
 	_, err := client.Web3.Wallets.Connect(context.TODO(), jocall3.Web3WalletConnectParams{
-		BlockchainNetwork:  jocall3.F[any]("Ethereum"),
-		SignedMessage:      jocall3.F[any]("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"),
-		WalletAddress:      jocall3.F[any]("0x123abc456def7890..."),
-		WalletProvider:     jocall3.F[any]("MetaMask"),
-		RequestWriteAccess: jocall3.F[any](true),
+		BlockchainNetwork:  jocall3.F("Ethereum"),
+		SignedMessage:      jocall3.F("0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890"),
+		WalletAddress:      jocall3.F("0x123abc456def7890..."),
+		WalletProvider:     jocall3.F("MetaMask"),
+		RequestWriteAccess: jocall3.F(true),
 	})
 	if err != nil {
 		var apierr *jocall3.Error
@@ -82,8 +82,8 @@ func TestWeb3WalletGetBalancesWithOptionalParams(t *testing.T) {
 		context.TODO(),
 		"wallet_conn_eth_0xabc123",
 		jocall3.Web3WalletGetBalancesParams{
-			Limit:  jocall3.F[any](map[string]interface{}{}),
-			Offset: jocall3.F[any](map[string]interface{}{}),
+			Limit:  jocall3.F(int64(0)),
+			Offset: jocall3.F(int64(0)),
 		},
 	)
 	if err != nil {
@@ -0,0 +1,678 @@
+package jocall3
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jocall3/go/internal/apijson"
+	"github.com/jocall3/go/internal/requestconfig"
+	"github.com/jocall3/go/option"
+	"github.com/tidwall/gjson"
+)
+
+// Webhook deliveries carry their signature in the following headers, using the
+// Standard Webhooks scheme (https://www.standardwebhooks.com).
+const (
+	WebhookIDHeader        = "Webhook-Id"
+	WebhookTimestampHeader = "Webhook-Timestamp"
+	WebhookSignatureHeader = "Webhook-Signature"
+)
+
+// DefaultWebhookTolerance is how far a delivery's timestamp may differ from the
+// local clock before it is rejected, unless overridden with
+// [option.WithWebhookTolerance].
+const DefaultWebhookTolerance = 5 * time.Minute
+
+// maxWebhookBodySize bounds how much of a request body [WebhookService.Handler]
+// reads.
+const maxWebhookBodySize = 1 << 20
+
+var (
+	// ErrWebhookSignature is returned when a delivery is missing its signature
+	// headers or none of its signatures match the webhook secret.
+	ErrWebhookSignature = errors.New("jocall3: webhook signature verification failed")
+	// ErrWebhookTimestamp is returned when a delivery's timestamp is outside the
+	// allowed tolerance.
+	ErrWebhookTimestamp = errors.New("jocall3: webhook timestamp is outside the allowed tolerance")
+)
+
+// WebhookService contains methods for verifying and decoding webhook deliveries
+// sent to the callback URLs registered with [DeveloperWebhookService.New]. The
+// secret used to sign deliveries must be supplied with
+// [option.WithWebhookSecret].
+//
+// Note, unlike clients, this service does not read variables from the
+// environment automatically. You should not instantiate this service directly,
+// and instead use the [NewWebhookService] method instead.
+type WebhookService struct {
+	Options []option.RequestOption
+	replays *webhookReplayCache
+}
+
+// NewWebhookService generates a new service that applies the given options to
+// each request. These options are applied after the parent client's options (if
+// there is one), and before any request-specific options.
+func NewWebhookService(opts ...option.RequestOption) (r *WebhookService) {
+	r = &WebhookService{}
+	r.Options = opts
+	r.replays = &webhookReplayCache{seen: map[string]webhookDelivery{}}
+	return
+}
+
+// Verify checks the signature and timestamp of a webhook delivery. It does not
+// detect duplicate deliveries; [WebhookService.Handler] does that, and callers
+// verifying deliveries themselves should de-duplicate on the webhook ID.
+//
+// The payload must be the exact, unmodified request body.
+func (r *WebhookService) Verify(payload []byte, headers http.Header, opts ...option.RequestOption) (err error) {
+	opts = slices.Concat(r.Options, opts)
+	cfg, err := requestconfig.PreRequestOptions(opts...)
+	if err != nil {
+		return err
+	}
+	return r.verify(cfg, payload, headers, time.Now())
+}
+
+// Unwrap verifies a webhook delivery like [WebhookService.Verify] and decodes
+// its body into a [WebhookEvent].
+func (r *WebhookService) Unwrap(payload []byte, headers http.Header, opts ...option.RequestOption) (res *WebhookEvent, err error) {
+	opts = slices.Concat(r.Options, opts)
+	cfg, err := requestconfig.PreRequestOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	err = r.verify(cfg, payload, headers, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	res = &WebhookEvent{}
+	err = res.UnmarshalJSON(payload)
+	if err != nil {
+		return nil, fmt.Errorf("jocall3: error parsing webhook payload: %w", err)
+	}
+	return res, nil
+}
+
+// Sign computes the value of the Webhook-Signature header for a delivery. It is
+// mainly useful for testing webhook consumers.
+func (r *WebhookService) Sign(id string, timestamp time.Time, payload []byte, opts ...option.RequestOption) (signature string, err error) {
+	opts = slices.Concat(r.Options, opts)
+	cfg, err := requestconfig.PreRequestOptions(opts...)
+	if err != nil {
+		return "", err
+	}
+	key, err := webhookKey(cfg.WebhookSecret)
+	if err != nil {
+		return "", err
+	}
+	return "v1," + base64.StdEncoding.EncodeToString(webhookMAC(key, id, strconv.FormatInt(timestamp.Unix(), 10), payload)), nil
+}
+
+// Handler returns an [http.Handler] that verifies and decodes each webhook
+// delivery and passes the event to fn.
+//
+// Deliveries that fail verification are answered with 400 Bad Request and are
+// not passed to fn. Duplicate deliveries of an event fn has already handled are
+// acknowledged with 200 OK without calling fn again, and duplicates that arrive
+// while fn is still handling the event are answered with 409 Conflict so that
+// they are retried. If fn returns an error the handler responds with 500
+// Internal Server Error and the event may be redelivered.
+func (r *WebhookService) Handler(fn func(ctx context.Context, event *WebhookEvent) error, opts ...option.RequestOption) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		payload, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxWebhookBodySize))
+		if err != nil {
+			http.Error(w, "unable to read webhook body", http.StatusBadRequest)
+			return
+		}
+
+		cfg, err := requestconfig.PreRequestOptions(slices.Concat(r.Options, opts)...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		event, err := r.Unwrap(payload, req.Header, opts...)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		id := req.Header.Get(WebhookIDHeader)
+		switch r.replays.begin(id, time.Now()) {
+		case webhookDone:
+			w.WriteHeader(http.StatusOK)
+			return
+		case webhookInFlight:
+			http.Error(w, "webhook is already being processed", http.StatusConflict)
+			return
+		}
+		// Until fn succeeds the ID is only held while fn runs, so a failed or
+		// panicking delivery can be retried.
+		done := false
+		defer func() {
+			if !done {
+				r.replays.forget(id)
+			}
+		}()
+		if err := fn(req.Context(), event); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		// A replay of this delivery older than the tolerance is rejected by the
+		// timestamp check, so the ID only needs to be remembered for that long.
+		r.replays.finish(id, time.Now().Add(webhookTolerance(cfg)))
+		done = true
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// verify checks the signature and timestamp of a delivery.
+func (r *WebhookService) verify(cfg requestconfig.RequestConfig, payload []byte, headers http.Header, now time.Time) error {
+	key, err := webhookKey(cfg.WebhookSecret)
+	if err != nil {
+		return err
+	}
+
+	id := headers.Get(WebhookIDHeader)
+	ts := headers.Get(WebhookTimestampHeader)
+	signatures := headers.Get(WebhookSignatureHeader)
+	if id == "" || ts == "" || signatures == "" {
+		return fmt.Errorf("%w: missing %s, %s or %s header", ErrWebhookSignature, WebhookIDHeader, WebhookTimestampHeader, WebhookSignatureHeader)
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: invalid %s header", ErrWebhookTimestamp, WebhookTimestampHeader)
+	}
+	tolerance := webhookTolerance(cfg)
+	sent := time.Unix(unix, 0)
+	if now.Sub(sent) > tolerance || sent.Sub(now) > tolerance {
+		return ErrWebhookTimestamp
+	}
+
+	expected := webhookMAC(key, id, ts, payload)
+	matched := false
+	for _, sig := range strings.Fields(signatures) {
+		version, value, ok := strings.Cut(sig, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return ErrWebhookSignature
+	}
+	return nil
+}
+
+func webhookTolerance(cfg requestconfig.RequestConfig) time.Duration {
+	if cfg.WebhookTolerance <= 0 {
+		return DefaultWebhookTolerance
+	}
+	return cfg.WebhookTolerance
+}
+
+// webhookKey returns the HMAC key for a webhook secret. Secrets generated by the
+// API have a "whsec_" prefix followed by the base64-encoded key; custom secrets
+// are used as-is.
+func webhookKey(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, errors.New("jocall3: the WebhookSecret option must be set in order to verify webhooks")
+	}
+	if encoded, ok := strings.CutPrefix(secret, "whsec_"); ok {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("jocall3: invalid webhook secret: %w", err)
+		}
+		return key, nil
+	}
+	return []byte(secret), nil
+}
+
+func webhookMAC(key []byte, id string, timestamp string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id))
+	mac.Write([]byte("."))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// webhookState is the state of a webhook ID in a [webhookReplayCache].
+type webhookState int
+
+const (
+	webhookNew webhookState = iota
+	webhookInFlight
+	webhookDone
+)
+
+type webhookDelivery struct {
+	state   webhookState
+	expires time.Time
+}
+
+// webhookReplayCache tracks the webhook IDs being handled by
+// [WebhookService.Handler], and the handled ones until they expire.
+type webhookReplayCache struct {
+	mu   sync.Mutex
+	seen map[string]webhookDelivery
+}
+
+// begin marks id as in flight if it is new, and reports the state it was in.
+func (c *webhookReplayCache) begin(id string, now time.Time) webhookState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, d := range c.seen {
+		if d.state == webhookDone && !d.expires.After(now) {
+			delete(c.seen, k)
+		}
+	}
+	if d, ok := c.seen[id]; ok {
+		return d.state
+	}
+	c.seen[id] = webhookDelivery{state: webhookInFlight}
+	return webhookNew
+}
+
+// finish marks id as handled until expires.
+func (c *webhookReplayCache) finish(id string, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seen[id] = webhookDelivery{state: webhookDone, expires: expires}
+}
+
+func (c *webhookReplayCache) forget(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.seen, id)
+}
+
+// An event delivered to a webhook subscription.
+type WebhookEvent struct {
+	// Unique identifier for the event. Redeliveries of the same event share this
+	// ID.
+	ID string `json:"id,required"`
+	// Timestamp when the event occurred.
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
+	// The type of the event.
+	Type WebhookEventType `json:"type,required"`
+	// This field can have the runtime type of [Transaction], [LinkedAccount],
+	// [InternationalPaymentStatus], [FinancialAnomaly], [KYCStatus].
+	Data  interface{}      `json:"data"`
+	JSON  webhookEventJSON `json:"-"`
+	union WebhookEventUnion
+}
+
+// webhookEventJSON contains the JSON metadata for the struct [WebhookEvent]
+type webhookEventJSON struct {
+	ID          apijson.Field
+	CreatedAt   apijson.Field
+	Type        apijson.Field
+	Data        apijson.Field
+	raw         string
+	ExtraFields map[string]apijson.Field
+}
+
+func (r webhookEventJSON) RawJSON() string {
+	return r.raw
+}
+
+func (r *WebhookEvent) UnmarshalJSON(data []byte) (err error) {
+	*r = WebhookEvent{}
+	// Event types added to the API after this version of the SDK are decoded
+	// without a union variant rather than being coerced into an unrelated one.
+	if !WebhookEventType(gjson.GetBytes(data, "type").String()).IsKnown() {
+		return apijson.UnmarshalRoot(data, r)
+	}
+	err = apijson.UnmarshalRoot(data, &r.union)
+	if err != nil {
+		return err
+	}
+	return apijson.Port(r.union, &r)
+}
+
+// AsUnion returns a [WebhookEventUnion] interface which you can cast to the
+// specific types for more type safety. It returns nil for event types that are
+// not known to this version of the SDK.
+//
+// Possible runtime types of the union are [TransactionWebhookEvent],
+// [AccountUpdatedWebhookEvent], [PaymentStatusChangedWebhookEvent],
+// [AnomalyDetectedWebhookEvent], [KYCStatusChangedWebhookEvent].
+func (r WebhookEvent) AsUnion() WebhookEventUnion {
+	return r.union
+}
+
+// Union satisfied by [TransactionWebhookEvent], [AccountUpdatedWebhookEvent],
+// [PaymentStatusChangedWebhookEvent], [AnomalyDetectedWebhookEvent] or
+// [KYCStatusChangedWebhookEvent].
+type WebhookEventUnion interface {
+	implementsWebhookEvent()
+}
+
+func init() {
+	apijson.RegisterUnion(
+		reflect.TypeOf((*WebhookEventUnion)(nil)).Elem(),
+		"type",
+		apijson.UnionVariant{
+			TypeFilter:         gjson.JSON,
+			Type:               reflect.TypeOf(TransactionWebhookEvent{}),
+			DiscriminatorValue: "transaction.created",
+		},
+		apijson.UnionVariant{
+			TypeFilter:         gjson.JSON,
+			Type:               reflect.TypeOf(TransactionWebhookEvent{}),
+			DiscriminatorValue: "transaction.updated",
+		},
+		apijson.UnionVariant{
+			TypeFilter:         gjson.JSON,
+			Type:               reflect.TypeOf(AccountUpdatedWebhookEvent{}),
+			DiscriminatorValue: "account.updated",
+		},
+		apijson.UnionVariant{
+			TypeFilter:         gjson.JSON,
+			Type:               reflect.TypeOf(PaymentStatusChangedWebhookEvent{}),
+			DiscriminatorValue: "payment.status_changed",
+		},
+		apijson.UnionVariant{
+			TypeFilter:         gjson.JSON,
+			Type:               reflect.TypeOf(AnomalyDetectedWebhookEvent{}),
+			DiscriminatorValue: "anomaly.detected",
+		},
+		apijson.UnionVariant{
+			TypeFilter:         gjson.JSON,
+			Type:               reflect.TypeOf(KYCStatusChangedWebhookEvent{}),
+			DiscriminatorValue: "kyc.status_changed",
+		},
+	)
+}
+
+// The type of the event.
+type WebhookEventType string
+
+const (
+	WebhookEventTypeTransactionCreated   WebhookEventType = "transaction.created"
+	WebhookEventTypeTransactionUpdated   WebhookEventType = "transaction.updated"
+	WebhookEventTypeAccountUpdated       WebhookEventType = "account.updated"
+	WebhookEventTypePaymentStatusChanged WebhookEventType = "payment.status_changed"
+	WebhookEventTypeAnomalyDetected      WebhookEventType = "anomaly.detected"
+	WebhookEventTypeKYCStatusChanged     WebhookEventType = "kyc.status_changed"
+)
+
+func (r WebhookEventType) IsKnown() bool {
+	switch r {
+	case WebhookEventTypeTransactionCreated, WebhookEventTypeTransactionUpdated, WebhookEventTypeAccountUpdated, WebhookEventTypePaymentStatusChanged, WebhookEventTypeAnomalyDetected, WebhookEventTypeKYCStatusChanged:
+		return true
+	}
+	return false
+}
+
+// Sent when a transaction is created or updated.
+type TransactionWebhookEvent struct {
+	// Unique identifier for the event.
+	ID string `json:"id,required"`
+	// Timestamp when the event occurred.
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
+	// The transaction as it was after the change.
+	Data Transaction `json:"data,required"`
+	// The type of the event.
+	Type TransactionWebhookEventType `json:"type,required"`
+	JSON transactionWebhookEventJSON `json:"-"`
+}
+
+// transactionWebhookEventJSON contains the JSON metadata for the struct
+// [TransactionWebhookEvent]
+type transactionWebhookEventJSON struct {
+	ID          apijson.Field
+	CreatedAt   apijson.Field
+	Data        apijson.Field
+	Type        apijson.Field
+	raw         string
+	ExtraFields map[string]apijson.Field
+}
+
+func (r *TransactionWebhookEvent) UnmarshalJSON(data []byte) (err error) {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r transactionWebhookEventJSON) RawJSON() string {
+	return r.raw
+}
+
+func (r TransactionWebhookEvent) implementsWebhookEvent() {}
+
+// The type of the event.
+type TransactionWebhookEventType string
+
+const (
+	TransactionWebhookEventTypeTransactionCreated TransactionWebhookEventType = "transaction.created"
+	TransactionWebhookEventTypeTransactionUpdated TransactionWebhookEventType = "transaction.updated"
+)
+
+func (r TransactionWebhookEventType) IsKnown() bool {
+	switch r {
+	case TransactionWebhookEventTypeTransactionCreated, TransactionWebhookEventTypeTransactionUpdated:
+		return true
+	}
+	return false
+}
+
+// Sent when the balance or details of a linked account change.
+type AccountUpdatedWebhookEvent struct {
+	// Unique identifier for the event.
+	ID string `json:"id,required"`
+	// Timestamp when the event occurred.
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
+	// The account as it was after the change.
+	Data LinkedAccount `json:"data,required"`
+	// The type of the event.
+	Type AccountUpdatedWebhookEventType `json:"type,required"`
+	JSON accountUpdatedWebhookEventJSON `json:"-"`
+}
+
+// accountUpdatedWebhookEventJSON contains the JSON metadata for the struct
+// [AccountUpdatedWebhookEvent]
+type accountUpdatedWebhookEventJSON struct {
+	ID          apijson.Field
+	CreatedAt   apijson.Field
+	Data        apijson.Field
+	Type        apijson.Field
+	raw         string
+	ExtraFields map[string]apijson.Field
+}
+
+func (r *AccountUpdatedWebhookEvent) UnmarshalJSON(data []byte) (err error) {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r accountUpdatedWebhookEventJSON) RawJSON() string {
+	return r.raw
+}
+
+func (r AccountUpdatedWebhookEvent) implementsWebhookEvent() {}
+
+// The type of the event.
+type AccountUpdatedWebhookEventType string
+
+const (
+	AccountUpdatedWebhookEventTypeAccountUpdated AccountUpdatedWebhookEventType = "account.updated"
+)
+
+func (r AccountUpdatedWebhookEventType) IsKnown() bool {
+	switch r {
+	case AccountUpdatedWebhookEventTypeAccountUpdated:
+		return true
+	}
+	return false
+}
+
+// Sent when an international payment moves to a new processing status.
+type PaymentStatusChangedWebhookEvent struct {
+	// Unique identifier for the event.
+	ID string `json:"id,required"`
+	// Timestamp when the event occurred.
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
+	// The payment status after the change.
+	Data InternationalPaymentStatus `json:"data,required"`
+	// The type of the event.
+	Type PaymentStatusChangedWebhookEventType `json:"type,required"`
+	JSON paymentStatusChangedWebhookEventJSON `json:"-"`
+}
+
+// paymentStatusChangedWebhookEventJSON contains the JSON metadata for the struct
+// [PaymentStatusChangedWebhookEvent]
+type paymentStatusChangedWebhookEventJSON struct {
+	ID          apijson.Field
+	CreatedAt   apijson.Field
+	Data        apijson.Field
+	Type        apijson.Field
+	raw         string
+	ExtraFields map[string]apijson.Field
+}
+
+func (r *PaymentStatusChangedWebhookEvent) UnmarshalJSON(data []byte) (err error) {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r paymentStatusChangedWebhookEventJSON) RawJSON() string {
+	return r.raw
+}
+
+func (r PaymentStatusChangedWebhookEvent) implementsWebhookEvent() {}
+
+// The type of the event.
+type PaymentStatusChangedWebhookEventType string
+
+const (
+	PaymentStatusChangedWebhookEventTypePaymentStatusChanged PaymentStatusChangedWebhookEventType = "payment.status_changed"
+)
+
+func (r PaymentStatusChangedWebhookEventType) IsKnown() bool {
+	switch r {
+	case PaymentStatusChangedWebhookEventTypePaymentStatusChanged:
+		return true
+	}
+	return false
+}
+
+// Sent when the AI detects a suspicious or unusual financial activity.
+type AnomalyDetectedWebhookEvent struct {
+	// Unique identifier for the event.
+	ID string `json:"id,required"`
+	// Timestamp when the event occurred.
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
+	// The detected anomaly.
+	Data FinancialAnomaly `json:"data,required"`
+	// The type of the event.
+	Type AnomalyDetectedWebhookEventType `json:"type,required"`
+	JSON anomalyDetectedWebhookEventJSON `json:"-"`
+}
+
+// anomalyDetectedWebhookEventJSON contains the JSON metadata for the struct
+// [AnomalyDetectedWebhookEvent]
+type anomalyDetectedWebhookEventJSON struct {
+	ID          apijson.Field
+	CreatedAt   apijson.Field
+	Data        apijson.Field
+	Type        apijson.Field
+	raw         string
+	ExtraFields map[string]apijson.Field
+}
+
+func (r *AnomalyDetectedWebhookEvent) UnmarshalJSON(data []byte) (err error) {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r anomalyDetectedWebhookEventJSON) RawJSON() string {
+	return r.raw
+}
+
+func (r AnomalyDetectedWebhookEvent) implementsWebhookEvent() {}
+
+// The type of the event.
+type AnomalyDetectedWebhookEventType string
+
+const (
+	AnomalyDetectedWebhookEventTypeAnomalyDetected AnomalyDetectedWebhookEventType = "anomaly.detected"
+)
+
+func (r AnomalyDetectedWebhookEventType) IsKnown() bool {
+	switch r {
+	case AnomalyDetectedWebhookEventTypeAnomalyDetected:
+		return true
+	}
+	return false
+}
+
+// Sent when the user's KYC verification status changes.
+type KYCStatusChangedWebhookEvent struct {
+	// Unique identifier for the event.
+	ID string `json:"id,required"`
+	// Timestamp when the event occurred.
+	CreatedAt time.Time `json:"createdAt,required" format:"date-time"`
+	// The KYC status after the change.
+	Data KYCStatus `json:"data,required"`
+	// The type of the event.
+	Type KYCStatusChangedWebhookEventType `json:"type,required"`
+	JSON kycStatusChangedWebhookEventJSON `json:"-"`
+}
+
+// kycStatusChangedWebhookEventJSON contains the JSON metadata for the struct
+// [KYCStatusChangedWebhookEvent]
+type kycStatusChangedWebhookEventJSON struct {
+	ID          apijson.Field
+	CreatedAt   apijson.Field
+	Data        apijson.Field
+	Type        apijson.Field
+	raw         string
+	ExtraFields map[string]apijson.Field
+}
+
+func (r *KYCStatusChangedWebhookEvent) UnmarshalJSON(data []byte) (err error) {
+	return apijson.UnmarshalRoot(data, r)
+}
+
+func (r kycStatusChangedWebhookEventJSON) RawJSON() string {
+	return r.raw
+}
+
+func (r KYCStatusChangedWebhookEvent) implementsWebhookEvent() {}
+
+// The type of the event.
+type KYCStatusChangedWebhookEventType string
+
+const (
+	KYCStatusChangedWebhookEventTypeKYCStatusChanged KYCStatusChangedWebhookEventType = "kyc.status_changed"
+)
+
+func (r KYCStatusChangedWebhookEventType) IsKnown() bool {
+	switch r {
+	case KYCStatusChangedWebhookEventTypeKYCStatusChanged:
+		return true
+	}
+	return false
+}
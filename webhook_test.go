@@ -0,0 +1,204 @@
+package jocall3_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jocall3/go"
+	"github.com/jocall3/go/option"
+)
+
+const webhookPayload = `{"id":"evt_1","type":"transaction.created","createdAt":"2024-07-20T10:00:00Z","data":{"id":"txn_1","accountId":"acc_1","amount":"-42.10","currency":"USD","date":"2024-07-20","description":"Coffee","category":"Dining","type":"expense"}}`
+
+func signedWebhookHeaders(t *testing.T, webhooks *jocall3.WebhookService, id string, ts time.Time, payload string) http.Header {
+	t.Helper()
+	sig, err := webhooks.Sign(id, ts, []byte(payload))
+	if err != nil {
+		t.Fatalf("unexpected error signing webhook: %v", err)
+	}
+	return http.Header{
+		"Webhook-Id":        []string{id},
+		"Webhook-Timestamp": []string{strconv.FormatInt(ts.Unix(), 10)},
+		"Webhook-Signature": []string{"v1,bm90LXRoaXMtb25l " + sig},
+	}
+}
+
+func TestWebhookUnwrap(t *testing.T) {
+	webhooks := jocall3.NewWebhookService(option.WithWebhookSecret("whsec_c2VjcmV0LWtleQ=="))
+	headers := signedWebhookHeaders(t, webhooks, "msg_1", time.Now(), webhookPayload)
+
+	event, err := webhooks.Unwrap([]byte(webhookPayload), headers)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Type != jocall3.WebhookEventTypeTransactionCreated || event.ID != "evt_1" {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	created, ok := event.AsUnion().(jocall3.TransactionWebhookEvent)
+	if !ok {
+		t.Fatalf("expected a TransactionWebhookEvent, got %T", event.AsUnion())
+	}
+	if created.Data.ID != "txn_1" || created.Data.Amount.String() != "-42.10" {
+		t.Fatalf("unexpected transaction: %+v", created.Data)
+	}
+	if event.JSON.RawJSON() != webhookPayload {
+		t.Fatalf("expected RawJSON to preserve the payload")
+	}
+
+	// Verification has no side effects, so the same delivery can be checked
+	// again.
+	if err := webhooks.Verify([]byte(webhookPayload), headers); err != nil {
+		t.Fatalf("unexpected error verifying an unwrapped delivery: %v", err)
+	}
+	if _, err := webhooks.Unwrap([]byte(webhookPayload), headers); err != nil {
+		t.Fatalf("unexpected error unwrapping a verified delivery: %v", err)
+	}
+}
+
+func TestWebhookVerifyRejectsTampering(t *testing.T) {
+	webhooks := jocall3.NewWebhookService(option.WithWebhookSecret("custom-secret"))
+
+	headers := signedWebhookHeaders(t, webhooks, "msg_1", time.Now(), webhookPayload)
+	tampered := strings.Replace(webhookPayload, "-42.10", "-4210.00", 1)
+	if err := webhooks.Verify([]byte(tampered), headers); !errors.Is(err, jocall3.ErrWebhookSignature) {
+		t.Fatalf("expected a signature error, got %v", err)
+	}
+
+	other := jocall3.NewWebhookService(option.WithWebhookSecret("other-secret"))
+	if err := other.Verify([]byte(webhookPayload), headers); !errors.Is(err, jocall3.ErrWebhookSignature) {
+		t.Fatalf("expected a signature error for the wrong secret, got %v", err)
+	}
+
+	headers.Del("Webhook-Signature")
+	if err := webhooks.Verify([]byte(webhookPayload), headers); !errors.Is(err, jocall3.ErrWebhookSignature) {
+		t.Fatalf("expected a signature error for a missing header, got %v", err)
+	}
+
+	old := signedWebhookHeaders(t, webhooks, "msg_2", time.Now().Add(-10*time.Minute), webhookPayload)
+	if err := webhooks.Verify([]byte(webhookPayload), old); !errors.Is(err, jocall3.ErrWebhookTimestamp) {
+		t.Fatalf("expected a timestamp error, got %v", err)
+	}
+	if err := webhooks.Verify([]byte(webhookPayload), old, option.WithWebhookTolerance(time.Hour)); err != nil {
+		t.Fatalf("expected the wider tolerance to accept the delivery, got %v", err)
+	}
+
+	if err := jocall3.NewWebhookService().Verify([]byte(webhookPayload), old); err == nil {
+		t.Fatalf("expected an error without a webhook secret")
+	}
+}
+
+func TestWebhookUnknownEventType(t *testing.T) {
+	webhooks := jocall3.NewWebhookService(option.WithWebhookSecret("custom-secret"))
+	payload := `{"id":"evt_2","type":"goal.achieved","createdAt":"2024-07-20T10:00:00Z","data":{"goalId":"goal_1"}}`
+	event, err := webhooks.Unwrap([]byte(payload), signedWebhookHeaders(t, webhooks, "msg_3", time.Now(), payload))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Type.IsKnown() || event.Type != "goal.achieved" || event.AsUnion() != nil {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	if event.JSON.Data.Raw() != `{"goalId":"goal_1"}` {
+		t.Fatalf("expected the raw data to be kept, got %s", event.JSON.Data.Raw())
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	webhooks := jocall3.NewWebhookService(option.WithWebhookSecret("custom-secret"))
+	calls := 0
+	fail := true
+	handler := webhooks.Handler(func(ctx context.Context, event *jocall3.WebhookEvent) error {
+		calls++
+		if fail {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	deliver := func(headers http.Header, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+		req.Header = headers
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	headers := signedWebhookHeaders(t, webhooks, "msg_1", time.Now(), webhookPayload)
+	if code := deliver(headers, webhookPayload); code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 when the callback fails, got %d", code)
+	}
+	fail = false
+	if code := deliver(headers, webhookPayload); code != http.StatusOK {
+		t.Fatalf("expected a redelivery to be accepted, got %d", code)
+	}
+	if code := deliver(headers, webhookPayload); code != http.StatusOK {
+		t.Fatalf("expected a duplicate to be acknowledged, got %d", code)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the callback to run twice, got %d", calls)
+	}
+	if code := deliver(headers, strings.Replace(webhookPayload, "Coffee", "Tea", 1)); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad signature, got %d", code)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/webhooks", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405 for GET, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandlerConcurrentDuplicate(t *testing.T) {
+	webhooks := jocall3.NewWebhookService(option.WithWebhookSecret("custom-secret"))
+	started := make(chan struct{}, 1)
+	release := make(chan error)
+	var calls atomic.Int32
+	handler := webhooks.Handler(func(ctx context.Context, event *jocall3.WebhookEvent) error {
+		calls.Add(1)
+		started <- struct{}{}
+		return <-release
+	})
+
+	headers := signedWebhookHeaders(t, webhooks, "msg_1", time.Now(), webhookPayload)
+	deliver := func() int {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(webhookPayload))
+		req.Header = headers
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	first := make(chan int)
+	go func() { first <- deliver() }()
+	<-started
+	// The first delivery is still being handled, so the duplicate must not be
+	// acknowledged: the first one may yet fail.
+	if code := deliver(); code != http.StatusConflict {
+		t.Fatalf("expected 409 for a duplicate in flight, got %d", code)
+	}
+	release <- errors.New("database unavailable")
+	if code := <-first; code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 when the callback fails, got %d", code)
+	}
+
+	second := make(chan int)
+	go func() { second <- deliver() }()
+	<-started
+	release <- nil
+	if code := <-second; code != http.StatusOK {
+		t.Fatalf("expected the redelivery to be accepted, got %d", code)
+	}
+	if code := deliver(); code != http.StatusOK {
+		t.Fatalf("expected a duplicate of a handled delivery to be acknowledged, got %d", code)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected the callback to run twice, got %d", n)
+	}
+}